@@ -0,0 +1,91 @@
+// Package attributes validates the custom profile attributes of users against the JSON Schema managed by admins,
+// so that business units can add profile fields without a database migration.
+package attributes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"io"
+	"sort"
+	"strings"
+)
+
+// schemaURL is the URL the schema is compiled under, it's only used to resolve `$ref` within the schema itself
+const schemaURL = "attributes.schema.json"
+
+var (
+	ErrInvalidSchema   = errors.New("must be a valid JSON Schema")
+	ErrNotObjectSchema = errors.New(`must be a JSON Schema of "type": "object"`)
+	errExternalRef     = errors.New("external $ref are not allowed")
+)
+
+// Violation is a value of the attributes not satisfying the schema, as returned by Validate
+type Violation struct {
+	// Keyword is the schema keyword the value doesn't satisfy, e.g. pattern or required
+	Keyword string
+	// Message details the violation in English, e.g. `does not match pattern "^[A-Z]{3}-\d{2}$"`
+	Message string
+}
+
+func (v *Violation) Error() string {
+	return v.Message
+}
+
+// Schema is a compiled JSON Schema of the attributes
+type Schema struct {
+	schema *jsonschema.Schema
+}
+
+// Compile will compile the JSON Schema (draft 2020-12 unless `$schema` says otherwise), its root must be an object.
+// References to other documents are rejected, the schema is managed through the API and must be self-contained.
+func Compile(raw []byte) (*Schema, error) {
+	c := jsonschema.NewCompiler()
+	c.Draft = jsonschema.Draft2020
+	c.LoadURL = func(string) (io.ReadCloser, error) { return nil, errExternalRef }
+	if err := c.AddResource(schemaURL, bytes.NewReader(raw)); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchema, err)
+	}
+	schema, err := c.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchema, err)
+	}
+	if len(schema.Types) != 1 || schema.Types[0] != "object" {
+		return nil, ErrNotObjectSchema
+	}
+	return &Schema{schema: schema}, nil
+}
+
+// Validate will validate the attributes, and return the errors by JSON pointer of the invalid value (e.g.
+// `/estate_code`, or an empty pointer for the attributes object itself). The errors are *Violation, unless the
+// validation itself failed. The attributes must be decoded with encoding/json.
+func (s *Schema) Validate(attrs map[string]interface{}) map[string][]error {
+	err := s.schema.Validate(attrs)
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return map[string][]error{"": {err}}
+	}
+	res := map[string][]error{}
+	collectErrors(validationErr, res)
+	for _, errs := range res {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	}
+	return res
+}
+
+// collectErrors will only keep the leaves of the error tree, the inner nodes merely say that a subschema failed
+func collectErrors(err *jsonschema.ValidationError, res map[string][]error) {
+	if len(err.Causes) == 0 {
+		keyword := err.KeywordLocation[strings.LastIndex(err.KeywordLocation, "/")+1:]
+		res[err.InstanceLocation] = append(res[err.InstanceLocation], &Violation{Keyword: keyword, Message: err.Message})
+		return
+	}
+	for _, cause := range err.Causes {
+		collectErrors(cause, res)
+	}
+}
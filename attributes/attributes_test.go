@@ -0,0 +1,97 @@
+package attributes
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const testSchema = `{
+	"type": "object",
+	"properties": {
+		"estate_code": {"type": "string", "pattern": "^[A-Z]{3}-[0-9]{2}$"},
+		"cooperative": {
+			"type": "object",
+			"properties": {"membership_no": {"type": "string"}},
+			"required": ["membership_no"]
+		},
+		"preferred_language": {"$ref": "#/$defs/language"}
+	},
+	"additionalProperties": false,
+	"$defs": {"language": {"enum": ["id", "en"]}}
+}`
+
+func decode(t *testing.T, s string) map[string]interface{} {
+	var res map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &res); err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestCompile(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := Compile([]byte(testSchema))
+	a.Empty(err)
+}
+
+func TestCompileInvalid(t *testing.T) {
+	a := assert.New(t)
+
+	for _, raw := range []string{`{"type":`, `{"type": "object", "properties": {"x": {"type": "text"}}}`, `{"type": "object", "minProperties": -1}`} {
+		_, err := Compile([]byte(raw))
+		a.ErrorIs(err, ErrInvalidSchema, raw)
+	}
+}
+
+func TestCompileExternalRef(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := Compile([]byte(`{"type": "object", "properties": {"x": {"$ref": "file:///etc/passwd"}}}`))
+	a.ErrorIs(err, ErrInvalidSchema)
+}
+
+func TestCompileNotObject(t *testing.T) {
+	a := assert.New(t)
+
+	for _, raw := range []string{`{"type": "string"}`, `{}`, `{"type": ["object", "null"]}`} {
+		_, err := Compile([]byte(raw))
+		a.ErrorIs(err, ErrNotObjectSchema, raw)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	a := assert.New(t)
+
+	schema, err := Compile([]byte(testSchema))
+	a.Empty(err)
+
+	a.Empty(schema.Validate(decode(t, `{}`)))
+	a.Empty(schema.Validate(decode(t, `{"estate_code": "KAL-01", "cooperative": {"membership_no": "123"}, "preferred_language": "id"}`)))
+}
+
+func TestValidateErrors(t *testing.T) {
+	a := assert.New(t)
+
+	schema, err := Compile([]byte(testSchema))
+	a.Empty(err)
+
+	errs := schema.Validate(decode(t, `{"estate_code": 12, "cooperative": {}, "preferred_language": "fr", "shoe_size": 42}`))
+	a.Len(errs, 4)
+	a.Len(errs[""], 1)
+	a.Contains(errs[""][0].Error(), "shoe_size")
+	a.Len(errs["/estate_code"], 1)
+	a.Contains(errs["/estate_code"][0].Error(), "expected string")
+	a.Len(errs["/cooperative"], 1)
+	a.Contains(errs["/cooperative"][0].Error(), "membership_no")
+	a.Len(errs["/preferred_language"], 1)
+
+	keywords := map[string]string{}
+	for pointer, pointerErrs := range errs {
+		var violation *Violation
+		a.ErrorAs(pointerErrs[0], &violation, pointer)
+		keywords[pointer] = violation.Keyword
+	}
+	a.Equal(map[string]string{"": "additionalProperties", "/estate_code": "type", "/cooperative": "required", "/preferred_language": "enum"}, keywords)
+}
@@ -0,0 +1,191 @@
+// Package avatar turns images uploaded by users into square JPEG thumbnails. The content type is sniffed from the
+// content itself, and the thumbnails are re-encoded from the pixels only, so that no metadata of the upload (EXIF,
+// GPS location, camera details...) is ever kept. Only JPEG, PNG & WebP images are supported.
+package avatar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+)
+
+const (
+	// MaxPixels is the maximum width x height of an upload, the decoded image is kept in memory
+	MaxPixels = 24_000_000
+
+	// thumbnailQuality is the JPEG quality of the thumbnails
+	thumbnailQuality = 85
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("must be a JPEG, PNG or WebP image")
+	ErrTooLarge          = fmt.Errorf("must be at most %d megapixels", MaxPixels/1_000_000)
+	ErrInvalidImage      = errors.New("is not a valid image")
+)
+
+type format struct {
+	decode       func(r io.Reader) (image.Image, error)
+	decodeConfig func(r io.Reader) (image.Config, error)
+}
+
+// formats contains the supported formats, by sniffed content type
+var formats = map[string]format{
+	"image/jpeg": {decode: jpeg.Decode, decodeConfig: jpeg.DecodeConfig},
+	"image/png":  {decode: png.Decode, decodeConfig: png.DecodeConfig},
+	"image/webp": {decode: webp.Decode, decodeConfig: webp.DecodeConfig},
+}
+
+// Image is a decoded upload
+type Image struct {
+	img image.Image
+	// orientation is the EXIF orientation (1 to 8) of JPEG images, applied to the thumbnails
+	orientation int
+}
+
+// Decode will decode the image, whatever content type is claimed by the uploader
+func Decode(content []byte) (*Image, error) {
+	f, ok := formats[http.DetectContentType(content)]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	// the dimensions are checked before decoding, so that a tiny file can't make us allocate gigabytes
+	cfg, err := f.decodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrTooLarge
+	}
+
+	img, err := f.decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	return &Image{img: img, orientation: jpegOrientation(content)}, nil
+}
+
+// Thumbnail will return the centered square of the image as a JPEG of size x size pixels, smaller images are not
+// upscaled. Transparent pixels are made white.
+func (i *Image) Thumbnail(size int) ([]byte, error) {
+	bounds := i.img.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	crop := image.Rect(0, 0, side, side).Add(bounds.Min).
+		Add(image.Pt((bounds.Dx()-side)/2, (bounds.Dy()-side)/2))
+	if size > side {
+		size = side
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), i.img, crop, draw.Over, nil)
+
+	// a centered square is the same once rotated, so the orientation is applied on the small thumbnail only
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, orient(dst, i.orientation), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// orient will apply the EXIF orientation to the square image, so that it's displayed upright without the metadata
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	n := src.Bounds().Dx() - 1
+	dst := image.NewRGBA(src.Bounds())
+	for y := 0; y <= n; y++ {
+		for x := 0; x <= n; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				sx, sy = n-x, y
+			case 3: // rotated 180°
+				sx, sy = n-x, n-y
+			case 4: // mirrored vertically
+				sx, sy = x, n-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // must be rotated 90° clockwise
+				sx, sy = y, n-x
+			case 7: // transversed
+				sx, sy = n-y, n-x
+			case 8: // must be rotated 90° counter-clockwise
+				sx, sy = n-y, x
+			}
+			dst.SetRGBA(x, y, src.RGBAAt(sx, sy))
+		}
+	}
+	return dst
+}
+
+// jpegOrientation will return the EXIF orientation tag of a JPEG image, or 1 (upright) when there's none
+func jpegOrientation(content []byte) int {
+	if len(content) < 4 || content[0] != 0xFF || content[1] != 0xD8 {
+		return 1
+	}
+	for pos := 2; pos+4 <= len(content) && content[pos] == 0xFF; {
+		marker := content[pos+1]
+		length := int(binary.BigEndian.Uint16(content[pos+2:]))
+		if marker == 0xDA || length < 2 || pos+2+length > len(content) { // start of scan, no more metadata
+			return 1
+		}
+		segment := content[pos+4 : pos+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		pos += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation will return the orientation tag of the first IFD of the TIFF structure of EXIF
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 { // orientation, a SHORT stored in the value itself
+			if orientation := int(order.Uint16(tiff[entry+8:])); orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			return 1
+		}
+	}
+	return 1
+}
@@ -0,0 +1,192 @@
+package avatar
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+)
+
+// quadrantImage will return a width x height image, with the top-left quadrant red and the rest blue
+func quadrantImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x < width/2 && y < height/2 {
+				img.SetRGBA(x, y, red)
+			} else {
+				img.SetRGBA(x, y, blue)
+			}
+		}
+	}
+	return img
+}
+
+// withExifOrientation will insert an EXIF segment with the orientation tag right after the JPEG start of image
+func withExifOrientation(content []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08") // big endian, first IFD right after the header
+	tiff = append(tiff, 0x00, 0x01)              // one entry
+	tiff = append(tiff, 0x01, 0x12, 0x00, 0x03)  // orientation tag, SHORT
+	tiff = append(tiff, 0x00, 0x00, 0x00, 0x01)  // one value
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00) // value padding, no next IFD
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	res := append([]byte{0xFF, 0xD8, 0xFF, 0xE1}, binary.BigEndian.AppendUint16(nil, uint16(len(segment)+2))...)
+	res = append(res, segment...)
+	return append(res, content[2:]...)
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// isNear will check whether the colors are the same, besides JPEG compression artifacts
+func isNear(expected color.RGBA, actual color.Color) bool {
+	r, g, b, _ := actual.RGBA()
+	diff := func(x uint8, y uint32) bool { return int(x)-int(y>>8) < 40 && int(y>>8)-int(x) < 40 }
+	return diff(expected.R, r) && diff(expected.G, g) && diff(expected.B, b)
+}
+
+func TestThumbnail(t *testing.T) {
+	a := assert.New(t)
+
+	// the centered square of a 200x100 image spans x=50..150, so only its left half has red at the top
+	img, err := Decode(encodePNG(t, quadrantImage(200, 100)))
+	a.Empty(err)
+
+	thumbnail, err := img.Thumbnail(64)
+	a.Empty(err)
+	decoded, err := jpeg.Decode(bytes.NewReader(thumbnail))
+	a.Empty(err)
+	a.Equal(image.Rect(0, 0, 64, 64), decoded.Bounds())
+	a.True(isNear(red, decoded.At(8, 8)))
+	a.True(isNear(red, decoded.At(24, 8)))
+	a.True(isNear(blue, decoded.At(40, 8)))
+	a.True(isNear(blue, decoded.At(8, 56)))
+}
+
+func TestThumbnailNoUpscale(t *testing.T) {
+	a := assert.New(t)
+
+	img, err := Decode(encodePNG(t, quadrantImage(40, 50)))
+	a.Empty(err)
+
+	thumbnail, err := img.Thumbnail(512)
+	a.Empty(err)
+	decoded, err := jpeg.Decode(bytes.NewReader(thumbnail))
+	a.Empty(err)
+	a.Equal(image.Rect(0, 0, 40, 40), decoded.Bounds())
+}
+
+func TestThumbnailTransparent(t *testing.T) {
+	a := assert.New(t)
+
+	img, err := Decode(encodePNG(t, image.NewNRGBA(image.Rect(0, 0, 16, 16))))
+	a.Empty(err)
+
+	thumbnail, err := img.Thumbnail(16)
+	a.Empty(err)
+	decoded, err := jpeg.Decode(bytes.NewReader(thumbnail))
+	a.Empty(err)
+	a.True(isNear(color.RGBA{R: 255, G: 255, B: 255, A: 255}, decoded.At(8, 8)))
+}
+
+func TestThumbnailExifOrientation(t *testing.T) {
+	a := assert.New(t)
+
+	content := withExifOrientation(encodeJPEG(t, quadrantImage(64, 64)), 6)
+	a.Equal(6, jpegOrientation(content))
+
+	img, err := Decode(content)
+	a.Empty(err)
+	thumbnail, err := img.Thumbnail(64)
+	a.Empty(err)
+
+	// the metadata is gone, and the red quadrant is rotated to the top-right
+	a.False(bytes.Contains(thumbnail, []byte("Exif")))
+	a.Equal(1, jpegOrientation(thumbnail))
+	decoded, err := jpeg.Decode(bytes.NewReader(thumbnail))
+	a.Empty(err)
+	a.True(isNear(blue, decoded.At(8, 8)))
+	a.True(isNear(red, decoded.At(56, 8)))
+}
+
+func TestOrient(t *testing.T) {
+	a := assert.New(t)
+
+	// the red pixel is at the top-left, its expected position once oriented
+	expected := map[int]image.Point{
+		1: {0, 0}, 2: {2, 0}, 3: {2, 2}, 4: {0, 2}, 5: {0, 0}, 6: {2, 0}, 7: {2, 2}, 8: {0, 2},
+	}
+	for orientation, pos := range expected {
+		src := image.NewRGBA(image.Rect(0, 0, 3, 3))
+		src.SetRGBA(0, 0, red)
+		a.Equal(red, orient(src, orientation).RGBAAt(pos.X, pos.Y), "orientation %d", orientation)
+	}
+}
+
+func TestDecodeWebP(t *testing.T) {
+	a := assert.New(t)
+
+	content, _ := base64.StdEncoding.DecodeString("UklGRiIAAABXRUJQVlA4IBYAAAAwAQCdASoBAAEADsD+JaQAA3AAAAAA")
+	img, err := Decode(content)
+	a.Empty(err)
+	a.Equal(image.Rect(0, 0, 1, 1), img.img.Bounds())
+}
+
+func TestDecodeUnsupportedFormat(t *testing.T) {
+	a := assert.New(t)
+
+	for _, content := range [][]byte{[]byte("GIF89a...."), []byte("<svg></svg>"), nil} {
+		_, err := Decode(content)
+		a.ErrorIs(err, ErrUnsupportedFormat)
+	}
+}
+
+func TestDecodeInvalidImage(t *testing.T) {
+	a := assert.New(t)
+
+	content := encodePNG(t, quadrantImage(16, 16))
+	_, err := Decode(content[:len(content)/2])
+	a.ErrorIs(err, ErrInvalidImage)
+}
+
+func TestDecodeTooLarge(t *testing.T) {
+	a := assert.New(t)
+
+	// only the PNG header is needed, the pixels are never decoded
+	ihdr := []byte("IHDR")
+	ihdr = binary.BigEndian.AppendUint32(ihdr, 10000)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, 10000)
+	ihdr = append(ihdr, 8, 2, 0, 0, 0)
+	content := append([]byte("\x89PNG\r\n\x1a\n"), binary.BigEndian.AppendUint32(nil, 13)...)
+	content = append(content, ihdr...)
+	content = binary.BigEndian.AppendUint32(content, crc32.ChecksumIEEE(ihdr))
+
+	_, err := Decode(content)
+	a.ErrorIs(err, ErrTooLarge)
+}
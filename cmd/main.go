@@ -1,11 +1,35 @@
 package main
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"errors"
+	"flag"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/mail/filesender"
+	"github.com/SawitProRecruitment/UserService/metrics"
+	attributeSchemasRepo "github.com/SawitProRecruitment/UserService/repository/attributeschemas"
+	auditRepo "github.com/SawitProRecruitment/UserService/repository/audit"
+	clientsRepo "github.com/SawitProRecruitment/UserService/repository/clients"
+	devicesRepo "github.com/SawitProRecruitment/UserService/repository/devices"
+	exportsRepo "github.com/SawitProRecruitment/UserService/repository/exports"
+	otpsRepo "github.com/SawitProRecruitment/UserService/repository/otps"
+	phoneChangesRepo "github.com/SawitProRecruitment/UserService/repository/phonechanges"
+	tokensRepo "github.com/SawitProRecruitment/UserService/repository/tokens"
+	userChangesRepo "github.com/SawitProRecruitment/UserService/repository/userchanges"
 	usersRepo "github.com/SawitProRecruitment/UserService/repository/users"
+	"github.com/SawitProRecruitment/UserService/sms/logsender"
+	"github.com/SawitProRecruitment/UserService/storage/localstore"
+	"github.com/SawitProRecruitment/UserService/tracing"
+	"github.com/SawitProRecruitment/UserService/usecase"
 	"github.com/SawitProRecruitment/UserService/usecase/users"
+	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/SawitProRecruitment/UserService/generated"
@@ -16,32 +40,267 @@ import (
 )
 
 func main() {
+	normalizePhoneNumbers := flag.Bool("normalize-phone-numbers", false, "convert the stored phone numbers to E.164 then exit")
+	dryRun := flag.Bool("dry-run", false, "with -normalize-phone-numbers, only report the changes")
+	configLoader := config.NewLoader(flag.CommandLine)
+	flag.Parse()
+
+	cfg, err := configLoader.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *normalizePhoneNumbers {
+		userUsecase := newUserUsecases(cfg)
+		defer closeUserUsecases(userUsecase)
+		runNormalizeUserPhoneNumbers(userUsecase, *dryRun)
+		return
+	}
+
+	// the server & background workers are stopped on SIGTERM (e.g. on deploy) or Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.SetupOptions{
+		Exporter:     cfg.Tracing.Exporter,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure: cfg.Tracing.OTLPInsecure,
+		File:         cfg.Tracing.File,
+	})
+	if err != nil {
+		log.Fatalf("failed to setup the tracing: %v", err)
+	}
+
 	e := echo.New()
+	e.HTTPErrorHandler = handler.HTTPErrorHandler
+	e.IPExtractor = handler.NewIPExtractor(cfg.HTTP.TrustedProxyRanges())
+	e.Server.ReadTimeout = cfg.HTTP.ReadTimeout
+	e.Server.WriteTimeout = cfg.HTTP.WriteTimeout
+	e.Server.IdleTimeout = cfg.HTTP.IdleTimeout
 
-	var server generated.ServerInterface = newServer()
+	userUsecase := newUserUsecases(cfg)
 
+	var workers sync.WaitGroup
+	workers.Add(2)
+	go func() {
+		defer workers.Done()
+		runPeriodically(ctx, time.Hour, "anonymize deleted users", func(ctx context.Context) (int, error) {
+			result, err := userUsecase.AnonymizeDeletedUsers(ctx, usecase.AnonymizeDeletedUsersInput{})
+			return len(result.UserIDs), err
+		})
+	}()
+	go func() {
+		defer workers.Done()
+		runPeriodically(ctx, 10*time.Second, "process user exports", func(ctx context.Context) (int, error) {
+			result, err := userUsecase.ProcessUserExports(ctx, usecase.ProcessUserExportsInput{})
+			return len(result.ReadyExportIDs) + len(result.FailedExportIDs), err
+		})
+	}()
+
+	server := handler.NewServer(handler.NewServerOptions{
+		UserUsecase: userUsecase,
+		HTTP:        cfg.HTTP,
+	})
+
+	e.Use(server.RequestID())
+	e.Use(server.Tracing())
+	e.Use(server.Metrics())
+	e.Use(server.Authorize())
 	generated.RegisterHandlers(e, server)
-	e.Logger.Fatal(e.Start(":1323"))
+	// the metrics are meant to be scraped from the internal network, the gateway should not expose them
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+	// the blobs (e.g. avatars) are public, they're served as is until they're moved to an object storage
+	e.Static("/blobs", cfg.Storage.BlobDir)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := e.Start(cfg.HTTP.Addr); !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	var startErr error
+	select {
+	case startErr = <-serverErr:
+		stop()
+	case <-ctx.Done():
+		log.Print("shutting down, draining the in-flight requests")
+	}
+
+	// the listener is closed right away, then the in-flight requests have until the deadline to complete
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+	if err = e.Shutdown(shutdownCtx); err != nil {
+		log.Printf("failed to drain the in-flight requests: %v", err)
+	}
+	// the workers stop once their current job is done, their context being cancelled
+	workers.Wait()
+	closeUserUsecases(userUsecase)
+	// the pending spans are flushed last, so that the spans of the drained requests are exported too
+	if err = shutdownTracing(shutdownCtx); err != nil {
+		log.Printf("failed to flush the spans: %v", err)
+	}
+
+	if startErr != nil {
+		log.Fatalf("failed to start the server: %v", startErr)
+	}
+}
+
+// closeUserUsecases will release the database connection pool, once nothing uses it anymore
+func closeUserUsecases(userUsecase usecase.UserUsecases) {
+	if err := userUsecase.Close(); err != nil {
+		log.Printf("failed to close the database: %v", err)
+	}
 }
 
-func newServer() *handler.Server {
-	userRepository, err := usersRepo.NewUserRepository(repository.NewRepositoryOptions{Dsn: os.Getenv("DATABASE_URL")})
+func newUserUsecases(cfg config.Config) usecase.UserUsecases {
+	db, err := repository.OpenDatabase(repository.NewRepositoryOptions{Database: cfg.Database})
+	if err != nil {
+		panic(err)
+	}
+	if err = repository.RegisterDatabaseMetrics(db); err != nil {
+		panic(err)
+	}
+	repositoryOpts := repository.NewRepositoryOptions{DB: db}
+
+	userRepository, err := usersRepo.NewUserRepository(repositoryOpts)
+	if err != nil {
+		panic(err)
+	}
+	tokenRepository, err := tokensRepo.NewTokenRepository(repositoryOpts)
+	if err != nil {
+		panic(err)
+	}
+	oauthClientRepository, err := clientsRepo.NewOAuthClientRepository(repositoryOpts)
+	if err != nil {
+		panic(err)
+	}
+	auditRepository, err := auditRepo.NewAuditRepository(repositoryOpts)
+	if err != nil {
+		panic(err)
+	}
+	userExportRepository, err := exportsRepo.NewUserExportRepository(repositoryOpts)
+	if err != nil {
+		panic(err)
+	}
+	loginOTPRepository, err := otpsRepo.NewLoginOTPRepository(repositoryOpts)
+	if err != nil {
+		panic(err)
+	}
+	userDeviceRepository, err := devicesRepo.NewUserDeviceRepository(repositoryOpts)
+	if err != nil {
+		panic(err)
+	}
+	phoneChangeRepository, err := phoneChangesRepo.NewPhoneChangeRepository(repositoryOpts)
+	if err != nil {
+		panic(err)
+	}
+	attributeSchemaRepository, err := attributeSchemasRepo.NewAttributeSchemaRepository(repositoryOpts)
+	if err != nil {
+		panic(err)
+	}
+	userChangeRepository, err := userChangesRepo.NewUserChangeRepository(repositoryOpts)
 	if err != nil {
 		panic(err)
 	}
 
-	// for demo purposes, we'll just generate a key with the same lifetime as the server,
+	// without a configured key, we'll just generate a key with the same lifetime as the server for demo purposes,
 	// meaning all JWT will be invalidated on restart
-	rsaPrivateKey, _ := rsa.GenerateKey(rand.Reader, 1024)
+	rsaPrivateKey := cfg.Auth.JwtSecret()
+	if rsaPrivateKey == nil {
+		log.Print("no JWT private key is configured, the tokens will be invalidated on restart")
+		if rsaPrivateKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			panic(err)
+		}
+	}
 
-	userUsecase := users.NewUserUsecases(users.NewUserUsecasesOptions{
-		UserRepo:  userRepository,
-		JwtSecret: rsaPrivateKey,
-		JwtTtl:    10 * time.Minute,
+	// there's no SMS provider integration yet, the login codes are written to the log instead
+	smsSender := logsender.NewLogSender()
+
+	// there's no email provider integration yet, the emails are written as files into the outbox directory instead
+	mailSender, err := filesender.NewFileSender(filesender.NewFileSenderOptions{
+		Dir:  cfg.Mail.OutboxDir,
+		From: cfg.Mail.From,
 	})
+	if err != nil {
+		panic(err)
+	}
 
-	opts := handler.NewServerOptions{
-		UserUsecase: userUsecase,
+	blobStore, err := localstore.NewLocalStore(localstore.NewLocalStoreOptions{
+		Dir:     cfg.Storage.BlobDir,
+		BaseURL: cfg.HTTP.PublicURL + "/blobs",
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return users.NewUserUsecases(users.NewUserUsecasesOptions{
+		UserRepo:        userRepository,
+		TokenRepo:       tokenRepository,
+		OAuthClientRepo: oauthClientRepository,
+		AuditRepo:       auditRepository,
+		UserExportRepo:  userExportRepository,
+		LoginOTPRepo:    loginOTPRepository,
+		UserDeviceRepo:  userDeviceRepository,
+		PhoneChangeRepo: phoneChangeRepository,
+		AttrSchemaRepo:  attributeSchemaRepository,
+		UserChangeRepo:  userChangeRepository,
+		SMSSender:       smsSender,
+		MailSender:      mailSender,
+		BlobStore:       blobStore,
+		JwtSecret:       rsaPrivateKey,
+		Auth:            cfg.Auth,
+		Users:           cfg.Users,
+		HTTP:            cfg.HTTP,
+		Database:        cfg.Database,
+	})
+}
+
+// runNormalizeUserPhoneNumbers is the one-off migration of the phone numbers stored before they were normalized,
+// the numbers that can't be migrated automatically are logged to be fixed manually
+func runNormalizeUserPhoneNumbers(userUsecase usecase.UserUsecases, dryRun bool) {
+	result, err := userUsecase.NormalizeUserPhoneNumbers(context.Background(), usecase.NormalizeUserPhoneNumbersInput{DryRun: dryRun})
+	if err != nil {
+		log.Fatalf("failed to normalize phone numbers: %v", err)
+	}
+
+	for _, change := range result.Normalized {
+		log.Printf("user %d: %q normalized to %q", change.UserID, change.PhoneNo, change.NormalizedPhoneNo)
+	}
+	for _, collision := range result.Collisions {
+		log.Printf("user %d: %q collides with user %d on %q", collision.UserID, collision.PhoneNo,
+			collision.ConflictingUserID, collision.NormalizedPhoneNo)
+	}
+	for _, invalid := range result.Invalid {
+		log.Printf("user %d: %q is invalid, %s", invalid.UserID, invalid.PhoneNo, invalid.Reason)
+	}
+	log.Printf("normalized %d phone numbers (dry run: %t), %d collisions, %d invalid",
+		len(result.Normalized), dryRun, len(result.Collisions), len(result.Invalid))
+}
+
+// runPeriodically will run the job on every interval, until the context is done. The job returns the number of
+// processed items, and is repeated right away while there is something to process, so a backlog doesn't wait for
+// the next tick
+func runPeriodically(ctx context.Context, interval time.Duration, name string, job func(ctx context.Context) (int, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for {
+			processed, err := job(ctx)
+			if err != nil {
+				log.Printf("failed to %s: %v", name, err)
+				break
+			}
+			if processed == 0 {
+				break
+			}
+			log.Printf("%s: processed %d", name, processed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
-	return handler.NewServer(opts)
 }
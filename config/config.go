@@ -0,0 +1,156 @@
+// Package config loads the configuration of the service from, by increasing precedence: the defaults, a YAML file,
+// the environment variables and the command line flags
+package config
+
+import (
+	"crypto/rsa"
+	"github.com/SawitProRecruitment/UserService/tracing"
+	"net"
+	"time"
+)
+
+// Config is the configuration of the service, every setting has a YAML key (its path, e.g. `database.url`, is also
+// the name of its flag) and an environment variable. The secrets can also be read from the file named by the
+// environment variable suffixed by `_FILE`, e.g. `DATABASE_URL_FILE`
+type Config struct {
+	HTTP     HTTP     `yaml:"http"`
+	Database Database `yaml:"database"`
+	Auth     Auth     `yaml:"auth"`
+	Users    Users    `yaml:"users"`
+	Storage  Storage  `yaml:"storage"`
+	Mail     Mail     `yaml:"mail"`
+	Tracing  Tracing  `yaml:"tracing"`
+}
+
+type HTTP struct {
+	// Addr is the address the API listens on
+	Addr string `yaml:"addr" env:"HTTP_ADDR"`
+	// PublicURL is the URL the API is reached at by the clients, used for the links in emails and the blob URLs
+	PublicURL string `yaml:"public_url" env:"PUBLIC_URL"`
+	// RequireIfMatch rejects the profile updates that are not conditional on the ETag of the profile
+	RequireIfMatch bool `yaml:"require_if_match" env:"REQUIRE_IF_MATCH"`
+	// ReadTimeout, WriteTimeout & IdleTimeout are the timeouts of the connections, see http.Server
+	ReadTimeout  time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	WriteTimeout time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	// TrustedProxies are the CIDR ranges (e.g. 10.0.0.0/8) of the proxies whose X-Forwarded-For header is trusted to
+	// identify the client IP, the IP of the connection is used when empty. The environment variable & flag are comma
+	// separated
+	TrustedProxies []string `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"`
+	// ShutdownTimeout is how long the in-flight requests are waited for on shutdown, before they're cut
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT"`
+
+	// trustedProxyRanges is TrustedProxies once parsed by the validation
+	trustedProxyRanges []*net.IPNet
+}
+
+// TrustedProxyRanges will return the parsed TrustedProxies
+func (h HTTP) TrustedProxyRanges() []*net.IPNet {
+	return h.trustedProxyRanges
+}
+
+type Database struct {
+	// URL is the PostgreSQL connection string
+	URL string `yaml:"url" env:"DATABASE_URL" secret:"true"`
+	// MaxOpenConns & ConnMaxLifetime are unlimited when zero, MaxIdleConns is the default of database/sql when zero
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DATABASE_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DATABASE_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DATABASE_CONN_MAX_LIFETIME"`
+	// PingTimeout bounds the database check of the readiness probe
+	PingTimeout time.Duration `yaml:"ping_timeout" env:"DATABASE_PING_TIMEOUT"`
+}
+
+type Auth struct {
+	// JwtPrivateKey is the PEM encoded RSA key signing the tokens, a key is generated on startup when it's empty,
+	// meaning every token is invalidated on restart
+	JwtPrivateKey string        `yaml:"jwt_private_key" env:"JWT_PRIVATE_KEY" secret:"true"`
+	JwtTtl        time.Duration `yaml:"jwt_ttl" env:"JWT_TTL"`
+	// LoginOTPTtl is how long a code sent by SMS is valid, for login and phone number changes
+	LoginOTPTtl time.Duration `yaml:"login_otp_ttl" env:"LOGIN_OTP_TTL"`
+	// EmailVerificationTtl is how long an email verification link is valid
+	EmailVerificationTtl time.Duration `yaml:"email_verification_ttl" env:"EMAIL_VERIFICATION_TTL"`
+	// ImpersonationTtl is how long an impersonation token is valid, it should be kept short
+	ImpersonationTtl time.Duration `yaml:"impersonation_ttl" env:"IMPERSONATION_TTL"`
+
+	// jwtSecret is JwtPrivateKey once parsed by the validation
+	jwtSecret *rsa.PrivateKey
+}
+
+// JwtSecret will return the parsed JwtPrivateKey, nil when it's empty
+func (a Auth) JwtSecret() *rsa.PrivateKey {
+	return a.jwtSecret
+}
+
+type Users struct {
+	// PhoneNoCooldown is how long the old phone number is reserved to the user after a phone number change
+	PhoneNoCooldown time.Duration `yaml:"phone_no_cooldown" env:"PHONE_NO_COOLDOWN"`
+	// DeletionGracePeriod is how long a deletion request can be cancelled, before the account is anonymized
+	DeletionGracePeriod time.Duration `yaml:"deletion_grace_period" env:"DELETION_GRACE_PERIOD"`
+	// UserExportRetention is how long a generated personal data export can be downloaded
+	UserExportRetention time.Duration `yaml:"user_export_retention" env:"USER_EXPORT_RETENTION"`
+	// AllowedPhoneCountries are the country codes (e.g. ID) of the phone numbers that can be registered, every
+	// supported country is allowed when empty. The environment variable & flag are comma separated
+	AllowedPhoneCountries []string `yaml:"allowed_phone_countries" env:"ALLOWED_PHONE_COUNTRIES"`
+}
+
+type Storage struct {
+	// BlobDir is the directory the blobs (e.g. avatars) are stored in
+	BlobDir string `yaml:"blob_dir" env:"BLOB_DIR"`
+}
+
+type Mail struct {
+	// OutboxDir is the directory the emails are written to, there's no email provider integration yet
+	OutboxDir string `yaml:"outbox_dir" env:"MAIL_OUTBOX_DIR"`
+	From      string `yaml:"from" env:"MAIL_FROM"`
+}
+
+type Tracing struct {
+	// Exporter is where the spans are sent, either none, otlp (OpenTelemetry collector) or stdout (for local testing)
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER"`
+	// OTLPEndpoint is the host & port of the OTLP/HTTP collector, e.g. localhost:4318
+	OTLPEndpoint string `yaml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	// OTLPInsecure sends the spans over plain HTTP, e.g. to a collector running as a sidecar
+	OTLPInsecure bool `yaml:"otlp_insecure" env:"TRACING_OTLP_INSECURE"`
+	// File is where the stdout exporter writes the spans, stdout when empty
+	File string `yaml:"file" env:"TRACING_FILE"`
+}
+
+// Default will return the configuration used for the settings that are not set
+func Default() Config {
+	return Config{
+		HTTP: HTTP{
+			Addr:      ":1323",
+			PublicURL: "http://localhost:1323",
+			// the avatar uploads & export downloads are the slowest requests
+			ReadTimeout:     30 * time.Second,
+			WriteTimeout:    60 * time.Second,
+			IdleTimeout:     2 * time.Minute,
+			ShutdownTimeout: 30 * time.Second,
+		},
+		Database: Database{
+			MaxIdleConns: 2,
+			PingTimeout:  2 * time.Second,
+		},
+		Auth: Auth{
+			JwtTtl:               10 * time.Minute,
+			LoginOTPTtl:          5 * time.Minute,
+			EmailVerificationTtl: 24 * time.Hour,
+			ImpersonationTtl:     10 * time.Minute,
+		},
+		Users: Users{
+			PhoneNoCooldown:     30 * 24 * time.Hour,
+			DeletionGracePeriod: 14 * 24 * time.Hour,
+			UserExportRetention: 7 * 24 * time.Hour,
+		},
+		Storage: Storage{
+			BlobDir: "blobs",
+		},
+		Mail: Mail{
+			OutboxDir: "outbox",
+			From:      "no-reply@localhost",
+		},
+		Tracing: Tracing{
+			Exporter: tracing.ExporterNone,
+		},
+	}
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// load will load the configuration with the command line args
+func load(t *testing.T, args ...string) (Config, error) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	loader := NewLoader(flags)
+	if err := flags.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return loader.Load()
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	name = filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(name, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestDefaults(t *testing.T) {
+	a := assert.New(t)
+	t.Setenv("DATABASE_URL", "postgres://localhost/database")
+
+	cfg, err := load(t)
+
+	a.NoError(err)
+	expected := Default()
+	expected.Database.URL = "postgres://localhost/database"
+	a.Equal(expected, cfg)
+	a.Nil(cfg.Auth.JwtSecret())
+}
+
+func TestPrecedence(t *testing.T) {
+	a := assert.New(t)
+	configFile := writeFile(t, "config.yml", `
+http:
+  addr: ":8080"
+  public_url: "https://users.example.com"
+database:
+  url: "postgres://file/database"
+  max_open_conns: 10
+auth:
+  jwt_ttl: 15m
+users:
+  allowed_phone_countries: [id, SG]
+`)
+	t.Setenv("CONFIG_FILE", configFile)
+	t.Setenv("HTTP_ADDR", ":9090")
+	t.Setenv("JWT_TTL", "20m")
+	t.Setenv("REQUIRE_IF_MATCH", "true")
+
+	cfg, err := load(t, "-auth.jwt_ttl", "30m", "-users.allowed_phone_countries", "MY")
+
+	a.NoError(err)
+	a.Equal(":9090", cfg.HTTP.Addr, "the environment overrides the file")
+	a.Equal("https://users.example.com", cfg.HTTP.PublicURL)
+	a.True(cfg.HTTP.RequireIfMatch)
+	a.Equal("postgres://file/database", cfg.Database.URL)
+	a.Equal(10, cfg.Database.MaxOpenConns)
+	a.Equal(2, cfg.Database.MaxIdleConns, "the defaults are kept")
+	a.Equal(30*time.Minute, cfg.Auth.JwtTtl, "the flags override the environment")
+	a.Equal([]string{"MY"}, cfg.Users.AllowedPhoneCountries)
+}
+
+func TestConfigFlag(t *testing.T) {
+	a := assert.New(t)
+	t.Setenv("CONFIG_FILE", writeFile(t, "env.yml", `database: {url: "postgres://env/database"}`))
+	configFile := writeFile(t, "flag.yml", `database: {url: "postgres://flag/database"}`)
+
+	cfg, err := load(t, "-config", configFile, "-http.require_if_match")
+
+	a.NoError(err)
+	a.Equal("postgres://flag/database", cfg.Database.URL)
+	a.True(cfg.HTTP.RequireIfMatch)
+}
+
+func TestUnknownFileKey(t *testing.T) {
+	a := assert.New(t)
+	configFile := writeFile(t, "config.yml", `
+database:
+  uri: "postgres://localhost/database"
+`)
+
+	_, err := load(t, "-config", configFile)
+
+	a.ErrorContains(err, "field uri not found")
+}
+
+func TestSecretFile(t *testing.T) {
+	a := assert.New(t)
+
+	key, _ := rsa.GenerateKey(rand.Reader, 1024)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	t.Setenv("DATABASE_URL_FILE", writeFile(t, "database_url", "postgres://secret/database\n"))
+	t.Setenv("JWT_PRIVATE_KEY_FILE", writeFile(t, "jwt.pem", string(keyPEM)))
+
+	cfg, err := load(t)
+
+	a.NoError(err)
+	a.Equal("postgres://secret/database", cfg.Database.URL)
+	a.Equal(key, cfg.Auth.JwtSecret())
+}
+
+func TestSecretFileConflict(t *testing.T) {
+	a := assert.New(t)
+	t.Setenv("DATABASE_URL", "postgres://localhost/database")
+	t.Setenv("DATABASE_URL_FILE", writeFile(t, "database_url", "postgres://secret/database"))
+
+	_, err := load(t)
+
+	a.EqualError(err, "database.url: only one of DATABASE_URL and DATABASE_URL_FILE can be set")
+}
+
+func TestInvalidValue(t *testing.T) {
+	a := assert.New(t)
+	t.Setenv("DATABASE_URL", "postgres://localhost/database")
+	t.Setenv("JWT_TTL", "10")
+
+	_, err := load(t)
+
+	a.EqualError(err, `auth.jwt_ttl: invalid duration "10" from JWT_TTL, e.g. 10m or 24h`)
+}
+
+func TestValidation(t *testing.T) {
+	a := assert.New(t)
+	t.Setenv("PUBLIC_URL", "localhost:1323")
+	t.Setenv("JWT_PRIVATE_KEY", "not-a-key")
+	t.Setenv("LOGIN_OTP_TTL", "0s")
+	t.Setenv("ALLOWED_PHONE_COUNTRIES", "ID,US")
+	t.Setenv("TRACING_EXPORTER", "otlp")
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8,10.0.0.1")
+
+	_, err := load(t)
+
+	a.IsType(ValidationError{}, err)
+	a.Equal([]string{
+		`http.public_url (PUBLIC_URL) must be an absolute http(s) URL, got "localhost:1323"`,
+		`http.trusted_proxies (TRUSTED_PROXIES) must be CIDR ranges, e.g. 10.0.0.0/8, got "10.0.0.1"`,
+		"database.url (DATABASE_URL or DATABASE_URL_FILE) is required",
+		"auth.jwt_private_key (JWT_PRIVATE_KEY or JWT_PRIVATE_KEY_FILE) must be a PEM encoded RSA private key",
+		"auth.login_otp_ttl (LOGIN_OTP_TTL) must be a positive duration",
+		`users.allowed_phone_countries (ALLOWED_PHONE_COUNTRIES) has the unsupported country "US", supported countries are [ID MY PH SG TH]`,
+		"tracing.otlp_endpoint (TRACING_OTLP_ENDPOINT) is required by the otlp exporter, e.g. localhost:4318",
+	}, err.(ValidationError).Problems)
+}
@@ -0,0 +1,218 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// configFileEnv is the environment variable of the YAML file, the `-config` flag takes precedence over it
+	configFileEnv = "CONFIG_FILE"
+	// secretFileSuffix is appended to the environment variable of a secret to read it from a file instead
+	secretFileSuffix = "_FILE"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setting is a leaf of Config, along with where it's loaded from
+type setting struct {
+	// path is made of the YAML keys, e.g. `database.url`, it's also the name of the flag
+	path   string
+	env    string
+	secret bool
+	field  reflect.Value
+}
+
+// settings will list the settings of the configuration, their fields are the ones of cfg
+func settings(cfg *Config) []setting {
+	var res []setting
+	var walk func(prefix string, v reflect.Value)
+	walk = func(prefix string, v reflect.Value) {
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			key, ok := field.Tag.Lookup("yaml")
+			if !ok || !field.IsExported() {
+				continue
+			}
+			if field.Type.Kind() == reflect.Struct {
+				walk(prefix+key+".", v.Field(i))
+				continue
+			}
+			res = append(res, setting{
+				path:   prefix + key,
+				env:    field.Tag.Get("env"),
+				secret: field.Tag.Get("secret") == "true",
+				field:  v.Field(i),
+			})
+		}
+	}
+	walk("", reflect.ValueOf(cfg).Elem())
+	return res
+}
+
+// set will parse the value of the setting, source is where the value comes from for the error messages
+func (s setting) set(value, source string) error {
+	switch {
+	case s.field.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s: invalid duration %q from %s, e.g. 10m or 24h", s.path, value, source)
+		}
+		s.field.SetInt(int64(d))
+	case s.field.Kind() == reflect.String:
+		s.field.SetString(value)
+	case s.field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: invalid boolean %q from %s, either true or false", s.path, value, source)
+		}
+		s.field.SetBool(b)
+	case s.field.Kind() == reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s: invalid integer %q from %s", s.path, value, source)
+		}
+		s.field.SetInt(int64(n))
+	case s.field.Kind() == reflect.Slice && s.field.Type().Elem().Kind() == reflect.String:
+		var values []string
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		s.field.Set(reflect.ValueOf(values))
+	default:
+		panic(fmt.Sprintf("unsupported type %s of setting %s", s.field.Type(), s.path))
+	}
+	return nil
+}
+
+// flagValue collects the value of a setting flag, it's only applied once the file & environment are loaded
+type flagValue struct {
+	value  *string
+	isBool bool
+}
+
+func (f flagValue) String() string {
+	if f.value == nil {
+		return ""
+	}
+	return *f.value
+}
+
+func (f flagValue) Set(value string) error {
+	*f.value = value
+	return nil
+}
+
+func (f flagValue) IsBoolFlag() bool {
+	return f.isBool
+}
+
+// Loader loads the configuration, its flags are registered on a flag.FlagSet along with the other flags of the
+// command
+type Loader struct {
+	flags      *flag.FlagSet
+	configFile string
+	values     map[string]*string
+}
+
+// NewLoader will register the `-config` flag and a flag per setting, e.g. `-database.url`
+func NewLoader(flags *flag.FlagSet) *Loader {
+	l := &Loader{flags: flags, values: map[string]*string{}}
+	flags.StringVar(&l.configFile, "config", "", "YAML configuration file, "+configFileEnv+" by default")
+	for _, s := range settings(&Config{}) {
+		value := new(string)
+		l.values[s.path] = value
+		usage := s.path
+		if s.env != "" {
+			usage += ", " + s.env + " by default"
+		}
+		flags.Var(flagValue{value: value, isBool: s.field.Kind() == reflect.Bool}, s.path, usage)
+	}
+	return l
+}
+
+// Load will load & validate the configuration, once the flags are parsed
+func (l *Loader) Load() (cfg Config, err error) {
+	cfg = Default()
+	configSettings := settings(&cfg)
+
+	configFile := l.configFile
+	if configFile == "" {
+		configFile = os.Getenv(configFileEnv)
+	}
+	if configFile != "" {
+		if err = loadFile(&cfg, configFile); err != nil {
+			return
+		}
+	}
+
+	for _, s := range configSettings {
+		if err = loadEnv(s); err != nil {
+			return
+		}
+	}
+
+	setFlags := map[string]bool{}
+	l.flags.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+	for _, s := range configSettings {
+		if setFlags[s.path] {
+			if err = s.set(*l.values[s.path], "flag -"+s.path); err != nil {
+				return
+			}
+		}
+	}
+
+	if err = cfg.validate(); err != nil {
+		return
+	}
+	return cfg, nil
+}
+
+// loadFile will set the settings of the YAML file, unknown keys are rejected as they're most likely typos
+func loadFile(cfg *Config, name string) error {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("failed to read the configuration file: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(cfg); err != nil && err != io.EOF {
+		return fmt.Errorf("invalid configuration file %s: %w", name, err)
+	}
+	return nil
+}
+
+// loadEnv will set the setting from its environment variable, or the file of its `_FILE` variant for the secrets
+func loadEnv(s setting) error {
+	if s.env == "" {
+		return nil
+	}
+	value, isSet := os.LookupEnv(s.env)
+	if s.secret {
+		if name, ok := os.LookupEnv(s.env + secretFileSuffix); ok {
+			if isSet {
+				return fmt.Errorf("%s: only one of %s and %s%s can be set", s.path, s.env, s.env, secretFileSuffix)
+			}
+			content, err := os.ReadFile(name)
+			if err != nil {
+				return fmt.Errorf("%s: failed to read %s%s: %w", s.path, s.env, secretFileSuffix, err)
+			}
+			// files written by editors or `echo` usually end with a newline which is not part of the secret
+			return s.set(strings.TrimRight(string(content), "\r\n"), s.env+secretFileSuffix)
+		}
+	}
+	if !isSet {
+		return nil
+	}
+	return s.set(value, s.env)
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/phone"
+	"github.com/SawitProRecruitment/UserService/tracing"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// ValidationError lists every invalid setting of a configuration, so that they can all be fixed at once
+type ValidationError struct {
+	Problems []string
+}
+
+func (e ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// validate will check the configuration, and parse the settings that need it (e.g. the JWT private key)
+func (c *Config) validate() error {
+	var problems []string
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if c.HTTP.Addr == "" {
+		addProblem("http.addr (HTTP_ADDR) must not be empty, e.g. :1323")
+	}
+	if u, err := url.Parse(c.HTTP.PublicURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		addProblem("http.public_url (PUBLIC_URL) must be an absolute http(s) URL, got %q", c.HTTP.PublicURL)
+	} else if strings.HasSuffix(c.HTTP.PublicURL, "/") {
+		addProblem("http.public_url (PUBLIC_URL) must not end with a slash, got %q", c.HTTP.PublicURL)
+	}
+	c.HTTP.trustedProxyRanges = nil
+	for _, proxy := range c.HTTP.TrustedProxies {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(proxy))
+		if err != nil {
+			addProblem("http.trusted_proxies (TRUSTED_PROXIES) must be CIDR ranges, e.g. 10.0.0.0/8, got %q", proxy)
+			continue
+		}
+		c.HTTP.trustedProxyRanges = append(c.HTTP.trustedProxyRanges, ipNet)
+	}
+
+	if c.Database.URL == "" {
+		addProblem("database.url (DATABASE_URL or DATABASE_URL_FILE) is required")
+	}
+	if c.Database.MaxOpenConns < 0 {
+		addProblem("database.max_open_conns (DATABASE_MAX_OPEN_CONNS) must not be negative, zero means unlimited")
+	}
+	if c.Database.MaxIdleConns < 0 {
+		addProblem("database.max_idle_conns (DATABASE_MAX_IDLE_CONNS) must not be negative")
+	}
+	if c.Database.ConnMaxLifetime < 0 {
+		addProblem("database.conn_max_lifetime (DATABASE_CONN_MAX_LIFETIME) must not be negative, zero means unlimited")
+	}
+
+	c.Auth.jwtSecret = nil
+	if c.Auth.JwtPrivateKey != "" {
+		var err error
+		if c.Auth.jwtSecret, err = parseRSAPrivateKey(c.Auth.JwtPrivateKey); err != nil {
+			addProblem("auth.jwt_private_key (JWT_PRIVATE_KEY or JWT_PRIVATE_KEY_FILE) %s", err)
+		}
+	}
+	for _, d := range []struct {
+		path, env string
+		positive  bool
+	}{
+		{"http.read_timeout", "HTTP_READ_TIMEOUT", c.HTTP.ReadTimeout > 0},
+		{"http.write_timeout", "HTTP_WRITE_TIMEOUT", c.HTTP.WriteTimeout > 0},
+		{"http.idle_timeout", "HTTP_IDLE_TIMEOUT", c.HTTP.IdleTimeout > 0},
+		{"http.shutdown_timeout", "HTTP_SHUTDOWN_TIMEOUT", c.HTTP.ShutdownTimeout > 0},
+		{"database.ping_timeout", "DATABASE_PING_TIMEOUT", c.Database.PingTimeout > 0},
+		{"auth.jwt_ttl", "JWT_TTL", c.Auth.JwtTtl > 0},
+		{"auth.login_otp_ttl", "LOGIN_OTP_TTL", c.Auth.LoginOTPTtl > 0},
+		{"auth.email_verification_ttl", "EMAIL_VERIFICATION_TTL", c.Auth.EmailVerificationTtl > 0},
+		{"auth.impersonation_ttl", "IMPERSONATION_TTL", c.Auth.ImpersonationTtl > 0},
+		{"users.phone_no_cooldown", "PHONE_NO_COOLDOWN", c.Users.PhoneNoCooldown > 0},
+		{"users.deletion_grace_period", "DELETION_GRACE_PERIOD", c.Users.DeletionGracePeriod > 0},
+		{"users.user_export_retention", "USER_EXPORT_RETENTION", c.Users.UserExportRetention > 0},
+	} {
+		if !d.positive {
+			addProblem("%s (%s) must be a positive duration", d.path, d.env)
+		}
+	}
+
+	for i, country := range c.Users.AllowedPhoneCountries {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if _, ok := phone.Countries[country]; !ok {
+			addProblem("users.allowed_phone_countries (ALLOWED_PHONE_COUNTRIES) has the unsupported country %q, "+
+				"supported countries are %v", c.Users.AllowedPhoneCountries[i], phone.SupportedCountries())
+		}
+		c.Users.AllowedPhoneCountries[i] = country
+	}
+
+	if c.Storage.BlobDir == "" {
+		addProblem("storage.blob_dir (BLOB_DIR) must not be empty")
+	}
+	if c.Mail.OutboxDir == "" {
+		addProblem("mail.outbox_dir (MAIL_OUTBOX_DIR) must not be empty")
+	}
+	if c.Mail.From == "" {
+		addProblem("mail.from (MAIL_FROM) must not be empty")
+	}
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout:
+	case tracing.ExporterOTLP:
+		if c.Tracing.OTLPEndpoint == "" {
+			addProblem("tracing.otlp_endpoint (TRACING_OTLP_ENDPOINT) is required by the otlp exporter, e.g. localhost:4318")
+		}
+	default:
+		addProblem("tracing.exporter (TRACING_EXPORTER) must be one of %v, got %q", tracing.Exporters, c.Tracing.Exporter)
+	}
+
+	if len(problems) > 0 {
+		return ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// parseRSAPrivateKey will parse a PEM encoded RSA private key, either PKCS #1 or PKCS #8
+func parseRSAPrivateKey(key string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, fmt.Errorf("must be a PEM encoded RSA private key")
+	}
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("must be a PEM encoded RSA private key, %v", err)
+	}
+	privateKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("must be an RSA private key, got a %T", parsed)
+	}
+	return privateKey, nil
+}
@@ -11,6 +11,171 @@ CREATE TABLE users (
     id bigserial PRIMARY KEY,
     phone_no VARCHAR(32) UNIQUE NOT NULL,
     full_name VARCHAR(64) NOT NULL,
-    password_hash VARCHAR(64) NOT NULL,
-    successful_login_count INT NOT NULL DEFAULT 0
+    -- optional login identifier, stored lowercase, only usable for login once verified. Only the verified emails are
+    -- unique, so that nobody can hold an email they don't own and prevent its owner from adding it
+    email VARCHAR(254),
+    email_verified_at TIMESTAMPTZ,
+    -- NULL once the account is anonymized after a deletion request
+    password_hash VARCHAR(64),
+    -- optional 6-digit PIN, only usable on devices enrolled through a password login
+    pin_hash VARCHAR(64),
+    -- consecutive wrong PINs, PIN login is locked once the limit is reached until the next password login
+    pin_failed_attempts INT NOT NULL DEFAULT 0,
+    successful_login_count INT NOT NULL DEFAULT 0,
+    -- prefix of the avatar thumbnails in the blob store, NULL when the user has no avatar
+    avatar_key VARCHAR(128),
+    -- custom profile attributes (e.g. estate_code), validated against the latest attribute_schemas row
+    attributes JSONB NOT NULL DEFAULT '{}',
+    -- incremented by every change of the profile (including the login count), exposed as its ETag for optimistic
+    -- concurrency control
+    version BIGINT NOT NULL DEFAULT 1,
+    -- suspended accounts can't login nor use already issued tokens, deactivated accounts are closed for good
+    status VARCHAR(16) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'suspended', 'deactivated')),
+    -- set while a self-service deletion is pending, the account is anonymized once the grace period is over
+    deletion_requested_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX users_email_idx ON users (LOWER(email)) WHERE email_verified_at IS NOT NULL;
+CREATE INDEX users_deletion_requested_at_idx ON users (deletion_requested_at) WHERE deletion_requested_at IS NOT NULL;
+
+-- Registered OAuth clients (e.g. legacy services) that are allowed to call the token introspection endpoint.
+-- The client secret is stored as a bcrypt hash, just like users password.
+CREATE TABLE oauth_clients (
+    id bigserial PRIMARY KEY,
+    client_id VARCHAR(64) UNIQUE NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    client_secret_hash VARCHAR(64) NOT NULL
+);
+
+-- Revoked JWT tokens, identified by their `jti` claim.
+-- Records can be removed once expires_at has passed, since the token will be rejected anyway.
+CREATE TABLE token_revocations (
+    jti VARCHAR(64) PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Roles assigned to each user, e.g. farmer, estate_manager, support, admin.
+-- The first admin must be assigned manually, e.g.:
+--   INSERT INTO user_roles (user_id, role) VALUES (1, 'admin');
+CREATE TABLE user_roles (
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    role VARCHAR(32) NOT NULL,
+    PRIMARY KEY (user_id, role)
+);
+
+-- Append-only log of security sensitive actions, e.g. role assignment.
+-- actor_user_id is NULL for actions performed by the system itself.
+CREATE TABLE audit_events (
+    id bigserial PRIMARY KEY,
+    actor_user_id BIGINT REFERENCES users (id),
+    action VARCHAR(64) NOT NULL,
+    target_user_id BIGINT REFERENCES users (id),
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX audit_events_target_user_id_idx ON audit_events (target_user_id, created_at);
+
+-- Support for the admin user search API.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX users_phone_no_prefix_idx ON users (phone_no varchar_pattern_ops);
+CREATE INDEX users_full_name_trgm_idx ON users USING gin (full_name gin_trgm_ops);
+CREATE INDEX users_created_at_idx ON users (created_at);
+
+-- Personal data exports requested by users, generated asynchronously by a background worker.
+-- The archive is kept in the database until expires_at, after which it's deleted by the same worker.
+-- Exports still processing long after claimed_at (e.g. the worker was stopped mid-job) are claimed again.
+-- The exports of a user are deleted along with the anonymization of the account.
+CREATE TABLE user_exports (
+    id bigserial PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    format VARCHAR(8) NOT NULL CHECK (format IN ('json', 'zip')),
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'ready', 'failed')),
+    content BYTEA,
+    content_type VARCHAR(64),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    claimed_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ
+);
+CREATE INDEX user_exports_pending_idx ON user_exports (id) WHERE status = 'pending';
+CREATE INDEX user_exports_processing_idx ON user_exports (claimed_at) WHERE status = 'processing';
+CREATE INDEX user_exports_expires_at_idx ON user_exports (expires_at);
+
+-- One-time login codes sent by SMS, only the bcrypt hash of the code is stored.
+-- The rows are also used to throttle the codes requested per phone number and per IP address.
+CREATE TABLE login_otps (
+    id bigserial PRIMARY KEY,
+    phone_no VARCHAR(32) NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    consumed_at TIMESTAMPTZ
+);
+CREATE INDEX login_otps_phone_no_idx ON login_otps (phone_no, created_at);
+CREATE INDEX login_otps_ip_address_idx ON login_otps (ip_address, created_at);
+
+-- Devices enrolled for PIN login, through a password login. Only the SHA-256 hash of the device token is stored.
+CREATE TABLE user_devices (
+    id bigserial PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX user_devices_user_id_idx ON user_devices (user_id);
+
+-- Pending phone number changes, the new phone number must be confirmed with the code sent to it by SMS.
+-- Only the bcrypt hash of the code is stored, the rows are also used to throttle the changes requested per user.
+CREATE TABLE user_phone_changes (
+    id bigserial PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    phone_no VARCHAR(32) NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    confirmed_at TIMESTAMPTZ
+);
+CREATE INDEX user_phone_changes_user_id_idx ON user_phone_changes (user_id, created_at);
+
+-- Phone numbers released by a phone number change, reserved to their previous owner until reserved_until,
+-- so that nobody else can register them right away and receive the codes meant for the previous owner.
+CREATE TABLE phone_no_reservations (
+    phone_no VARCHAR(32) PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    reserved_until TIMESTAMPTZ NOT NULL
+);
+
+-- JSON Schema of the custom profile attributes of users, managed by admins. Every change inserts a new row, the
+-- latest one is in effect. Stored attributes are only validated against the schema in effect when they're updated.
+CREATE TABLE attribute_schemas (
+    id bigserial PRIMARY KEY,
+    schema JSONB NOT NULL,
+    created_by BIGINT NOT NULL REFERENCES users (id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Field-level history of the changes made to the user profiles, kept as evidence of who changed what and when.
+-- Values are stored as JSON, NULL when the field was unset. actor_user_id is NULL for changes made by the system
+-- itself, and request_id correlates the change with the request logs. Values are erased once the user is anonymized.
+CREATE TABLE user_changes (
+    id bigserial PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    actor_user_id BIGINT REFERENCES users (id),
+    field VARCHAR(128) NOT NULL,
+    old_value JSONB,
+    new_value JSONB,
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX user_changes_user_id_idx ON user_changes (user_id, id);
+
+-- Avatars to remove from the blob store, queued in the same transaction as the replacement of the avatar or the
+-- anonymization of their owner, so that the removal is retried until it succeeds.
+CREATE TABLE avatar_deletions (
+    avatar_key VARCHAR(128) PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
 );
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+// Search users, newest first. Only accessible to admins.
+// (GET /admin/users)
+func (s *Server) ListUsers(ctx echo.Context, params generated.ListUsersParams) error {
+	input := usecase.ListUsersInput{}
+	if params.PhonePrefix != nil {
+		input.PhonePrefix = *params.PhonePrefix
+	}
+	if params.Name != nil {
+		input.Name = *params.Name
+	}
+	if params.CreatedFrom != nil {
+		input.CreatedFrom = *params.CreatedFrom
+	}
+	if params.CreatedTo != nil {
+		input.CreatedTo = *params.CreatedTo
+	}
+	if params.Status != nil {
+		input.Status = *params.Status
+	}
+	if params.Cursor != nil {
+		input.Cursor = *params.Cursor
+	}
+	if params.Limit != nil {
+		input.Limit = *params.Limit
+	}
+
+	result, err := s.userUsecase.ListUsers(ctx.Request().Context(), input)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	resp := generated.ListUsersResponse{Users: make([]generated.UserDetail, 0, len(result.Users))}
+	for _, usr := range result.Users {
+		resp.Users = append(resp.Users, toUserDetailResponse(usr))
+	}
+	if result.NextCursor != "" {
+		resp.NextCursor = &result.NextCursor
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// Inspect the full record of the target user. Only accessible to admins.
+// (GET /admin/users/{user_id})
+func (s *Server) GetUserDetail(ctx echo.Context, userID generated.UserIdPath) error {
+	result, err := s.userUsecase.GetUserDetail(ctx.Request().Context(), usecase.GetUserDetailInput{
+		UserID: uint64(userID),
+	})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, toUserDetailResponse(result.UserDetail))
+}
+
+// Replace the roles assigned to the target user. Only accessible to admins.
+// (PUT /admin/users/{user_id}/roles)
+func (s *Server) SetUserRoles(ctx echo.Context, userID generated.UserIdPath) error {
+	principal, err := s.getCurrentPrincipal(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	var payload generated.SetUserRolesRequest
+	if err = json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		err = JsonBodyInvalid
+		return renderError(ctx, err)
+	}
+
+	result, err := s.userUsecase.SetUserRoles(ctx.Request().Context(), usecase.SetUserRolesInput{
+		ActorUserID: principal.UserID,
+		UserID:      uint64(userID),
+		Roles:       payload.Roles,
+	})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	resp := generated.SetUserRolesResponse{UserId: int(result.UserID), Roles: result.Roles}
+	if resp.Roles == nil {
+		resp.Roles = []string{}
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+func toUserDetailResponse(usr usecase.UserDetail) generated.UserDetail {
+	resp := generated.UserDetail{
+		UserId:               int(usr.UserID),
+		PhoneNo:              usr.PhoneNo,
+		FullName:             usr.FullName,
+		SuccessfulLoginCount: int(usr.SuccessfulLoginCount),
+		Roles:                usr.Roles,
+		Status:               usr.Status,
+		CreatedAt:            usr.CreatedAt,
+	}
+	if resp.Roles == nil {
+		resp.Roles = []string{}
+	}
+	return resp
+}
+
+// Suspend an active user, blocking login and already issued tokens. Only accessible to admins.
+// (POST /admin/users/{user_id}/suspend)
+func (s *Server) SuspendUser(ctx echo.Context, userID generated.UserIdPath) error {
+	principal, err := s.getCurrentPrincipal(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	var payload generated.SuspendUserRequest
+	if err = json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		err = JsonBodyInvalid
+		return renderError(ctx, err)
+	}
+
+	result, err := s.userUsecase.SuspendUser(ctx.Request().Context(), usecase.SuspendUserInput{
+		ActorUserID: principal.UserID,
+		UserID:      uint64(userID),
+		Reason:      payload.Reason,
+	})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.UserStatusResponse{UserId: int(result.UserID), Status: result.Status})
+}
+
+// Lift the suspension of a user. Only accessible to admins.
+// (POST /admin/users/{user_id}/reactivate)
+func (s *Server) ReactivateUser(ctx echo.Context, userID generated.UserIdPath) error {
+	principal, err := s.getCurrentPrincipal(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	result, err := s.userUsecase.ReactivateUser(ctx.Request().Context(), usecase.ReactivateUserInput{
+		ActorUserID: principal.UserID,
+		UserID:      uint64(userID),
+	})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.UserStatusResponse{UserId: int(result.UserID), Status: result.Status})
+}
+
+// Get the JSON Schema of the custom profile attributes. Only accessible to admins.
+// (GET /admin/attributes/schema)
+func (s *Server) GetAttributeSchema(ctx echo.Context) error {
+	result, err := s.userUsecase.GetAttributeSchema(ctx.Request().Context(), usecase.GetAttributeSchemaInput{})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return renderAttributeSchema(ctx, result.Schema, result.UpdatedBy, result.UpdatedAt)
+}
+
+// Replace the JSON Schema of the custom profile attributes. Only accessible to admins.
+// (PUT /admin/attributes/schema)
+func (s *Server) SetAttributeSchema(ctx echo.Context) error {
+	principal, err := s.getCurrentPrincipal(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	var payload generated.SetAttributeSchemaRequest
+	if err = json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil || payload.Schema == nil {
+		err = JsonBodyInvalid
+		return renderError(ctx, err)
+	}
+	schema, err := json.Marshal(payload.Schema)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	result, err := s.userUsecase.SetAttributeSchema(ctx.Request().Context(), usecase.SetAttributeSchemaInput{
+		ActorUserID: principal.UserID,
+		Schema:      schema,
+	})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return renderAttributeSchema(ctx, result.Schema, result.UpdatedBy, result.UpdatedAt)
+}
+
+func renderAttributeSchema(ctx echo.Context, schema json.RawMessage, updatedBy uint64, updatedAt time.Time) error {
+	resp := generated.AttributeSchemaResponse{UpdatedBy: int(updatedBy), UpdatedAt: updatedAt}
+	if err := json.Unmarshal(schema, &resp.Schema); err != nil {
+		return renderError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
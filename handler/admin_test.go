@@ -0,0 +1,379 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type AdminHandlerTestSuite struct {
+	suite.Suite
+
+	gomock  *gomock.Controller
+	usecase *usecase.MockUserUsecases
+
+	handler *Server
+	echo    *echo.Echo
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestAdminHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AdminHandlerTestSuite))
+}
+
+func (s *AdminHandlerTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.usecase = usecase.NewMockUserUsecases(s.gomock)
+
+	s.handler = NewServer(NewServerOptions{UserUsecase: s.usecase})
+
+	// admin routes are tested through echo, to make sure the route authorization is applied
+	s.echo = echo.New()
+	s.echo.Use(s.handler.Authorize())
+	generated.RegisterHandlers(s.echo, s.handler)
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *AdminHandlerTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *AdminHandlerTestSuite) expectPrincipal(principal usecase.Principal) {
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: principal.UserID, Roles: principal.Roles, Scopes: principal.Scopes}, nil)
+}
+
+func (s *AdminHandlerTestSuite) TestSetUserRolesWithoutAuth() {
+	a := assert.New(s.T())
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/123/roles", strings.NewReader(`{"roles":["farmer"]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusForbidden, rec.Code)
+	a.Equal(`{"code":"auth.invalid_token","error":"invalid / expired token, please login again"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestSetUserRolesNotAdmin() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleSupport}, Scopes: []string{usecase.ScopeAdminUsers}})
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/123/roles", strings.NewReader(`{"roles":["farmer"]}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusForbidden, rec.Code)
+	a.Equal(`{"code":"auth.forbidden","error":"you don't have permission to access this resource"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestSetUserRolesUserNotFound() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+	s.usecase.EXPECT().SetUserRoles(gomock.Any(), usecase.SetUserRolesInput{
+		ActorUserID: 1,
+		UserID:      123,
+		Roles:       []string{"farmer"},
+	}).Return(usecase.SetUserRolesOutput{}, usecase.UserNotFoundError)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/123/roles", strings.NewReader(`{"roles":["farmer"]}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusNotFound, rec.Code)
+	a.Equal(`{"code":"user.not_found","error":"user not found"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestSetUserRolesSuccess() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+	s.usecase.EXPECT().SetUserRoles(gomock.Any(), usecase.SetUserRolesInput{
+		ActorUserID: 1,
+		UserID:      123,
+		Roles:       []string{"farmer", "estate_manager"},
+	}).DoAndReturn(func(ctx context.Context, input usecase.SetUserRolesInput) (usecase.SetUserRolesOutput, error) {
+		principal, ok := usecase.PrincipalFromContext(ctx)
+		a.True(ok)
+		a.Equal(uint64(1), principal.UserID)
+		return usecase.SetUserRolesOutput{UserID: 123, Roles: []string{"estate_manager", "farmer"}}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/123/roles", strings.NewReader(`{"roles":["farmer","estate_manager"]}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"roles":["estate_manager","farmer"],"user_id":123}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestListUsersValidationError() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+	s.usecase.EXPECT().ListUsers(gomock.Any(), usecase.ListUsersInput{
+		Limit: 500,
+	}).Return(usecase.ListUsersOutput{}, usecase.NewValidationError(map[string][]error{
+		"limit": {fmt.Errorf("limit must be between 1 and 100")},
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/users?limit=500", nil)
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *AdminHandlerTestSuite) TestListUsersSuccess() {
+	a := assert.New(s.T())
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+	s.usecase.EXPECT().ListUsers(gomock.Any(), usecase.ListUsersInput{
+		PhonePrefix: "+6281",
+		Name:        "john",
+		CreatedFrom: createdAt,
+		Status:      "active",
+		Cursor:      "MTI0",
+		Limit:       1,
+	}).Return(usecase.ListUsersOutput{
+		Users: []usecase.UserDetail{
+			{UserID: 123, PhoneNo: "+628123456789", FullName: "John Doe", SuccessfulLoginCount: 2, Status: "active", CreatedAt: createdAt},
+		},
+		NextCursor: "MTIz",
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/users?phone_prefix=%2B6281&name=john&created_from=2024-01-02T03:04:05Z&status=active&cursor=MTI0&limit=1", nil)
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"next_cursor":"MTIz","users":[{"created_at":"2024-01-02T03:04:05Z","full_name":"John Doe","phone_no":"+628123456789","roles":[],"status":"active","successful_login_count":2,"user_id":123}]}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestGetUserDetailNotAdmin() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleFarmer}, Scopes: []string{usecase.ScopeProfileRead}})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/users/123", nil)
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *AdminHandlerTestSuite) TestGetUserDetailNotFound() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+	s.usecase.EXPECT().GetUserDetail(gomock.Any(), usecase.GetUserDetailInput{UserID: 123}).
+		Return(usecase.GetUserDetailOutput{}, usecase.UserNotFoundError)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/users/123", nil)
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusNotFound, rec.Code)
+	a.Equal(`{"code":"user.not_found","error":"user not found"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestGetUserDetailSuccess() {
+	a := assert.New(s.T())
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+	s.usecase.EXPECT().GetUserDetail(gomock.Any(), usecase.GetUserDetailInput{UserID: 123}).
+		Return(usecase.GetUserDetailOutput{UserDetail: usecase.UserDetail{
+			UserID: 123, PhoneNo: "+628123456789", FullName: "John Doe", SuccessfulLoginCount: 2,
+			Roles: []string{usecase.RoleFarmer}, Status: "suspended", CreatedAt: createdAt,
+		}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/users/123", nil)
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"created_at":"2024-01-02T03:04:05Z","full_name":"John Doe","phone_no":"+628123456789","roles":["farmer"],"status":"suspended","successful_login_count":2,"user_id":123}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestSuspendUserInvalidJson() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/123/suspend", strings.NewReader(`{"reason":`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"request.invalid_json","error":"invalid JSON Body"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestSuspendUserConflict() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+	s.usecase.EXPECT().SuspendUser(gomock.Any(), usecase.SuspendUserInput{ActorUserID: 1, UserID: 123, Reason: "fraud"}).
+		Return(usecase.SuspendUserOutput{}, usecase.UserStatusConflict)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/123/suspend", strings.NewReader(`{"reason":"fraud"}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusConflict, rec.Code)
+	a.Equal(`{"code":"user.status_conflict","error":"operation is not allowed on the current user account status"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestSuspendUserSuccess() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+	s.usecase.EXPECT().SuspendUser(gomock.Any(), usecase.SuspendUserInput{ActorUserID: 1, UserID: 123, Reason: "fraud"}).
+		Return(usecase.SuspendUserOutput{UserID: 123, Status: "suspended"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/123/suspend", strings.NewReader(`{"reason":"fraud"}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"status":"suspended","user_id":123}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestReactivateUserSuccess() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+	s.usecase.EXPECT().ReactivateUser(gomock.Any(), usecase.ReactivateUserInput{ActorUserID: 1, UserID: 123}).
+		Return(usecase.ReactivateUserOutput{UserID: 123, Status: "active"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/123/reactivate", nil)
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"status":"active","user_id":123}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestGetAttributeSchemaNotFound() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+	s.usecase.EXPECT().GetAttributeSchema(gomock.Any(), usecase.GetAttributeSchemaInput{}).
+		Return(usecase.GetAttributeSchemaOutput{}, usecase.AttributeSchemaNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/attributes/schema", nil)
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusNotFound, rec.Code)
+	a.Equal(`{"code":"attribute_schema.not_found","error":"no attribute schema is defined"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestGetAttributeSchemaSuccess() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+	s.usecase.EXPECT().GetAttributeSchema(gomock.Any(), usecase.GetAttributeSchemaInput{}).
+		Return(usecase.GetAttributeSchemaOutput{
+			Schema:    json.RawMessage(`{"type": "object"}`),
+			UpdatedBy: 1,
+			UpdatedAt: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC),
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/attributes/schema", nil)
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"schema":{"type":"object"},"updated_at":"2024-03-16T09:55:00Z","updated_by":1}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestSetAttributeSchemaNotAdmin() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleSupport}, Scopes: []string{usecase.ScopeAdminUsers}})
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/attributes/schema", strings.NewReader(`{"schema":{"type":"object"}}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *AdminHandlerTestSuite) TestSetAttributeSchemaInvalidJson() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/attributes/schema", strings.NewReader(`{"type":"object"}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"request.invalid_json","error":"invalid JSON Body"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AdminHandlerTestSuite) TestSetAttributeSchemaSuccess() {
+	a := assert.New(s.T())
+
+	s.expectPrincipal(usecase.Principal{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}})
+	s.usecase.EXPECT().SetAttributeSchema(gomock.Any(), usecase.SetAttributeSchemaInput{
+		ActorUserID: 1,
+		Schema:      json.RawMessage(`{"properties":{"estate_code":{"type":"string"}},"type":"object"}`),
+	}).DoAndReturn(func(ctx context.Context, input usecase.SetAttributeSchemaInput) (usecase.SetAttributeSchemaOutput, error) {
+		return usecase.SetAttributeSchemaOutput{Schema: input.Schema, UpdatedBy: 1, UpdatedAt: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/attributes/schema", strings.NewReader(`{"schema":{"type":"object","properties":{"estate_code":{"type":"string"}}}}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"schema":{"properties":{"estate_code":{"type":"string"}},"type":"object"},"updated_at":"2024-03-16T09:55:00Z","updated_by":1}`,
+		strings.TrimSpace(rec.Body.String()))
+}
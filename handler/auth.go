@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"strings"
+)
+
+const (
+	// principalContextKey is the echo context key of the authenticated usecase.Principal
+	principalContextKey = "principal"
+
+	// bearerAuthScheme is the name of the JWT security scheme in api.yml, its requirement lists the token scopes needed
+	bearerAuthScheme = "bearerAuth"
+	// requiredRolesExtension is the OpenAPI operation extension listing the roles allowed to call the operation
+	requiredRolesExtension = "x-required-roles"
+	// allowImpersonationExtension is the OpenAPI operation extension allowing impersonation tokens to call the operation
+	allowImpersonationExtension = "x-allow-impersonation"
+)
+
+var (
+	pathParamRegex = regexp.MustCompile(`\{([^}]+)\}`) // match OpenAPI path parameters, e.g. `{user_id}`
+)
+
+// routeSecurity is the security requirement of a single route, as declared in api.yml
+type routeSecurity struct {
+	scopes             []string
+	roles              []string
+	allowImpersonation bool
+}
+
+// Authorize is an echo middleware enforcing the per route security requirements declared in api.yml:
+// the `bearerAuth` scopes, the roles listed in the `x-required-roles` operation extension, and rejecting
+// impersonation tokens unless allowed by the `x-allow-impersonation` operation extension.
+// Routes without `bearerAuth` security are passed through as-is.
+func (s *Server) Authorize() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			security, ok := s.routeSecurity[routeKey(ctx.Request().Method, ctx.Path())]
+			if !ok {
+				return next(ctx)
+			}
+
+			handler := next
+			if len(security.roles) > 0 {
+				handler = s.RequireRoles(security.roles...)(handler)
+			}
+			if !security.allowImpersonation {
+				handler = s.RejectImpersonation()(handler)
+			}
+			handler = s.RequireScopes(security.scopes...)(handler)
+			return handler(ctx)
+		}
+	}
+}
+
+// RequireScopes is an echo middleware that only allows authenticated principals whose token is granted every scope.
+// The authenticated principal will be available to the next handlers through getCurrentPrincipal
+// and usecase.PrincipalFromContext
+func (s *Server) RequireScopes(scopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			principal, err := s.authenticate(ctx)
+			if err != nil {
+				return renderError(ctx, err)
+			}
+			if !principal.HasScopes(scopes...) {
+				// RFC 6750 section 3.1
+				ctx.Response().Header().Set(echo.HeaderWWWAuthenticate,
+					fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, strings.Join(scopes, " ")))
+				return renderError(ctx, usecase.UserInsufficientScope)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// RequireRoles is an echo middleware that only allows authenticated principals having at least one of the roles.
+// The authenticated principal will be available to the next handlers through getCurrentPrincipal
+// and usecase.PrincipalFromContext
+func (s *Server) RequireRoles(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			principal, err := s.authenticate(ctx)
+			if err != nil {
+				return renderError(ctx, err)
+			}
+			if !principal.HasAnyRole(roles...) {
+				return renderError(ctx, usecase.UserForbidden)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// RejectImpersonation is an echo middleware that only allows authenticated principals acting on their own behalf,
+// i.e. not using an impersonation token. The authenticated principal will be available to the next handlers
+// through getCurrentPrincipal and usecase.PrincipalFromContext
+func (s *Server) RejectImpersonation() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			principal, err := s.authenticate(ctx)
+			if err != nil {
+				return renderError(ctx, err)
+			}
+			if principal.IsImpersonated() {
+				return renderError(ctx, usecase.UserImpersonationForbidden)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// getCurrentPrincipal will return the principal authenticated by the middlewares,
+// or validate the request bearer token when there's none
+func (s *Server) getCurrentPrincipal(ctx echo.Context) (principal usecase.Principal, err error) {
+	if principal, ok := ctx.Get(principalContextKey).(usecase.Principal); ok {
+		return principal, nil
+	}
+
+	jwtToken, err := getBearerToken(ctx)
+	if err != nil {
+		return principal, err
+	}
+
+	token, err := s.userUsecase.ValidateUserToken(ctx.Request().Context(), usecase.ValidateUserTokenInput{JwtToken: jwtToken})
+	if err != nil {
+		return principal, err
+	}
+
+	return usecase.Principal{UserID: token.UserID, Roles: token.Roles, Scopes: token.Scopes, ActorUserID: token.ActorUserID, Language: token.Language}, nil
+}
+
+// getBearerToken will return the JWT Token of the request `Authorization` header
+func getBearerToken(ctx echo.Context) (string, error) {
+	authHeaders := ctx.Request().Header["Authorization"]
+	if len(authHeaders) == 0 {
+		return "", usecase.UserInvalidToken
+	}
+	authHeader := authHeaders[0]
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		return "", usecase.UserInvalidToken
+	}
+	return strings.TrimPrefix(authHeader, "Bearer "), nil
+}
+
+// authenticate will resolve the current principal, and make it available to the next handlers
+// through getCurrentPrincipal and usecase.PrincipalFromContext
+func (s *Server) authenticate(ctx echo.Context) (principal usecase.Principal, err error) {
+	if principal, err = s.getCurrentPrincipal(ctx); err != nil {
+		return
+	}
+	ctx.Set(principalContextKey, principal)
+	ctx.SetRequest(ctx.Request().WithContext(usecase.ContextWithPrincipal(ctx.Request().Context(), principal)))
+	return principal, nil
+}
+
+// loadRouteSecurity will collect the security requirement of every `bearerAuth` operation in the OpenAPI
+// specification, keyed by the route as registered in echo
+func loadRouteSecurity(swagger *openapi3.T) (map[string]routeSecurity, error) {
+	routes := map[string]routeSecurity{}
+	for path, pathItem := range swagger.Paths {
+		for method, operation := range pathItem.Operations() {
+			if operation.Security == nil {
+				continue
+			}
+
+			var security routeSecurity
+			var isBearerAuth bool
+			for _, requirement := range *operation.Security {
+				if scopes, ok := requirement[bearerAuthScheme]; ok {
+					isBearerAuth = true
+					security.scopes = append(security.scopes, scopes...)
+				}
+			}
+			if !isBearerAuth {
+				continue
+			}
+
+			if ext, ok := operation.Extensions[requiredRolesExtension]; ok {
+				values, ok := ext.([]interface{})
+				if !ok {
+					return nil, fmt.Errorf("%s %s: %s must be a list of roles", method, path, requiredRolesExtension)
+				}
+				for _, value := range values {
+					role, ok := value.(string)
+					if !ok {
+						return nil, fmt.Errorf("%s %s: %s must be a list of roles", method, path, requiredRolesExtension)
+					}
+					security.roles = append(security.roles, role)
+				}
+			}
+			if ext, ok := operation.Extensions[allowImpersonationExtension]; ok {
+				if security.allowImpersonation, ok = ext.(bool); !ok {
+					return nil, fmt.Errorf("%s %s: %s must be a boolean", method, path, allowImpersonationExtension)
+				}
+			}
+			routes[routeKey(method, pathParamRegex.ReplaceAllString(path, ":$1"))] = security
+		}
+	}
+	return routes, nil
+}
+
+func routeKey(method string, path string) string {
+	return method + " " + path
+}
+
+// mustLoadRouteSecurity is loadRouteSecurity on the embedded specification, which is always valid once generated
+func mustLoadRouteSecurity() map[string]routeSecurity {
+	swagger, err := generated.GetSwagger()
+	if err != nil {
+		panic(err)
+	}
+	routes, err := loadRouteSecurity(swagger)
+	if err != nil {
+		panic(err)
+	}
+	return routes
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type AuthMiddlewareTestSuite struct {
+	suite.Suite
+
+	gomock  *gomock.Controller
+	usecase *usecase.MockUserUsecases
+
+	handler *Server
+	echo    *echo.Echo
+}
+
+func TestAuthMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthMiddlewareTestSuite))
+}
+
+func (s *AuthMiddlewareTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.usecase = usecase.NewMockUserUsecases(s.gomock)
+
+	s.handler = NewServer(NewServerOptions{UserUsecase: s.usecase})
+
+	s.echo = echo.New()
+	s.echo.Use(s.handler.Authorize())
+	generated.RegisterHandlers(s.echo, s.handler)
+}
+
+func (s *AuthMiddlewareTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *AuthMiddlewareTestSuite) TestRouteSecurityLoadedFromSpec() {
+	a := assert.New(s.T())
+
+	a.Equal(routeSecurity{scopes: []string{"profile:read"}, allowImpersonation: true}, s.handler.routeSecurity["GET /user"])
+	a.Equal(routeSecurity{scopes: []string{"profile:write"}}, s.handler.routeSecurity["PATCH /user"])
+	a.Equal(routeSecurity{scopes: []string{"admin:users"}, roles: []string{"admin"}}, s.handler.routeSecurity["PUT /admin/users/:user_id/roles"])
+	a.NotContains(s.handler.routeSecurity, "POST /user/session")
+}
+
+func (s *AuthMiddlewareTestSuite) TestInsufficientScope() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{"profile:read"}}, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/user", strings.NewReader(`{"full_name":"Alex Smith"}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusForbidden, rec.Code)
+	a.Equal(`Bearer error="insufficient_scope", scope="profile:write"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+	a.Equal(`{"code":"auth.insufficient_scope","error":"insufficient token scope for this resource"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AuthMiddlewareTestSuite) TestInvalidTokenIsNotInsufficientScope() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{}, usecase.UserInvalidToken)
+
+	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusForbidden, rec.Code)
+	a.Empty(rec.Header().Get(echo.HeaderWWWAuthenticate))
+	a.Equal(`{"code":"auth.invalid_token","error":"invalid / expired token, please login again"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AuthMiddlewareTestSuite) TestSufficientScope() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{"profile:read"}}, nil)
+	s.usecase.EXPECT().GetUserProfile(gomock.Any(), usecase.GetUserProfileInput{
+		UserID: 123,
+	}).DoAndReturn(func(ctx context.Context, input usecase.GetUserProfileInput) (usecase.GetUserProfileOutput, error) {
+		principal, ok := usecase.PrincipalFromContext(ctx)
+		a.True(ok)
+		a.Equal(uint64(123), principal.UserID)
+		return usecase.GetUserProfileOutput{UserID: 123, PhoneNo: "+62812141733", FullName: "John Smith"}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *AuthMiddlewareTestSuite) TestRoleWithoutScope() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 1, Roles: []string{"admin"}, Scopes: []string{"profile:read"}}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/123/roles", strings.NewReader(`{"roles":["farmer"]}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusForbidden, rec.Code)
+	a.Equal(`Bearer error="insufficient_scope", scope="admin:users"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+}
+
+func (s *AuthMiddlewareTestSuite) TestImpersonationRejected() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{"profile:read"}, ActorUserID: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/user/export", strings.NewReader(`{"format":"json"}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusForbidden, rec.Code)
+	a.Equal(`{"code":"impersonation.forbidden","error":"this operation is not allowed while impersonating a user"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *AuthMiddlewareTestSuite) TestImpersonationAllowed() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{"profile:read"}, ActorUserID: 1}, nil)
+	s.usecase.EXPECT().GetUserProfile(gomock.Any(), usecase.GetUserProfileInput{
+		UserID: 123,
+	}).DoAndReturn(func(ctx context.Context, input usecase.GetUserProfileInput) (usecase.GetUserProfileOutput, error) {
+		principal, ok := usecase.PrincipalFromContext(ctx)
+		a.True(ok)
+		a.Equal(uint64(1), principal.ActorUserID)
+		return usecase.GetUserProfileOutput{UserID: 123, PhoneNo: "+62812141733", FullName: "John Smith"}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusOK, rec.Code)
+}
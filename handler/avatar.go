@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"errors"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+)
+
+const (
+	// maxAvatarRequestBytes leaves room for the multipart envelope around the 5 MB image checked by the usecase
+	maxAvatarRequestBytes = 6 << 20
+)
+
+var (
+	AvatarRequestTooLarge = usecase.NewError(http.StatusRequestEntityTooLarge, "request.too_large", "request.avatar_too_large")
+)
+
+// Upload the avatar of the logged-in user, as a JPEG, PNG or WebP image.
+// (PUT /user/avatar)
+func (s *Server) UploadUserAvatar(ctx echo.Context) error {
+	userID, err := s.getCurrentUser(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	// the body is limited before parsing, so that large uploads are never spooled to disk
+	ctx.Request().Body = http.MaxBytesReader(ctx.Response(), ctx.Request().Body, maxAvatarRequestBytes)
+	file, err := ctx.FormFile("avatar")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return renderError(ctx, AvatarRequestTooLarge)
+		}
+		return renderError(ctx, usecase.NewValidationError(map[string][]error{
+			"avatar": {usecase.NewFieldError("avatar.required", "validation.file_required", i18n.Params{"field": "avatar"})},
+		}))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return renderError(ctx, err)
+	}
+	defer src.Close()
+	content, err := io.ReadAll(src)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	result, err := s.userUsecase.UploadUserAvatar(ctx.Request().Context(), usecase.UploadUserAvatarInput{
+		UserID:  userID,
+		Content: content,
+	})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.UploadUserAvatarResponse{
+		AvatarUrl:          result.AvatarURL,
+		AvatarThumbnailUrl: result.AvatarThumbnailURL,
+	})
+}
+
+// Remove the avatar of the logged-in user.
+// (DELETE /user/avatar)
+func (s *Server) RemoveUserAvatar(ctx echo.Context) error {
+	userID, err := s.getCurrentUser(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	if _, err = s.userUsecase.RemoveUserAvatar(ctx.Request().Context(), usecase.RemoveUserAvatarInput{UserID: userID}); err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type AvatarHandlerTestSuite struct {
+	suite.Suite
+
+	gomock  *gomock.Controller
+	usecase *usecase.MockUserUsecases
+
+	handler *Server
+	echo    *echo.Echo
+
+	mockErr error
+}
+
+func TestAvatarHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AvatarHandlerTestSuite))
+}
+
+func (s *AvatarHandlerTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.usecase = usecase.NewMockUserUsecases(s.gomock)
+
+	s.handler = NewServer(NewServerOptions{UserUsecase: s.usecase})
+
+	s.echo = echo.New()
+	s.echo.Use(s.handler.Authorize())
+	generated.RegisterHandlers(s.echo, s.handler)
+
+	s.mockErr = fmt.Errorf("simulated error")
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{JwtToken: "user-token"}).
+		Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{usecase.ScopeProfileRead, usecase.ScopeProfileWrite}}, nil).AnyTimes()
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{JwtToken: "read-only-token"}).
+		Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{usecase.ScopeProfileRead}}, nil).AnyTimes()
+}
+
+func (s *AvatarHandlerTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+// upload will send a multipart request, with the content as the file of the specified field
+func (s *AvatarHandlerTestSuite) upload(token, field string, content []byte) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, _ := w.CreateFormFile(field, "avatar.png")
+	_, _ = part.Write(content)
+	_ = w.Close()
+
+	req := httptest.NewRequest(http.MethodPut, "/user/avatar", &body)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(echo.HeaderContentType, w.FormDataContentType())
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *AvatarHandlerTestSuite) serve(method, target, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *AvatarHandlerTestSuite) TestUploadInsufficientScope() {
+	a := assert.New(s.T())
+
+	rec := s.upload("read-only-token", "avatar", []byte("image-content"))
+
+	a.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *AvatarHandlerTestSuite) TestUploadMissingFile() {
+	a := assert.New(s.T())
+
+	rec := s.upload("user-token", "picture", []byte("image-content"))
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.JSONEq(`{"code":"validation.failed","errors":[{"code":"avatar.required","field":"avatar","error":"avatar file is required"}]}`, rec.Body.String())
+}
+
+func (s *AvatarHandlerTestSuite) TestUploadTooLarge() {
+	a := assert.New(s.T())
+
+	rec := s.upload("user-token", "avatar", make([]byte, maxAvatarRequestBytes))
+
+	a.Equal(http.StatusRequestEntityTooLarge, rec.Code)
+	a.JSONEq(`{"code":"request.too_large","error":"request body is too large, the avatar must be at most 5 MB"}`, rec.Body.String())
+}
+
+func (s *AvatarHandlerTestSuite) TestUploadInvalidImage() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().UploadUserAvatar(gomock.Any(), usecase.UploadUserAvatarInput{UserID: 123, Content: []byte("image-content")}).
+		Return(usecase.UploadUserAvatarOutput{}, usecase.NewValidationError(map[string][]error{
+			"avatar": {usecase.NewFieldError("avatar.unsupported_format", "validation.avatar_format", nil)},
+		}))
+
+	rec := s.upload("user-token", "avatar", []byte("image-content"))
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.JSONEq(`{"code":"validation.failed","errors":[{"code":"avatar.unsupported_format","field":"avatar","error":"avatar must be a JPEG, PNG or WebP image"}]}`, rec.Body.String())
+}
+
+func (s *AvatarHandlerTestSuite) TestUploadError() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().UploadUserAvatar(gomock.Any(), gomock.Any()).Return(usecase.UploadUserAvatarOutput{}, s.mockErr)
+
+	rec := s.upload("user-token", "avatar", []byte("image-content"))
+
+	a.Equal(http.StatusInternalServerError, rec.Code)
+}
+
+func (s *AvatarHandlerTestSuite) TestUploadSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().UploadUserAvatar(gomock.Any(), usecase.UploadUserAvatarInput{UserID: 123, Content: []byte("image-content")}).
+		Return(usecase.UploadUserAvatarOutput{
+			AvatarURL:          "https://example.com/blobs/avatars/123/abc/512.jpg",
+			AvatarThumbnailURL: "https://example.com/blobs/avatars/123/abc/128.jpg",
+		}, nil)
+
+	rec := s.upload("user-token", "avatar", []byte("image-content"))
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.JSONEq(`{"avatar_url":"https://example.com/blobs/avatars/123/abc/512.jpg",`+
+		`"avatar_thumbnail_url":"https://example.com/blobs/avatars/123/abc/128.jpg"}`, rec.Body.String())
+}
+
+func (s *AvatarHandlerTestSuite) TestRemoveInsufficientScope() {
+	a := assert.New(s.T())
+
+	rec := s.serve(http.MethodDelete, "/user/avatar", "read-only-token")
+
+	a.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *AvatarHandlerTestSuite) TestRemoveError() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().RemoveUserAvatar(gomock.Any(), usecase.RemoveUserAvatarInput{UserID: 123}).
+		Return(usecase.RemoveUserAvatarOutput{}, s.mockErr)
+
+	rec := s.serve(http.MethodDelete, "/user/avatar", "user-token")
+
+	a.Equal(http.StatusInternalServerError, rec.Code)
+}
+
+func (s *AvatarHandlerTestSuite) TestRemoveSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().RemoveUserAvatar(gomock.Any(), usecase.RemoveUserAvatarInput{UserID: 123}).
+		Return(usecase.RemoveUserAvatarOutput{}, nil)
+
+	rec := s.serve(http.MethodDelete, "/user/avatar", "user-token")
+
+	a.Equal(http.StatusNoContent, rec.Code)
+}
+
+func (s *AvatarHandlerTestSuite) TestGetUserWithAvatar() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().GetUserProfile(gomock.Any(), usecase.GetUserProfileInput{UserID: 123}).
+		Return(usecase.GetUserProfileOutput{
+			UserID:               123,
+			PhoneNo:              "+62812141733",
+			FullName:             "John Smith",
+			SuccessfulLoginCount: 42,
+			AvatarURL:            "https://example.com/blobs/avatars/123/abc/512.jpg",
+			AvatarThumbnailURL:   "https://example.com/blobs/avatars/123/abc/128.jpg",
+		}, nil)
+
+	rec := s.serve(http.MethodGet, "/user", "user-token")
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.JSONEq(`{"full_name":"John Smith","phone_no":"+62812141733","successful_login_count":42,"user_id":123,`+
+		`"avatar_url":"https://example.com/blobs/avatars/123/abc/512.jpg",`+
+		`"avatar_thumbnail_url":"https://example.com/blobs/avatars/123/abc/128.jpg"}`, rec.Body.String())
+}
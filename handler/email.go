@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// Send a new verification link to the unverified email of the logged-in user.
+// (POST /user/email/verification)
+func (s *Server) RequestEmailVerification(ctx echo.Context) error {
+	userID, err := s.getCurrentUser(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	if _, err = s.userUsecase.RequestEmailVerification(ctx.Request().Context(), usecase.RequestEmailVerificationInput{UserID: userID}); err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// Verify the email of a user, this is the link sent by email.
+// (GET /user/email/verify)
+func (s *Server) VerifyUserEmail(ctx echo.Context, params generated.VerifyUserEmailParams) error {
+	result, err := s.userUsecase.VerifyUserEmail(ctx.Request().Context(), usecase.VerifyUserEmailInput{Token: params.Token})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.VerifyUserEmailResponse{Email: result.Email})
+}
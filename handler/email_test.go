@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type EmailHandlerTestSuite struct {
+	suite.Suite
+
+	gomock  *gomock.Controller
+	usecase *usecase.MockUserUsecases
+
+	handler *Server
+	echo    *echo.Echo
+
+	mockErr error
+}
+
+func TestEmailHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(EmailHandlerTestSuite))
+}
+
+func (s *EmailHandlerTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.usecase = usecase.NewMockUserUsecases(s.gomock)
+
+	s.handler = NewServer(NewServerOptions{UserUsecase: s.usecase})
+
+	s.echo = echo.New()
+	s.echo.Use(s.handler.Authorize())
+	generated.RegisterHandlers(s.echo, s.handler)
+
+	s.mockErr = fmt.Errorf("simulated error")
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{JwtToken: "user-token"}).
+		Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{usecase.ScopeProfileRead, usecase.ScopeProfileWrite}}, nil).AnyTimes()
+}
+
+func (s *EmailHandlerTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *EmailHandlerTestSuite) serve(method, target, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *EmailHandlerTestSuite) TestRequestEmailVerificationWithoutAuth() {
+	a := assert.New(s.T())
+
+	rec := s.serve(http.MethodPost, "/user/email/verification", "")
+
+	a.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *EmailHandlerTestSuite) TestRequestEmailVerificationEmailNotSet() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().RequestEmailVerification(gomock.Any(), usecase.RequestEmailVerificationInput{UserID: 123}).
+		Return(usecase.RequestEmailVerificationOutput{}, usecase.UserEmailNotSet)
+
+	rec := s.serve(http.MethodPost, "/user/email/verification", "user-token")
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"email.not_set","error":"no email address is set on the account"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *EmailHandlerTestSuite) TestRequestEmailVerificationSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().RequestEmailVerification(gomock.Any(), usecase.RequestEmailVerificationInput{UserID: 123}).
+		Return(usecase.RequestEmailVerificationOutput{}, nil)
+
+	rec := s.serve(http.MethodPost, "/user/email/verification", "user-token")
+
+	a.Equal(http.StatusNoContent, rec.Code)
+}
+
+func (s *EmailHandlerTestSuite) TestVerifyUserEmailMissingToken() {
+	a := assert.New(s.T())
+
+	rec := s.serve(http.MethodGet, "/user/email/verify", "")
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *EmailHandlerTestSuite) TestVerifyUserEmailInvalid() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().VerifyUserEmail(gomock.Any(), usecase.VerifyUserEmailInput{Token: "verification-token"}).
+		Return(usecase.VerifyUserEmailOutput{}, usecase.UserInvalidEmailVerification)
+
+	rec := s.serve(http.MethodGet, "/user/email/verify?token=verification-token", "")
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"email.invalid_verification_link","error":"invalid or expired email verification link"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *EmailHandlerTestSuite) TestVerifyUserEmailSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().VerifyUserEmail(gomock.Any(), usecase.VerifyUserEmailInput{Token: "verification-token"}).
+		Return(usecase.VerifyUserEmailOutput{UserID: 123, Email: "john.smith@example.com"}, nil)
+
+	rec := s.serve(http.MethodGet, "/user/email/verify?token=verification-token", "")
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"email":"john.smith@example.com"}`, strings.TrimSpace(rec.Body.String()))
+}
@@ -1,19 +1,107 @@
 package handler
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/i18n"
 	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const (
+	// problemTypePrefix makes the URI of the type of the RFC 7807 problems out of their error code
+	problemTypePrefix = "urn:userservice:problem:"
+	// mimeApplicationProblemJSON is the media type of RFC 7807 problem responses
+	mimeApplicationProblemJSON = "application/problem+json"
 )
 
 var (
-	JsonBodyInvalid = fmt.Errorf("invalid JSON Body")
+	JsonBodyInvalid = usecase.NewError(http.StatusBadRequest, "request.invalid_json", "request.invalid_json")
+	// InternalError is rendered instead of the errors unknown by the clients (e.g. a database failure), so that their
+	// details never reach the clients
+	InternalError = usecase.NewError(http.StatusInternalServerError, usecase.InternalErrorCode, "internal.error")
 
-	errorsToCodeMap = map[error]int{
-		JsonBodyInvalid: 400,
+	// wrapper to the logging of internal errors to make testing easier
+	logInternalError = func(ctx echo.Context, errorID string, err error) {
+		ctx.Logger().Errorf("internal error %s (request %s) on %s %s: %v", errorID, currentRequestID(ctx), ctx.Request().Method,
+			ctx.Request().URL.Path, err)
+	}
 
-		usecase.UserInvalidLogin:  400,
-		usecase.UserInvalidToken:  403,
-		usecase.UserConflictError: 409,
-		usecase.UserNotFoundError: 404,
+	// wrapper to the error ID generation to make testing easier. The ID is always generated, unlike the request ID
+	// which can be sent by the clients, so that it identifies a single logged error
+	generateErrorID = func() string {
+		id := make([]byte, 16)
+		_, _ = rand.Read(id)
+		return hex.EncodeToString(id)
 	}
 )
+
+// acceptsProblem will return true when the client accepts RFC 7807 problem responses
+func acceptsProblem(ctx echo.Context) bool {
+	for _, accept := range strings.Split(ctx.Request().Header.Get(echo.HeaderAccept), ",") {
+		if mediaType, _, _ := mime.ParseMediaType(accept); mediaType == mimeApplicationProblemJSON {
+			return true
+		}
+	}
+	return false
+}
+
+// newProblem will create the RFC 7807 problem of an error, its occurrence is identified by the request path
+func newProblem(ctx echo.Context, status int, code string) generated.ProblemDetails {
+	instance := ctx.Request().URL.Path
+	return generated.ProblemDetails{
+		Type:     problemTypePrefix + code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Code:     code,
+		Instance: &instance,
+	}
+}
+
+func renderProblem(ctx echo.Context, problem generated.ProblemDetails) error {
+	ctx.Response().Header().Set(echo.HeaderContentType, mimeApplicationProblemJSON)
+	ctx.Response().WriteHeader(problem.Status)
+	return json.NewEncoder(ctx.Response()).Encode(problem)
+}
+
+// httpErrorCodes maps the statuses of the errors of echo into their code, the other statuses are `request.invalid`
+var httpErrorCodes = map[int]string{
+	http.StatusNotFound:              "request.route_not_found",
+	http.StatusMethodNotAllowed:      "request.method_not_allowed",
+	http.StatusRequestEntityTooLarge: "request.too_large",
+}
+
+// HTTPErrorHandler renders the errors returned by echo, e.g. unknown routes or invalid parameters, like the errors of
+// the handlers
+func HTTPErrorHandler(err error, ctx echo.Context) {
+	if ctx.Response().Committed {
+		return
+	}
+
+	// the server errors of echo are internal errors, e.g. a failure writing the response
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) && httpErr.Code < http.StatusInternalServerError {
+		if httpErr.Internal != nil {
+			ctx.Logger().Error(httpErr.Internal)
+		}
+		code, ok := httpErrorCodes[httpErr.Code]
+		if !ok {
+			code = "request.invalid"
+		}
+		err = &usecase.Error{
+			Status:  httpErr.Code,
+			Code:    code,
+			Message: i18n.NewError(code, i18n.Params{"message": fmt.Sprint(httpErr.Message)}),
+		}
+	}
+	if renderErr := renderError(ctx, err); renderErr != nil {
+		ctx.Logger().Error(renderErr)
+	}
+}
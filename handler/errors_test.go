@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRenderErrorWrapped(t *testing.T) {
+	a := assert.New(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	rec := httptest.NewRecorder()
+	err := renderError(echo.New().NewContext(req, rec), fmt.Errorf("get user: %w", usecase.UserNotFoundError))
+
+	a.Empty(err)
+	a.Equal(http.StatusNotFound, rec.Code)
+	a.JSONEq(`{"code":"user.not_found","error":"user not found"}`, rec.Body.String())
+}
+
+func TestRenderErrorProblem(t *testing.T) {
+	a := assert.New(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	req.Header.Set(echo.HeaderAccept, "application/json, application/problem+json")
+	rec := httptest.NewRecorder()
+	err := renderError(echo.New().NewContext(req, rec), usecase.UserNotFoundError)
+
+	a.Empty(err)
+	a.Equal(http.StatusNotFound, rec.Code)
+	a.Equal("application/problem+json", rec.Header().Get(echo.HeaderContentType))
+	a.JSONEq(`{
+		"type": "urn:userservice:problem:user.not_found",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "user not found",
+		"instance": "/user",
+		"code": "user.not_found"
+	}`, rec.Body.String())
+}
+
+func TestRenderErrorInternal(t *testing.T) {
+	a := assert.New(t)
+
+	var loggedID string
+	var loggedErr error
+	originalLogInternalError := logInternalError
+	logInternalError = func(ctx echo.Context, errorID string, err error) { loggedID, loggedErr = errorID, err }
+	defer func() { logInternalError = originalLogInternalError }()
+	originalGenerateErrorID := generateErrorID
+	generateErrorID = func() string { return "error-5678" }
+	defer func() { generateErrorID = originalGenerateErrorID }()
+
+	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	rec := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, rec)
+	ctx.SetRequest(req.WithContext(usecase.ContextWithRequestID(req.Context(), "gateway-1234")))
+	err := renderError(ctx, fmt.Errorf("pq: failed connecting to database"))
+
+	a.Empty(err)
+	a.Equal(http.StatusInternalServerError, rec.Code)
+	// the error ID isn't the request ID sent by the client, which isn't guaranteed to be unique
+	a.JSONEq(`{"code":"internal","error":"an unexpected error occurred, please try again later","error_id":"error-5678"}`, rec.Body.String())
+	a.Equal("error-5678", loggedID)
+	a.EqualError(loggedErr, "pq: failed connecting to database")
+}
+
+func TestRenderErrorProblemInternal(t *testing.T) {
+	a := assert.New(t)
+
+	originalGenerateRequestID := generateRequestID
+	generateRequestID = func() string { return "generated-request-id" }
+	defer func() { generateRequestID = originalGenerateRequestID }()
+	originalGenerateErrorID := generateErrorID
+	generateErrorID = func() string { return "error-5678" }
+	defer func() { generateErrorID = originalGenerateErrorID }()
+
+	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	req.Header.Set(echo.HeaderAccept, "application/problem+json")
+	rec := httptest.NewRecorder()
+	err := renderError(echo.New().NewContext(req, rec), fmt.Errorf("simulated error"))
+
+	a.Empty(err)
+	a.Equal(http.StatusInternalServerError, rec.Code)
+	a.Equal("generated-request-id", rec.Header().Get(echo.HeaderXRequestID))
+	a.JSONEq(`{
+		"type": "urn:userservice:problem:internal",
+		"title": "Internal Server Error",
+		"status": 500,
+		"detail": "an unexpected error occurred, please try again later",
+		"instance": "/user",
+		"code": "internal",
+		"error_id": "error-5678"
+	}`, rec.Body.String())
+}
+
+func TestRenderValidationErrorsProblem(t *testing.T) {
+	a := assert.New(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/user", nil)
+	req.Header.Set(echo.HeaderAccept, "application/problem+json; charset=utf-8")
+	rec := httptest.NewRecorder()
+	err := renderError(echo.New().NewContext(req, rec), usecase.NewValidationError(map[string][]error{
+		"phone_no": {usecase.NewFieldError("phone_no.invalid_country_code", "validation.phone_country_code", nil)},
+	}))
+
+	a.Empty(err)
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal("application/problem+json", rec.Header().Get(echo.HeaderContentType))
+	a.JSONEq(`{
+		"type": "urn:userservice:problem:validation.failed",
+		"title": "Bad Request",
+		"status": 400,
+		"instance": "/user",
+		"code": "validation.failed",
+		"errors": [{
+			"code": "phone_no.invalid_country_code",
+			"field": "phone_no",
+			"error": "phone_no must start with the country calling code, e.g. +62"
+		}]
+	}`, rec.Body.String())
+}
+
+func TestRenderValidationErrorsWithoutCode(t *testing.T) {
+	a := assert.New(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/user", nil)
+	rec := httptest.NewRecorder()
+	err := renderError(echo.New().NewContext(req, rec), usecase.NewValidationError(map[string][]error{
+		"/attributes/estate_code": {fmt.Errorf("/attributes/estate_code does not match pattern")},
+	}))
+
+	a.Empty(err)
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.JSONEq(`{"code":"validation.failed","errors":[{"code":"validation.invalid","field":"/attributes/estate_code","error":"/attributes/estate_code does not match pattern"}]}`, rec.Body.String())
+}
+
+func TestHTTPErrorHandler(t *testing.T) {
+	a := assert.New(t)
+
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	e.GET("/admin/users", func(ctx echo.Context) error {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter limit")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/users?limit=abc", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.JSONEq(`{"code":"request.invalid","error":"invalid request: Invalid format for parameter limit"}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/unknown", nil)
+	req.Header.Set("Accept-Language", "id")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusNotFound, rec.Code)
+	a.JSONEq(`{"code":"request.route_not_found","error":"rute tidak ditemukan"}`, rec.Body.String())
+}
+
+func TestHTTPErrorHandlerInternal(t *testing.T) {
+	a := assert.New(t)
+
+	var loggedErr error
+	originalLogInternalError := logInternalError
+	logInternalError = func(ctx echo.Context, errorID string, err error) { loggedErr = err }
+	defer func() { logInternalError = originalLogInternalError }()
+	originalGenerateErrorID := generateErrorID
+	generateErrorID = func() string { return "error-5678" }
+	defer func() { generateErrorID = originalGenerateErrorID }()
+
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	e.Use(NewServer(NewServerOptions{}).RequestID())
+	e.GET("/user", func(ctx echo.Context) error {
+		return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(fmt.Errorf("simulated error"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	req.Header.Set(echo.HeaderXRequestID, "gateway-1234")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	a.Equal(http.StatusInternalServerError, rec.Code)
+	a.Equal("gateway-1234", rec.Header().Get(echo.HeaderXRequestID))
+	a.JSONEq(`{"code":"internal","error":"an unexpected error occurred, please try again later","error_id":"error-5678"}`, rec.Body.String())
+	a.ErrorContains(loggedErr, "simulated error")
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"net/http"
+	"strings"
+)
+
+var (
+	IfMatchRequired = usecase.NewError(http.StatusPreconditionRequired, "request.if_match_required", "request.if_match_required")
+)
+
+// profileETag is the strong ETag of a version of the user profile, the user ID is part of it so that an ETag of
+// another user never matches
+func profileETag(userID, version uint64) string {
+	return fmt.Sprintf(`"%d-%d"`, userID, version)
+}
+
+// etagMatches will check whether the etag is listed in an If-None-Match header, using the weak comparison
+// (RFC 9110 section 8.8.3.2), `*` matches any etag
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion will extract the profile version of the user from an If-Match header, using the strong comparison.
+// `*` matches any version and returns zero. Headers not listing an ETag of the user are reported as a version
+// mismatch, as they can't match the current profile
+func ifMatchVersion(header string, userID uint64) (version uint64, err error) {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return 0, nil
+		}
+		var etagUserID uint64
+		if _, scanErr := fmt.Sscanf(candidate, `"%d-%d"`, &etagUserID, &version); scanErr == nil && etagUserID == userID &&
+			version != 0 && profileETag(userID, version) == candidate {
+			return version, nil
+		}
+	}
+	return 0, usecase.UserVersionMismatch
+}
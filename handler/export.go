@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+)
+
+// Request an export of every personal data held about the logged-in user.
+// (POST /user/export)
+func (s *Server) RequestUserExport(ctx echo.Context) error {
+	userID, err := s.getCurrentUser(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	// the request body is optional, an empty body means the default format
+	var payload generated.RequestUserExportRequest
+	if err = json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		err = JsonBodyInvalid
+		return renderError(ctx, err)
+	}
+
+	input := usecase.RequestUserExportInput{UserID: userID}
+	if payload.Format != nil {
+		input.Format = *payload.Format
+	}
+	result, err := s.userUsecase.RequestUserExport(ctx.Request().Context(), input)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusAccepted, generated.UserExportStatusResponse{
+		ExportId:  int(result.ExportID),
+		Status:    result.Status,
+		CreatedAt: result.CreatedAt,
+	})
+}
+
+// Poll the status of a personal data export, or download it once ready.
+// (GET /user/export/{export_id})
+func (s *Server) GetUserExport(ctx echo.Context, exportID generated.ExportIdPath) error {
+	userID, err := s.getCurrentUser(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	result, err := s.userUsecase.GetUserExport(ctx.Request().Context(), usecase.GetUserExportInput{
+		UserID:   userID,
+		ExportID: uint64(exportID),
+	})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	switch result.Status {
+	case usecase.UserExportStatusReady:
+		ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename=%q`, result.FileName))
+		return ctx.Blob(http.StatusOK, result.ContentType, result.Content)
+	case usecase.UserExportStatusFailed:
+		return ctx.JSON(http.StatusOK, toUserExportStatusResponse(result))
+	default:
+		return ctx.JSON(http.StatusAccepted, toUserExportStatusResponse(result))
+	}
+}
+
+func toUserExportStatusResponse(result usecase.GetUserExportOutput) generated.UserExportStatusResponse {
+	resp := generated.UserExportStatusResponse{
+		ExportId:  int(result.ExportID),
+		Status:    result.Status,
+		CreatedAt: result.CreatedAt,
+	}
+	if !result.ExpiresAt.IsZero() {
+		resp.ExpiresAt = &result.ExpiresAt
+	}
+	return resp
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ExportHandlerTestSuite struct {
+	suite.Suite
+
+	gomock  *gomock.Controller
+	usecase *usecase.MockUserUsecases
+
+	handler *Server
+	echo    *echo.Echo
+
+	createdAt time.Time
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestExportHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportHandlerTestSuite))
+}
+
+func (s *ExportHandlerTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.usecase = usecase.NewMockUserUsecases(s.gomock)
+
+	s.handler = NewServer(NewServerOptions{UserUsecase: s.usecase})
+
+	s.echo = echo.New()
+	s.echo.Use(s.handler.Authorize())
+	generated.RegisterHandlers(s.echo, s.handler)
+
+	s.createdAt = time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{JwtToken: "jwt-token"}).
+		Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{usecase.ScopeProfileRead}}, nil).AnyTimes()
+}
+
+func (s *ExportHandlerTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *ExportHandlerTestSuite) serve(method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *ExportHandlerTestSuite) TestRequestUserExportInvalidJson() {
+	a := assert.New(s.T())
+
+	rec := s.serve(http.MethodPost, "/user/export", `{"format":`)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"request.invalid_json","error":"invalid JSON Body"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *ExportHandlerTestSuite) TestRequestUserExportEmptyBody() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().RequestUserExport(gomock.Any(), usecase.RequestUserExportInput{UserID: 123}).
+		Return(usecase.RequestUserExportOutput{ExportID: 7, Status: "pending", CreatedAt: s.createdAt}, nil)
+
+	rec := s.serve(http.MethodPost, "/user/export", "")
+
+	a.Equal(http.StatusAccepted, rec.Code)
+	a.Equal(`{"created_at":"2024-03-16T09:55:00Z","export_id":7,"status":"pending"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *ExportHandlerTestSuite) TestRequestUserExportZip() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().RequestUserExport(gomock.Any(), usecase.RequestUserExportInput{UserID: 123, Format: "zip"}).
+		Return(usecase.RequestUserExportOutput{ExportID: 7, Status: "pending", CreatedAt: s.createdAt}, nil)
+
+	rec := s.serve(http.MethodPost, "/user/export", `{"format":"zip"}`)
+
+	a.Equal(http.StatusAccepted, rec.Code)
+}
+
+func (s *ExportHandlerTestSuite) TestGetUserExportNotFound() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().GetUserExport(gomock.Any(), usecase.GetUserExportInput{UserID: 123, ExportID: 7}).
+		Return(usecase.GetUserExportOutput{}, usecase.UserExportNotFound)
+
+	rec := s.serve(http.MethodGet, "/user/export/7", "")
+
+	a.Equal(http.StatusNotFound, rec.Code)
+	a.Equal(`{"code":"export.not_found","error":"export not found or expired"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *ExportHandlerTestSuite) TestGetUserExportPending() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().GetUserExport(gomock.Any(), usecase.GetUserExportInput{UserID: 123, ExportID: 7}).
+		Return(usecase.GetUserExportOutput{ExportID: 7, Format: "json", Status: "processing", CreatedAt: s.createdAt}, nil)
+
+	rec := s.serve(http.MethodGet, "/user/export/7", "")
+
+	a.Equal(http.StatusAccepted, rec.Code)
+	a.Equal(`{"created_at":"2024-03-16T09:55:00Z","export_id":7,"status":"processing"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *ExportHandlerTestSuite) TestGetUserExportReady() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().GetUserExport(gomock.Any(), usecase.GetUserExportInput{UserID: 123, ExportID: 7}).
+		Return(usecase.GetUserExportOutput{
+			ExportID:    7,
+			Format:      "json",
+			Status:      "ready",
+			CreatedAt:   s.createdAt,
+			ExpiresAt:   s.createdAt.Add(7 * 24 * time.Hour),
+			Content:     []byte(`{"profile":{}}`),
+			ContentType: "application/json",
+			FileName:    "user-export-7.json",
+		}, nil)
+
+	rec := s.serve(http.MethodGet, "/user/export/7", "")
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal("application/json", rec.Header().Get(echo.HeaderContentType))
+	a.Equal(`attachment; filename="user-export-7.json"`, rec.Header().Get(echo.HeaderContentDisposition))
+	a.Equal(`{"profile":{}}`, rec.Body.String())
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// Liveness probe, the dependencies are not checked so that an outage of the database doesn't restart every instance.
+// (GET /healthz)
+func (s *Server) GetHealth(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, generated.HealthResponse{Status: "ok"})
+}
+
+// Readiness probe, the failed checks are only logged as their errors may expose internal details.
+// (GET /readyz)
+func (s *Server) GetReadiness(ctx echo.Context) error {
+	result, err := s.userUsecase.CheckReadiness(ctx.Request().Context(), usecase.CheckReadinessInput{})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	status, resp := http.StatusOK, generated.ReadinessResponse{
+		Status: generated.Ready,
+		Checks: make(map[string]generated.ReadinessResponseChecks, len(result.Checks)),
+	}
+	for name, checkErr := range result.Checks {
+		if checkErr != nil {
+			ctx.Logger().Errorf("readiness check %s failed: %v", name, checkErr)
+			resp.Checks[name] = generated.Failed
+			continue
+		}
+		resp.Checks[name] = generated.Ok
+	}
+	if !result.Ready() {
+		status, resp.Status = http.StatusServiceUnavailable, generated.NotReady
+	}
+	return ctx.JSON(status, resp)
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type HealthHandlerTestSuite struct {
+	suite.Suite
+
+	gomock  *gomock.Controller
+	usecase *usecase.MockUserUsecases
+
+	handler *Server
+	echo    *echo.Echo
+}
+
+func TestHealthHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthHandlerTestSuite))
+}
+
+func (s *HealthHandlerTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.usecase = usecase.NewMockUserUsecases(s.gomock)
+
+	s.handler = NewServer(NewServerOptions{UserUsecase: s.usecase})
+
+	s.echo = echo.New()
+	s.echo.Use(s.handler.Authorize())
+	generated.RegisterHandlers(s.echo, s.handler)
+}
+
+func (s *HealthHandlerTestSuite) serve(path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *HealthHandlerTestSuite) TestHealth() {
+	a := assert.New(s.T())
+
+	rec := s.serve("/healthz")
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.JSONEq(`{"status":"ok"}`, rec.Body.String())
+}
+
+func (s *HealthHandlerTestSuite) TestReady() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().CheckReadiness(gomock.Any(), usecase.CheckReadinessInput{}).
+		Return(usecase.CheckReadinessOutput{Checks: map[string]error{"database": nil, "jwt_key": nil}}, nil)
+	rec := s.serve("/readyz")
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.JSONEq(`{"status":"ready","checks":{"database":"ok","jwt_key":"ok"}}`, rec.Body.String())
+}
+
+func (s *HealthHandlerTestSuite) TestNotReady() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().CheckReadiness(gomock.Any(), usecase.CheckReadinessInput{}).
+		Return(usecase.CheckReadinessOutput{Checks: map[string]error{
+			"database": fmt.Errorf("dial tcp 10.0.0.5:5432: connection refused"),
+			"jwt_key":  nil,
+		}}, nil)
+	rec := s.serve("/readyz")
+
+	a.Equal(http.StatusServiceUnavailable, rec.Code)
+	a.JSONEq(`{"status":"not_ready","checks":{"database":"failed","jwt_key":"ok"}}`, rec.Body.String())
+	a.NotContains(rec.Body.String(), "10.0.0.5")
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// List the changes made to the logged-in user profile, newest first.
+// (GET /user/history)
+func (s *Server) ListUserChanges(ctx echo.Context, params generated.ListUserChangesParams) error {
+	userID, err := s.getCurrentUser(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return s.renderUserChanges(ctx, userID, params.Cursor, params.Limit)
+}
+
+// List the changes made to the target user profile, newest first. Only accessible to admins.
+// (GET /admin/users/{user_id}/history)
+func (s *Server) ListAdminUserChanges(ctx echo.Context, userID generated.UserIdPath, params generated.ListAdminUserChangesParams) error {
+	return s.renderUserChanges(ctx, uint64(userID), params.Cursor, params.Limit)
+}
+
+// renderUserChanges will render a page of the profile history of the user, shared by the owner & admin routes
+func (s *Server) renderUserChanges(ctx echo.Context, userID uint64, cursor *string, limit *int) error {
+	input := usecase.ListUserChangesInput{UserID: userID}
+	if cursor != nil {
+		input.Cursor = *cursor
+	}
+	if limit != nil {
+		input.Limit = *limit
+	}
+
+	result, err := s.userUsecase.ListUserChanges(ctx.Request().Context(), input)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	// the values are referenced from the result, since the loop variable is reused across iterations
+	resp := generated.UserChangesResponse{Changes: make([]generated.UserChange, 0, len(result.Changes))}
+	for i, change := range result.Changes {
+		item := generated.UserChange{
+			Id:        int(change.ID),
+			Field:     change.Field,
+			OldValue:  &result.Changes[i].OldValue,
+			NewValue:  &result.Changes[i].NewValue,
+			ChangedAt: change.ChangedAt,
+		}
+		if change.ActorUserID != 0 {
+			actorUserID := int(change.ActorUserID)
+			item.ActorUserId = &actorUserID
+		}
+		if change.RequestID != "" {
+			item.RequestId = &result.Changes[i].RequestID
+		}
+		resp.Changes = append(resp.Changes, item)
+	}
+	if result.NextCursor != "" {
+		resp.NextCursor = &result.NextCursor
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type HistoryHandlerTestSuite struct {
+	suite.Suite
+
+	gomock  *gomock.Controller
+	usecase *usecase.MockUserUsecases
+
+	handler *Server
+	echo    *echo.Echo
+
+	changes usecase.ListUserChangesOutput
+	mockErr error
+}
+
+func TestHistoryHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HistoryHandlerTestSuite))
+}
+
+func (s *HistoryHandlerTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.usecase = usecase.NewMockUserUsecases(s.gomock)
+
+	s.handler = NewServer(NewServerOptions{UserUsecase: s.usecase})
+
+	s.echo = echo.New()
+	s.echo.Use(s.handler.Authorize())
+	generated.RegisterHandlers(s.echo, s.handler)
+
+	changedAt := time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)
+	s.changes = usecase.ListUserChangesOutput{
+		Changes: []usecase.UserChange{
+			{ID: 9, Field: "attributes.estate_code", NewValue: "KAL-01", ActorUserID: 123, RequestID: "request-id", ChangedAt: changedAt},
+			{ID: 8, Field: "phone_no", OldValue: "+62****4400", NewValue: "+62****1234", ChangedAt: changedAt},
+		},
+		NextCursor: "OA",
+	}
+	s.mockErr = fmt.Errorf("simulated error")
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{JwtToken: "user-token"}).
+		Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{usecase.ScopeProfileRead}}, nil).AnyTimes()
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{JwtToken: "admin-token"}).
+		Return(usecase.ValidateUserTokenOutput{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}}, nil).AnyTimes()
+}
+
+func (s *HistoryHandlerTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *HistoryHandlerTestSuite) serve(target, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *HistoryHandlerTestSuite) TestListUserChangesError() {
+	a := assert.New(s.T())
+
+	originalGenerateErrorID := generateErrorID
+	generateErrorID = func() string { return "error-5678" }
+	defer func() { generateErrorID = originalGenerateErrorID }()
+
+	s.usecase.EXPECT().ListUserChanges(gomock.Any(), usecase.ListUserChangesInput{UserID: 123}).
+		Return(usecase.ListUserChangesOutput{}, s.mockErr)
+
+	rec := s.serve("/user/history", "user-token")
+
+	a.Equal(http.StatusInternalServerError, rec.Code)
+	a.JSONEq(`{"code":"internal","error":"an unexpected error occurred, please try again later","error_id":"error-5678"}`, rec.Body.String())
+}
+
+func (s *HistoryHandlerTestSuite) TestListUserChangesSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ListUserChanges(gomock.Any(), usecase.ListUserChangesInput{UserID: 123, Cursor: "OQ", Limit: 2}).
+		Return(s.changes, nil)
+
+	rec := s.serve("/user/history?cursor=OQ&limit=2", "user-token")
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.JSONEq(`{"changes":[
+		{"id":9,"field":"attributes.estate_code","old_value":null,"new_value":"KAL-01","actor_user_id":123,"request_id":"request-id","changed_at":"2024-03-16T09:55:00Z"},
+		{"id":8,"field":"phone_no","old_value":"+62****4400","new_value":"+62****1234","changed_at":"2024-03-16T09:55:00Z"}
+	],"next_cursor":"OA"}`, rec.Body.String())
+}
+
+func (s *HistoryHandlerTestSuite) TestListAdminUserChangesNotAdmin() {
+	a := assert.New(s.T())
+
+	rec := s.serve("/admin/users/123/history", "user-token")
+
+	a.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *HistoryHandlerTestSuite) TestListAdminUserChangesUserNotFound() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ListUserChanges(gomock.Any(), usecase.ListUserChangesInput{UserID: 124}).
+		Return(usecase.ListUserChangesOutput{}, usecase.UserNotFoundError)
+
+	rec := s.serve("/admin/users/124/history", "admin-token")
+
+	a.Equal(http.StatusNotFound, rec.Code)
+	a.Equal(`{"code":"user.not_found","error":"user not found"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *HistoryHandlerTestSuite) TestListAdminUserChangesSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ListUserChanges(gomock.Any(), usecase.ListUserChangesInput{UserID: 123}).
+		Return(usecase.ListUserChangesOutput{Changes: s.changes.Changes[1:]}, nil)
+
+	rec := s.serve("/admin/users/123/history", "admin-token")
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.JSONEq(`{"changes":[
+		{"id":8,"field":"phone_no","old_value":"+62****4400","new_value":"+62****1234","changed_at":"2024-03-16T09:55:00Z"}
+	]}`, rec.Body.String())
+}
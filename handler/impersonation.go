@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// Issue a short-lived, read-only token to act as the user. Only accessible to admins.
+// (POST /admin/users/{user_id}/impersonation)
+func (s *Server) StartImpersonation(ctx echo.Context, userID generated.UserIdPath) error {
+	principal, err := s.getCurrentPrincipal(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	var payload generated.StartImpersonationRequest
+	if err = json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		err = JsonBodyInvalid
+		return renderError(ctx, err)
+	}
+
+	result, err := s.userUsecase.StartImpersonation(ctx.Request().Context(), usecase.StartImpersonationInput{
+		ActorUserID: principal.UserID,
+		UserID:      uint64(userID),
+		Reason:      payload.Reason,
+	})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.StartImpersonationResponse{JwtToken: result.JwtToken, ExpiresAt: result.ExpiresAt})
+}
+
+// Stop the impersonation session of the impersonation token used to call this API.
+// (DELETE /user/impersonation)
+func (s *Server) StopImpersonation(ctx echo.Context) error {
+	jwtToken, err := getBearerToken(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	if _, err = s.userUsecase.StopImpersonation(ctx.Request().Context(), usecase.StopImpersonationInput{JwtToken: jwtToken}); err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ImpersonationHandlerTestSuite struct {
+	suite.Suite
+
+	gomock  *gomock.Controller
+	usecase *usecase.MockUserUsecases
+
+	handler *Server
+	echo    *echo.Echo
+
+	mockErr error
+}
+
+func TestImpersonationHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ImpersonationHandlerTestSuite))
+}
+
+func (s *ImpersonationHandlerTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.usecase = usecase.NewMockUserUsecases(s.gomock)
+
+	s.handler = NewServer(NewServerOptions{UserUsecase: s.usecase})
+
+	s.echo = echo.New()
+	s.echo.Use(s.handler.Authorize())
+	generated.RegisterHandlers(s.echo, s.handler)
+
+	s.mockErr = fmt.Errorf("simulated error")
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{JwtToken: "admin-token"}).
+		Return(usecase.ValidateUserTokenOutput{UserID: 1, Roles: []string{usecase.RoleAdmin}, Scopes: []string{usecase.ScopeAdminUsers}}, nil).AnyTimes()
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{JwtToken: "impersonation-token"}).
+		Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{usecase.ScopeProfileRead}, ActorUserID: 1}, nil).AnyTimes()
+}
+
+func (s *ImpersonationHandlerTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *ImpersonationHandlerTestSuite) serve(method, target, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *ImpersonationHandlerTestSuite) TestStartImpersonationInvalidJson() {
+	a := assert.New(s.T())
+
+	rec := s.serve(http.MethodPost, "/admin/users/123/impersonation", "admin-token", `{"reason":`)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"request.invalid_json","error":"invalid JSON Body"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *ImpersonationHandlerTestSuite) TestStartImpersonationByImpersonatedUser() {
+	a := assert.New(s.T())
+
+	rec := s.serve(http.MethodPost, "/admin/users/124/impersonation", "impersonation-token", `{"reason":"support ticket #42"}`)
+
+	a.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *ImpersonationHandlerTestSuite) TestStartImpersonationUsecaseError() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().StartImpersonation(gomock.Any(), usecase.StartImpersonationInput{
+		ActorUserID: 1,
+		UserID:      123,
+		Reason:      "support ticket #42",
+	}).Return(usecase.StartImpersonationOutput{}, usecase.UserForbidden)
+
+	rec := s.serve(http.MethodPost, "/admin/users/123/impersonation", "admin-token", `{"reason":"support ticket #42"}`)
+
+	a.Equal(http.StatusForbidden, rec.Code)
+	a.Equal(`{"code":"auth.forbidden","error":"you don't have permission to access this resource"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *ImpersonationHandlerTestSuite) TestStartImpersonationSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().StartImpersonation(gomock.Any(), usecase.StartImpersonationInput{
+		ActorUserID: 1,
+		UserID:      123,
+		Reason:      "support ticket #42",
+	}).Return(usecase.StartImpersonationOutput{
+		JwtToken:  "impersonation-token",
+		ExpiresAt: time.Date(2024, 3, 16, 10, 5, 0, 0, time.UTC),
+	}, nil)
+
+	rec := s.serve(http.MethodPost, "/admin/users/123/impersonation", "admin-token", `{"reason":"support ticket #42"}`)
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"expires_at":"2024-03-16T10:05:00Z","jwt_token":"impersonation-token"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *ImpersonationHandlerTestSuite) TestStopImpersonationNotImpersonating() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{JwtToken: "user-token"}).
+		Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{usecase.ScopeProfileRead}}, nil)
+	s.usecase.EXPECT().StopImpersonation(gomock.Any(), usecase.StopImpersonationInput{JwtToken: "user-token"}).
+		Return(usecase.StopImpersonationOutput{}, usecase.UserNotImpersonating)
+
+	rec := s.serve(http.MethodDelete, "/user/impersonation", "user-token", "")
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"impersonation.not_impersonating","error":"token is not an impersonation token"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *ImpersonationHandlerTestSuite) TestStopImpersonationUsecaseError() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().StopImpersonation(gomock.Any(), usecase.StopImpersonationInput{JwtToken: "impersonation-token"}).
+		Return(usecase.StopImpersonationOutput{}, s.mockErr)
+
+	rec := s.serve(http.MethodDelete, "/user/impersonation", "impersonation-token", "")
+
+	a.Equal(http.StatusInternalServerError, rec.Code)
+}
+
+func (s *ImpersonationHandlerTestSuite) TestStopImpersonationSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().StopImpersonation(gomock.Any(), usecase.StopImpersonationInput{JwtToken: "impersonation-token"}).
+		Return(usecase.StopImpersonationOutput{}, nil)
+
+	rec := s.serve(http.MethodDelete, "/user/impersonation", "impersonation-token", "")
+
+	a.Equal(http.StatusNoContent, rec.Code)
+	a.Empty(rec.Body.String())
+}
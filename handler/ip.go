@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"net"
+)
+
+// NewIPExtractor will return how the client IP is identified (ctx.RealIP), e.g. for the OTP throttling. The
+// X-Forwarded-For header can be set by anyone, so it's only trusted when the request comes through one of the
+// trusted proxies, otherwise the IP of the connection is used
+func NewIPExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	// echo trusts the loopback, link-local & private ranges by default, only the configured ranges are trusted here
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, proxy := range trustedProxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewIPExtractor(t *testing.T) {
+	a := assert.New(t)
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+
+	request := func(remoteAddr, forwardedFor string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/user/session/otp", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		req.Header.Set("X-Real-IP", "198.51.100.9")
+		return req
+	}
+
+	direct := NewIPExtractor(nil)
+	a.Equal("203.0.113.7", direct(request("203.0.113.7:4321", "198.51.100.1")), "the headers are ignored without proxy")
+
+	extract := NewIPExtractor([]*net.IPNet{proxies})
+	a.Equal("198.51.100.1", extract(request("10.1.2.3:4321", "198.51.100.1")))
+	a.Equal("198.51.100.1", extract(request("10.1.2.3:4321", "192.0.2.5, 198.51.100.1")),
+		"the client can't spoof the IP by prepending to the header")
+	a.Equal("203.0.113.7", extract(request("203.0.113.7:4321", "198.51.100.1")),
+		"the header is ignored when the request doesn't come through a trusted proxy")
+	a.Equal("192.168.1.20", extract(request("192.168.1.20:4321", "198.51.100.1")),
+		"the private ranges are not trusted unless configured")
+}
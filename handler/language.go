@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// responseLanguage will return the language error messages are rendered in: the preference of the authenticated
+// user first, then the request `Accept-Language` header, falling back to English
+func responseLanguage(ctx echo.Context) string {
+	if principal, ok := ctx.Get(principalContextKey).(usecase.Principal); ok && principal.Language != "" {
+		return principal.Language
+	}
+	if lang := i18n.MatchLanguage(ctx.Request().Header.Get("Accept-Language")); lang != "" {
+		return lang
+	}
+	return i18n.DefaultLanguage
+}
+
+// localizeError will return the message of the error in the specified language, errors without a message key
+// (e.g. the JSON Schema violations) are rendered as is
+func localizeError(err error, lang string) string {
+	if localizable, ok := err.(interface{ Localize(lang string) string }); ok {
+		return localizable.Localize(lang)
+	}
+	return err.Error()
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResponseLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		principal      *usecase.Principal
+		expected       string
+	}{
+		{name: "default", expected: "en"},
+		{name: "accept language", acceptLanguage: "id-ID,id;q=0.9,en;q=0.8", expected: "id"},
+		{name: "unsupported accept language", acceptLanguage: "fr-FR,de;q=0.8", expected: "en"},
+		{name: "user preference", acceptLanguage: "en", principal: &usecase.Principal{UserID: 123, Language: "id"}, expected: "id"},
+		{name: "no user preference", acceptLanguage: "id", principal: &usecase.Principal{UserID: 123}, expected: "id"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/user", nil)
+			if test.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", test.acceptLanguage)
+			}
+			ctx := echo.New().NewContext(req, httptest.NewRecorder())
+			if test.principal != nil {
+				ctx.Set(principalContextKey, *test.principal)
+			}
+			assert.Equal(t, test.expected, responseLanguage(ctx))
+		})
+	}
+}
+
+func TestRenderErrorLocalized(t *testing.T) {
+	a := assert.New(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	req.Header.Set("Accept-Language", "id")
+	rec := httptest.NewRecorder()
+	err := renderError(echo.New().NewContext(req, rec), usecase.UserNotFoundError)
+
+	a.Empty(err)
+	a.Equal(http.StatusNotFound, rec.Code)
+	a.Equal("id", rec.Header().Get("Content-Language"))
+	a.Equal(`{"code":"user.not_found","error":"pengguna tidak ditemukan"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func TestRenderInternalErrorLocalized(t *testing.T) {
+	a := assert.New(t)
+
+	originalGenerateErrorID := generateErrorID
+	generateErrorID = func() string { return "error-5678" }
+	defer func() { generateErrorID = originalGenerateErrorID }()
+
+	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	req.Header.Set("Accept-Language", "id")
+	rec := httptest.NewRecorder()
+	err := renderError(echo.New().NewContext(req, rec), fmt.Errorf("simulated error"))
+
+	a.Empty(err)
+	a.Equal(http.StatusInternalServerError, rec.Code)
+	a.JSONEq(`{"code":"internal","error":"terjadi kesalahan yang tidak terduga, silakan coba lagi nanti","error_id":"error-5678"}`, rec.Body.String())
+}
+
+func TestRenderValidationErrorsLocalized(t *testing.T) {
+	a := assert.New(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/123/suspend", nil)
+	rec := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, rec)
+	ctx.Set(principalContextKey, usecase.Principal{UserID: 1, Language: "id"})
+	err := renderError(ctx, usecase.NewValidationError(map[string][]error{
+		"reason": {usecase.NewFieldError("reason.too_long", "validation.max_length", i18n.Params{"field": "reason", "max": 500})},
+	}))
+
+	a.Empty(err)
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal("id", rec.Header().Get("Content-Language"))
+	a.Equal(`{"code":"validation.failed","errors":[{"code":"reason.too_long","error":"reason tidak boleh melebihi 500 karakter","field":"reason"}]}`, strings.TrimSpace(rec.Body.String()))
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"github.com/SawitProRecruitment/UserService/metrics"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// unmatchedRoute is the route label of the requests not matching any route, their path is not used as a label
+	// since it's chosen by the client
+	unmatchedRoute = "unmatched"
+	// otherMethod is the method label of the non standard methods, for the same reason
+	otherMethod = "OTHER"
+)
+
+var metricsMethods = map[string]bool{
+	http.MethodGet: true, http.MethodHead: true, http.MethodPost: true, http.MethodPut: true, http.MethodPatch: true,
+	http.MethodDelete: true, http.MethodOptions: true,
+}
+
+// Metrics is an echo middleware observing the duration of every request, by method, route template & status code.
+// The errors are rendered right away so that their status code is observed
+func (s *Server) Metrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			start := time.Now()
+			if err := next(ctx); err != nil {
+				ctx.Error(err)
+			}
+
+			method, route := ctx.Request().Method, ctx.Path()
+			if !metricsMethods[method] {
+				method = otherMethod
+			}
+			if route == "" {
+				route = unmatchedRoute
+			}
+			metrics.HTTPRequestDuration.
+				WithLabelValues(method, route, strconv.Itoa(ctx.Response().Status)).
+				Observe(time.Since(start).Seconds())
+			return nil
+		}
+	}
+}
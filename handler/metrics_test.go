@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"github.com/SawitProRecruitment/UserService/metrics"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	a := assert.New(t)
+
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	e.Use(NewServer(NewServerOptions{}).Metrics())
+	e.GET("/admin/users/:user_id", func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusNoContent)
+	})
+
+	count := func(method, route, status string) uint64 {
+		m := &dto.Metric{}
+		_ = metrics.HTTPRequestDuration.WithLabelValues(method, route, status).(prometheus.Metric).Write(m)
+		return m.GetHistogram().GetSampleCount()
+	}
+	for _, r := range []struct{ method, path string }{
+		{http.MethodGet, "/admin/users/123"},
+		{http.MethodPost, "/admin/users/123"},
+		{"PROPFIND", "/admin/users/123"},
+		{http.MethodGet, "/unknown/path"},
+	} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(r.method, r.path, nil))
+	}
+
+	a.EqualValues(1, count(http.MethodGet, "/admin/users/:user_id", "204"), "the route template is the label, not the path")
+	a.EqualValues(1, count(http.MethodPost, "/admin/users/:user_id", "405"), "the status of the rendered errors is observed")
+	a.EqualValues(1, count(otherMethod, "/admin/users/:user_id", "405"))
+	a.EqualValues(1, count(http.MethodGet, unmatchedRoute, "404"))
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+var (
+	IntrospectTokenMissing = usecase.NewError(http.StatusBadRequest, "request.token_missing", "request.token_missing")
+)
+
+// Token introspection API (RFC 7662). Check whether a token is currently active, authenticated using client credentials.
+// (POST /oauth/introspect)
+func (s *Server) IntrospectToken(ctx echo.Context) error {
+	clientID, clientSecret, ok := ctx.Request().BasicAuth()
+	if !ok {
+		return renderClientAuthError(ctx, usecase.ClientInvalidCredentials)
+	}
+
+	token := ctx.FormValue("token")
+	if token == "" {
+		return renderError(ctx, IntrospectTokenMissing)
+	}
+
+	result, err := s.userUsecase.IntrospectUserToken(ctx.Request().Context(), usecase.IntrospectUserTokenInput{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Token:        token,
+	})
+	if err != nil {
+		return renderClientAuthError(ctx, err)
+	}
+
+	resp := generated.IntrospectTokenResponse{Active: result.Active}
+	if result.Active {
+		resp.Sub = &result.Subject
+		exp := result.ExpiresAt.Unix()
+		resp.Exp = &exp
+		if !result.IssuedAt.IsZero() {
+			iat := result.IssuedAt.Unix()
+			resp.Iat = &iat
+		}
+		if result.Scope != "" {
+			resp.Scope = &result.Scope
+		}
+		if result.ClientID != "" {
+			resp.ClientId = &result.ClientID
+		}
+		if result.ActorSubject != "" {
+			resp.Act = &generated.TokenActor{Sub: result.ActorSubject}
+		}
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// renderClientAuthError adds the `WWW-Authenticate` challenge required by RFC 6749 for failed client authentication
+func renderClientAuthError(ctx echo.Context, err error) error {
+	if errors.Is(err, usecase.ClientInvalidCredentials) {
+		ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="oauth"`)
+	}
+	return renderError(ctx, err)
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type OAuthHandlerTestSuite struct {
+	suite.Suite
+
+	gomock  *gomock.Controller
+	usecase *usecase.MockUserUsecases
+
+	handler *Server
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestOAuthHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(OAuthHandlerTestSuite))
+}
+
+func (s *OAuthHandlerTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.usecase = usecase.NewMockUserUsecases(s.gomock)
+
+	s.handler = NewServer(NewServerOptions{UserUsecase: s.usecase})
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *OAuthHandlerTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *OAuthHandlerTestSuite) TestIntrospectTokenWithoutClientAuth() {
+	a := assert.New(s.T())
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader("token=jwt-token"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	err := s.handler.IntrospectToken(e.NewContext(req, rec))
+
+	a.Empty(err)
+	a.Equal(http.StatusUnauthorized, rec.Code)
+	a.Equal(`Basic realm="oauth"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+	a.Equal(`{"code":"client.invalid_credentials","error":"invalid client credentials"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *OAuthHandlerTestSuite) TestIntrospectTokenMissingToken() {
+	a := assert.New(s.T())
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader("token_type_hint=access_token"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.SetBasicAuth("legacy-service", "client-secret")
+	rec := httptest.NewRecorder()
+	err := s.handler.IntrospectToken(e.NewContext(req, rec))
+
+	a.Empty(err)
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"request.token_missing","error":"missing token parameter"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *OAuthHandlerTestSuite) TestIntrospectTokenInvalidClient() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().IntrospectUserToken(s.ctx, usecase.IntrospectUserTokenInput{
+		ClientID:     "legacy-service",
+		ClientSecret: "wrong-secret",
+		Token:        "jwt-token",
+	}).Return(usecase.IntrospectUserTokenOutput{}, usecase.ClientInvalidCredentials)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader("token=jwt-token"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.SetBasicAuth("legacy-service", "wrong-secret")
+	rec := httptest.NewRecorder()
+	err := s.handler.IntrospectToken(e.NewContext(req, rec))
+
+	a.Empty(err)
+	a.Equal(http.StatusUnauthorized, rec.Code)
+	a.Equal(`Basic realm="oauth"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+	a.Equal(`{"code":"client.invalid_credentials","error":"invalid client credentials"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *OAuthHandlerTestSuite) TestIntrospectTokenInactive() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().IntrospectUserToken(s.ctx, usecase.IntrospectUserTokenInput{
+		ClientID:     "legacy-service",
+		ClientSecret: "client-secret",
+		Token:        "jwt-token",
+	}).Return(usecase.IntrospectUserTokenOutput{Active: false}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader("token=jwt-token"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.SetBasicAuth("legacy-service", "client-secret")
+	rec := httptest.NewRecorder()
+	err := s.handler.IntrospectToken(e.NewContext(req, rec))
+
+	a.Empty(err)
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"active":false}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *OAuthHandlerTestSuite) TestIntrospectTokenActive() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().IntrospectUserToken(s.ctx, usecase.IntrospectUserTokenInput{
+		ClientID:     "legacy-service",
+		ClientSecret: "client-secret",
+		Token:        "jwt-token",
+	}).Return(usecase.IntrospectUserTokenOutput{
+		Active:    true,
+		Subject:   "123",
+		ExpiresAt: time.Unix(1710553953, 0),
+		IssuedAt:  time.Unix(1710553353, 0),
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader("token=jwt-token"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.SetBasicAuth("legacy-service", "client-secret")
+	rec := httptest.NewRecorder()
+	err := s.handler.IntrospectToken(e.NewContext(req, rec))
+
+	a.Empty(err)
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"active":true,"exp":1710553953,"iat":1710553353,"sub":"123"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *OAuthHandlerTestSuite) TestIntrospectTokenActiveImpersonation() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().IntrospectUserToken(s.ctx, usecase.IntrospectUserTokenInput{
+		ClientID:     "legacy-service",
+		ClientSecret: "client-secret",
+		Token:        "jwt-token",
+	}).Return(usecase.IntrospectUserTokenOutput{
+		Active:       true,
+		Subject:      "123",
+		ExpiresAt:    time.Unix(1710553953, 0),
+		ActorSubject: "1",
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader("token=jwt-token"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.SetBasicAuth("legacy-service", "client-secret")
+	rec := httptest.NewRecorder()
+	err := s.handler.IntrospectToken(e.NewContext(req, rec))
+
+	a.Empty(err)
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"act":{"sub":"1"},"active":true,"exp":1710553953,"sub":"123"}`, strings.TrimSpace(rec.Body.String()))
+}
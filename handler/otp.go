@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// Passwordless login API. Send a one-time login code by SMS to the phone number, if it belongs to a user.
+// (POST /user/session/otp)
+func (s *Server) RequestLoginOtp(ctx echo.Context) error {
+	var payload generated.RequestLoginOtpRequest
+	if err := json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		err = JsonBodyInvalid
+		return renderError(ctx, err)
+	}
+
+	result, err := s.userUsecase.RequestLoginOTP(ctx.Request().Context(), usecase.RequestLoginOTPInput{
+		PhoneNo:   payload.PhoneNo,
+		IPAddress: ctx.RealIP(),
+	})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusAccepted, generated.RequestLoginOtpResponse{ExpiresAt: result.ExpiresAt})
+}
+
+// Passwordless login API. Exchange the one-time login code sent by SMS for a new session.
+// (POST /user/session/otp/verify)
+func (s *Server) VerifyLoginOtp(ctx echo.Context) error {
+	var payload generated.VerifyLoginOtpRequest
+	if err := json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		err = JsonBodyInvalid
+		return renderError(ctx, err)
+	}
+
+	input := usecase.VerifyLoginOTPInput{
+		PhoneNo: payload.PhoneNo,
+		Code:    payload.Code,
+	}
+	if payload.Scope != nil {
+		input.Scope = *payload.Scope
+	}
+	result, err := s.userUsecase.VerifyLoginOTP(ctx.Request().Context(), input)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.LoginUserResponse{JwtToken: result.JwtToken})
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type OTPHandlerTestSuite struct {
+	suite.Suite
+
+	gomock  *gomock.Controller
+	usecase *usecase.MockUserUsecases
+
+	handler *Server
+	echo    *echo.Echo
+
+	mockErr error
+}
+
+func TestOTPHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(OTPHandlerTestSuite))
+}
+
+func (s *OTPHandlerTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.usecase = usecase.NewMockUserUsecases(s.gomock)
+
+	s.handler = NewServer(NewServerOptions{UserUsecase: s.usecase})
+
+	s.echo = echo.New()
+	s.echo.Use(s.handler.Authorize())
+	generated.RegisterHandlers(s.echo, s.handler)
+
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *OTPHandlerTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *OTPHandlerTestSuite) serve(target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.RemoteAddr = "10.0.0.1:52100"
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *OTPHandlerTestSuite) TestRequestLoginOtpInvalidJson() {
+	a := assert.New(s.T())
+
+	rec := s.serve("/user/session/otp", `{"phone_no":`)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"request.invalid_json","error":"invalid JSON Body"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *OTPHandlerTestSuite) TestRequestLoginOtpThrottled() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().RequestLoginOTP(gomock.Any(), usecase.RequestLoginOTPInput{PhoneNo: "+62812151833", IPAddress: "10.0.0.1"}).
+		Return(usecase.RequestLoginOTPOutput{}, usecase.UserLoginOTPThrottled)
+
+	rec := s.serve("/user/session/otp", `{"phone_no":"+62812151833"}`)
+
+	a.Equal(http.StatusTooManyRequests, rec.Code)
+	a.Equal(`{"code":"auth.login_code_throttled","error":"too many login codes requested, please try again later"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *OTPHandlerTestSuite) TestRequestLoginOtpSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().RequestLoginOTP(gomock.Any(), usecase.RequestLoginOTPInput{PhoneNo: "+62812151833", IPAddress: "10.0.0.1"}).
+		Return(usecase.RequestLoginOTPOutput{ExpiresAt: time.Date(2024, 3, 16, 10, 5, 0, 0, time.UTC)}, nil)
+
+	rec := s.serve("/user/session/otp", `{"phone_no":"+62812151833"}`)
+
+	a.Equal(http.StatusAccepted, rec.Code)
+	a.Equal(`{"expires_at":"2024-03-16T10:05:00Z"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *OTPHandlerTestSuite) TestVerifyLoginOtpInvalidJson() {
+	a := assert.New(s.T())
+
+	rec := s.serve("/user/session/otp/verify", `{"phone_no":`)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"request.invalid_json","error":"invalid JSON Body"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *OTPHandlerTestSuite) TestVerifyLoginOtpInvalidCode() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().VerifyLoginOTP(gomock.Any(), usecase.VerifyLoginOTPInput{PhoneNo: "+62812151833", Code: "000000"}).
+		Return(usecase.VerifyLoginOTPOutput{}, usecase.UserInvalidLoginOTP)
+
+	rec := s.serve("/user/session/otp/verify", `{"phone_no":"+62812151833","code":"000000"}`)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"auth.invalid_login_code","error":"invalid or expired login code"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *OTPHandlerTestSuite) TestVerifyLoginOtpUsecaseError() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().VerifyLoginOTP(gomock.Any(), usecase.VerifyLoginOTPInput{PhoneNo: "+62812151833", Code: "042917"}).
+		Return(usecase.VerifyLoginOTPOutput{}, s.mockErr)
+
+	rec := s.serve("/user/session/otp/verify", `{"phone_no":"+62812151833","code":"042917"}`)
+
+	a.Equal(http.StatusInternalServerError, rec.Code)
+}
+
+func (s *OTPHandlerTestSuite) TestVerifyLoginOtpSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().VerifyLoginOTP(gomock.Any(), usecase.VerifyLoginOTPInput{PhoneNo: "+62812151833", Code: "042917", Scope: "profile:read"}).
+		Return(usecase.VerifyLoginOTPOutput{JwtToken: "jwt-token"}, nil)
+
+	rec := s.serve("/user/session/otp/verify", `{"phone_no":"+62812151833","code":"042917","scope":"profile:read"}`)
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"jwt_token":"jwt-token"}`, strings.TrimSpace(rec.Body.String()))
+}
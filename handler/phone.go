@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// Request a change of the phone number of the logged-in user, a confirmation code is sent to the new phone number.
+// (POST /user/phone)
+func (s *Server) RequestPhoneChange(ctx echo.Context) error {
+	userID, err := s.getCurrentUser(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	var payload generated.RequestPhoneChangeRequest
+	if err = json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		err = JsonBodyInvalid
+		return renderError(ctx, err)
+	}
+
+	result, err := s.userUsecase.RequestPhoneChange(ctx.Request().Context(), usecase.RequestPhoneChangeInput{
+		UserID:  userID,
+		PhoneNo: payload.PhoneNo,
+	})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusAccepted, generated.RequestPhoneChangeResponse{ExpiresAt: result.ExpiresAt})
+}
+
+// Confirm the phone number change with the code sent to the new phone number.
+// (POST /user/phone/confirm)
+func (s *Server) ConfirmPhoneChange(ctx echo.Context) error {
+	userID, err := s.getCurrentUser(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	var payload generated.ConfirmPhoneChangeRequest
+	if err = json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		err = JsonBodyInvalid
+		return renderError(ctx, err)
+	}
+
+	result, err := s.userUsecase.ConfirmPhoneChange(ctx.Request().Context(), usecase.ConfirmPhoneChangeInput{
+		UserID: userID,
+		Code:   payload.Code,
+	})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.ConfirmPhoneChangeResponse{PhoneNo: result.PhoneNo})
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type PhoneHandlerTestSuite struct {
+	suite.Suite
+
+	gomock  *gomock.Controller
+	usecase *usecase.MockUserUsecases
+
+	handler *Server
+	echo    *echo.Echo
+
+	mockErr error
+}
+
+func TestPhoneHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(PhoneHandlerTestSuite))
+}
+
+func (s *PhoneHandlerTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.usecase = usecase.NewMockUserUsecases(s.gomock)
+
+	s.handler = NewServer(NewServerOptions{UserUsecase: s.usecase})
+
+	s.echo = echo.New()
+	s.echo.Use(s.handler.Authorize())
+	generated.RegisterHandlers(s.echo, s.handler)
+
+	s.mockErr = fmt.Errorf("simulated error")
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{JwtToken: "user-token"}).
+		Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{usecase.ScopeProfileRead, usecase.ScopeProfileWrite}}, nil).AnyTimes()
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{JwtToken: "read-only-token"}).
+		Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{usecase.ScopeProfileRead}}, nil).AnyTimes()
+}
+
+func (s *PhoneHandlerTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *PhoneHandlerTestSuite) serve(method, target, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *PhoneHandlerTestSuite) TestRequestPhoneChangeInsufficientScope() {
+	a := assert.New(s.T())
+
+	rec := s.serve(http.MethodPost, "/user/phone", "read-only-token", `{"phone_no":"+6581234567"}`)
+
+	a.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *PhoneHandlerTestSuite) TestRequestPhoneChangeInvalidJson() {
+	a := assert.New(s.T())
+
+	rec := s.serve(http.MethodPost, "/user/phone", "user-token", `{"phone_no":`)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"request.invalid_json","error":"invalid JSON Body"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *PhoneHandlerTestSuite) TestRequestPhoneChangeConflict() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().RequestPhoneChange(gomock.Any(), usecase.RequestPhoneChangeInput{UserID: 123, PhoneNo: "+6581234567"}).
+		Return(usecase.RequestPhoneChangeOutput{}, usecase.UserConflictError)
+
+	rec := s.serve(http.MethodPost, "/user/phone", "user-token", `{"phone_no":"+6581234567"}`)
+
+	a.Equal(http.StatusConflict, rec.Code)
+}
+
+func (s *PhoneHandlerTestSuite) TestRequestPhoneChangeThrottled() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().RequestPhoneChange(gomock.Any(), gomock.Any()).
+		Return(usecase.RequestPhoneChangeOutput{}, usecase.UserPhoneChangeThrottled)
+
+	rec := s.serve(http.MethodPost, "/user/phone", "user-token", `{"phone_no":"+6581234567"}`)
+
+	a.Equal(http.StatusTooManyRequests, rec.Code)
+	a.Equal(`{"code":"phone_no.change_throttled","error":"too many phone number changes requested, please try again later"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *PhoneHandlerTestSuite) TestRequestPhoneChangeSuccess() {
+	a := assert.New(s.T())
+
+	expiresAt := time.Date(2024, 3, 16, 10, 5, 0, 0, time.UTC)
+	s.usecase.EXPECT().RequestPhoneChange(gomock.Any(), usecase.RequestPhoneChangeInput{UserID: 123, PhoneNo: "+6581234567"}).
+		Return(usecase.RequestPhoneChangeOutput{ExpiresAt: expiresAt}, nil)
+
+	rec := s.serve(http.MethodPost, "/user/phone", "user-token", `{"phone_no":"+6581234567"}`)
+
+	a.Equal(http.StatusAccepted, rec.Code)
+	a.Equal(`{"expires_at":"2024-03-16T10:05:00Z"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *PhoneHandlerTestSuite) TestConfirmPhoneChangeInvalidCode() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ConfirmPhoneChange(gomock.Any(), usecase.ConfirmPhoneChangeInput{UserID: 123, Code: "111111"}).
+		Return(usecase.ConfirmPhoneChangeOutput{}, usecase.UserInvalidPhoneChangeCode)
+
+	rec := s.serve(http.MethodPost, "/user/phone/confirm", "user-token", `{"code":"111111"}`)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"phone_no.invalid_change_code","error":"invalid or expired phone number change code"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *PhoneHandlerTestSuite) TestConfirmPhoneChangeError() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ConfirmPhoneChange(gomock.Any(), gomock.Any()).Return(usecase.ConfirmPhoneChangeOutput{}, s.mockErr)
+
+	rec := s.serve(http.MethodPost, "/user/phone/confirm", "user-token", `{"code":"042917"}`)
+
+	a.Equal(http.StatusInternalServerError, rec.Code)
+}
+
+func (s *PhoneHandlerTestSuite) TestConfirmPhoneChangeSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ConfirmPhoneChange(gomock.Any(), usecase.ConfirmPhoneChangeInput{UserID: 123, Code: "042917"}).
+		Return(usecase.ConfirmPhoneChangeOutput{PhoneNo: "+6581234567"}, nil)
+
+	rec := s.serve(http.MethodPost, "/user/phone/confirm", "user-token", `{"code":"042917"}`)
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"phone_no":"+6581234567"}`, strings.TrimSpace(rec.Body.String()))
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// PIN login API. Create new session for the target user with the PIN, only from an enrolled device.
+// (POST /user/session/pin)
+func (s *Server) LoginUserPin(ctx echo.Context) error {
+	var payload generated.LoginUserPinRequest
+	if err := json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		err = JsonBodyInvalid
+		return renderError(ctx, err)
+	}
+
+	input := usecase.LoginUserPINInput{
+		PhoneNo:     payload.PhoneNo,
+		PIN:         payload.Pin,
+		DeviceToken: payload.DeviceToken,
+	}
+	if payload.Scope != nil {
+		input.Scope = *payload.Scope
+	}
+	result, err := s.userUsecase.LoginUserPIN(ctx.Request().Context(), input)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.LoginUserResponse{JwtToken: result.JwtToken})
+}
+
+// Set the PIN of the logged-in user after re-confirming the password.
+// (PUT /user/pin)
+func (s *Server) SetUserPin(ctx echo.Context) error {
+	userID, err := s.getCurrentUser(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	var payload generated.SetUserPinRequest
+	if err = json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		err = JsonBodyInvalid
+		return renderError(ctx, err)
+	}
+	_, err = s.userUsecase.SetUserPIN(ctx.Request().Context(), usecase.SetUserPINInput{
+		UserID:   userID,
+		Password: payload.Password,
+		PIN:      payload.Pin,
+	})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// Remove the PIN of the logged-in user, disabling PIN login.
+// (DELETE /user/pin)
+func (s *Server) RemoveUserPin(ctx echo.Context) error {
+	userID, err := s.getCurrentUser(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	if _, err = s.userUsecase.RemoveUserPIN(ctx.Request().Context(), usecase.RemoveUserPINInput{UserID: userID}); err != nil {
+		return renderError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type PINHandlerTestSuite struct {
+	suite.Suite
+
+	gomock  *gomock.Controller
+	usecase *usecase.MockUserUsecases
+
+	handler *Server
+	echo    *echo.Echo
+
+	mockErr error
+}
+
+func TestPINHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(PINHandlerTestSuite))
+}
+
+func (s *PINHandlerTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.usecase = usecase.NewMockUserUsecases(s.gomock)
+
+	s.handler = NewServer(NewServerOptions{UserUsecase: s.usecase})
+
+	s.echo = echo.New()
+	s.echo.Use(s.handler.Authorize())
+	generated.RegisterHandlers(s.echo, s.handler)
+
+	s.mockErr = fmt.Errorf("simulated error")
+
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{JwtToken: "user-token"}).
+		Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{usecase.ScopeProfileRead, usecase.ScopeProfileWrite}}, nil).AnyTimes()
+	s.usecase.EXPECT().ValidateUserToken(gomock.Any(), usecase.ValidateUserTokenInput{JwtToken: "read-only-token"}).
+		Return(usecase.ValidateUserTokenOutput{UserID: 123, Scopes: []string{usecase.ScopeProfileRead}}, nil).AnyTimes()
+}
+
+func (s *PINHandlerTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *PINHandlerTestSuite) serve(method, target, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *PINHandlerTestSuite) TestLoginUserPinInvalidJson() {
+	a := assert.New(s.T())
+
+	rec := s.serve(http.MethodPost, "/user/session/pin", "", `{"phone_no":`)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"request.invalid_json","error":"invalid JSON Body"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *PINHandlerTestSuite) TestLoginUserPinLocked() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().LoginUserPIN(gomock.Any(), usecase.LoginUserPINInput{
+		PhoneNo:     "+62812151833",
+		PIN:         "274916",
+		DeviceToken: "device-token",
+	}).Return(usecase.LoginUserPINOutput{}, usecase.UserPINLocked)
+
+	rec := s.serve(http.MethodPost, "/user/session/pin", "", `{"phone_no":"+62812151833","pin":"274916","device_token":"device-token"}`)
+
+	a.Equal(http.StatusForbidden, rec.Code)
+	a.Equal(`{"code":"auth.pin_locked","error":"PIN login is locked after too many wrong attempts, please login with your password"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *PINHandlerTestSuite) TestLoginUserPinInvalid() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().LoginUserPIN(gomock.Any(), usecase.LoginUserPINInput{
+		PhoneNo:     "+62812151833",
+		PIN:         "000000",
+		DeviceToken: "device-token",
+	}).Return(usecase.LoginUserPINOutput{}, usecase.UserInvalidPINLogin)
+
+	rec := s.serve(http.MethodPost, "/user/session/pin", "", `{"phone_no":"+62812151833","pin":"000000","device_token":"device-token"}`)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"auth.invalid_pin_login","error":"invalid phone number, PIN or device"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *PINHandlerTestSuite) TestLoginUserPinSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().LoginUserPIN(gomock.Any(), usecase.LoginUserPINInput{
+		PhoneNo:     "+62812151833",
+		PIN:         "274916",
+		DeviceToken: "device-token",
+		Scope:       "profile:read",
+	}).Return(usecase.LoginUserPINOutput{JwtToken: "jwt-token"}, nil)
+
+	rec := s.serve(http.MethodPost, "/user/session/pin", "", `{"phone_no":"+62812151833","pin":"274916","device_token":"device-token","scope":"profile:read"}`)
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"jwt_token":"jwt-token"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *PINHandlerTestSuite) TestSetUserPinInsufficientScope() {
+	a := assert.New(s.T())
+
+	rec := s.serve(http.MethodPut, "/user/pin", "read-only-token", `{"password":"SomeP@ssw0rdHere","pin":"274916"}`)
+
+	a.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *PINHandlerTestSuite) TestSetUserPinInvalidPassword() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().SetUserPIN(gomock.Any(), usecase.SetUserPINInput{UserID: 123, Password: "wrong-password", PIN: "274916"}).
+		Return(usecase.SetUserPINOutput{}, usecase.UserInvalidPassword)
+
+	rec := s.serve(http.MethodPut, "/user/pin", "user-token", `{"password":"wrong-password","pin":"274916"}`)
+
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"auth.invalid_password","error":"invalid password"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *PINHandlerTestSuite) TestSetUserPinSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().SetUserPIN(gomock.Any(), usecase.SetUserPINInput{UserID: 123, Password: "SomeP@ssw0rdHere", PIN: "274916"}).
+		Return(usecase.SetUserPINOutput{}, nil)
+
+	rec := s.serve(http.MethodPut, "/user/pin", "user-token", `{"password":"SomeP@ssw0rdHere","pin":"274916"}`)
+
+	a.Equal(http.StatusNoContent, rec.Code)
+	a.Empty(rec.Body.String())
+}
+
+func (s *PINHandlerTestSuite) TestRemoveUserPinUsecaseError() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().RemoveUserPIN(gomock.Any(), usecase.RemoveUserPINInput{UserID: 123}).
+		Return(usecase.RemoveUserPINOutput{}, s.mockErr)
+
+	rec := s.serve(http.MethodDelete, "/user/pin", "user-token", "")
+
+	a.Equal(http.StatusInternalServerError, rec.Code)
+}
+
+func (s *PINHandlerTestSuite) TestRemoveUserPinSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().RemoveUserPIN(gomock.Any(), usecase.RemoveUserPINInput{UserID: 123}).
+		Return(usecase.RemoveUserPINOutput{}, nil)
+
+	rec := s.serve(http.MethodDelete, "/user/pin", "user-token", "")
+
+	a.Equal(http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"regexp"
+)
+
+var (
+	requestIDRegex = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`) // match the request IDs accepted from the clients
+
+	// wrapper to request ID generation to make testing easier
+	generateRequestID = func() string {
+		id := make([]byte, 16)
+		_, _ = rand.Read(id)
+		return hex.EncodeToString(id)
+	}
+)
+
+// RequestID is an echo middleware identifying every request by the `X-Request-ID` header sent by the client (e.g. a
+// gateway), or a generated one when it's missing or malformed. The ID is returned as the `X-Request-ID` response
+// header, and available to the next handlers through usecase.RequestIDFromContext
+func (s *Server) RequestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			requestID := ctx.Request().Header.Get(echo.HeaderXRequestID)
+			if !requestIDRegex.MatchString(requestID) {
+				requestID = generateRequestID()
+			}
+			setRequestID(ctx, requestID)
+			return next(ctx)
+		}
+	}
+}
+
+// currentRequestID will return the ID of the request, requests that didn't go through the RequestID middleware (e.g.
+// when it's not registered yet) are identified on the fly
+func currentRequestID(ctx echo.Context) string {
+	if requestID := usecase.RequestIDFromContext(ctx.Request().Context()); requestID != "" {
+		return requestID
+	}
+	requestID := generateRequestID()
+	setRequestID(ctx, requestID)
+	return requestID
+}
+
+func setRequestID(ctx echo.Context, requestID string) {
+	ctx.Response().Header().Set(echo.HeaderXRequestID, requestID)
+	ctx.SetRequest(ctx.Request().WithContext(usecase.ContextWithRequestID(ctx.Request().Context(), requestID)))
+}
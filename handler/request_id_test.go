@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type RequestIDMiddlewareTestSuite struct {
+	suite.Suite
+
+	gomock *gomock.Controller
+
+	handler *Server
+	echo    *echo.Echo
+
+	requestID                 string
+	originalGenerateRequestID func() string
+}
+
+func TestRequestIDMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(RequestIDMiddlewareTestSuite))
+}
+
+func (s *RequestIDMiddlewareTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.handler = NewServer(NewServerOptions{UserUsecase: usecase.NewMockUserUsecases(s.gomock)})
+
+	// the handler echoes the request ID seen by the usecase layer
+	s.echo = echo.New()
+	s.echo.Use(s.handler.RequestID())
+	s.echo.GET("/", func(ctx echo.Context) error {
+		return ctx.String(http.StatusOK, usecase.RequestIDFromContext(ctx.Request().Context()))
+	})
+
+	s.requestID = "generated-request-id"
+	s.originalGenerateRequestID = generateRequestID
+	generateRequestID = func() string { return s.requestID }
+}
+
+func (s *RequestIDMiddlewareTestSuite) TearDownTest() {
+	generateRequestID = s.originalGenerateRequestID
+	s.gomock.Finish()
+}
+
+func (s *RequestIDMiddlewareTestSuite) serve(requestID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if requestID != "" {
+		req.Header.Set(echo.HeaderXRequestID, requestID)
+	}
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *RequestIDMiddlewareTestSuite) TestGenerated() {
+	a := assert.New(s.T())
+
+	rec := s.serve("")
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(s.requestID, rec.Header().Get(echo.HeaderXRequestID))
+	a.Equal(s.requestID, rec.Body.String())
+}
+
+func (s *RequestIDMiddlewareTestSuite) TestPropagated() {
+	a := assert.New(s.T())
+
+	rec := s.serve("gateway-1234")
+
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal("gateway-1234", rec.Header().Get(echo.HeaderXRequestID))
+	a.Equal("gateway-1234", rec.Body.String())
+}
+
+func (s *RequestIDMiddlewareTestSuite) TestMalformed() {
+	a := assert.New(s.T())
+
+	for _, requestID := range []string{"not valid", strings.Repeat("a", 65), "id\x00"} {
+		rec := s.serve(requestID)
+
+		a.Equal(s.requestID, rec.Header().Get(echo.HeaderXRequestID), requestID)
+		a.Equal(s.requestID, rec.Body.String(), requestID)
+	}
+}
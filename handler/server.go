@@ -2,43 +2,41 @@ package handler
 
 import (
 	"errors"
+	"github.com/SawitProRecruitment/UserService/config"
 	"github.com/SawitProRecruitment/UserService/generated"
 	"github.com/SawitProRecruitment/UserService/usecase"
 	"github.com/labstack/echo/v4"
 	"net/http"
-	"strings"
 )
 
 type Server struct {
 	userUsecase usecase.UserUsecases
+	// routeSecurity maps echo routes into their security requirement
+	routeSecurity map[string]routeSecurity
+	// requireIfMatch rejects profile updates without an If-Match header
+	requireIfMatch bool
 }
 
 type NewServerOptions struct {
 	UserUsecase usecase.UserUsecases
+	// HTTP contains the settings of the API, e.g. whether the profile updates require If-Match
+	HTTP config.HTTP
 }
 
 func NewServer(opts NewServerOptions) *Server {
 	return &Server{
-		userUsecase: opts.UserUsecase,
+		userUsecase:    opts.UserUsecase,
+		routeSecurity:  mustLoadRouteSecurity(),
+		requireIfMatch: opts.HTTP.RequireIfMatch,
 	}
 }
 
 func (s *Server) getCurrentUser(ctx echo.Context) (userID uint64, err error) {
-	authHeaders := ctx.Request().Header["Authorization"]
-	if len(authHeaders) == 0 {
-		return 0, usecase.UserInvalidToken
-	}
-	authHeader := authHeaders[0]
-	if !strings.HasPrefix(authHeader, "Bearer ") {
-		return 0, usecase.UserInvalidToken
-	}
-	jwtToken := strings.TrimPrefix(authHeader, "Bearer ")
-
-	token, err := s.userUsecase.ValidateUserToken(ctx.Request().Context(), usecase.ValidateUserTokenInput{JwtToken: jwtToken})
+	principal, err := s.getCurrentPrincipal(ctx)
 	if err != nil {
 		return 0, err
 	}
-	return token.UserID, nil
+	return principal.UserID, nil
 }
 
 func renderError(ctx echo.Context, err error) error {
@@ -47,24 +45,43 @@ func renderError(ctx echo.Context, err error) error {
 		return renderValidationErrors(ctx, validationErrors)
 	}
 
-	var code int
-	var ok bool
-	resp := generated.ErrorResponse{Error: err.Error()}
-	if code, ok = errorsToCodeMap[err]; !ok {
-		code = 500
+	lang := responseLanguage(ctx)
+	ctx.Response().Header().Set("Content-Language", lang)
+
+	// the details of the errors unknown by the clients are only logged, along with the ID the clients get to report them
+	var errorID *string
+	var knownErr *usecase.Error
+	if !errors.As(err, &knownErr) {
+		id := generateErrorID()
+		logInternalError(ctx, id, err)
+		knownErr, errorID = InternalError, &id
 	}
-	return ctx.JSON(code, resp)
+	message := knownErr.Localize(lang)
+
+	if acceptsProblem(ctx) {
+		problem := newProblem(ctx, knownErr.Status, knownErr.Code)
+		problem.Detail = &message
+		problem.ErrorId = errorID
+		return renderProblem(ctx, problem)
+	}
+	return ctx.JSON(knownErr.Status, generated.ErrorResponse{Code: knownErr.Code, Error: message, ErrorId: errorID})
 }
 
 func renderValidationErrors(ctx echo.Context, fieldErrors usecase.ValidationErrors) error {
-	resp := generated.FieldErrorsResponse{Errors: nil}
-	for field, errs := range fieldErrors.GetErrors() {
-		for _, err := range errs {
-			resp.Errors = append(resp.Errors, struct {
-				Error string `json:"error"`
-				Field string `json:"field"`
-			}{Error: err.Error(), Field: field})
+	lang := responseLanguage(ctx)
+	ctx.Response().Header().Set("Content-Language", lang)
+
+	var errs []generated.FieldError
+	for field, fieldErrs := range fieldErrors.GetErrors() {
+		for _, err := range fieldErrs {
+			errs = append(errs, generated.FieldError{Code: usecase.FieldErrorCode(err), Error: localizeError(err, lang), Field: field})
 		}
 	}
-	return ctx.JSON(http.StatusBadRequest, resp)
+
+	if acceptsProblem(ctx) {
+		problem := newProblem(ctx, http.StatusBadRequest, usecase.ValidationErrorsCode)
+		problem.Errors = &errs
+		return renderProblem(ctx, problem)
+	}
+	return ctx.JSON(http.StatusBadRequest, generated.FieldErrorsResponse{Code: usecase.ValidationErrorsCode, Errors: errs})
 }
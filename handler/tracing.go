@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/tracing"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+// Tracing is an echo middleware creating a span per request, as a child of the trace of the `traceparent` header
+// sent by the client (e.g. a gateway). The span is available to the next handlers through the request context, and
+// its trace context is returned as the `traceparent` response header. Like the metrics, it's named after the route
+// template rather than the path. The errors are rendered right away so that their status code is recorded
+func (s *Server) Tracing() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			route := ctx.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+
+			spanCtx := tracing.Propagator.Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			spanCtx, span := tracing.Start(spanCtx, fmt.Sprintf("%s %s", req.Method, route),
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.method", req.Method),
+					attribute.String("http.route", route),
+					attribute.String("http.request_id", usecase.RequestIDFromContext(req.Context())),
+				))
+			defer span.End()
+			ctx.SetRequest(req.WithContext(spanCtx))
+			tracing.Propagator.Inject(spanCtx, propagation.HeaderCarrier(ctx.Response().Header()))
+
+			if err := next(ctx); err != nil {
+				ctx.Error(err)
+			}
+
+			status := ctx.Response().Status
+			span.SetAttributes(attribute.Int("http.status_code", status))
+			// the client errors are not failures of the service
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			return nil
+		}
+	}
+}
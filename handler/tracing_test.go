@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTracing(t *testing.T) {
+	a := assert.New(t)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	server := NewServer(NewServerOptions{})
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	e.Use(server.RequestID())
+	e.Use(server.Tracing())
+	var handlerSpan trace.SpanContext
+	e.GET("/admin/users/:user_id", func(ctx echo.Context) error {
+		handlerSpan = trace.SpanContextFromContext(ctx.Request().Context())
+		return fmt.Errorf("simulated error")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/users/123", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set(echo.HeaderXRequestID, "gateway-1234")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	spans := recorder.Ended()
+	a.Len(spans, 1)
+	span := spans[0]
+	a.Equal("GET /admin/users/:user_id", span.Name())
+	a.Equal(trace.SpanKindServer, span.SpanKind())
+	a.Equal("4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String(), "the trace of the client is continued")
+	a.Equal("00f067aa0ba902b7", span.Parent().SpanID().String())
+	a.Equal(span.SpanContext(), handlerSpan, "the span is available to the handlers")
+	a.Contains(span.Attributes(), attribute.String("http.request_id", "gateway-1234"))
+	a.Contains(span.Attributes(), attribute.Int("http.status_code", http.StatusInternalServerError))
+	a.Equal(codes.Error, span.Status().Code)
+
+	a.Equal(http.StatusInternalServerError, rec.Code)
+	traceparent := rec.Header().Get("traceparent")
+	a.True(strings.HasPrefix(traceparent, "00-4bf92f3577b34da6a3ce929d0e0e4736-"+span.SpanContext().SpanID().String()), traceparent)
+}
+
+func TestTracingClientError(t *testing.T) {
+	a := assert.New(t)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	e.Use(NewServer(NewServerOptions{}).Tracing())
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown/path", nil))
+
+	spans := recorder.Ended()
+	a.Len(spans, 1)
+	a.Equal("GET unmatched", spans[0].Name(), "the path chosen by the client is not used as the name")
+	a.False(spans[0].Parent().IsValid(), "a new trace is started without traceparent")
+	a.Contains(spans[0].Attributes(), attribute.Int("http.status_code", http.StatusNotFound))
+	a.Equal(codes.Unset, spans[0].Status().Code)
+}
@@ -3,6 +3,8 @@ package handler
 import (
 	"encoding/json"
 	"github.com/SawitProRecruitment/UserService/usecase"
+	"io"
+	"mime"
 	"net/http"
 
 	"github.com/SawitProRecruitment/UserService/generated"
@@ -18,15 +20,28 @@ func (s *Server) LoginUser(ctx echo.Context) error {
 		return renderError(ctx, err)
 	}
 
-	result, err := s.userUsecase.LoginUser(ctx.Request().Context(), usecase.LoginUserInput{
-		PhoneNo:  payload.PhoneNo,
-		Password: payload.Password,
-	})
+	input := usecase.LoginUserInput{Password: payload.Password}
+	if payload.PhoneNo != nil {
+		input.PhoneNo = *payload.PhoneNo
+	}
+	if payload.Email != nil {
+		input.Email = *payload.Email
+	}
+	if payload.Scope != nil {
+		input.Scope = *payload.Scope
+	}
+	if payload.EnrollDevice != nil {
+		input.EnrollDevice = *payload.EnrollDevice
+	}
+	result, err := s.userUsecase.LoginUser(ctx.Request().Context(), input)
 	if err != nil {
 		return renderError(ctx, err)
 	}
 
 	resp := generated.LoginUserResponse{JwtToken: result.JwtToken}
+	if result.DeviceToken != "" {
+		resp.DeviceToken = &result.DeviceToken
+	}
 	return ctx.JSON(http.StatusOK, resp)
 }
 
@@ -54,7 +69,7 @@ func (s *Server) RegisterUser(ctx echo.Context) error {
 
 // Get logged-in user profile
 // (GET /user)
-func (s *Server) GetUser(ctx echo.Context) error {
+func (s *Server) GetUser(ctx echo.Context, params generated.GetUserParams) error {
 	userID, err := s.getCurrentUser(ctx)
 	if err != nil {
 		return renderError(ctx, err)
@@ -71,31 +86,122 @@ func (s *Server) GetUser(ctx echo.Context) error {
 		PhoneNo:              result.PhoneNo,
 		SuccessfulLoginCount: int(result.SuccessfulLoginCount),
 	}
+	if result.Email != "" {
+		resp.Email = &result.Email
+		resp.EmailVerified = &result.EmailVerified
+	}
+	if result.AvatarURL != "" {
+		resp.AvatarUrl = &result.AvatarURL
+		resp.AvatarThumbnailUrl = &result.AvatarThumbnailURL
+	}
+	if len(result.Attributes) > 0 {
+		resp.Attributes = &result.Attributes
+	}
+
+	etag := profileETag(result.UserID, result.Version)
+	ctx.Response().Header().Set("ETag", etag)
+	if params.IfNoneMatch != nil && etagMatches(*params.IfNoneMatch, etag) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
 	return ctx.JSON(http.StatusOK, resp)
 }
 
-// Update logged-in user profile
+// patchFormats maps the media types of the patches accepted by PATCH /user into their format
+var patchFormats = map[string]string{
+	"application/merge-patch+json": usecase.PatchFormatMergePatch,
+	"application/json-patch+json":  usecase.PatchFormatJSONPatch,
+}
+
+// Update logged-in user profile, with a JSON Merge Patch or a JSON Patch as well
 // (PATCH /user)
-func (s *Server) UpdateUser(ctx echo.Context) error {
+func (s *Server) UpdateUser(ctx echo.Context, params generated.UpdateUserParams) error {
 	userID, err := s.getCurrentUser(ctx)
 	if err != nil {
 		return renderError(ctx, err)
 	}
 
+	var version uint64
+	if params.IfMatch != nil {
+		if version, err = ifMatchVersion(*params.IfMatch, userID); err != nil {
+			return renderError(ctx, err)
+		}
+	} else if s.requireIfMatch {
+		return renderError(ctx, IfMatchRequired)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(ctx.Request().Header.Get(echo.HeaderContentType))
+	if format, ok := patchFormats[mediaType]; ok {
+		return s.patchUser(ctx, userID, version, format)
+	}
+
 	var payload generated.UpdateUserRequest
 	if err = json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
 		err = JsonBodyInvalid
 		return renderError(ctx, err)
 	}
-	_, err = s.userUsecase.UpdateUserProfile(ctx.Request().Context(), usecase.UpdateUserProfileInput{
+	input := usecase.UpdateUserProfileInput{
 		UserID:   userID,
 		PhoneNo:  payload.PhoneNo,
 		FullName: payload.FullName,
+		Email:    payload.Email,
+	}
+	if payload.Attributes != nil {
+		input.Attributes = *payload.Attributes
+	}
+	input.Version = version
+	result, err := s.userUsecase.UpdateUserProfile(ctx.Request().Context(), input)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	ctx.Response().Header().Set("ETag", profileETag(userID, result.Version))
+	resp := generated.UpdateUserResponse{Message: "profile updated"}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// patchUser will apply the patch in the request body to the profile of the logged-in user, the patch is parsed by the
+// usecase since a JSON Patch can only be applied to the current profile
+func (s *Server) patchUser(ctx echo.Context, userID, version uint64, format string) error {
+	patch, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+	result, err := s.userUsecase.PatchUserProfile(ctx.Request().Context(), usecase.PatchUserProfileInput{
+		UserID:  userID,
+		Format:  format,
+		Patch:   patch,
+		Version: version,
 	})
 	if err != nil {
 		return renderError(ctx, err)
 	}
 
+	ctx.Response().Header().Set("ETag", profileETag(userID, result.Version))
 	resp := generated.UpdateUserResponse{Message: "profile updated"}
 	return ctx.JSON(http.StatusOK, resp)
 }
+
+// Delete the logged-in user account, after re-confirming the password.
+// (DELETE /user)
+func (s *Server) DeleteUser(ctx echo.Context) error {
+	userID, err := s.getCurrentUser(ctx)
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	var payload generated.DeleteUserRequest
+	if err = json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		err = JsonBodyInvalid
+		return renderError(ctx, err)
+	}
+	result, err := s.userUsecase.DeleteUser(ctx.Request().Context(), usecase.DeleteUserInput{
+		UserID:   userID,
+		Password: payload.Password,
+	})
+	if err != nil {
+		return renderError(ctx, err)
+	}
+
+	resp := generated.DeleteUserResponse{DeletionDueAt: result.DeletionDueAt}
+	return ctx.JSON(http.StatusAccepted, resp)
+}
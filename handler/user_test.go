@@ -3,6 +3,8 @@ package handler
 import (
 	"context"
 	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/generated"
 	"github.com/SawitProRecruitment/UserService/usecase"
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
@@ -12,6 +14,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 type UserHandlerTestSuite struct {
@@ -55,7 +58,7 @@ func (s *UserHandlerTestSuite) TestLoginUserInvalidJson() {
 
 	a.Empty(err)
 	a.Equal(http.StatusBadRequest, rec.Code)
-	a.Equal(`{"error":"invalid JSON Body"}`, strings.TrimSpace(rec.Body.String()))
+	a.Equal(`{"code":"request.invalid_json","error":"invalid JSON Body"}`, strings.TrimSpace(rec.Body.String()))
 }
 
 func (s *UserHandlerTestSuite) TestLoginUserInvalidCredentials() {
@@ -74,7 +77,7 @@ func (s *UserHandlerTestSuite) TestLoginUserInvalidCredentials() {
 
 	a.Empty(err)
 	a.Equal(http.StatusBadRequest, rec.Code)
-	a.Equal(`{"error":"invalid phone number or password"}`, strings.TrimSpace(rec.Body.String()))
+	a.Equal(`{"code":"auth.invalid_credentials","error":"invalid phone number or password"}`, strings.TrimSpace(rec.Body.String()))
 }
 
 func (s *UserHandlerTestSuite) TestLoginUserSuccess() {
@@ -96,9 +99,91 @@ func (s *UserHandlerTestSuite) TestLoginUserSuccess() {
 	a.Equal(`{"jwt_token":"jwt-token"}`, strings.TrimSpace(rec.Body.String()))
 }
 
+func (s *UserHandlerTestSuite) TestLoginUserEnrollDevice() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().LoginUser(s.ctx, usecase.LoginUserInput{
+		PhoneNo:      "+62812141733",
+		Password:     "SomeP@ssw0rdHere",
+		EnrollDevice: true,
+	}).Return(usecase.LoginUserOutput{JwtToken: "jwt-token", DeviceToken: "device-token"}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/user/session", strings.NewReader(`{"phone_no":"+62812141733","password":"SomeP@ssw0rdHere","enroll_device":true}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	err := s.handler.LoginUser(e.NewContext(req, rec))
+
+	a.Empty(err)
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"device_token":"device-token","jwt_token":"jwt-token"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *UserHandlerTestSuite) TestLoginUserWithEmail() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().LoginUser(s.ctx, usecase.LoginUserInput{
+		Email:    "john.smith@example.com",
+		Password: "SomeP@ssw0rdHere",
+	}).Return(usecase.LoginUserOutput{JwtToken: "jwt-token"}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/user/session", strings.NewReader(`{"email":"john.smith@example.com","password":"SomeP@ssw0rdHere"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	err := s.handler.LoginUser(e.NewContext(req, rec))
+
+	a.Empty(err)
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"jwt_token":"jwt-token"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *UserHandlerTestSuite) TestLoginUserInvalidScope() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().LoginUser(s.ctx, usecase.LoginUserInput{
+		PhoneNo:  "+62812141733",
+		Password: "SomeP@ssw0rdHere",
+		Scope:    "admin:users",
+	}).Return(usecase.LoginUserOutput{}, usecase.UserInvalidScope)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/user/session", strings.NewReader(`{"phone_no":"+62812141733","password":"SomeP@ssw0rdHere","scope":"admin:users"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	err := s.handler.LoginUser(e.NewContext(req, rec))
+
+	a.Empty(err)
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"auth.invalid_scope","error":"requested scope is invalid or exceeds the granted scope"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *UserHandlerTestSuite) TestLoginUserSuspended() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().LoginUser(s.ctx, usecase.LoginUserInput{
+		PhoneNo:  "+62812141733",
+		Password: "SomeP@ssw0rdHere",
+	}).Return(usecase.LoginUserOutput{}, usecase.UserSuspended)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/user/session", strings.NewReader(`{"phone_no":"+62812141733","password":"SomeP@ssw0rdHere"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	err := s.handler.LoginUser(e.NewContext(req, rec))
+
+	a.Empty(err)
+	a.Equal(http.StatusForbidden, rec.Code)
+	a.Equal(`{"code":"user.suspended","error":"user account is suspended, please contact support"}`, strings.TrimSpace(rec.Body.String()))
+}
+
 func (s *UserHandlerTestSuite) TestRegisterUserInternalError() {
 	a := assert.New(s.T())
 
+	originalGenerateErrorID := generateErrorID
+	generateErrorID = func() string { return "error-5678" }
+	defer func() { generateErrorID = originalGenerateErrorID }()
+
 	s.usecase.EXPECT().RegisterUser(s.ctx, usecase.RegisterUserInput{
 		PhoneNo:  "+62812141733",
 		FullName: "John Smith",
@@ -113,7 +198,7 @@ func (s *UserHandlerTestSuite) TestRegisterUserInternalError() {
 
 	a.Empty(err)
 	a.Equal(http.StatusInternalServerError, rec.Code)
-	a.Equal(`{"error":"simulated error"}`, strings.TrimSpace(rec.Body.String()))
+	a.JSONEq(`{"code":"internal","error":"an unexpected error occurred, please try again later","error_id":"error-5678"}`, rec.Body.String())
 }
 
 func (s *UserHandlerTestSuite) TestRegisterUserBadRequest() {
@@ -136,8 +221,8 @@ func (s *UserHandlerTestSuite) TestRegisterUserBadRequest() {
 
 	a.Empty(err)
 	a.Equal(http.StatusBadRequest, rec.Code)
-	a.Contains(rec.Body.String(), `{"error":"phone_no too long","field":"phone_no"},{"error":"phone_no must only consist of digits","field":"phone_no"}`)
-	a.Contains(rec.Body.String(), `{"error":"full_name short","field":"full_name"}`)
+	a.Contains(rec.Body.String(), `{"code":"validation.invalid","error":"phone_no too long","field":"phone_no"},{"code":"validation.invalid","error":"phone_no must only consist of digits","field":"phone_no"}`)
+	a.Contains(rec.Body.String(), `{"code":"validation.invalid","error":"full_name short","field":"full_name"}`)
 }
 
 func (s *UserHandlerTestSuite) TestRegisterUserSuccess() {
@@ -167,11 +252,11 @@ func (s *UserHandlerTestSuite) TestGetUserWithoutAuth() {
 	req := httptest.NewRequest(http.MethodGet, "/user", strings.NewReader(""))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	err := s.handler.GetUser(e.NewContext(req, rec))
+	err := s.handler.GetUser(e.NewContext(req, rec), generated.GetUserParams{})
 
 	a.Empty(err)
 	a.Equal(http.StatusForbidden, rec.Code)
-	a.Equal(`{"error":"invalid / expired token, please login again"}`, strings.TrimSpace(rec.Body.String()))
+	a.Equal(`{"code":"auth.invalid_token","error":"invalid / expired token, please login again"}`, strings.TrimSpace(rec.Body.String()))
 }
 
 func (s *UserHandlerTestSuite) TestGetUserInvalidToken() {
@@ -186,11 +271,11 @@ func (s *UserHandlerTestSuite) TestGetUserInvalidToken() {
 	req.Header.Set("Authorization", "Bearer jwt-token")
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	err := s.handler.GetUser(e.NewContext(req, rec))
+	err := s.handler.GetUser(e.NewContext(req, rec), generated.GetUserParams{})
 
 	a.Empty(err)
 	a.Equal(http.StatusForbidden, rec.Code)
-	a.Equal(`{"error":"invalid / expired token, please login again"}`, strings.TrimSpace(rec.Body.String()))
+	a.Equal(`{"code":"auth.invalid_token","error":"invalid / expired token, please login again"}`, strings.TrimSpace(rec.Body.String()))
 }
 
 func (s *UserHandlerTestSuite) TestGetUserSuccess() {
@@ -206,6 +291,7 @@ func (s *UserHandlerTestSuite) TestGetUserSuccess() {
 		PhoneNo:              "+62812141733",
 		FullName:             "John Smith",
 		SuccessfulLoginCount: 42,
+		Version:              3,
 	}, nil)
 
 	e := echo.New()
@@ -213,29 +299,93 @@ func (s *UserHandlerTestSuite) TestGetUserSuccess() {
 	req.Header.Set("Authorization", "Bearer jwt-token")
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	err := s.handler.GetUser(e.NewContext(req, rec))
+	err := s.handler.GetUser(e.NewContext(req, rec), generated.GetUserParams{})
 
 	a.Empty(err)
 	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`"123-3"`, rec.Header().Get("ETag"))
 	a.Equal(`{"full_name":"John Smith","phone_no":"+62812141733","successful_login_count":42,"user_id":123}`, strings.TrimSpace(rec.Body.String()))
 }
 
+func (s *UserHandlerTestSuite) TestGetUserNotModified() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(s.ctx, usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123}, nil).Times(2)
+	s.usecase.EXPECT().GetUserProfile(s.ctx, usecase.GetUserProfileInput{
+		UserID: 123,
+	}).Return(usecase.GetUserProfileOutput{
+		UserID:   123,
+		PhoneNo:  "+62812141733",
+		FullName: "John Smith",
+		Version:  3,
+	}, nil).Times(2)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/user", strings.NewReader(""))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	rec := httptest.NewRecorder()
+	ifNoneMatch := `"123-2", W/"123-3"`
+	err := s.handler.GetUser(e.NewContext(req, rec), generated.GetUserParams{IfNoneMatch: &ifNoneMatch})
+
+	a.Empty(err)
+	a.Equal(http.StatusNotModified, rec.Code)
+	a.Equal(`"123-3"`, rec.Header().Get("ETag"))
+	a.Empty(rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	ifNoneMatch = `"123-2"`
+	err = s.handler.GetUser(e.NewContext(req, rec), generated.GetUserParams{IfNoneMatch: &ifNoneMatch})
+
+	a.Empty(err)
+	a.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *UserHandlerTestSuite) TestGetUserWithEmail() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(s.ctx, usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123}, nil)
+	s.usecase.EXPECT().GetUserProfile(s.ctx, usecase.GetUserProfileInput{
+		UserID: 123,
+	}).Return(usecase.GetUserProfileOutput{
+		UserID:               123,
+		PhoneNo:              "+62812141733",
+		FullName:             "John Smith",
+		Email:                "john.smith@example.com",
+		SuccessfulLoginCount: 42,
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/user", strings.NewReader(""))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	err := s.handler.GetUser(e.NewContext(req, rec), generated.GetUserParams{})
+
+	a.Empty(err)
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`{"email":"john.smith@example.com","email_verified":false,"full_name":"John Smith","phone_no":"+62812141733","successful_login_count":42,"user_id":123}`, strings.TrimSpace(rec.Body.String()))
+}
+
 func (s *UserHandlerTestSuite) TestUpdateUserWithBrokenAuth() {
 	a := assert.New(s.T())
 
 	e := echo.New()
-	req := httptest.NewRequest(http.MethodPatch, "/user", strings.NewReader(`{"phone_no":"+628121417","full_name":"Alex Smith"}`))
+	req := httptest.NewRequest(http.MethodPatch, "/user", strings.NewReader(`{"full_name":"Alex Smith"}`))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	req.Header.Set("Authorization", "Basic basic-auth")
 	rec := httptest.NewRecorder()
-	err := s.handler.UpdateUser(e.NewContext(req, rec))
+	err := s.handler.UpdateUser(e.NewContext(req, rec), generated.UpdateUserParams{})
 
 	a.Empty(err)
 	a.Equal(http.StatusForbidden, rec.Code)
-	a.Equal(`{"error":"invalid / expired token, please login again"}`, strings.TrimSpace(rec.Body.String()))
+	a.Equal(`{"code":"auth.invalid_token","error":"invalid / expired token, please login again"}`, strings.TrimSpace(rec.Body.String()))
 }
 
-func (s *UserHandlerTestSuite) TestUpdateUserSuccess() {
+func (s *UserHandlerTestSuite) TestUpdateUserPhoneNoRejected() {
 	a := assert.New(s.T())
 
 	phoneNo := "+62812141722"
@@ -247,16 +397,231 @@ func (s *UserHandlerTestSuite) TestUpdateUserSuccess() {
 		UserID:   123,
 		PhoneNo:  &phoneNo,
 		FullName: &fullName,
-	}).Return(usecase.UpdateUserProfileOutput{}, nil)
+	}).Return(usecase.UpdateUserProfileOutput{}, usecase.NewValidationError(map[string][]error{
+		"phone_no": {usecase.NewFieldError("phone_no.read_only", "validation.phone_change_endpoint", nil)},
+	}))
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPatch, "/user", strings.NewReader(`{"phone_no":"+62812141722","full_name":"Alex Smith"}`))
 	req.Header.Set("Authorization", "Bearer jwt-token")
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	err := s.handler.UpdateUser(e.NewContext(req, rec))
+	err := s.handler.UpdateUser(e.NewContext(req, rec), generated.UpdateUserParams{})
+
+	a.Empty(err)
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Contains(rec.Body.String(), `{"code":"phone_no.read_only","error":"phone_no must be changed with POST /user/phone","field":"phone_no"}`)
+}
+
+func (s *UserHandlerTestSuite) TestUpdateUserSuccess() {
+	a := assert.New(s.T())
+
+	fullName := "Alex Smith"
+	s.usecase.EXPECT().ValidateUserToken(s.ctx, usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123}, nil)
+	s.usecase.EXPECT().UpdateUserProfile(s.ctx, usecase.UpdateUserProfileInput{
+		UserID:   123,
+		FullName: &fullName,
+	}).Return(usecase.UpdateUserProfileOutput{Version: 4}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/user", strings.NewReader(`{"full_name":"Alex Smith"}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	err := s.handler.UpdateUser(e.NewContext(req, rec), generated.UpdateUserParams{})
 
 	a.Empty(err)
 	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`"123-4"`, rec.Header().Get("ETag"))
 	a.Equal(`{"message":"profile updated"}`, strings.TrimSpace(rec.Body.String()))
 }
+
+func (s *UserHandlerTestSuite) TestUpdateUserIfMatch() {
+	a := assert.New(s.T())
+
+	fullName := "Alex Smith"
+	s.usecase.EXPECT().ValidateUserToken(s.ctx, usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123}, nil)
+	s.usecase.EXPECT().UpdateUserProfile(s.ctx, usecase.UpdateUserProfileInput{
+		UserID:   123,
+		FullName: &fullName,
+		Version:  3,
+	}).Return(usecase.UpdateUserProfileOutput{}, usecase.UserVersionMismatch)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/user", strings.NewReader(`{"full_name":"Alex Smith"}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	ifMatch := `"12-5", "123-3"`
+	err := s.handler.UpdateUser(e.NewContext(req, rec), generated.UpdateUserParams{IfMatch: &ifMatch})
+
+	a.Empty(err)
+	a.Equal(http.StatusPreconditionFailed, rec.Code)
+	a.Equal(`{"code":"user.version_mismatch","error":"the profile was changed in the meantime, please reload it and try again"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *UserHandlerTestSuite) TestUpdateUserIfMatchInvalid() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(s.ctx, usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/user", strings.NewReader(`{"full_name":"Alex Smith"}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	ifMatch := `W/"123-3"`
+	err := s.handler.UpdateUser(e.NewContext(req, rec), generated.UpdateUserParams{IfMatch: &ifMatch})
+
+	a.Empty(err)
+	a.Equal(http.StatusPreconditionFailed, rec.Code)
+}
+
+func (s *UserHandlerTestSuite) TestUpdateUserIfMatchRequired() {
+	a := assert.New(s.T())
+
+	s.handler = NewServer(NewServerOptions{UserUsecase: s.usecase, HTTP: config.HTTP{RequireIfMatch: true}})
+	s.usecase.EXPECT().ValidateUserToken(s.ctx, usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/user", strings.NewReader(`{"full_name":"Alex Smith"}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	err := s.handler.UpdateUser(e.NewContext(req, rec), generated.UpdateUserParams{})
+
+	a.Empty(err)
+	a.Equal(http.StatusPreconditionRequired, rec.Code)
+	a.Equal(`{"code":"request.if_match_required","error":"If-Match header is required, send the ETag of the profile being updated"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *UserHandlerTestSuite) TestUpdateUserAttributes() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(s.ctx, usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123}, nil)
+	s.usecase.EXPECT().UpdateUserProfile(s.ctx, usecase.UpdateUserProfileInput{
+		UserID:     123,
+		Attributes: map[string]interface{}{"estate_code": "KAL-01", "preferred_language": nil},
+	}).Return(usecase.UpdateUserProfileOutput{}, usecase.NewValidationError(map[string][]error{
+		"/attributes/estate_code": {fmt.Errorf("/attributes/estate_code does not match pattern")},
+	}))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/user", strings.NewReader(`{"attributes":{"estate_code":"KAL-01","preferred_language":null}}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	err := s.handler.UpdateUser(e.NewContext(req, rec), generated.UpdateUserParams{})
+
+	a.Empty(err)
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"validation.failed","errors":[{"code":"validation.invalid","error":"/attributes/estate_code does not match pattern","field":"/attributes/estate_code"}]}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *UserHandlerTestSuite) TestUpdateUserMergePatch() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(s.ctx, usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123}, nil)
+	s.usecase.EXPECT().PatchUserProfile(s.ctx, usecase.PatchUserProfileInput{
+		UserID:  123,
+		Format:  usecase.PatchFormatMergePatch,
+		Patch:   []byte(`{"email":null}`),
+		Version: 3,
+	}).Return(usecase.PatchUserProfileOutput{Version: 4}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/user", strings.NewReader(`{"email":null}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, "application/merge-patch+json; charset=utf-8")
+	rec := httptest.NewRecorder()
+	ifMatch := `"123-3"`
+	err := s.handler.UpdateUser(e.NewContext(req, rec), generated.UpdateUserParams{IfMatch: &ifMatch})
+
+	a.Empty(err)
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(`"123-4"`, rec.Header().Get("ETag"))
+	a.Equal(`{"message":"profile updated"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *UserHandlerTestSuite) TestUpdateUserJSONPatchConflict() {
+	a := assert.New(s.T())
+
+	patch := `[{"op":"test","path":"/full_name","value":"John Smith"}]`
+	s.usecase.EXPECT().ValidateUserToken(s.ctx, usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123}, nil)
+	s.usecase.EXPECT().PatchUserProfile(s.ctx, usecase.PatchUserProfileInput{
+		UserID: 123,
+		Format: usecase.PatchFormatJSONPatch,
+		Patch:  []byte(patch),
+	}).Return(usecase.PatchUserProfileOutput{}, usecase.UserPatchConflict)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/user", strings.NewReader(patch))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, "application/json-patch+json")
+	rec := httptest.NewRecorder()
+	err := s.handler.UpdateUser(e.NewContext(req, rec), generated.UpdateUserParams{})
+
+	a.Empty(err)
+	a.Equal(http.StatusConflict, rec.Code)
+	a.Equal(`{"code":"user.patch_conflict","error":"the patch can't be applied to the current profile"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *UserHandlerTestSuite) TestDeleteUserInvalidPassword() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(s.ctx, usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123}, nil)
+	s.usecase.EXPECT().DeleteUser(s.ctx, usecase.DeleteUserInput{
+		UserID:   123,
+		Password: "wrong-password",
+	}).Return(usecase.DeleteUserOutput{}, usecase.UserInvalidPassword)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/user", strings.NewReader(`{"password":"wrong-password"}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	err := s.handler.DeleteUser(e.NewContext(req, rec))
+
+	a.Empty(err)
+	a.Equal(http.StatusBadRequest, rec.Code)
+	a.Equal(`{"code":"auth.invalid_password","error":"invalid password"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *UserHandlerTestSuite) TestDeleteUserSuccess() {
+	a := assert.New(s.T())
+
+	s.usecase.EXPECT().ValidateUserToken(s.ctx, usecase.ValidateUserTokenInput{
+		JwtToken: "jwt-token",
+	}).Return(usecase.ValidateUserTokenOutput{UserID: 123}, nil)
+	s.usecase.EXPECT().DeleteUser(s.ctx, usecase.DeleteUserInput{
+		UserID:   123,
+		Password: "SomeP@ssw0rdHere",
+	}).Return(usecase.DeleteUserOutput{DeletionDueAt: time.Date(2024, 3, 30, 9, 55, 0, 0, time.UTC)}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/user", strings.NewReader(`{"password":"SomeP@ssw0rdHere"}`))
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	err := s.handler.DeleteUser(e.NewContext(req, rec))
+
+	a.Empty(err)
+	a.Equal(http.StatusAccepted, rec.Code)
+	a.Equal(`{"deletion_due_at":"2024-03-30T09:55:00Z"}`, strings.TrimSpace(rec.Body.String()))
+}
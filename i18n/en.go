@@ -0,0 +1,83 @@
+package i18n
+
+// en is the English catalog, the default one: every message key must be defined here
+var en = map[string]string{
+	"internal.error":              "an unexpected error occurred, please try again later",
+	"request.invalid_json":        "invalid JSON Body",
+	"request.if_match_required":   "If-Match header is required, send the ETag of the profile being updated",
+	"request.avatar_too_large":    "request body is too large, the avatar must be at most 5 MB",
+	"request.token_missing":       "missing token parameter",
+	"request.invalid":             "invalid request: {message}",
+	"request.route_not_found":     "no such route",
+	"request.method_not_allowed":  "method not allowed on this route",
+	"request.too_large":           "request body is too large",
+	"client.invalid_credentials":  "invalid client credentials",
+	"attribute_schema.not_found":  "no attribute schema is defined",
+	"user.invalid_login":          "invalid phone number or password",
+	"user.invalid_login_otp":      "invalid or expired login code",
+	"user.login_otp_throttled":    "too many login codes requested, please try again later",
+	"user.invalid_pin_login":      "invalid phone number, PIN or device",
+	"user.pin_locked":             "PIN login is locked after too many wrong attempts, please login with your password",
+	"user.invalid_email_link":     "invalid or expired email verification link",
+	"user.email_not_set":          "no email address is set on the account",
+	"user.email_taken":            "this email address is already verified by another account",
+	"user.invalid_phone_code":     "invalid or expired phone number change code",
+	"user.phone_change_throttled": "too many phone number changes requested, please try again later",
+	"user.invalid_password":       "invalid password",
+	"user.invalid_token":          "invalid / expired token, please login again",
+	"user.not_found":              "user not found",
+	"user.conflict":               "user record conflict, phone number and email must be unique",
+	"user.forbidden":              "you don't have permission to access this resource",
+	"user.suspended":              "user account is suspended, please contact support",
+	"user.export_not_found":       "export not found or expired",
+	"user.status_conflict":        "operation is not allowed on the current user account status",
+	"user.impersonation_denied":   "this operation is not allowed while impersonating a user",
+	"user.not_impersonating":      "token is not an impersonation token",
+	"user.invalid_scope":          "requested scope is invalid or exceeds the granted scope",
+	"user.insufficient_scope":     "insufficient token scope for this resource",
+	"user.version_mismatch":       "the profile was changed in the meantime, please reload it and try again",
+	"user.patch_conflict":         "the patch can't be applied to the current profile",
+
+	"validation.required":              "{field} must not be empty",
+	"validation.max_length":            "{field} must not exceed {max} characters",
+	"validation.must_be_string":        "{field} must be a string",
+	"validation.must_be_object":        "{field} must be an object",
+	"validation.not_removable":         "{field} can't be removed",
+	"validation.file_required":         "{field} file is required",
+	"validation.limit_range":           "limit must be between 1 and {max}",
+	"validation.invalid_cursor":        "invalid cursor",
+	"validation.created_range":         "created_to must be after created_from",
+	"validation.unknown_status":        `unknown status "{status}"`,
+	"validation.unknown_role":          `unknown role "{role}"`,
+	"validation.export_format":         `format must be either "{json}" or "{zip}"`,
+	"validation.suspend_self":          "you can't suspend your own account",
+	"validation.impersonate_self":      "you can't impersonate your own account",
+	"validation.email_length":          "email must be at most {max} characters long",
+	"validation.email_invalid":         "email must be a valid email address",
+	"validation.full_name_length":      "full_name must be between {min} and {max} characters long",
+	"validation.password_length":       "password must be between {min} and {max} characters long",
+	"validation.password_number":       "password must contains at least one number [0-9]",
+	"validation.password_capital":      "password must contains at least one capital letter [A-Z]",
+	"validation.password_symbol":       "password must contains at least one non alphanumeric character",
+	"validation.pin_format":            "pin must be exactly 6 digits",
+	"validation.pin_sequence":          "pin must not be a repeated digit or a sequence like 123456",
+	"validation.pin_phone_no":          "pin must not be the end of the phone number",
+	"validation.phone_country_code":    "phone_no must start with the country calling code, e.g. +62",
+	"validation.phone_characters":      "phone_no must only contain numbers, besides the leading + and separators (spaces, dashes, dots & parentheses)",
+	"validation.phone_unsupported":     "phone_no country calling code is not supported",
+	"validation.phone_length":          "phone_no has an invalid number of digits, {country} numbers must have {min} to {max} digits after +{calling_code}",
+	"validation.phone_length_exact":    "phone_no has an invalid number of digits, {country} numbers must have {min} digits after +{calling_code}",
+	"validation.phone_country_denied":  "phone_no from {country} is not allowed",
+	"validation.phone_unchanged":       "phone_no must be different from the current phone number",
+	"validation.phone_change_endpoint": "phone_no must be changed with POST /user/phone",
+	"validation.avatar_size":           "avatar must be at most {max} MB",
+	"validation.avatar_format":         "avatar must be a JPEG, PNG or WebP image",
+	"validation.avatar_pixels":         "avatar must be at most {max} megapixels",
+	"validation.avatar_invalid":        "avatar is not a valid image",
+	"validation.attributes_no_schema":  "/attributes can't be set, no attribute schema is defined",
+	"validation.patch_invalid_json":    "patch must be a valid JSON document",
+	"validation.patch_not_object":      "the patched profile must be a JSON object",
+	"validation.patch_operation":       "patch must be a JSON Patch array of valid operations (RFC 6902)",
+	"validation.attribute_schema":      "{field} {message} (schema keyword {keyword})",
+	"validation.unknown_profile_field": "{field} is not a profile field",
+}
@@ -0,0 +1,112 @@
+// Package i18n translates the messages of the API (e.g. errors) into the language of the users, most of them reading
+// Bahasa Indonesia rather than English.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage is the language of the messages when the user prefers none of the supported ones
+const DefaultLanguage = "en"
+
+// catalogs maps every supported language into its messages, keyed by message key. The `{name}` placeholders of the
+// messages are replaced by the parameters of the same name.
+var catalogs = map[string]map[string]string{
+	"en": en,
+	"id": id,
+}
+
+// Params are the parameters of a message, replacing its `{name}` placeholders
+type Params map[string]interface{}
+
+// Error is an error whose message is translated into the language of the user, it's identified by its message key
+type Error struct {
+	Key    string
+	Params Params
+}
+
+// NewError will create an error with the message of the key, the params replace the placeholders of the message
+func NewError(key string, params Params) *Error {
+	return &Error{Key: key, Params: params}
+}
+
+// Error will return the message in the default language
+func (e *Error) Error() string {
+	return Translate(DefaultLanguage, e.Key, e.Params)
+}
+
+// Localize will return the message in the language
+func (e *Error) Localize(lang string) string {
+	return Translate(lang, e.Key, e.Params)
+}
+
+// IsSupported will return true if messages can be translated into the language
+func IsSupported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// Translate will render the message of the key in the language, messages missing from the catalog of the language
+// fall back to the default language, and unknown keys to the key itself
+func Translate(lang, key string, params Params) string {
+	msg, ok := catalogs[lang][key]
+	if !ok {
+		if msg, ok = catalogs[DefaultLanguage][key]; !ok {
+			msg = key
+		}
+	}
+	if len(params) == 0 {
+		return msg
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(msg)
+}
+
+// MatchLanguage will return the supported language most preferred by an Accept-Language header (RFC 9110 section
+// 12.5.4), e.g. `id-ID,id;q=0.9,en;q=0.8`. Regional variants match their primary language, and an empty language is
+// returned when none of the supported languages is acceptable.
+func MatchLanguage(acceptLanguage string) string {
+	type candidate struct {
+		lang    string
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		lang := strings.ToLower(strings.TrimSpace(fields[0]))
+		if i := strings.IndexByte(lang, '-'); i > 0 {
+			lang = lang[:i]
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if param = strings.TrimSpace(param); strings.HasPrefix(param, "q=") {
+				if parsed, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		if lang == "*" {
+			lang = DefaultLanguage
+		}
+		if IsSupported(lang) {
+			candidates = append(candidates, candidate{lang: lang, quality: quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	// the order of the header breaks the ties between languages of the same quality
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].quality > candidates[j].quality })
+	return candidates[0].lang
+}
@@ -0,0 +1,64 @@
+package i18n
+
+import (
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"sort"
+	"testing"
+)
+
+func TestCatalogsComplete(t *testing.T) {
+	placeholders := regexp.MustCompile(`\{[a-z_]+\}`)
+	for lang, catalog := range catalogs {
+		for key, msg := range en {
+			translated, ok := catalog[key]
+			if !assert.True(t, ok, "%s is missing from %s", key, lang) {
+				continue
+			}
+			expected, actual := placeholders.FindAllString(msg, -1), placeholders.FindAllString(translated, -1)
+			sort.Strings(expected)
+			sort.Strings(actual)
+			assert.Equal(t, expected, actual, "placeholders of %s in %s", key, lang)
+		}
+		for key := range catalog {
+			_, ok := en[key]
+			assert.True(t, ok, "%s of %s is missing from en", key, lang)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	a := assert.New(t)
+
+	params := Params{"min": 3, "max": 60}
+	a.Equal("full_name must be between 3 and 60 characters long", Translate("en", "validation.full_name_length", params))
+	a.Equal("full_name harus antara 3 dan 60 karakter", Translate("id", "validation.full_name_length", params))
+	a.Equal("full_name must be between 3 and 60 characters long", Translate("fr", "validation.full_name_length", params))
+	a.Equal("unknown.key", Translate("id", "unknown.key", nil))
+}
+
+func TestError(t *testing.T) {
+	a := assert.New(t)
+
+	err := NewError("validation.limit_range", Params{"max": 100})
+	a.Equal("limit must be between 1 and 100", err.Error())
+	a.Equal("limit harus antara 1 dan 100", err.Localize("id"))
+}
+
+func TestMatchLanguage(t *testing.T) {
+	tests := map[string]string{
+		"":                           "",
+		"id":                         "id",
+		"id-ID,id;q=0.9,en;q=0.8":    "id",
+		"en-US,en;q=0.9,id;q=0.8":    "en",
+		"fr-FR, id;q=0.5, en;q=0.7":  "en",
+		"fr, de":                     "",
+		"fr, *;q=0.1":                "en",
+		"en;q=0, id;q=0.2":           "id",
+		"EN-gb;q=0.8, id-id;q=0.8":   "en",
+		"id;q=0.5, en;q=invalid, de": "en",
+	}
+	for header, expected := range tests {
+		assert.Equal(t, expected, MatchLanguage(header), header)
+	}
+}
@@ -0,0 +1,83 @@
+package i18n
+
+// id is the Bahasa Indonesia catalog
+var id = map[string]string{
+	"internal.error":              "terjadi kesalahan yang tidak terduga, silakan coba lagi nanti",
+	"request.invalid_json":        "body JSON tidak valid",
+	"request.if_match_required":   "header If-Match wajib diisi, kirim ETag dari profil yang diperbarui",
+	"request.avatar_too_large":    "body permintaan terlalu besar, avatar maksimal 5 MB",
+	"request.token_missing":       "parameter token tidak ada",
+	"request.invalid":             "permintaan tidak valid: {message}",
+	"request.route_not_found":     "rute tidak ditemukan",
+	"request.method_not_allowed":  "metode tidak diizinkan pada rute ini",
+	"request.too_large":           "body permintaan terlalu besar",
+	"client.invalid_credentials":  "kredensial klien tidak valid",
+	"attribute_schema.not_found":  "skema atribut belum ditentukan",
+	"user.invalid_login":          "nomor telepon atau kata sandi salah",
+	"user.invalid_login_otp":      "kode masuk tidak valid atau sudah kedaluwarsa",
+	"user.login_otp_throttled":    "terlalu banyak permintaan kode masuk, silakan coba lagi nanti",
+	"user.invalid_pin_login":      "nomor telepon, PIN, atau perangkat tidak valid",
+	"user.pin_locked":             "masuk dengan PIN dikunci setelah terlalu banyak percobaan yang salah, silakan masuk dengan kata sandi Anda",
+	"user.invalid_email_link":     "tautan verifikasi email tidak valid atau sudah kedaluwarsa",
+	"user.email_not_set":          "belum ada alamat email pada akun ini",
+	"user.email_taken":            "alamat email ini sudah diverifikasi oleh akun lain",
+	"user.invalid_phone_code":     "kode perubahan nomor telepon tidak valid atau sudah kedaluwarsa",
+	"user.phone_change_throttled": "terlalu banyak permintaan perubahan nomor telepon, silakan coba lagi nanti",
+	"user.invalid_password":       "kata sandi salah",
+	"user.invalid_token":          "token tidak valid / kedaluwarsa, silakan masuk kembali",
+	"user.not_found":              "pengguna tidak ditemukan",
+	"user.conflict":               "data pengguna bentrok, nomor telepon dan email harus unik",
+	"user.forbidden":              "Anda tidak memiliki izin untuk mengakses sumber daya ini",
+	"user.suspended":              "akun pengguna ditangguhkan, silakan hubungi layanan pelanggan",
+	"user.export_not_found":       "ekspor tidak ditemukan atau sudah kedaluwarsa",
+	"user.status_conflict":        "operasi tidak diizinkan pada status akun pengguna saat ini",
+	"user.impersonation_denied":   "operasi ini tidak diizinkan saat menyamar sebagai pengguna",
+	"user.not_impersonating":      "token bukan token penyamaran",
+	"user.invalid_scope":          "cakupan yang diminta tidak valid atau melebihi cakupan yang diberikan",
+	"user.insufficient_scope":     "cakupan token tidak mencukupi untuk sumber daya ini",
+	"user.version_mismatch":       "profil telah diubah oleh permintaan lain, silakan muat ulang dan coba lagi",
+	"user.patch_conflict":         "patch tidak dapat diterapkan pada profil saat ini",
+
+	"validation.required":              "{field} tidak boleh kosong",
+	"validation.max_length":            "{field} tidak boleh melebihi {max} karakter",
+	"validation.must_be_string":        "{field} harus berupa string",
+	"validation.must_be_object":        "{field} harus berupa objek",
+	"validation.not_removable":         "{field} tidak dapat dihapus",
+	"validation.file_required":         "berkas {field} wajib diisi",
+	"validation.limit_range":           "limit harus antara 1 dan {max}",
+	"validation.invalid_cursor":        "cursor tidak valid",
+	"validation.created_range":         "created_to harus setelah created_from",
+	"validation.unknown_status":        `status "{status}" tidak dikenal`,
+	"validation.unknown_role":          `peran "{role}" tidak dikenal`,
+	"validation.export_format":         `format harus "{json}" atau "{zip}"`,
+	"validation.suspend_self":          "Anda tidak dapat menangguhkan akun Anda sendiri",
+	"validation.impersonate_self":      "Anda tidak dapat menyamar sebagai akun Anda sendiri",
+	"validation.email_length":          "email maksimal {max} karakter",
+	"validation.email_invalid":         "email harus berupa alamat email yang valid",
+	"validation.full_name_length":      "full_name harus antara {min} dan {max} karakter",
+	"validation.password_length":       "password harus antara {min} dan {max} karakter",
+	"validation.password_number":       "password harus mengandung setidaknya satu angka [0-9]",
+	"validation.password_capital":      "password harus mengandung setidaknya satu huruf kapital [A-Z]",
+	"validation.password_symbol":       "password harus mengandung setidaknya satu karakter non-alfanumerik",
+	"validation.pin_format":            "pin harus tepat 6 digit angka",
+	"validation.pin_sequence":          "pin tidak boleh berupa angka berulang atau berurutan seperti 123456",
+	"validation.pin_phone_no":          "pin tidak boleh sama dengan akhir nomor telepon",
+	"validation.phone_country_code":    "phone_no harus diawali dengan kode negara, misalnya +62",
+	"validation.phone_characters":      "phone_no hanya boleh berisi angka, selain + di awal dan pemisah (spasi, tanda hubung, titik & tanda kurung)",
+	"validation.phone_unsupported":     "kode negara phone_no tidak didukung",
+	"validation.phone_length":          "jumlah digit phone_no tidak valid, nomor {country} harus memiliki {min} sampai {max} digit setelah +{calling_code}",
+	"validation.phone_length_exact":    "jumlah digit phone_no tidak valid, nomor {country} harus memiliki {min} digit setelah +{calling_code}",
+	"validation.phone_country_denied":  "phone_no dari {country} tidak diizinkan",
+	"validation.phone_unchanged":       "phone_no harus berbeda dari nomor telepon saat ini",
+	"validation.phone_change_endpoint": "phone_no harus diubah melalui POST /user/phone",
+	"validation.avatar_size":           "avatar maksimal {max} MB",
+	"validation.avatar_format":         "avatar harus berupa gambar JPEG, PNG, atau WebP",
+	"validation.avatar_pixels":         "avatar maksimal {max} megapiksel",
+	"validation.avatar_invalid":        "avatar bukan gambar yang valid",
+	"validation.attributes_no_schema":  "/attributes tidak dapat diisi, skema atribut belum ditentukan",
+	"validation.patch_invalid_json":    "patch harus berupa dokumen JSON yang valid",
+	"validation.patch_not_object":      "profil hasil patch harus berupa objek JSON",
+	"validation.patch_operation":       "patch harus berupa array JSON Patch berisi operasi yang valid (RFC 6902)",
+	"validation.attribute_schema":      "{field} tidak sesuai dengan kata kunci skema {keyword}: {message}",
+	"validation.unknown_profile_field": "{field} bukan bidang profil",
+}
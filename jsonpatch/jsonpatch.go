@@ -0,0 +1,296 @@
+// Package jsonpatch applies JSON Merge Patch (RFC 7396) and JSON Patch (RFC 6902) documents to JSON values decoded
+// with encoding/json, so that partial updates can clear fields instead of only setting non-empty values.
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPatch = errors.New("invalid patch document")
+	ErrPathNotFound = errors.New("path not found")
+	ErrTestFailed   = errors.New("test operation failed")
+)
+
+// Operation is a single operation of a JSON Patch document
+type Operation struct {
+	Op   string
+	Path string
+	// From is the source location of move & copy operations
+	From string
+	// Value is the value of add, replace & test operations, as decoded by encoding/json
+	Value interface{}
+}
+
+// MergePatch will apply the merge patch to the target (RFC 7396 section 2), a null member of the patch removes the
+// member from the target. The target is left unchanged, the patched value is returned.
+func MergePatch(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+
+	res := make(map[string]interface{}, len(targetObj))
+	for name, value := range targetObj {
+		res[name] = value
+	}
+	for name, value := range patchObj {
+		if value == nil {
+			delete(res, name)
+		} else {
+			res[name] = MergePatch(res[name], value)
+		}
+	}
+	return res
+}
+
+// Decode will decode a JSON Patch document, checking every operation has the members its kind requires
+func Decode(raw []byte) (ops []Operation, err error) {
+	var decoded []struct {
+		Op    string          `json:"op"`
+		Path  *string         `json:"path"`
+		From  *string         `json:"from"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err = json.Unmarshal(raw, &decoded); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPatch, err)
+	}
+
+	for i, d := range decoded {
+		op := Operation{Op: d.Op}
+		if d.Path == nil {
+			return nil, fmt.Errorf(`%w: operation %d has no "path"`, ErrInvalidPatch, i)
+		}
+		op.Path = *d.Path
+		switch d.Op {
+		case "add", "replace", "test":
+			if d.Value == nil {
+				return nil, fmt.Errorf(`%w: %s operation %d has no "value"`, ErrInvalidPatch, d.Op, i)
+			}
+			if err = json.Unmarshal(d.Value, &op.Value); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidPatch, err)
+			}
+		case "move", "copy":
+			if d.From == nil {
+				return nil, fmt.Errorf(`%w: %s operation %d has no "from"`, ErrInvalidPatch, d.Op, i)
+			}
+			op.From = *d.From
+		case "remove":
+		default:
+			return nil, fmt.Errorf(`%w: operation %d has an unknown "op" %q`, ErrInvalidPatch, i, d.Op)
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// Apply will apply the operations to the document in order, the patch is atomic: an error is returned as soon as an
+// operation fails, and the document is left unchanged whatever the outcome.
+func Apply(doc interface{}, ops []Operation) (res interface{}, err error) {
+	res = deepCopy(doc)
+	for _, op := range ops {
+		var path, from []string
+		if path, err = parsePointer(op.Path); err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case "add":
+			res, err = add(res, path, deepCopy(op.Value))
+		case "remove":
+			res, err = remove(res, path)
+		case "replace":
+			if len(path) == 0 {
+				res = deepCopy(op.Value)
+			} else if res, err = remove(res, path); err == nil {
+				res, err = add(res, path, deepCopy(op.Value))
+			}
+		case "move":
+			if from, err = parsePointer(op.From); err != nil {
+				return nil, err
+			}
+			// a value can't be moved into one of its own children
+			if strings.HasPrefix(op.Path, op.From+"/") {
+				return nil, fmt.Errorf("%w: can't move %s into %s", ErrInvalidPatch, op.From, op.Path)
+			}
+			var value interface{}
+			if value, err = get(res, from); err == nil {
+				if res, err = remove(res, from); err == nil {
+					res, err = add(res, path, value)
+				}
+			}
+		case "copy":
+			if from, err = parsePointer(op.From); err != nil {
+				return nil, err
+			}
+			var value interface{}
+			if value, err = get(res, from); err == nil {
+				res, err = add(res, path, deepCopy(value))
+			}
+		case "test":
+			var value interface{}
+			if value, err = get(res, path); err == nil && !reflect.DeepEqual(value, op.Value) {
+				err = fmt.Errorf("%w: %s", ErrTestFailed, op.Path)
+			}
+		default:
+			err = fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.Op)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// parsePointer will split a JSON Pointer (RFC 6901) into its unescaped reference tokens, the empty pointer refers to
+// the whole document
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: %q is not a JSON pointer", ErrInvalidPatch, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// arrayIndex will parse an array index token, leading zeros are not allowed. The index may be equal to the array
+// length only when inserting.
+func arrayIndex(token string, length int, inserting bool) (int, error) {
+	max := length - 1
+	if inserting {
+		max = length
+	}
+	if token == "-" && inserting {
+		return length, nil
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || idx > max || (len(token) > 1 && token[0] == '0') || token[0] == '+' {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrPathNotFound, token)
+	}
+	return idx, nil
+}
+
+func get(node interface{}, tokens []string) (interface{}, error) {
+	for _, token := range tokens {
+		switch container := node.(type) {
+		case map[string]interface{}:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: member %q", ErrPathNotFound, token)
+			}
+			node = value
+		case []interface{}:
+			idx, err := arrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			node = container[idx]
+		default:
+			return nil, fmt.Errorf("%w: %q is not within an object or an array", ErrPathNotFound, token)
+		}
+	}
+	return node, nil
+}
+
+// modify will call fn with the parent container of the last token, and replace the containers along the path with
+// the returned ones, since inserting into or removing from an array creates a new slice
+func modify(node interface{}, tokens []string, fn func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 1 {
+		return fn(node, tokens[0])
+	}
+
+	child, err := get(node, tokens[:1])
+	if err != nil {
+		return nil, err
+	}
+	if child, err = modify(child, tokens[1:], fn); err != nil {
+		return nil, err
+	}
+	switch container := node.(type) {
+	case map[string]interface{}:
+		container[tokens[0]] = child
+	case []interface{}:
+		idx, _ := arrayIndex(tokens[0], len(container), false)
+		container[idx] = child
+	}
+	return node, nil
+}
+
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return modify(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			idx, err := arrayIndex(token, len(container), true)
+			if err != nil {
+				return nil, err
+			}
+			res := make([]interface{}, 0, len(container)+1)
+			res = append(res, container[:idx]...)
+			res = append(res, value)
+			return append(res, container[idx:]...), nil
+		}
+		return nil, fmt.Errorf("%w: %q is not within an object or an array", ErrPathNotFound, token)
+	})
+}
+
+func remove(doc interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: the whole document can't be removed", ErrInvalidPatch)
+	}
+	return modify(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := container[token]; !ok {
+				return nil, fmt.Errorf("%w: member %q", ErrPathNotFound, token)
+			}
+			delete(container, token)
+			return container, nil
+		case []interface{}:
+			idx, err := arrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			res := make([]interface{}, 0, len(container)-1)
+			res = append(res, container[:idx]...)
+			return append(res, container[idx+1:]...), nil
+		}
+		return nil, fmt.Errorf("%w: %q is not within an object or an array", ErrPathNotFound, token)
+	})
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for name, child := range v {
+			res[name] = deepCopy(child)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, child := range v {
+			res[i] = deepCopy(child)
+		}
+		return res
+	}
+	return value
+}
@@ -0,0 +1,117 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func decode(t *testing.T, s string) interface{} {
+	var res interface{}
+	if err := json.Unmarshal([]byte(s), &res); err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestMergePatch(t *testing.T) {
+	// examples of RFC 7396 appendix A
+	tests := []struct {
+		target, patch, expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, test := range tests {
+		target := decode(t, test.target)
+		res := MergePatch(target, decode(t, test.patch))
+		assert.Equal(t, decode(t, test.expected), res, "%s merged with %s", test.target, test.patch)
+		assert.Equal(t, decode(t, test.target), target, "target must be left unchanged")
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []string{
+		`{"op":"add","path":"/a","value":1}`,
+		`[{"op":"add","path":"/a"}]`,
+		`[{"op":"remove"}]`,
+		`[{"op":"move","path":"/a"}]`,
+		`[{"op":"merge","path":"/a"}]`,
+	}
+	for _, test := range tests {
+		_, err := Decode([]byte(test))
+		assert.ErrorIs(t, err, ErrInvalidPatch, test)
+	}
+}
+
+func TestApply(t *testing.T) {
+	// examples of RFC 6902 appendix A
+	tests := []struct {
+		doc, patch, expected string
+	}{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			`{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{`{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			`{"baz":"qux","foo":["a",2,"c"]}`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+		{`{"foo":null}`, `[{"op":"test","path":"/foo","value":null}]`, `{"foo":null}`},
+		{`{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10},{"op":"remove","path":"/~1"}]`, `{"~1":10}`},
+		{`{"foo":{"bar":1}}`, `[{"op":"copy","from":"/foo","path":"/baz"},{"op":"replace","path":"/baz/bar","value":2}]`,
+			`{"foo":{"bar":1},"baz":{"bar":2}}`},
+		{`{"foo":1}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+	}
+	for _, test := range tests {
+		ops, err := Decode([]byte(test.patch))
+		assert.Empty(t, err, test.patch)
+		doc := decode(t, test.doc)
+		res, err := Apply(doc, ops)
+		assert.Empty(t, err, test.patch)
+		assert.Equal(t, decode(t, test.expected), res, "%s patched with %s", test.doc, test.patch)
+		assert.Equal(t, decode(t, test.doc), doc, "document must be left unchanged")
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		doc, patch string
+		err        error
+	}{
+		{`{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`, ErrTestFailed},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`, ErrPathNotFound},
+		{`{"foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, ErrPathNotFound},
+		{`{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":1}]`, ErrPathNotFound},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/2","value":1}]`, ErrPathNotFound},
+		{`{"foo":["bar","baz"]}`, `[{"op":"remove","path":"/foo/01"}]`, ErrPathNotFound},
+		{`{"foo":["bar"]}`, `[{"op":"remove","path":"/foo/-"}]`, ErrPathNotFound},
+		{`{"foo":{"bar":1}}`, `[{"op":"move","from":"/foo","path":"/foo/bar/baz"}]`, ErrInvalidPatch},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"foo","value":1}]`, ErrInvalidPatch},
+		{`{"foo":"bar"}`, `[{"op":"remove","path":""}]`, ErrInvalidPatch},
+	}
+	for _, test := range tests {
+		ops, err := Decode([]byte(test.patch))
+		assert.Empty(t, err, test.patch)
+		doc := decode(t, test.doc)
+		_, err = Apply(doc, ops)
+		assert.ErrorIs(t, err, test.err, test.patch)
+		assert.Equal(t, decode(t, test.doc), doc, "document must be left unchanged")
+	}
+}
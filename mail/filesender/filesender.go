@@ -0,0 +1,59 @@
+// Package filesender is an implementation of mail.Sender which writes every message as an `.eml` file into a
+// directory, instead of sending them. The files can be opened by any mail client, the same way as messages captured
+// by an SMTP capture tool. It's meant for local development only.
+package filesender
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fileSender is an implementation of mail.Sender writing every message into dir
+type fileSender struct {
+	dir  string
+	from string
+}
+
+type NewFileSenderOptions struct {
+	// Dir is the directory receiving the messages, created when missing
+	Dir string
+	// From is the sender address of every message
+	From string
+}
+
+func NewFileSender(opts NewFileSenderOptions) (mail.Sender, error) {
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &fileSender{dir: opts.Dir, from: opts.From}, nil
+}
+
+func (f *fileSender) SendMail(ctx context.Context, input mail.SendMailInput) (output mail.SendMailOutput, err error) {
+	id := make([]byte, 8)
+	if _, err = rand.Read(id); err != nil {
+		return
+	}
+	now := time.Now()
+	output.MessageID = fmt.Sprintf("%s-%s", now.UTC().Format("20060102T150405"), hex.EncodeToString(id))
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "Message-ID: <%s@localhost>\r\n", output.MessageID)
+	fmt.Fprintf(&msg, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "From: %s\r\n", f.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", input.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", input.Subject)
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(input.Body, "\n", "\r\n"))
+
+	if err = os.WriteFile(filepath.Join(f.dir, output.MessageID+".eml"), []byte(msg.String()), 0o644); err != nil {
+		return mail.SendMailOutput{}, err
+	}
+	return output, nil
+}
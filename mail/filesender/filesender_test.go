@@ -0,0 +1,33 @@
+package filesender
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/mail"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSendMail(t *testing.T) {
+	a := assert.New(t)
+
+	dir := filepath.Join(t.TempDir(), "outbox")
+	sender, err := NewFileSender(NewFileSenderOptions{Dir: dir, From: "no-reply@example.com"})
+	a.Empty(err)
+
+	out, err := sender.SendMail(context.Background(), mail.SendMailInput{
+		To:      "john.smith@example.com",
+		Subject: "Verify your email",
+		Body:    "Hello,\nclick the link",
+	})
+	a.Empty(err)
+	a.NotEmpty(out.MessageID)
+
+	content, err := os.ReadFile(filepath.Join(dir, out.MessageID+".eml"))
+	a.Empty(err)
+	a.Contains(string(content), "From: no-reply@example.com\r\n")
+	a.Contains(string(content), "To: john.smith@example.com\r\n")
+	a.Contains(string(content), "Subject: Verify your email\r\n")
+	a.Contains(string(content), "\r\n\r\nHello,\r\nclick the link")
+}
@@ -0,0 +1,14 @@
+// This file contains the interfaces for the mail layer.
+// The mail layer is responsible for delivering emails to the users, through the configured provider.
+// For testing purpose we will generate mock implementations of these
+// interfaces using mockgen. See the Makefile for more information.
+package mail
+
+import "context"
+
+// Sender is an interface to deliver emails to an address
+type Sender interface {
+	// SendMail will deliver the plain text message to the email address
+	// Will return error when the provider rejects the message
+	SendMail(ctx context.Context, input SendMailInput) (output SendMailOutput, err error)
+}
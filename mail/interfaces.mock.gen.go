@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mail/interfaces.go
+
+// Package mail is a generated GoMock package.
+package mail
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSender is a mock of Sender interface.
+type MockSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockSenderMockRecorder
+}
+
+// MockSenderMockRecorder is the mock recorder for MockSender.
+type MockSenderMockRecorder struct {
+	mock *MockSender
+}
+
+// NewMockSender creates a new mock instance.
+func NewMockSender(ctrl *gomock.Controller) *MockSender {
+	mock := &MockSender{ctrl: ctrl}
+	mock.recorder = &MockSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSender) EXPECT() *MockSenderMockRecorder {
+	return m.recorder
+}
+
+// SendMail mocks base method.
+func (m *MockSender) SendMail(ctx context.Context, input SendMailInput) (SendMailOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMail", ctx, input)
+	ret0, _ := ret[0].(SendMailOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendMail indicates an expected call of SendMail.
+func (mr *MockSenderMockRecorder) SendMail(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMail", reflect.TypeOf((*MockSender)(nil).SendMail), ctx, input)
+}
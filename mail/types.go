@@ -0,0 +1,13 @@
+// This file contains types that are used by the mail layer.
+package mail
+
+type SendMailInput struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type SendMailOutput struct {
+	// MessageID is the provider reference of the sent message, if any
+	MessageID string
+}
@@ -0,0 +1,57 @@
+// Package metrics holds the Prometheus metrics of the service, registered on the default registry along with the Go
+// runtime & process metrics. Their labels only take a bounded set of values (e.g. routes, error codes), never user
+// input or IDs, to keep the number of series under control
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+)
+
+const namespace = "userservice"
+
+var (
+	// HTTPRequestDuration is labelled by the route template (e.g. /admin/users/:user_id), its count is the number of
+	// requests
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of the HTTP requests by method, route & status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// LoginAttempts is labelled by the login method (password, pin or otp), and the outcome which is either success or
+	// the code of the error
+	LoginAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "login_attempts_total",
+		Help:      "Number of login attempts by method & outcome.",
+	}, []string{"method", "outcome"})
+
+	// TokenValidationFailures is labelled by the code of the error, e.g. auth.token_expired
+	TokenValidationFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "token_validation_failures_total",
+		Help:      "Number of rejected user tokens by reason.",
+	}, []string{"reason"})
+
+	// BcryptDuration is labelled by the operation, either hash or compare. It's expected to be around 50-100ms with
+	// the default cost, a much lower duration would hint at a too low cost
+	BcryptDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "bcrypt_duration_seconds",
+		Help:      "Duration of the bcrypt hashing by operation.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 8),
+	}, []string{"operation"})
+)
+
+// Handler will return the handler exposing the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
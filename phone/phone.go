@@ -0,0 +1,124 @@
+// Package phone parses phone numbers typed by users into their canonical E.164 format (e.g. `+6281234567890`),
+// so that the same number is always stored & looked up the same way, regardless of the separators used.
+// Only the countries listed in Countries are supported.
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrMissingCountryCode = errors.New("must start with the country calling code, e.g. +62")
+	ErrInvalidCharacters  = errors.New("must only contain numbers, besides the leading + and separators (spaces, dashes, dots & parentheses)")
+	ErrUnsupportedCountry = errors.New("country calling code is not supported")
+	ErrInvalidLength      = errors.New("has an invalid number of digits")
+)
+
+// LengthError is returned by Parse when the number of digits isn't allowed by the country of the number, it matches
+// ErrInvalidLength with errors.Is
+type LengthError struct {
+	// Country is the numbering plan of the country of the number, detailing the allowed lengths
+	Country Country
+}
+
+func (e *LengthError) Error() string {
+	if e.Country.MinLength == e.Country.MaxLength {
+		return fmt.Sprintf("%s, %s numbers must have %d digits after +%s", ErrInvalidLength, e.Country.Code, e.Country.MinLength, e.Country.CallingCode)
+	}
+	return fmt.Sprintf("%s, %s numbers must have %d to %d digits after +%s", ErrInvalidLength, e.Country.Code, e.Country.MinLength, e.Country.MaxLength, e.Country.CallingCode)
+}
+
+func (e *LengthError) Unwrap() error {
+	return ErrInvalidLength
+}
+
+// Country contains the numbering plan of a country
+type Country struct {
+	// Code is the ISO 3166-1 alpha-2 code of the country, e.g. ID
+	Code string
+	// CallingCode is the international calling code without the `+`, e.g. 62
+	CallingCode string
+	// MinLength & MaxLength are the allowed number of digits after the calling code (national significant number)
+	MinLength int
+	MaxLength int
+	// TrunkPrefix is dialed before national numbers within the country, and dropped when typed after the calling
+	// code (e.g. `+62 0812...` is `+62812...`). Empty when the country doesn't use one.
+	TrunkPrefix string
+}
+
+// Countries contains the numbering plan of every supported country, by country code
+var Countries = map[string]Country{
+	"ID": {Code: "ID", CallingCode: "62", MinLength: 8, MaxLength: 12, TrunkPrefix: "0"},
+	"MY": {Code: "MY", CallingCode: "60", MinLength: 8, MaxLength: 10, TrunkPrefix: "0"},
+	"SG": {Code: "SG", CallingCode: "65", MinLength: 8, MaxLength: 8},
+	"TH": {Code: "TH", CallingCode: "66", MinLength: 8, MaxLength: 9, TrunkPrefix: "0"},
+	"PH": {Code: "PH", CallingCode: "63", MinLength: 8, MaxLength: 10, TrunkPrefix: "0"},
+}
+
+// Number is a successfully parsed phone number
+type Number struct {
+	// E164 is the canonical format of the number, e.g. `+6281234567890`
+	E164 string
+	// Country is the country code of the number, e.g. ID
+	Country string
+}
+
+// Parse will parse an international phone number (starting with `+` or `00`) of a supported country
+func Parse(phoneNo string) (Number, error) {
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(phoneNo))
+
+	switch {
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	case strings.HasPrefix(digits, "00"):
+		digits = digits[2:]
+	default:
+		return Number{}, ErrMissingCountryCode
+	}
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Number{}, ErrInvalidCharacters
+	}
+
+	country, ok := countryOf(digits)
+	if !ok {
+		return Number{}, ErrUnsupportedCountry
+	}
+	national := strings.TrimPrefix(digits, country.CallingCode)
+	if country.TrunkPrefix != "" {
+		national = strings.TrimPrefix(national, country.TrunkPrefix)
+	}
+	if len(national) < country.MinLength || len(national) > country.MaxLength {
+		return Number{}, &LengthError{Country: country}
+	}
+
+	return Number{E164: "+" + country.CallingCode + national, Country: country.Code}, nil
+}
+
+// SupportedCountries will return the code of every supported country, sorted alphabetically
+func SupportedCountries() []string {
+	codes := make([]string, 0, len(Countries))
+	for code := range Countries {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// countryOf will find the country whose calling code prefixes the digits, the longest calling code wins
+func countryOf(digits string) (match Country, ok bool) {
+	for _, country := range Countries {
+		if strings.HasPrefix(digits, country.CallingCode) && len(country.CallingCode) > len(match.CallingCode) {
+			match, ok = country, true
+		}
+	}
+	return match, ok
+}
@@ -0,0 +1,63 @@
+package phone
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	a := assert.New(t)
+
+	for input, expected := range map[string]Number{
+		"+6281234567890":      {E164: "+6281234567890", Country: "ID"},
+		"+62 812-3456-7890":   {E164: "+6281234567890", Country: "ID"},
+		" +62 (0)812.345.678": {E164: "+62812345678", Country: "ID"},
+		"006281234567890":     {E164: "+6281234567890", Country: "ID"},
+		"+60 12-345 6789":     {E164: "+60123456789", Country: "MY"},
+		"+60 012 345 6789":    {E164: "+60123456789", Country: "MY"},
+		"+65 9123 4567":       {E164: "+6591234567", Country: "SG"},
+		"+66 81 234 5678":     {E164: "+66812345678", Country: "TH"},
+		"+63 917 123 4567":    {E164: "+639171234567", Country: "PH"},
+	} {
+		number, err := Parse(input)
+
+		a.Empty(err, input)
+		a.Equal(expected, number, input)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	a := assert.New(t)
+
+	for input, expected := range map[string]error{
+		"":                     ErrMissingCountryCode,
+		"081234567890":         ErrMissingCountryCode,
+		"+":                    ErrInvalidCharacters,
+		"+62 812 abc 7890":     ErrInvalidCharacters,
+		"+1 415 555 2671":      ErrUnsupportedCountry,
+		"+62 812 345":          ErrInvalidLength,
+		"+62 812 3456 7890 12": ErrInvalidLength,
+		"+65 9123 45678":       ErrInvalidLength,
+	} {
+		_, err := Parse(input)
+
+		a.ErrorIs(err, expected, input)
+	}
+}
+
+func TestParseInvalidLengthMessage(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := Parse("+62 812 345")
+	a.EqualError(err, "has an invalid number of digits, ID numbers must have 8 to 12 digits after +62")
+	var lengthErr *LengthError
+	a.ErrorAs(err, &lengthErr)
+	a.Equal(Countries["ID"], lengthErr.Country)
+
+	_, err = Parse("+65 9123")
+	a.EqualError(err, "has an invalid number of digits, SG numbers must have 8 digits after +65")
+}
+
+func TestSupportedCountries(t *testing.T) {
+	assert.Equal(t, []string{"ID", "MY", "PH", "SG", "TH"}, SupportedCountries())
+}
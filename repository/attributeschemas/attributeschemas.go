@@ -0,0 +1,23 @@
+// This file contains the repository implementation layer.
+package attributeschemas
+
+import (
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+// attributeSchemaRepository is a postgresSQL implementation of repository.AttributeSchemaRepository
+type attributeSchemaRepository struct {
+	db *sql.DB
+}
+
+func NewAttributeSchemaRepository(opts repository.NewRepositoryOptions) (repository.AttributeSchemaRepository, error) {
+	db, err := repository.OpenDatabase(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attributeSchemaRepository{
+		db: db,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package attributeschemas
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	createAttributeSchemaQuery = `INSERT INTO attribute_schemas (schema, created_by) VALUES ($1, $2) RETURNING id, created_at;`
+)
+
+func (a *attributeSchemaRepository) CreateAttributeSchema(ctx context.Context, input repository.CreateAttributeSchemaInput) (output repository.CreateAttributeSchemaOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "AttributeSchemaRepository", "CreateAttributeSchema")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := a.db.QueryRowContext(ctx, createAttributeSchemaQuery, []byte(input.Schema), input.CreatedBy)
+	if err = row.Scan(&output.ID, &output.CreatedAt); err != nil {
+		return repository.CreateAttributeSchemaOutput{}, err
+	}
+	return output, nil
+}
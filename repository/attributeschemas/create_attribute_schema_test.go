@@ -0,0 +1,66 @@
+package attributeschemas
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type CreateAttributeSchemaTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.AttributeSchemaRepository
+
+	input repository.CreateAttributeSchemaInput
+	ctx   context.Context
+}
+
+func TestCreateAttributeSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateAttributeSchemaTestSuite))
+}
+
+func (s *CreateAttributeSchemaTestSuite) SetupTest() {
+	repo := &attributeSchemaRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.CreateAttributeSchemaInput{Schema: json.RawMessage(`{"type": "object"}`), CreatedBy: 1}
+	s.ctx = context.Background()
+}
+
+func (s *CreateAttributeSchemaTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *CreateAttributeSchemaTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createAttributeSchemaQuery)).WithArgs([]byte(s.input.Schema), s.input.CreatedBy).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.CreateAttributeSchema(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *CreateAttributeSchemaTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	createdAt := time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createAttributeSchemaQuery)).WithArgs([]byte(s.input.Schema), s.input.CreatedBy).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(3, createdAt))
+	res, err := s.repo.CreateAttributeSchema(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(repository.CreateAttributeSchemaOutput{ID: 3, CreatedAt: createdAt}, res)
+}
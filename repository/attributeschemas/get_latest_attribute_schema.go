@@ -0,0 +1,26 @@
+package attributeschemas
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	getLatestAttributeSchemaQuery = `SELECT id, schema, created_by, created_at FROM attribute_schemas ORDER BY id DESC LIMIT 1;`
+)
+
+func (a *attributeSchemaRepository) GetLatestAttributeSchema(ctx context.Context, input repository.GetLatestAttributeSchemaInput) (output repository.GetLatestAttributeSchemaOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "AttributeSchemaRepository", "GetLatestAttributeSchema")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := a.db.QueryRowContext(ctx, getLatestAttributeSchemaQuery)
+	if err = row.Scan(&output.ID, &output.Schema, &output.CreatedBy, &output.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrorRecordNotFound
+		}
+		return repository.GetLatestAttributeSchemaOutput{}, err
+	}
+	return output, nil
+}
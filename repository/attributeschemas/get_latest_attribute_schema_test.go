@@ -0,0 +1,83 @@
+package attributeschemas
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type GetLatestAttributeSchemaTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.AttributeSchemaRepository
+
+	output  repository.GetLatestAttributeSchemaOutput
+	columns []string
+	ctx     context.Context
+}
+
+func TestGetLatestAttributeSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(GetLatestAttributeSchemaTestSuite))
+}
+
+func (s *GetLatestAttributeSchemaTestSuite) SetupTest() {
+	repo := &attributeSchemaRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.output = repository.GetLatestAttributeSchemaOutput{
+		ID:        3,
+		Schema:    json.RawMessage(`{"type": "object"}`),
+		CreatedBy: 1,
+		CreatedAt: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC),
+	}
+	s.columns = []string{"id", "schema", "created_by", "created_at"}
+	s.ctx = context.Background()
+}
+
+func (s *GetLatestAttributeSchemaTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *GetLatestAttributeSchemaTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getLatestAttributeSchemaQuery)).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.GetLatestAttributeSchema(s.ctx, repository.GetLatestAttributeSchemaInput{})
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *GetLatestAttributeSchemaTestSuite) TestRecordNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getLatestAttributeSchemaQuery)).
+		WillReturnRows(sqlmock.NewRows(s.columns))
+	res, err := s.repo.GetLatestAttributeSchema(s.ctx, repository.GetLatestAttributeSchemaInput{})
+
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *GetLatestAttributeSchemaTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getLatestAttributeSchemaQuery)).
+		WillReturnRows(sqlmock.NewRows(s.columns).AddRow(s.output.ID, []byte(s.output.Schema), s.output.CreatedBy, s.output.CreatedAt))
+	res, err := s.repo.GetLatestAttributeSchema(s.ctx, repository.GetLatestAttributeSchemaInput{})
+
+	a.Empty(err)
+	a.Equal(s.output, res)
+}
@@ -0,0 +1,23 @@
+// This file contains the repository implementation layer.
+package audit
+
+import (
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+// auditRepository is a postgresSQL implementation of repository.AuditRepository
+type auditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(opts repository.NewRepositoryOptions) (repository.AuditRepository, error) {
+	db, err := repository.OpenDatabase(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auditRepository{
+		db: db,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	createAuditEventQuery = `INSERT INTO audit_events (actor_user_id, action, target_user_id, details) VALUES ($1, $2, $3, $4) RETURNING id;`
+)
+
+// queryRower is either the database connection pool, or a transaction
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func (a *auditRepository) CreateAuditEvent(ctx context.Context, input repository.CreateAuditEventInput) (output repository.CreateAuditEventOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "AuditRepository", "CreateAuditEvent")
+	defer func() { repository.EndSpan(span, err) }()
+
+	return createAuditEvent(ctx, a.db, input)
+}
+
+// CreateAuditEventTx will record the audit event in the transaction of the audited change, for the other
+// repositories, so that the event is recorded if and only if the change is committed
+func CreateAuditEventTx(ctx context.Context, tx *sql.Tx, input repository.CreateAuditEventInput) (output repository.CreateAuditEventOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "AuditRepository", "CreateAuditEvent")
+	defer func() { repository.EndSpan(span, err) }()
+
+	return createAuditEvent(ctx, tx, input)
+}
+
+func createAuditEvent(ctx context.Context, db queryRower, input repository.CreateAuditEventInput) (output repository.CreateAuditEventOutput, err error) {
+	var details []byte
+	if details, err = json.Marshal(input.Details); err != nil {
+		return
+	}
+
+	row := db.QueryRowContext(ctx, createAuditEventQuery, nullableID(input.ActorUserID), input.Action, nullableID(input.TargetUserID), details)
+	if err = row.Scan(&output.ID); err != nil {
+		return repository.CreateAuditEventOutput{}, err
+	}
+	return output, nil
+}
+
+// nullableID will convert the zero ID into NULL, e.g. for actions performed by the system itself
+func nullableID(id uint64) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id != 0}
+}
@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type CreateAuditEventTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.AuditRepository
+
+	input repository.CreateAuditEventInput
+	ctx   context.Context
+}
+
+func TestCreateAuditEventTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateAuditEventTestSuite))
+}
+
+func (s *CreateAuditEventTestSuite) SetupTest() {
+	repo := &auditRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.CreateAuditEventInput{
+		ActorUserID:  1,
+		Action:       "user.roles.set",
+		TargetUserID: 123,
+		Details:      map[string]interface{}{"roles": []string{"farmer"}},
+	}
+	s.ctx = context.Background()
+}
+
+func (s *CreateAuditEventTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *CreateAuditEventTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createAuditEventQuery)).
+		WithArgs(sql.NullInt64{Int64: 1, Valid: true}, s.input.Action, sql.NullInt64{Int64: 123, Valid: true}, []byte(`{"roles":["farmer"]}`)).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.CreateAuditEvent(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *CreateAuditEventTestSuite) TestSystemActorSuccess() {
+	a := assert.New(s.T())
+
+	s.input.ActorUserID = 0
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createAuditEventQuery)).
+		WithArgs(sql.NullInt64{}, s.input.Action, sql.NullInt64{Int64: 123, Valid: true}, []byte(`{"roles":["farmer"]}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	res, err := s.repo.CreateAuditEvent(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(uint64(42), res.ID)
+}
+
+func (s *CreateAuditEventTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createAuditEventQuery)).
+		WithArgs(sql.NullInt64{Int64: 1, Valid: true}, s.input.Action, sql.NullInt64{Int64: 123, Valid: true}, []byte(`{"roles":["farmer"]}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	res, err := s.repo.CreateAuditEvent(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(uint64(42), res.ID)
+}
+
+func (s *CreateAuditEventTestSuite) TestTransactionSuccess() {
+	a := assert.New(s.T())
+
+	db, dbMock, _ := sqlmock.New()
+	dbMock.ExpectBegin()
+	dbMock.ExpectQuery(regexp.QuoteMeta(createAuditEventQuery)).
+		WithArgs(sql.NullInt64{Int64: 1, Valid: true}, s.input.Action, sql.NullInt64{Int64: 123, Valid: true}, []byte(`{"roles":["farmer"]}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	tx, _ := db.Begin()
+	res, err := CreateAuditEventTx(s.ctx, tx, s.input)
+
+	a.Empty(err)
+	a.Equal(uint64(42), res.ID)
+	a.Nil(dbMock.ExpectationsWereMet())
+}
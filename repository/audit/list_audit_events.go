@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	listAuditEventsQuery = `SELECT id, actor_user_id, action, target_user_id, details, created_at FROM audit_events ` +
+		`WHERE target_user_id=$1 ORDER BY id;`
+)
+
+func (a *auditRepository) ListAuditEvents(ctx context.Context, input repository.ListAuditEventsInput) (output repository.ListAuditEventsOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "AuditRepository", "ListAuditEvents")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var rows *sql.Rows
+	if rows, err = a.db.QueryContext(ctx, listAuditEventsQuery, input.UserID); err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event repository.AuditEvent
+		var actorUserID, targetUserID sql.NullInt64
+		var details []byte
+		if err = rows.Scan(&event.ID, &actorUserID, &event.Action, &targetUserID, &details, &event.CreatedAt); err != nil {
+			return repository.ListAuditEventsOutput{}, err
+		}
+		event.ActorUserID = uint64(actorUserID.Int64)
+		event.TargetUserID = uint64(targetUserID.Int64)
+		if len(details) > 0 {
+			if err = json.Unmarshal(details, &event.Details); err != nil {
+				return repository.ListAuditEventsOutput{}, err
+			}
+		}
+		output.Events = append(output.Events, event)
+	}
+	if err = rows.Err(); err != nil {
+		return repository.ListAuditEventsOutput{}, err
+	}
+	return output, nil
+}
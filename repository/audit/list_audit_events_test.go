@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type ListAuditEventsTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.AuditRepository
+
+	input   repository.ListAuditEventsInput
+	columns []string
+	ctx     context.Context
+}
+
+func TestListAuditEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(ListAuditEventsTestSuite))
+}
+
+func (s *ListAuditEventsTestSuite) SetupTest() {
+	repo := &auditRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.ListAuditEventsInput{UserID: 123}
+	s.columns = []string{"id", "actor_user_id", "action", "target_user_id", "details", "created_at"}
+	s.ctx = context.Background()
+}
+
+func (s *ListAuditEventsTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *ListAuditEventsTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(listAuditEventsQuery)).WithArgs(s.input.UserID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.ListAuditEvents(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *ListAuditEventsTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	createdAt := time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(listAuditEventsQuery)).WithArgs(s.input.UserID).
+		WillReturnRows(sqlmock.NewRows(s.columns).
+			AddRow(1, 1, "user.roles.set", 123, []byte(`{"new_roles":["farmer"]}`), createdAt).
+			AddRow(2, nil, "user.anonymized", 123, []byte(`null`), createdAt))
+	res, err := s.repo.ListAuditEvents(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(repository.ListAuditEventsOutput{Events: []repository.AuditEvent{
+		{ID: 1, ActorUserID: 1, Action: "user.roles.set", TargetUserID: 123, Details: map[string]interface{}{"new_roles": []interface{}{"farmer"}}, CreatedAt: createdAt},
+		{ID: 2, Action: "user.anonymized", TargetUserID: 123, CreatedAt: createdAt},
+	}}, res)
+}
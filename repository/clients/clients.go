@@ -0,0 +1,23 @@
+// This file contains the repository implementation layer.
+package clients
+
+import (
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+// oauthClientRepository is a postgresSQL implementation of repository.OAuthClientRepository
+type oauthClientRepository struct {
+	db *sql.DB
+}
+
+func NewOAuthClientRepository(opts repository.NewRepositoryOptions) (repository.OAuthClientRepository, error) {
+	db, err := repository.OpenDatabase(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauthClientRepository{
+		db: db,
+	}, nil
+}
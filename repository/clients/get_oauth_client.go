@@ -0,0 +1,27 @@
+package clients
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	getOAuthClientQuery = `SELECT id, client_id, name, client_secret_hash FROM oauth_clients WHERE client_id=$1;`
+)
+
+func (c *oauthClientRepository) GetOAuthClient(ctx context.Context, input repository.GetOAuthClientInput) (output repository.GetOAuthClientOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "OAuthClientRepository", "GetOAuthClient")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := c.db.QueryRowContext(ctx, getOAuthClientQuery, input.ClientID)
+	if err = row.Scan(&output.ID, &output.ClientID, &output.Name, &output.ClientSecretHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrorRecordNotFound
+			return
+		}
+		return
+	}
+	return output, nil
+}
@@ -0,0 +1,84 @@
+package clients
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type GetOAuthClientTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.OAuthClientRepository
+
+	input  repository.GetOAuthClientInput
+	output repository.GetOAuthClientOutput
+	ctx    context.Context
+}
+
+func TestGetOAuthClientTestSuite(t *testing.T) {
+	suite.Run(t, new(GetOAuthClientTestSuite))
+}
+
+func (s *GetOAuthClientTestSuite) SetupTest() {
+	repo := &oauthClientRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.GetOAuthClientInput{ClientID: "legacy-service"}
+	s.output = repository.GetOAuthClientOutput{
+		ID:               7,
+		ClientID:         "legacy-service",
+		Name:             "Legacy Service",
+		ClientSecretHash: []byte("random-salted-secret-hash"),
+	}
+	s.ctx = context.Background()
+}
+
+func (s *GetOAuthClientTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *GetOAuthClientTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getOAuthClientQuery)).WithArgs(s.input.ClientID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.GetOAuthClient(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *GetOAuthClientTestSuite) TestNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getOAuthClientQuery)).WithArgs(s.input.ClientID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "name", "client_secret_hash"}))
+	res, err := s.repo.GetOAuthClient(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *GetOAuthClientTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getOAuthClientQuery)).WithArgs(s.input.ClientID).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "client_id", "name", "client_secret_hash"}).
+				AddRow(s.output.ID, s.output.ClientID, s.output.Name, s.output.ClientSecretHash),
+		)
+	res, err := s.repo.GetOAuthClient(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, res)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+
+	_ "github.com/lib/pq"
+)
+
+// databaseMetricsName is the `db_name` label of the connection pool metrics
+const databaseMetricsName = "userservice"
+
+// OpenDatabase will return the shared connection pool specified in NewRepositoryOptions opts,
+// or open a new one using the provided Database settings
+func OpenDatabase(opts NewRepositoryOptions) (*sql.DB, error) {
+	if opts.DB != nil {
+		return opts.DB, nil
+	}
+	db, err := sql.Open("postgres", opts.Database.URL)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(opts.Database.MaxOpenConns)
+	// zero keeps the default of database/sql, as it would disable idle connections
+	if opts.Database.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(opts.Database.MaxIdleConns)
+	}
+	db.SetConnMaxLifetime(opts.Database.ConnMaxLifetime)
+	return db, nil
+}
+
+// RegisterDatabaseMetrics will export the stats of the connection pool on the default Prometheus registry, e.g.
+// go_sql_open_connections, go_sql_in_use_connections, go_sql_wait_count_total & go_sql_wait_duration_seconds_total
+func RegisterDatabaseMetrics(db *sql.DB) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, databaseMetricsName))
+}
@@ -0,0 +1,21 @@
+package devices
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	createUserDeviceQuery = `INSERT INTO user_devices (user_id, token_hash) VALUES ($1, $2) RETURNING id;`
+)
+
+func (d *userDeviceRepository) CreateUserDevice(ctx context.Context, input repository.CreateUserDeviceInput) (output repository.CreateUserDeviceOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserDeviceRepository", "CreateUserDevice")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := d.db.QueryRowContext(ctx, createUserDeviceQuery, input.UserID, input.TokenHash)
+	if err = row.Scan(&output.ID); err != nil {
+		return repository.CreateUserDeviceOutput{}, err
+	}
+	return output, nil
+}
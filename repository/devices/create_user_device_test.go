@@ -0,0 +1,63 @@
+package devices
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type CreateUserDeviceTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserDeviceRepository
+
+	input repository.CreateUserDeviceInput
+	ctx   context.Context
+}
+
+func TestCreateUserDeviceTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateUserDeviceTestSuite))
+}
+
+func (s *CreateUserDeviceTestSuite) SetupTest() {
+	repo := &userDeviceRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.CreateUserDeviceInput{UserID: 123, TokenHash: "token-hash"}
+	s.ctx = context.Background()
+}
+
+func (s *CreateUserDeviceTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *CreateUserDeviceTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createUserDeviceQuery)).WithArgs(s.input.UserID, s.input.TokenHash).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.CreateUserDevice(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *CreateUserDeviceTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createUserDeviceQuery)).WithArgs(s.input.UserID, s.input.TokenHash).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	res, err := s.repo.CreateUserDevice(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(uint64(7), res.ID)
+}
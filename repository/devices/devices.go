@@ -0,0 +1,23 @@
+// This file contains the repository implementation layer.
+package devices
+
+import (
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+// userDeviceRepository is a postgresSQL implementation of repository.UserDeviceRepository
+type userDeviceRepository struct {
+	db *sql.DB
+}
+
+func NewUserDeviceRepository(opts repository.NewRepositoryOptions) (repository.UserDeviceRepository, error) {
+	db, err := repository.OpenDatabase(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userDeviceRepository{
+		db: db,
+	}, nil
+}
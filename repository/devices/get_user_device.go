@@ -0,0 +1,26 @@
+package devices
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	getUserDeviceQuery = `SELECT id, user_id, created_at FROM user_devices WHERE user_id=$1 AND token_hash=$2;`
+)
+
+func (d *userDeviceRepository) GetUserDevice(ctx context.Context, input repository.GetUserDeviceInput) (output repository.GetUserDeviceOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserDeviceRepository", "GetUserDevice")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := d.db.QueryRowContext(ctx, getUserDeviceQuery, input.UserID, input.TokenHash)
+	if err = row.Scan(&output.ID, &output.UserID, &output.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrorRecordNotFound
+		}
+		return repository.GetUserDeviceOutput{}, err
+	}
+	return output, nil
+}
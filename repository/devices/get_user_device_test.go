@@ -0,0 +1,77 @@
+package devices
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type GetUserDeviceTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserDeviceRepository
+
+	input  repository.GetUserDeviceInput
+	output repository.GetUserDeviceOutput
+	ctx    context.Context
+}
+
+func TestGetUserDeviceTestSuite(t *testing.T) {
+	suite.Run(t, new(GetUserDeviceTestSuite))
+}
+
+func (s *GetUserDeviceTestSuite) SetupTest() {
+	repo := &userDeviceRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.GetUserDeviceInput{UserID: 123, TokenHash: "token-hash"}
+	s.output = repository.GetUserDeviceOutput{ID: 7, UserID: 123, CreatedAt: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)}
+	s.ctx = context.Background()
+}
+
+func (s *GetUserDeviceTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *GetUserDeviceTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserDeviceQuery)).WithArgs(s.input.UserID, s.input.TokenHash).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.GetUserDevice(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *GetUserDeviceTestSuite) TestNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserDeviceQuery)).WithArgs(s.input.UserID, s.input.TokenHash).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "created_at"}))
+	res, err := s.repo.GetUserDevice(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *GetUserDeviceTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserDeviceQuery)).WithArgs(s.input.UserID, s.input.TokenHash).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "created_at"}).AddRow(s.output.ID, s.output.UserID, s.output.CreatedAt))
+	res, err := s.repo.GetUserDevice(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, res)
+}
@@ -0,0 +1,34 @@
+package devices
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	listUserDevicesQuery = `SELECT id, created_at FROM user_devices WHERE user_id=$1 ORDER BY id;`
+)
+
+func (d *userDeviceRepository) ListUserDevices(ctx context.Context, input repository.ListUserDevicesInput) (output repository.ListUserDevicesOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserDeviceRepository", "ListUserDevices")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var rows *sql.Rows
+	if rows, err = d.db.QueryContext(ctx, listUserDevicesQuery, input.UserID); err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var device repository.UserDevice
+		if err = rows.Scan(&device.ID, &device.CreatedAt); err != nil {
+			return repository.ListUserDevicesOutput{}, err
+		}
+		output.Devices = append(output.Devices, device)
+	}
+	if err = rows.Err(); err != nil {
+		return repository.ListUserDevicesOutput{}, err
+	}
+	return output, nil
+}
@@ -0,0 +1,68 @@
+package devices
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type ListUserDevicesTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserDeviceRepository
+
+	input repository.ListUserDevicesInput
+	ctx   context.Context
+}
+
+func TestListUserDevicesTestSuite(t *testing.T) {
+	suite.Run(t, new(ListUserDevicesTestSuite))
+}
+
+func (s *ListUserDevicesTestSuite) SetupTest() {
+	repo := &userDeviceRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.ListUserDevicesInput{UserID: 123}
+	s.ctx = context.Background()
+}
+
+func (s *ListUserDevicesTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *ListUserDevicesTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(listUserDevicesQuery)).WithArgs(s.input.UserID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.ListUserDevices(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *ListUserDevicesTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	createdAt := time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(listUserDevicesQuery)).WithArgs(s.input.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, createdAt).AddRow(8, createdAt))
+	res, err := s.repo.ListUserDevices(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(repository.ListUserDevicesOutput{Devices: []repository.UserDevice{
+		{ID: 7, CreatedAt: createdAt},
+		{ID: 8, CreatedAt: createdAt},
+	}}, res)
+}
@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrorRecordNotFound = errors.New("record not found")
 	ErrorRecordConflict = errors.New("record conflict error")
+	ErrorRecordOutdated = errors.New("record version mismatch")
 )
@@ -0,0 +1,40 @@
+package exports
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"time"
+)
+
+const (
+	// SKIP LOCKED allows multiple instances to process the exports concurrently, the exports left processing by a
+	// stopped worker are claimed again once stale
+	claimUserExportsQuery = `UPDATE user_exports SET status='processing', claimed_at=now() ` +
+		`WHERE id IN (SELECT id FROM user_exports WHERE status='pending' ` +
+		`OR (status='processing' AND claimed_at<now()-$2*interval '1 second') ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED) ` +
+		`RETURNING id, user_id, format;`
+)
+
+func (e *userExportRepository) ClaimUserExports(ctx context.Context, input repository.ClaimUserExportsInput) (output repository.ClaimUserExportsOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserExportRepository", "ClaimUserExports")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var rows *sql.Rows
+	if rows, err = e.db.QueryContext(ctx, claimUserExportsQuery, input.Limit, int64(input.StaleAfter/time.Second)); err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var export repository.ClaimedUserExport
+		if err = rows.Scan(&export.ID, &export.UserID, &export.Format); err != nil {
+			return repository.ClaimUserExportsOutput{}, err
+		}
+		output.Exports = append(output.Exports, export)
+	}
+	if err = rows.Err(); err != nil {
+		return repository.ClaimUserExportsOutput{}, err
+	}
+	return output, nil
+}
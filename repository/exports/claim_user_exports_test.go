@@ -0,0 +1,67 @@
+package exports
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type ClaimUserExportsTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserExportRepository
+
+	input repository.ClaimUserExportsInput
+	ctx   context.Context
+}
+
+func TestClaimUserExportsTestSuite(t *testing.T) {
+	suite.Run(t, new(ClaimUserExportsTestSuite))
+}
+
+func (s *ClaimUserExportsTestSuite) SetupTest() {
+	repo := &userExportRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.ClaimUserExportsInput{Limit: 10, StaleAfter: 15 * time.Minute}
+	s.ctx = context.Background()
+}
+
+func (s *ClaimUserExportsTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *ClaimUserExportsTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(claimUserExportsQuery)).WithArgs(s.input.Limit, 900).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.ClaimUserExports(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *ClaimUserExportsTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(claimUserExportsQuery)).WithArgs(s.input.Limit, 900).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "format"}).AddRow(7, 123, "json").AddRow(8, 42, "zip"))
+	res, err := s.repo.ClaimUserExports(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(repository.ClaimUserExportsOutput{Exports: []repository.ClaimedUserExport{
+		{ID: 7, UserID: 123, Format: "json"},
+		{ID: 8, UserID: 42, Format: "zip"},
+	}}, res)
+}
@@ -0,0 +1,30 @@
+package exports
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	completeUserExportQuery = `UPDATE user_exports SET status=$1, content=$2, content_type=$3, completed_at=now(), expires_at=$4 WHERE id=$5;`
+)
+
+func (e *userExportRepository) CompleteUserExport(ctx context.Context, input repository.CompleteUserExportInput) (output repository.CompleteUserExportOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserExportRepository", "CompleteUserExport")
+	defer func() { repository.EndSpan(span, err) }()
+
+	contentType := sql.NullString{String: input.ContentType, Valid: input.ContentType != ""}
+
+	var result sql.Result
+	if result, err = e.db.ExecContext(ctx, completeUserExportQuery, input.Status, input.Content, contentType, input.ExpiresAt, input.ID); err != nil {
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected <= 0 {
+		err = repository.ErrorRecordNotFound
+		return
+	}
+
+	return output, nil
+}
@@ -0,0 +1,93 @@
+package exports
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type CompleteUserExportTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserExportRepository
+
+	input repository.CompleteUserExportInput
+	ctx   context.Context
+}
+
+func TestCompleteUserExportTestSuite(t *testing.T) {
+	suite.Run(t, new(CompleteUserExportTestSuite))
+}
+
+func (s *CompleteUserExportTestSuite) SetupTest() {
+	repo := &userExportRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.CompleteUserExportInput{
+		ID:          7,
+		Status:      "ready",
+		Content:     []byte(`{}`),
+		ContentType: "application/json",
+		ExpiresAt:   time.Date(2024, 3, 23, 9, 56, 0, 0, time.UTC),
+	}
+	s.ctx = context.Background()
+}
+
+func (s *CompleteUserExportTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *CompleteUserExportTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(completeUserExportQuery)).
+		WithArgs(s.input.Status, s.input.Content, s.input.ContentType, s.input.ExpiresAt, s.input.ID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	_, err := s.repo.CompleteUserExport(s.ctx, s.input)
+
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *CompleteUserExportTestSuite) TestRecordNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(completeUserExportQuery)).
+		WithArgs(s.input.Status, s.input.Content, s.input.ContentType, s.input.ExpiresAt, s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err := s.repo.CompleteUserExport(s.ctx, s.input)
+
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *CompleteUserExportTestSuite) TestFailed() {
+	a := assert.New(s.T())
+
+	s.input = repository.CompleteUserExportInput{ID: 7, Status: "failed", ExpiresAt: s.input.ExpiresAt}
+	s.dbMock.ExpectExec(regexp.QuoteMeta(completeUserExportQuery)).
+		WithArgs("failed", []byte(nil), nil, s.input.ExpiresAt, s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := s.repo.CompleteUserExport(s.ctx, s.input)
+
+	a.Empty(err)
+}
+
+func (s *CompleteUserExportTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(completeUserExportQuery)).
+		WithArgs(s.input.Status, s.input.Content, s.input.ContentType, s.input.ExpiresAt, s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := s.repo.CompleteUserExport(s.ctx, s.input)
+
+	a.Empty(err)
+}
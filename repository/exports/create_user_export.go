@@ -0,0 +1,21 @@
+package exports
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	createUserExportQuery = `INSERT INTO user_exports (user_id, format) VALUES ($1, $2) RETURNING id, created_at;`
+)
+
+func (e *userExportRepository) CreateUserExport(ctx context.Context, input repository.CreateUserExportInput) (output repository.CreateUserExportOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserExportRepository", "CreateUserExport")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := e.db.QueryRowContext(ctx, createUserExportQuery, input.UserID, input.Format)
+	if err = row.Scan(&output.ID, &output.CreatedAt); err != nil {
+		return repository.CreateUserExportOutput{}, err
+	}
+	return output, nil
+}
@@ -0,0 +1,64 @@
+package exports
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type CreateUserExportTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserExportRepository
+
+	input repository.CreateUserExportInput
+	ctx   context.Context
+}
+
+func TestCreateUserExportTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateUserExportTestSuite))
+}
+
+func (s *CreateUserExportTestSuite) SetupTest() {
+	repo := &userExportRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.CreateUserExportInput{UserID: 123, Format: "json"}
+	s.ctx = context.Background()
+}
+
+func (s *CreateUserExportTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *CreateUserExportTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createUserExportQuery)).WithArgs(s.input.UserID, s.input.Format).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.CreateUserExport(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *CreateUserExportTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createUserExportQuery)).WithArgs(s.input.UserID, s.input.Format).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)))
+	res, err := s.repo.CreateUserExport(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(repository.CreateUserExportOutput{ID: 7, CreatedAt: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)}, res)
+}
@@ -0,0 +1,24 @@
+package exports
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	deleteExpiredUserExportsQuery = `DELETE FROM user_exports WHERE expires_at<$1;`
+)
+
+func (e *userExportRepository) DeleteExpiredUserExports(ctx context.Context, input repository.DeleteExpiredUserExportsInput) (output repository.DeleteExpiredUserExportsOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserExportRepository", "DeleteExpiredUserExports")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var result sql.Result
+	if result, err = e.db.ExecContext(ctx, deleteExpiredUserExportsQuery, input.ExpiredBefore); err != nil {
+		return
+	}
+
+	output.Count, _ = result.RowsAffected()
+	return output, nil
+}
@@ -0,0 +1,64 @@
+package exports
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type DeleteExpiredUserExportsTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserExportRepository
+
+	input repository.DeleteExpiredUserExportsInput
+	ctx   context.Context
+}
+
+func TestDeleteExpiredUserExportsTestSuite(t *testing.T) {
+	suite.Run(t, new(DeleteExpiredUserExportsTestSuite))
+}
+
+func (s *DeleteExpiredUserExportsTestSuite) SetupTest() {
+	repo := &userExportRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.DeleteExpiredUserExportsInput{ExpiredBefore: time.Date(2024, 3, 23, 9, 56, 0, 0, time.UTC)}
+	s.ctx = context.Background()
+}
+
+func (s *DeleteExpiredUserExportsTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *DeleteExpiredUserExportsTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(deleteExpiredUserExportsQuery)).WithArgs(s.input.ExpiredBefore).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.DeleteExpiredUserExports(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *DeleteExpiredUserExportsTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(deleteExpiredUserExportsQuery)).WithArgs(s.input.ExpiredBefore).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	res, err := s.repo.DeleteExpiredUserExports(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(repository.DeleteExpiredUserExportsOutput{Count: 3}, res)
+}
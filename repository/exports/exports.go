@@ -0,0 +1,23 @@
+// This file contains the repository implementation layer.
+package exports
+
+import (
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+// userExportRepository is a postgresSQL implementation of repository.UserExportRepository
+type userExportRepository struct {
+	db *sql.DB
+}
+
+func NewUserExportRepository(opts repository.NewRepositoryOptions) (repository.UserExportRepository, error) {
+	db, err := repository.OpenDatabase(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userExportRepository{
+		db: db,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package exports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	getUserExportQuery = `SELECT id, user_id, format, status, content, content_type, created_at, completed_at, expires_at ` +
+		`FROM user_exports WHERE id=$1 AND user_id=$2;`
+)
+
+func (e *userExportRepository) GetUserExport(ctx context.Context, input repository.GetUserExportInput) (output repository.GetUserExportOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserExportRepository", "GetUserExport")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := e.db.QueryRowContext(ctx, getUserExportQuery, input.ID, input.UserID)
+
+	var contentType sql.NullString
+	var completedAt, expiresAt sql.NullTime
+	if err = row.Scan(&output.ID, &output.UserID, &output.Format, &output.Status, &output.Content, &contentType, &output.CreatedAt, &completedAt, &expiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrorRecordNotFound
+		}
+		return repository.GetUserExportOutput{}, err
+	}
+	output.ContentType = contentType.String
+	output.CompletedAt = completedAt.Time
+	output.ExpiresAt = expiresAt.Time
+
+	return output, nil
+}
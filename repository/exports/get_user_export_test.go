@@ -0,0 +1,101 @@
+package exports
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type GetUserExportTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserExportRepository
+
+	input   repository.GetUserExportInput
+	output  repository.GetUserExportOutput
+	columns []string
+	ctx     context.Context
+}
+
+func TestGetUserExportTestSuite(t *testing.T) {
+	suite.Run(t, new(GetUserExportTestSuite))
+}
+
+func (s *GetUserExportTestSuite) SetupTest() {
+	repo := &userExportRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.GetUserExportInput{ID: 7, UserID: 123}
+	s.output = repository.GetUserExportOutput{
+		ID:          7,
+		UserID:      123,
+		Format:      "json",
+		Status:      "ready",
+		Content:     []byte(`{}`),
+		ContentType: "application/json",
+		CreatedAt:   time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC),
+		CompletedAt: time.Date(2024, 3, 16, 9, 56, 0, 0, time.UTC),
+		ExpiresAt:   time.Date(2024, 3, 23, 9, 56, 0, 0, time.UTC),
+	}
+	s.columns = []string{"id", "user_id", "format", "status", "content", "content_type", "created_at", "completed_at", "expires_at"}
+	s.ctx = context.Background()
+}
+
+func (s *GetUserExportTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *GetUserExportTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserExportQuery)).WithArgs(s.input.ID, s.input.UserID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.GetUserExport(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *GetUserExportTestSuite) TestRecordNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserExportQuery)).WithArgs(s.input.ID, s.input.UserID).
+		WillReturnRows(sqlmock.NewRows(s.columns))
+	res, err := s.repo.GetUserExport(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *GetUserExportTestSuite) TestPending() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserExportQuery)).WithArgs(s.input.ID, s.input.UserID).
+		WillReturnRows(sqlmock.NewRows(s.columns).AddRow(7, 123, "json", "pending", nil, nil, s.output.CreatedAt, nil, nil))
+	res, err := s.repo.GetUserExport(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(repository.GetUserExportOutput{ID: 7, UserID: 123, Format: "json", Status: "pending", CreatedAt: s.output.CreatedAt}, res)
+}
+
+func (s *GetUserExportTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserExportQuery)).WithArgs(s.input.ID, s.input.UserID).
+		WillReturnRows(sqlmock.NewRows(s.columns).AddRow(7, 123, "json", "ready", []byte(`{}`), "application/json",
+			s.output.CreatedAt, s.output.CompletedAt, s.output.ExpiresAt))
+	res, err := s.repo.GetUserExport(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, res)
+}
@@ -12,14 +12,237 @@ import "context"
 type UserRepository interface {
 
 	// CreateUser will create a new user as specified by the CreateUserInput input, and return the created record ID
-	// Will return error on Database Error or Record Conflict
+	// Will return error on Database Error or Record Conflict (the phone number is taken, or reserved after a change)
 	CreateUser(ctx context.Context, input CreateUserInput) (output CreateUserOutput, err error)
 
 	// GetUser will return a user data using either the User ID or PhoneNo, as specified by GetUserInput input
 	// Will return error on Database Error or No Record Found
 	GetUser(ctx context.Context, input GetUserInput) (output GetUserOutput, err error)
 
-	// UpdateUser will update a user data with the specified ID on UpdateUserInput input
-	// Will return error on Database Error or No Record Found
+	// ListUsers will return the users matching every filter on ListUsersInput input, ordered by descending ID
+	// Will return error on Database Error
+	ListUsers(ctx context.Context, input ListUsersInput) (output ListUsersOutput, err error)
+
+	// UpdateUser will update a user data with the specified ID on UpdateUserInput input, and increment its version
+	// Will return error on Database Error, Record Conflict, No Record Found, or Record Outdated when the expected
+	// Version is set and doesn't match (a missing record is reported as outdated as well in that case)
 	UpdateUser(ctx context.Context, input UpdateUserInput) (output UpdateUserOutput, err error)
+
+	// SetUserStatus will move the status of the user with the specified ID from the From status to the To status, and
+	// record the AuditEvent in the same transaction
+	// Will return error on Database Error, or Record Outdated when the user isn't in the From status (or doesn't exist)
+	SetUserStatus(ctx context.Context, input SetUserStatusInput) (output SetUserStatusOutput, err error)
+
+	// SetUserDeletionRequest will mark (or unmark, on zero RequestedAt) the user with the specified ID as pending deletion
+	// Will return error on Database Error or No Record Found
+	SetUserDeletionRequest(ctx context.Context, input SetUserDeletionRequestInput) (output SetUserDeletionRequestOutput, err error)
+
+	// AnonymizeUsers will erase the personal data of the users pending deletion since before DeletionRequestedBefore,
+	// and deactivate them. The user IDs are kept for referential integrity, and their phone numbers freed for re-registration.
+	// In the same transaction, the values of their profile history are erased, their login codes, phone number changes,
+	// phone number reservations & devices deleted, their avatars queued for deletion from the blob store, and an
+	// AuditAction event recorded for each of them
+	// Will return error on Database Error
+	AnonymizeUsers(ctx context.Context, input AnonymizeUsersInput) (output AnonymizeUsersOutput, err error)
+
+	// ListAvatarDeletions will return up to Limit avatars queued for deletion from the blob store, oldest first
+	// Will return error on Database Error
+	ListAvatarDeletions(ctx context.Context, input ListAvatarDeletionsInput) (output ListAvatarDeletionsOutput, err error)
+
+	// DeleteAvatarDeletion will remove the avatar from the deletion queue, once deleted from the blob store
+	// Will return error on Database Error
+	DeleteAvatarDeletion(ctx context.Context, input DeleteAvatarDeletionInput) (output DeleteAvatarDeletionOutput, err error)
+
+	// GetUserRoles will return the roles assigned to the user with the specified UserID
+	// Will return error on Database Error
+	GetUserRoles(ctx context.Context, input GetUserRolesInput) (output GetUserRolesOutput, err error)
+
+	// SetUserRoles will replace all roles assigned to the user with the specified UserID, and record the AuditEvent in
+	// the same transaction
+	// Will return error on Database Error or No Record Found
+	SetUserRoles(ctx context.Context, input SetUserRolesInput) (output SetUserRolesOutput, err error)
+
+	// ListUserPhoneNumbers will return the phone numbers of users, oldest first, excluding anonymized users
+	// Will return error on Database Error
+	ListUserPhoneNumbers(ctx context.Context, input ListUserPhoneNumbersInput) (output ListUserPhoneNumbersOutput, err error)
+
+	// VerifyUserEmail will mark the email of the user as verified, only if it's still the current email
+	// Will return error on Database Error, No Record Found or Record Conflict (the email is verified by another user)
+	VerifyUserEmail(ctx context.Context, input VerifyUserEmailInput) (output VerifyUserEmailOutput, err error)
+
+	// SetUserPIN will replace the PIN hash of the user (an empty PinHash removes the PIN), and reset its lockout
+	// Will return error on Database Error or No Record Found
+	SetUserPIN(ctx context.Context, input SetUserPINInput) (output SetUserPINOutput, err error)
+
+	// AddUserPINAttempt will record a PIN login attempt before the PIN is compared, unless MaxAttempts is already
+	// reached, and return the number of consecutive attempts. The attempts are reset by a successful login
+	// Will return error on Database Error or No Record Found (no attempts left)
+	AddUserPINAttempt(ctx context.Context, input AddUserPINAttemptInput) (output AddUserPINAttemptOutput, err error)
+
+	// IncrementUserLoginCount will count a successful login of the user, bumping the version of its profile
+	// Will return error on Database Error or No Record Found
+	IncrementUserLoginCount(ctx context.Context, input IncrementUserLoginCountInput) (output IncrementUserLoginCountOutput, err error)
+
+	// ResetUserPINFailures will clear the consecutive wrong PIN login attempts, lifting the PIN lockout
+	// Will return error on Database Error or No Record Found
+	ResetUserPINFailures(ctx context.Context, input ResetUserPINFailuresInput) (output ResetUserPINFailuresOutput, err error)
+
+	// SetUserAvatar will replace the avatar of the user (an empty AvatarKey removes the avatar), queue the previous
+	// one for deletion and return it. Will return error on Database Error or No Record Found
+	SetUserAvatar(ctx context.Context, input SetUserAvatarInput) (output SetUserAvatarOutput, err error)
+
+	// Ping will check that the database is reachable, for the readiness probe
+	// Will return error on Database Error
+	Ping(ctx context.Context) error
+
+	// Close will close the database connection pool, which is shared with the other repositories
+	// Meant to be called once, on shutdown
+	Close() error
+}
+
+// TokenRepository is an interface to interact with the token revocation records
+type TokenRepository interface {
+	// GetTokenRevocation will return the revocation record of the token with the specified TokenID (JWT `jti` claim)
+	// Will return error on Database Error or No Record Found (token is not revoked)
+	GetTokenRevocation(ctx context.Context, input GetTokenRevocationInput) (output GetTokenRevocationOutput, err error)
+	// CreateTokenRevocation will revoke the token with the specified TokenID (JWT `jti` claim), revoking an already
+	// revoked token is not an error. Will return error on Database Error
+	CreateTokenRevocation(ctx context.Context, input CreateTokenRevocationInput) (output CreateTokenRevocationOutput, err error)
+}
+
+// OAuthClientRepository is an interface to interact with the registered OAuth client data model
+type OAuthClientRepository interface {
+	// GetOAuthClient will return the OAuth client with the specified ClientID
+	// Will return error on Database Error or No Record Found
+	GetOAuthClient(ctx context.Context, input GetOAuthClientInput) (output GetOAuthClientOutput, err error)
+}
+
+// AuditRepository is an append-only interface to record security sensitive actions
+type AuditRepository interface {
+	// CreateAuditEvent will record a new audit event as specified by the CreateAuditEventInput input
+	// Will return error on Database Error
+	CreateAuditEvent(ctx context.Context, input CreateAuditEventInput) (output CreateAuditEventOutput, err error)
+
+	// ListAuditEvents will return every audit event affecting the specified user, oldest first
+	// Will return error on Database Error
+	ListAuditEvents(ctx context.Context, input ListAuditEventsInput) (output ListAuditEventsOutput, err error)
+}
+
+// UserExportRepository is an interface to interact with the personal data export requests & archives
+type UserExportRepository interface {
+	// CreateUserExport will create a new pending export request, and return the created record ID
+	// Will return error on Database Error
+	CreateUserExport(ctx context.Context, input CreateUserExportInput) (output CreateUserExportOutput, err error)
+
+	// GetUserExport will return the export with the specified ID, owned by the specified UserID
+	// Will return error on Database Error or No Record Found
+	GetUserExport(ctx context.Context, input GetUserExportInput) (output GetUserExportOutput, err error)
+
+	// ClaimUserExports will mark up to Limit pending exports as processing, and return them
+	// Exports claimed by another worker are skipped, unless they're still processing StaleAfter being claimed
+	// Will return error on Database Error
+	ClaimUserExports(ctx context.Context, input ClaimUserExportsInput) (output ClaimUserExportsOutput, err error)
+
+	// CompleteUserExport will store the result of a processed export
+	// Will return error on Database Error or No Record Found
+	CompleteUserExport(ctx context.Context, input CompleteUserExportInput) (output CompleteUserExportOutput, err error)
+
+	// DeleteExpiredUserExports will delete every export that expired before ExpiredBefore
+	// Will return error on Database Error
+	DeleteExpiredUserExports(ctx context.Context, input DeleteExpiredUserExportsInput) (output DeleteExpiredUserExportsOutput, err error)
+}
+
+// LoginOTPRepository is an interface to interact with the one-time login codes sent by SMS
+type LoginOTPRepository interface {
+	// CreateLoginOTP will store a new login code, and return the created record ID
+	// Will return error on Database Error
+	CreateLoginOTP(ctx context.Context, input CreateLoginOTPInput) (output CreateLoginOTPOutput, err error)
+
+	// CountLoginOTPs will return the number of login codes requested since CreatedAfter, for the phone number and
+	// from the IP address respectively
+	// Will return error on Database Error
+	CountLoginOTPs(ctx context.Context, input CountLoginOTPsInput) (output CountLoginOTPsOutput, err error)
+
+	// GetLatestLoginOTP will return the latest unused login code of the phone number
+	// Will return error on Database Error or No Record Found
+	GetLatestLoginOTP(ctx context.Context, input GetLatestLoginOTPInput) (output GetLatestLoginOTPOutput, err error)
+
+	// AddLoginOTPAttempt will record a verification attempt on the login code, unless MaxAttempts is already reached
+	// Will return error on Database Error or No Record Found (no attempts left, or the code is already used)
+	AddLoginOTPAttempt(ctx context.Context, input AddLoginOTPAttemptInput) (output AddLoginOTPAttemptOutput, err error)
+
+	// ConsumeLoginOTP will mark the login code as used, so it can't be used again
+	// Will return error on Database Error or No Record Found (the code is already used)
+	ConsumeLoginOTP(ctx context.Context, input ConsumeLoginOTPInput) (output ConsumeLoginOTPOutput, err error)
+}
+
+// PhoneChangeRepository is an interface to interact with the pending phone number changes, and the phone numbers
+// reserved to their previous owner after a change
+type PhoneChangeRepository interface {
+	// CreatePhoneChange will store a new pending phone number change, and return the created record ID
+	// Will return error on Database Error
+	CreatePhoneChange(ctx context.Context, input CreatePhoneChangeInput) (output CreatePhoneChangeOutput, err error)
+
+	// CountPhoneChanges will return the number of phone number changes requested by the user since CreatedAfter
+	// Will return error on Database Error
+	CountPhoneChanges(ctx context.Context, input CountPhoneChangesInput) (output CountPhoneChangesOutput, err error)
+
+	// GetLatestPhoneChange will return the latest unconfirmed phone number change of the user
+	// Will return error on Database Error or No Record Found
+	GetLatestPhoneChange(ctx context.Context, input GetLatestPhoneChangeInput) (output GetLatestPhoneChangeOutput, err error)
+
+	// AddPhoneChangeAttempt will record a confirmation attempt on the phone number change, unless MaxAttempts is already reached
+	// Will return error on Database Error or No Record Found (no attempts left, or the change is already confirmed)
+	AddPhoneChangeAttempt(ctx context.Context, input AddPhoneChangeAttemptInput) (output AddPhoneChangeAttemptOutput, err error)
+
+	// ConfirmPhoneChange will atomically mark the change as confirmed, replace the phone number of the user, reserve
+	// the old phone number to the user until ReservedUntil, and record the user changes and the audit event
+	// Will return error on Database Error, No Record Found (the change is already confirmed, or the user phone number
+	// has changed in the meantime) or Record Conflict (the new phone number is taken, or reserved to another user)
+	ConfirmPhoneChange(ctx context.Context, input ConfirmPhoneChangeInput) (output ConfirmPhoneChangeOutput, err error)
+
+	// GetPhoneNoReservation will return the active reservation of the phone number
+	// Will return error on Database Error or No Record Found
+	GetPhoneNoReservation(ctx context.Context, input GetPhoneNoReservationInput) (output GetPhoneNoReservationOutput, err error)
+
+	// ListPhoneChanges will return every phone number change requested by the user, oldest first
+	// Will return error on Database Error
+	ListPhoneChanges(ctx context.Context, input ListPhoneChangesInput) (output ListPhoneChangesOutput, err error)
+}
+
+// UserDeviceRepository is an interface to interact with the devices enrolled for PIN login
+type UserDeviceRepository interface {
+	// CreateUserDevice will enroll a new device for the user, and return the created record ID
+	// Will return error on Database Error
+	CreateUserDevice(ctx context.Context, input CreateUserDeviceInput) (output CreateUserDeviceOutput, err error)
+
+	// GetUserDevice will return the device of the specified UserID, identified by its token hash
+	// Will return error on Database Error or No Record Found
+	GetUserDevice(ctx context.Context, input GetUserDeviceInput) (output GetUserDeviceOutput, err error)
+
+	// ListUserDevices will return every device enrolled by the user, oldest first
+	// Will return error on Database Error
+	ListUserDevices(ctx context.Context, input ListUserDevicesInput) (output ListUserDevicesOutput, err error)
+}
+
+// AttributeSchemaRepository is an interface to interact with the JSON Schema of the custom profile attributes
+type AttributeSchemaRepository interface {
+	// GetLatestAttributeSchema will return the attribute schema in effect, which is the latest one created
+	// Will return error on Database Error or No Record Found (no schema is defined yet)
+	GetLatestAttributeSchema(ctx context.Context, input GetLatestAttributeSchemaInput) (output GetLatestAttributeSchemaOutput, err error)
+
+	// CreateAttributeSchema will create a new attribute schema, taking effect right away, and return its ID
+	// Will return error on Database Error
+	CreateAttributeSchema(ctx context.Context, input CreateAttributeSchemaInput) (output CreateAttributeSchemaOutput, err error)
+}
+
+// UserChangeRepository is an append-only interface to record the field-level history of the user profiles
+type UserChangeRepository interface {
+	// CreateUserChanges will record the changes made to the profile of the user at once
+	// Will return error on Database Error
+	CreateUserChanges(ctx context.Context, input CreateUserChangesInput) (output CreateUserChangesOutput, err error)
+
+	// ListUserChanges will return the changes made to the profile of the user, newest first
+	// Will return error on Database Error
+	ListUserChanges(ctx context.Context, input ListUserChangesInput) (output ListUserChangesOutput, err error)
 }
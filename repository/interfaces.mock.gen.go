@@ -34,6 +34,50 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddUserPINAttempt mocks base method.
+func (m *MockUserRepository) AddUserPINAttempt(ctx context.Context, input AddUserPINAttemptInput) (AddUserPINAttemptOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserPINAttempt", ctx, input)
+	ret0, _ := ret[0].(AddUserPINAttemptOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddUserPINAttempt indicates an expected call of AddUserPINAttempt.
+func (mr *MockUserRepositoryMockRecorder) AddUserPINAttempt(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserPINAttempt", reflect.TypeOf((*MockUserRepository)(nil).AddUserPINAttempt), ctx, input)
+}
+
+// AnonymizeUsers mocks base method.
+func (m *MockUserRepository) AnonymizeUsers(ctx context.Context, input AnonymizeUsersInput) (AnonymizeUsersOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUsers", ctx, input)
+	ret0, _ := ret[0].(AnonymizeUsersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUsers indicates an expected call of AnonymizeUsers.
+func (mr *MockUserRepositoryMockRecorder) AnonymizeUsers(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUsers", reflect.TypeOf((*MockUserRepository)(nil).AnonymizeUsers), ctx, input)
+}
+
+// Close mocks base method.
+func (m *MockUserRepository) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockUserRepositoryMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockUserRepository)(nil).Close))
+}
+
 // CreateUser mocks base method.
 func (m *MockUserRepository) CreateUser(ctx context.Context, input CreateUserInput) (CreateUserOutput, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +93,21 @@ func (mr *MockUserRepositoryMockRecorder) CreateUser(ctx, input interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepository)(nil).CreateUser), ctx, input)
 }
 
+// DeleteAvatarDeletion mocks base method.
+func (m *MockUserRepository) DeleteAvatarDeletion(ctx context.Context, input DeleteAvatarDeletionInput) (DeleteAvatarDeletionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAvatarDeletion", ctx, input)
+	ret0, _ := ret[0].(DeleteAvatarDeletionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAvatarDeletion indicates an expected call of DeleteAvatarDeletion.
+func (mr *MockUserRepositoryMockRecorder) DeleteAvatarDeletion(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvatarDeletion", reflect.TypeOf((*MockUserRepository)(nil).DeleteAvatarDeletion), ctx, input)
+}
+
 // GetUser mocks base method.
 func (m *MockUserRepository) GetUser(ctx context.Context, input GetUserInput) (GetUserOutput, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +123,185 @@ func (mr *MockUserRepositoryMockRecorder) GetUser(ctx, input interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserRepository)(nil).GetUser), ctx, input)
 }
 
+// GetUserRoles mocks base method.
+func (m *MockUserRepository) GetUserRoles(ctx context.Context, input GetUserRolesInput) (GetUserRolesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoles", ctx, input)
+	ret0, _ := ret[0].(GetUserRolesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoles indicates an expected call of GetUserRoles.
+func (mr *MockUserRepositoryMockRecorder) GetUserRoles(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockUserRepository)(nil).GetUserRoles), ctx, input)
+}
+
+// IncrementUserLoginCount mocks base method.
+func (m *MockUserRepository) IncrementUserLoginCount(ctx context.Context, input IncrementUserLoginCountInput) (IncrementUserLoginCountOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementUserLoginCount", ctx, input)
+	ret0, _ := ret[0].(IncrementUserLoginCountOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementUserLoginCount indicates an expected call of IncrementUserLoginCount.
+func (mr *MockUserRepositoryMockRecorder) IncrementUserLoginCount(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementUserLoginCount", reflect.TypeOf((*MockUserRepository)(nil).IncrementUserLoginCount), ctx, input)
+}
+
+// ListAvatarDeletions mocks base method.
+func (m *MockUserRepository) ListAvatarDeletions(ctx context.Context, input ListAvatarDeletionsInput) (ListAvatarDeletionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvatarDeletions", ctx, input)
+	ret0, _ := ret[0].(ListAvatarDeletionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvatarDeletions indicates an expected call of ListAvatarDeletions.
+func (mr *MockUserRepositoryMockRecorder) ListAvatarDeletions(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvatarDeletions", reflect.TypeOf((*MockUserRepository)(nil).ListAvatarDeletions), ctx, input)
+}
+
+// ListUserPhoneNumbers mocks base method.
+func (m *MockUserRepository) ListUserPhoneNumbers(ctx context.Context, input ListUserPhoneNumbersInput) (ListUserPhoneNumbersOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserPhoneNumbers", ctx, input)
+	ret0, _ := ret[0].(ListUserPhoneNumbersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserPhoneNumbers indicates an expected call of ListUserPhoneNumbers.
+func (mr *MockUserRepositoryMockRecorder) ListUserPhoneNumbers(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPhoneNumbers", reflect.TypeOf((*MockUserRepository)(nil).ListUserPhoneNumbers), ctx, input)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserRepository) ListUsers(ctx context.Context, input ListUsersInput) (ListUsersOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, input)
+	ret0, _ := ret[0].(ListUsersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserRepositoryMockRecorder) ListUsers(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserRepository)(nil).ListUsers), ctx, input)
+}
+
+// Ping mocks base method.
+func (m *MockUserRepository) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockUserRepositoryMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockUserRepository)(nil).Ping), ctx)
+}
+
+// ResetUserPINFailures mocks base method.
+func (m *MockUserRepository) ResetUserPINFailures(ctx context.Context, input ResetUserPINFailuresInput) (ResetUserPINFailuresOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetUserPINFailures", ctx, input)
+	ret0, _ := ret[0].(ResetUserPINFailuresOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetUserPINFailures indicates an expected call of ResetUserPINFailures.
+func (mr *MockUserRepositoryMockRecorder) ResetUserPINFailures(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserPINFailures", reflect.TypeOf((*MockUserRepository)(nil).ResetUserPINFailures), ctx, input)
+}
+
+// SetUserAvatar mocks base method.
+func (m *MockUserRepository) SetUserAvatar(ctx context.Context, input SetUserAvatarInput) (SetUserAvatarOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserAvatar", ctx, input)
+	ret0, _ := ret[0].(SetUserAvatarOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserAvatar indicates an expected call of SetUserAvatar.
+func (mr *MockUserRepositoryMockRecorder) SetUserAvatar(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserAvatar", reflect.TypeOf((*MockUserRepository)(nil).SetUserAvatar), ctx, input)
+}
+
+// SetUserDeletionRequest mocks base method.
+func (m *MockUserRepository) SetUserDeletionRequest(ctx context.Context, input SetUserDeletionRequestInput) (SetUserDeletionRequestOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserDeletionRequest", ctx, input)
+	ret0, _ := ret[0].(SetUserDeletionRequestOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserDeletionRequest indicates an expected call of SetUserDeletionRequest.
+func (mr *MockUserRepositoryMockRecorder) SetUserDeletionRequest(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDeletionRequest", reflect.TypeOf((*MockUserRepository)(nil).SetUserDeletionRequest), ctx, input)
+}
+
+// SetUserPIN mocks base method.
+func (m *MockUserRepository) SetUserPIN(ctx context.Context, input SetUserPINInput) (SetUserPINOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserPIN", ctx, input)
+	ret0, _ := ret[0].(SetUserPINOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserPIN indicates an expected call of SetUserPIN.
+func (mr *MockUserRepositoryMockRecorder) SetUserPIN(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserPIN", reflect.TypeOf((*MockUserRepository)(nil).SetUserPIN), ctx, input)
+}
+
+// SetUserRoles mocks base method.
+func (m *MockUserRepository) SetUserRoles(ctx context.Context, input SetUserRolesInput) (SetUserRolesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRoles", ctx, input)
+	ret0, _ := ret[0].(SetUserRolesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserRoles indicates an expected call of SetUserRoles.
+func (mr *MockUserRepositoryMockRecorder) SetUserRoles(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRoles", reflect.TypeOf((*MockUserRepository)(nil).SetUserRoles), ctx, input)
+}
+
+// SetUserStatus mocks base method.
+func (m *MockUserRepository) SetUserStatus(ctx context.Context, input SetUserStatusInput) (SetUserStatusOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserStatus", ctx, input)
+	ret0, _ := ret[0].(SetUserStatusOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserStatus indicates an expected call of SetUserStatus.
+func (mr *MockUserRepositoryMockRecorder) SetUserStatus(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserStatus", reflect.TypeOf((*MockUserRepository)(nil).SetUserStatus), ctx, input)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserRepository) UpdateUser(ctx context.Context, input UpdateUserInput) (UpdateUserOutput, error) {
 	m.ctrl.T.Helper()
@@ -78,3 +316,660 @@ func (mr *MockUserRepositoryMockRecorder) UpdateUser(ctx, input interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserRepository)(nil).UpdateUser), ctx, input)
 }
+
+// VerifyUserEmail mocks base method.
+func (m *MockUserRepository) VerifyUserEmail(ctx context.Context, input VerifyUserEmailInput) (VerifyUserEmailOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUserEmail", ctx, input)
+	ret0, _ := ret[0].(VerifyUserEmailOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyUserEmail indicates an expected call of VerifyUserEmail.
+func (mr *MockUserRepositoryMockRecorder) VerifyUserEmail(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserEmail", reflect.TypeOf((*MockUserRepository)(nil).VerifyUserEmail), ctx, input)
+}
+
+// MockTokenRepository is a mock of TokenRepository interface.
+type MockTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRepositoryMockRecorder
+}
+
+// MockTokenRepositoryMockRecorder is the mock recorder for MockTokenRepository.
+type MockTokenRepositoryMockRecorder struct {
+	mock *MockTokenRepository
+}
+
+// NewMockTokenRepository creates a new mock instance.
+func NewMockTokenRepository(ctrl *gomock.Controller) *MockTokenRepository {
+	mock := &MockTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRepository) EXPECT() *MockTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTokenRevocation mocks base method.
+func (m *MockTokenRepository) CreateTokenRevocation(ctx context.Context, input CreateTokenRevocationInput) (CreateTokenRevocationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTokenRevocation", ctx, input)
+	ret0, _ := ret[0].(CreateTokenRevocationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTokenRevocation indicates an expected call of CreateTokenRevocation.
+func (mr *MockTokenRepositoryMockRecorder) CreateTokenRevocation(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTokenRevocation", reflect.TypeOf((*MockTokenRepository)(nil).CreateTokenRevocation), ctx, input)
+}
+
+// GetTokenRevocation mocks base method.
+func (m *MockTokenRepository) GetTokenRevocation(ctx context.Context, input GetTokenRevocationInput) (GetTokenRevocationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenRevocation", ctx, input)
+	ret0, _ := ret[0].(GetTokenRevocationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenRevocation indicates an expected call of GetTokenRevocation.
+func (mr *MockTokenRepositoryMockRecorder) GetTokenRevocation(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenRevocation", reflect.TypeOf((*MockTokenRepository)(nil).GetTokenRevocation), ctx, input)
+}
+
+// MockOAuthClientRepository is a mock of OAuthClientRepository interface.
+type MockOAuthClientRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOAuthClientRepositoryMockRecorder
+}
+
+// MockOAuthClientRepositoryMockRecorder is the mock recorder for MockOAuthClientRepository.
+type MockOAuthClientRepositoryMockRecorder struct {
+	mock *MockOAuthClientRepository
+}
+
+// NewMockOAuthClientRepository creates a new mock instance.
+func NewMockOAuthClientRepository(ctrl *gomock.Controller) *MockOAuthClientRepository {
+	mock := &MockOAuthClientRepository{ctrl: ctrl}
+	mock.recorder = &MockOAuthClientRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOAuthClientRepository) EXPECT() *MockOAuthClientRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetOAuthClient mocks base method.
+func (m *MockOAuthClientRepository) GetOAuthClient(ctx context.Context, input GetOAuthClientInput) (GetOAuthClientOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuthClient", ctx, input)
+	ret0, _ := ret[0].(GetOAuthClientOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthClient indicates an expected call of GetOAuthClient.
+func (mr *MockOAuthClientRepositoryMockRecorder) GetOAuthClient(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthClient", reflect.TypeOf((*MockOAuthClientRepository)(nil).GetOAuthClient), ctx, input)
+}
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAuditEvent mocks base method.
+func (m *MockAuditRepository) CreateAuditEvent(ctx context.Context, input CreateAuditEventInput) (CreateAuditEventOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEvent", ctx, input)
+	ret0, _ := ret[0].(CreateAuditEventOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditEvent indicates an expected call of CreateAuditEvent.
+func (mr *MockAuditRepositoryMockRecorder) CreateAuditEvent(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEvent", reflect.TypeOf((*MockAuditRepository)(nil).CreateAuditEvent), ctx, input)
+}
+
+// ListAuditEvents mocks base method.
+func (m *MockAuditRepository) ListAuditEvents(ctx context.Context, input ListAuditEventsInput) (ListAuditEventsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEvents", ctx, input)
+	ret0, _ := ret[0].(ListAuditEventsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockAuditRepositoryMockRecorder) ListAuditEvents(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockAuditRepository)(nil).ListAuditEvents), ctx, input)
+}
+
+// MockUserExportRepository is a mock of UserExportRepository interface.
+type MockUserExportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserExportRepositoryMockRecorder
+}
+
+// MockUserExportRepositoryMockRecorder is the mock recorder for MockUserExportRepository.
+type MockUserExportRepositoryMockRecorder struct {
+	mock *MockUserExportRepository
+}
+
+// NewMockUserExportRepository creates a new mock instance.
+func NewMockUserExportRepository(ctrl *gomock.Controller) *MockUserExportRepository {
+	mock := &MockUserExportRepository{ctrl: ctrl}
+	mock.recorder = &MockUserExportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserExportRepository) EXPECT() *MockUserExportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimUserExports mocks base method.
+func (m *MockUserExportRepository) ClaimUserExports(ctx context.Context, input ClaimUserExportsInput) (ClaimUserExportsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimUserExports", ctx, input)
+	ret0, _ := ret[0].(ClaimUserExportsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimUserExports indicates an expected call of ClaimUserExports.
+func (mr *MockUserExportRepositoryMockRecorder) ClaimUserExports(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimUserExports", reflect.TypeOf((*MockUserExportRepository)(nil).ClaimUserExports), ctx, input)
+}
+
+// CompleteUserExport mocks base method.
+func (m *MockUserExportRepository) CompleteUserExport(ctx context.Context, input CompleteUserExportInput) (CompleteUserExportOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteUserExport", ctx, input)
+	ret0, _ := ret[0].(CompleteUserExportOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteUserExport indicates an expected call of CompleteUserExport.
+func (mr *MockUserExportRepositoryMockRecorder) CompleteUserExport(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUserExport", reflect.TypeOf((*MockUserExportRepository)(nil).CompleteUserExport), ctx, input)
+}
+
+// CreateUserExport mocks base method.
+func (m *MockUserExportRepository) CreateUserExport(ctx context.Context, input CreateUserExportInput) (CreateUserExportOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserExport", ctx, input)
+	ret0, _ := ret[0].(CreateUserExportOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserExport indicates an expected call of CreateUserExport.
+func (mr *MockUserExportRepositoryMockRecorder) CreateUserExport(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserExport", reflect.TypeOf((*MockUserExportRepository)(nil).CreateUserExport), ctx, input)
+}
+
+// DeleteExpiredUserExports mocks base method.
+func (m *MockUserExportRepository) DeleteExpiredUserExports(ctx context.Context, input DeleteExpiredUserExportsInput) (DeleteExpiredUserExportsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredUserExports", ctx, input)
+	ret0, _ := ret[0].(DeleteExpiredUserExportsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredUserExports indicates an expected call of DeleteExpiredUserExports.
+func (mr *MockUserExportRepositoryMockRecorder) DeleteExpiredUserExports(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredUserExports", reflect.TypeOf((*MockUserExportRepository)(nil).DeleteExpiredUserExports), ctx, input)
+}
+
+// GetUserExport mocks base method.
+func (m *MockUserExportRepository) GetUserExport(ctx context.Context, input GetUserExportInput) (GetUserExportOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserExport", ctx, input)
+	ret0, _ := ret[0].(GetUserExportOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserExport indicates an expected call of GetUserExport.
+func (mr *MockUserExportRepositoryMockRecorder) GetUserExport(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserExport", reflect.TypeOf((*MockUserExportRepository)(nil).GetUserExport), ctx, input)
+}
+
+// MockLoginOTPRepository is a mock of LoginOTPRepository interface.
+type MockLoginOTPRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginOTPRepositoryMockRecorder
+}
+
+// MockLoginOTPRepositoryMockRecorder is the mock recorder for MockLoginOTPRepository.
+type MockLoginOTPRepositoryMockRecorder struct {
+	mock *MockLoginOTPRepository
+}
+
+// NewMockLoginOTPRepository creates a new mock instance.
+func NewMockLoginOTPRepository(ctrl *gomock.Controller) *MockLoginOTPRepository {
+	mock := &MockLoginOTPRepository{ctrl: ctrl}
+	mock.recorder = &MockLoginOTPRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginOTPRepository) EXPECT() *MockLoginOTPRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddLoginOTPAttempt mocks base method.
+func (m *MockLoginOTPRepository) AddLoginOTPAttempt(ctx context.Context, input AddLoginOTPAttemptInput) (AddLoginOTPAttemptOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLoginOTPAttempt", ctx, input)
+	ret0, _ := ret[0].(AddLoginOTPAttemptOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLoginOTPAttempt indicates an expected call of AddLoginOTPAttempt.
+func (mr *MockLoginOTPRepositoryMockRecorder) AddLoginOTPAttempt(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLoginOTPAttempt", reflect.TypeOf((*MockLoginOTPRepository)(nil).AddLoginOTPAttempt), ctx, input)
+}
+
+// ConsumeLoginOTP mocks base method.
+func (m *MockLoginOTPRepository) ConsumeLoginOTP(ctx context.Context, input ConsumeLoginOTPInput) (ConsumeLoginOTPOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeLoginOTP", ctx, input)
+	ret0, _ := ret[0].(ConsumeLoginOTPOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeLoginOTP indicates an expected call of ConsumeLoginOTP.
+func (mr *MockLoginOTPRepositoryMockRecorder) ConsumeLoginOTP(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeLoginOTP", reflect.TypeOf((*MockLoginOTPRepository)(nil).ConsumeLoginOTP), ctx, input)
+}
+
+// CountLoginOTPs mocks base method.
+func (m *MockLoginOTPRepository) CountLoginOTPs(ctx context.Context, input CountLoginOTPsInput) (CountLoginOTPsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountLoginOTPs", ctx, input)
+	ret0, _ := ret[0].(CountLoginOTPsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountLoginOTPs indicates an expected call of CountLoginOTPs.
+func (mr *MockLoginOTPRepositoryMockRecorder) CountLoginOTPs(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountLoginOTPs", reflect.TypeOf((*MockLoginOTPRepository)(nil).CountLoginOTPs), ctx, input)
+}
+
+// CreateLoginOTP mocks base method.
+func (m *MockLoginOTPRepository) CreateLoginOTP(ctx context.Context, input CreateLoginOTPInput) (CreateLoginOTPOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoginOTP", ctx, input)
+	ret0, _ := ret[0].(CreateLoginOTPOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLoginOTP indicates an expected call of CreateLoginOTP.
+func (mr *MockLoginOTPRepositoryMockRecorder) CreateLoginOTP(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoginOTP", reflect.TypeOf((*MockLoginOTPRepository)(nil).CreateLoginOTP), ctx, input)
+}
+
+// GetLatestLoginOTP mocks base method.
+func (m *MockLoginOTPRepository) GetLatestLoginOTP(ctx context.Context, input GetLatestLoginOTPInput) (GetLatestLoginOTPOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestLoginOTP", ctx, input)
+	ret0, _ := ret[0].(GetLatestLoginOTPOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestLoginOTP indicates an expected call of GetLatestLoginOTP.
+func (mr *MockLoginOTPRepositoryMockRecorder) GetLatestLoginOTP(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestLoginOTP", reflect.TypeOf((*MockLoginOTPRepository)(nil).GetLatestLoginOTP), ctx, input)
+}
+
+// MockPhoneChangeRepository is a mock of PhoneChangeRepository interface.
+type MockPhoneChangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPhoneChangeRepositoryMockRecorder
+}
+
+// MockPhoneChangeRepositoryMockRecorder is the mock recorder for MockPhoneChangeRepository.
+type MockPhoneChangeRepositoryMockRecorder struct {
+	mock *MockPhoneChangeRepository
+}
+
+// NewMockPhoneChangeRepository creates a new mock instance.
+func NewMockPhoneChangeRepository(ctrl *gomock.Controller) *MockPhoneChangeRepository {
+	mock := &MockPhoneChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockPhoneChangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPhoneChangeRepository) EXPECT() *MockPhoneChangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddPhoneChangeAttempt mocks base method.
+func (m *MockPhoneChangeRepository) AddPhoneChangeAttempt(ctx context.Context, input AddPhoneChangeAttemptInput) (AddPhoneChangeAttemptOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPhoneChangeAttempt", ctx, input)
+	ret0, _ := ret[0].(AddPhoneChangeAttemptOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPhoneChangeAttempt indicates an expected call of AddPhoneChangeAttempt.
+func (mr *MockPhoneChangeRepositoryMockRecorder) AddPhoneChangeAttempt(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPhoneChangeAttempt", reflect.TypeOf((*MockPhoneChangeRepository)(nil).AddPhoneChangeAttempt), ctx, input)
+}
+
+// ConfirmPhoneChange mocks base method.
+func (m *MockPhoneChangeRepository) ConfirmPhoneChange(ctx context.Context, input ConfirmPhoneChangeInput) (ConfirmPhoneChangeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPhoneChange", ctx, input)
+	ret0, _ := ret[0].(ConfirmPhoneChangeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPhoneChange indicates an expected call of ConfirmPhoneChange.
+func (mr *MockPhoneChangeRepositoryMockRecorder) ConfirmPhoneChange(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPhoneChange", reflect.TypeOf((*MockPhoneChangeRepository)(nil).ConfirmPhoneChange), ctx, input)
+}
+
+// CountPhoneChanges mocks base method.
+func (m *MockPhoneChangeRepository) CountPhoneChanges(ctx context.Context, input CountPhoneChangesInput) (CountPhoneChangesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPhoneChanges", ctx, input)
+	ret0, _ := ret[0].(CountPhoneChangesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPhoneChanges indicates an expected call of CountPhoneChanges.
+func (mr *MockPhoneChangeRepositoryMockRecorder) CountPhoneChanges(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPhoneChanges", reflect.TypeOf((*MockPhoneChangeRepository)(nil).CountPhoneChanges), ctx, input)
+}
+
+// CreatePhoneChange mocks base method.
+func (m *MockPhoneChangeRepository) CreatePhoneChange(ctx context.Context, input CreatePhoneChangeInput) (CreatePhoneChangeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePhoneChange", ctx, input)
+	ret0, _ := ret[0].(CreatePhoneChangeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePhoneChange indicates an expected call of CreatePhoneChange.
+func (mr *MockPhoneChangeRepositoryMockRecorder) CreatePhoneChange(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePhoneChange", reflect.TypeOf((*MockPhoneChangeRepository)(nil).CreatePhoneChange), ctx, input)
+}
+
+// GetLatestPhoneChange mocks base method.
+func (m *MockPhoneChangeRepository) GetLatestPhoneChange(ctx context.Context, input GetLatestPhoneChangeInput) (GetLatestPhoneChangeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestPhoneChange", ctx, input)
+	ret0, _ := ret[0].(GetLatestPhoneChangeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestPhoneChange indicates an expected call of GetLatestPhoneChange.
+func (mr *MockPhoneChangeRepositoryMockRecorder) GetLatestPhoneChange(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestPhoneChange", reflect.TypeOf((*MockPhoneChangeRepository)(nil).GetLatestPhoneChange), ctx, input)
+}
+
+// GetPhoneNoReservation mocks base method.
+func (m *MockPhoneChangeRepository) GetPhoneNoReservation(ctx context.Context, input GetPhoneNoReservationInput) (GetPhoneNoReservationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPhoneNoReservation", ctx, input)
+	ret0, _ := ret[0].(GetPhoneNoReservationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPhoneNoReservation indicates an expected call of GetPhoneNoReservation.
+func (mr *MockPhoneChangeRepositoryMockRecorder) GetPhoneNoReservation(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhoneNoReservation", reflect.TypeOf((*MockPhoneChangeRepository)(nil).GetPhoneNoReservation), ctx, input)
+}
+
+// ListPhoneChanges mocks base method.
+func (m *MockPhoneChangeRepository) ListPhoneChanges(ctx context.Context, input ListPhoneChangesInput) (ListPhoneChangesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPhoneChanges", ctx, input)
+	ret0, _ := ret[0].(ListPhoneChangesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPhoneChanges indicates an expected call of ListPhoneChanges.
+func (mr *MockPhoneChangeRepositoryMockRecorder) ListPhoneChanges(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPhoneChanges", reflect.TypeOf((*MockPhoneChangeRepository)(nil).ListPhoneChanges), ctx, input)
+}
+
+// MockUserDeviceRepository is a mock of UserDeviceRepository interface.
+type MockUserDeviceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserDeviceRepositoryMockRecorder
+}
+
+// MockUserDeviceRepositoryMockRecorder is the mock recorder for MockUserDeviceRepository.
+type MockUserDeviceRepositoryMockRecorder struct {
+	mock *MockUserDeviceRepository
+}
+
+// NewMockUserDeviceRepository creates a new mock instance.
+func NewMockUserDeviceRepository(ctrl *gomock.Controller) *MockUserDeviceRepository {
+	mock := &MockUserDeviceRepository{ctrl: ctrl}
+	mock.recorder = &MockUserDeviceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserDeviceRepository) EXPECT() *MockUserDeviceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateUserDevice mocks base method.
+func (m *MockUserDeviceRepository) CreateUserDevice(ctx context.Context, input CreateUserDeviceInput) (CreateUserDeviceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserDevice", ctx, input)
+	ret0, _ := ret[0].(CreateUserDeviceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserDevice indicates an expected call of CreateUserDevice.
+func (mr *MockUserDeviceRepositoryMockRecorder) CreateUserDevice(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserDevice", reflect.TypeOf((*MockUserDeviceRepository)(nil).CreateUserDevice), ctx, input)
+}
+
+// GetUserDevice mocks base method.
+func (m *MockUserDeviceRepository) GetUserDevice(ctx context.Context, input GetUserDeviceInput) (GetUserDeviceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDevice", ctx, input)
+	ret0, _ := ret[0].(GetUserDeviceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDevice indicates an expected call of GetUserDevice.
+func (mr *MockUserDeviceRepositoryMockRecorder) GetUserDevice(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDevice", reflect.TypeOf((*MockUserDeviceRepository)(nil).GetUserDevice), ctx, input)
+}
+
+// ListUserDevices mocks base method.
+func (m *MockUserDeviceRepository) ListUserDevices(ctx context.Context, input ListUserDevicesInput) (ListUserDevicesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserDevices", ctx, input)
+	ret0, _ := ret[0].(ListUserDevicesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserDevices indicates an expected call of ListUserDevices.
+func (mr *MockUserDeviceRepositoryMockRecorder) ListUserDevices(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserDevices", reflect.TypeOf((*MockUserDeviceRepository)(nil).ListUserDevices), ctx, input)
+}
+
+// MockAttributeSchemaRepository is a mock of AttributeSchemaRepository interface.
+type MockAttributeSchemaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttributeSchemaRepositoryMockRecorder
+}
+
+// MockAttributeSchemaRepositoryMockRecorder is the mock recorder for MockAttributeSchemaRepository.
+type MockAttributeSchemaRepositoryMockRecorder struct {
+	mock *MockAttributeSchemaRepository
+}
+
+// NewMockAttributeSchemaRepository creates a new mock instance.
+func NewMockAttributeSchemaRepository(ctrl *gomock.Controller) *MockAttributeSchemaRepository {
+	mock := &MockAttributeSchemaRepository{ctrl: ctrl}
+	mock.recorder = &MockAttributeSchemaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttributeSchemaRepository) EXPECT() *MockAttributeSchemaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAttributeSchema mocks base method.
+func (m *MockAttributeSchemaRepository) CreateAttributeSchema(ctx context.Context, input CreateAttributeSchemaInput) (CreateAttributeSchemaOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttributeSchema", ctx, input)
+	ret0, _ := ret[0].(CreateAttributeSchemaOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttributeSchema indicates an expected call of CreateAttributeSchema.
+func (mr *MockAttributeSchemaRepositoryMockRecorder) CreateAttributeSchema(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttributeSchema", reflect.TypeOf((*MockAttributeSchemaRepository)(nil).CreateAttributeSchema), ctx, input)
+}
+
+// GetLatestAttributeSchema mocks base method.
+func (m *MockAttributeSchemaRepository) GetLatestAttributeSchema(ctx context.Context, input GetLatestAttributeSchemaInput) (GetLatestAttributeSchemaOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestAttributeSchema", ctx, input)
+	ret0, _ := ret[0].(GetLatestAttributeSchemaOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestAttributeSchema indicates an expected call of GetLatestAttributeSchema.
+func (mr *MockAttributeSchemaRepositoryMockRecorder) GetLatestAttributeSchema(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestAttributeSchema", reflect.TypeOf((*MockAttributeSchemaRepository)(nil).GetLatestAttributeSchema), ctx, input)
+}
+
+// MockUserChangeRepository is a mock of UserChangeRepository interface.
+type MockUserChangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserChangeRepositoryMockRecorder
+}
+
+// MockUserChangeRepositoryMockRecorder is the mock recorder for MockUserChangeRepository.
+type MockUserChangeRepositoryMockRecorder struct {
+	mock *MockUserChangeRepository
+}
+
+// NewMockUserChangeRepository creates a new mock instance.
+func NewMockUserChangeRepository(ctrl *gomock.Controller) *MockUserChangeRepository {
+	mock := &MockUserChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockUserChangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserChangeRepository) EXPECT() *MockUserChangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateUserChanges mocks base method.
+func (m *MockUserChangeRepository) CreateUserChanges(ctx context.Context, input CreateUserChangesInput) (CreateUserChangesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserChanges", ctx, input)
+	ret0, _ := ret[0].(CreateUserChangesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserChanges indicates an expected call of CreateUserChanges.
+func (mr *MockUserChangeRepositoryMockRecorder) CreateUserChanges(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserChanges", reflect.TypeOf((*MockUserChangeRepository)(nil).CreateUserChanges), ctx, input)
+}
+
+// ListUserChanges mocks base method.
+func (m *MockUserChangeRepository) ListUserChanges(ctx context.Context, input ListUserChangesInput) (ListUserChangesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserChanges", ctx, input)
+	ret0, _ := ret[0].(ListUserChangesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserChanges indicates an expected call of ListUserChanges.
+func (mr *MockUserChangeRepositoryMockRecorder) ListUserChanges(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserChanges", reflect.TypeOf((*MockUserChangeRepository)(nil).ListUserChanges), ctx, input)
+}
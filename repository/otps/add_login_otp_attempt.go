@@ -0,0 +1,27 @@
+package otps
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	// the attempt is only recorded while below the limit, so concurrent verifications can't exceed it
+	addLoginOTPAttemptQuery = `UPDATE login_otps SET attempts=attempts+1 WHERE id=$1 AND attempts<$2 AND consumed_at IS NULL RETURNING attempts;`
+)
+
+func (o *loginOTPRepository) AddLoginOTPAttempt(ctx context.Context, input repository.AddLoginOTPAttemptInput) (output repository.AddLoginOTPAttemptOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "LoginOTPRepository", "AddLoginOTPAttempt")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := o.db.QueryRowContext(ctx, addLoginOTPAttemptQuery, input.ID, input.MaxAttempts)
+	if err = row.Scan(&output.Attempts); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrorRecordNotFound
+		}
+		return repository.AddLoginOTPAttemptOutput{}, err
+	}
+	return output, nil
+}
@@ -0,0 +1,74 @@
+package otps
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type AddLoginOTPAttemptTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.LoginOTPRepository
+
+	input repository.AddLoginOTPAttemptInput
+	ctx   context.Context
+}
+
+func TestAddLoginOTPAttemptTestSuite(t *testing.T) {
+	suite.Run(t, new(AddLoginOTPAttemptTestSuite))
+}
+
+func (s *AddLoginOTPAttemptTestSuite) SetupTest() {
+	repo := &loginOTPRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.AddLoginOTPAttemptInput{ID: 42, MaxAttempts: 5}
+	s.ctx = context.Background()
+}
+
+func (s *AddLoginOTPAttemptTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *AddLoginOTPAttemptTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(addLoginOTPAttemptQuery)).WithArgs(s.input.ID, s.input.MaxAttempts).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.AddLoginOTPAttempt(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *AddLoginOTPAttemptTestSuite) TestNoAttemptsLeft() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(addLoginOTPAttemptQuery)).WithArgs(s.input.ID, s.input.MaxAttempts).
+		WillReturnRows(sqlmock.NewRows([]string{"attempts"}))
+	res, err := s.repo.AddLoginOTPAttempt(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *AddLoginOTPAttemptTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(addLoginOTPAttemptQuery)).WithArgs(s.input.ID, s.input.MaxAttempts).
+		WillReturnRows(sqlmock.NewRows([]string{"attempts"}).AddRow(2))
+	res, err := s.repo.AddLoginOTPAttempt(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(2, res.Attempts)
+}
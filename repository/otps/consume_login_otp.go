@@ -0,0 +1,28 @@
+package otps
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	consumeLoginOTPQuery = `UPDATE login_otps SET consumed_at=now() WHERE id=$1 AND consumed_at IS NULL;`
+)
+
+func (o *loginOTPRepository) ConsumeLoginOTP(ctx context.Context, input repository.ConsumeLoginOTPInput) (output repository.ConsumeLoginOTPOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "LoginOTPRepository", "ConsumeLoginOTP")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var result sql.Result
+	if result, err = o.db.ExecContext(ctx, consumeLoginOTPQuery, input.ID); err != nil {
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected <= 0 {
+		err = repository.ErrorRecordNotFound
+		return
+	}
+
+	return output, nil
+}
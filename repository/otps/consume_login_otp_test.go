@@ -0,0 +1,71 @@
+package otps
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type ConsumeLoginOTPTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.LoginOTPRepository
+
+	input repository.ConsumeLoginOTPInput
+	ctx   context.Context
+}
+
+func TestConsumeLoginOTPTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsumeLoginOTPTestSuite))
+}
+
+func (s *ConsumeLoginOTPTestSuite) SetupTest() {
+	repo := &loginOTPRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.ConsumeLoginOTPInput{ID: 42}
+	s.ctx = context.Background()
+}
+
+func (s *ConsumeLoginOTPTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *ConsumeLoginOTPTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(consumeLoginOTPQuery)).WithArgs(s.input.ID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	_, err := s.repo.ConsumeLoginOTP(s.ctx, s.input)
+
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *ConsumeLoginOTPTestSuite) TestAlreadyConsumed() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(consumeLoginOTPQuery)).WithArgs(s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err := s.repo.ConsumeLoginOTP(s.ctx, s.input)
+
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *ConsumeLoginOTPTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(consumeLoginOTPQuery)).WithArgs(s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := s.repo.ConsumeLoginOTP(s.ctx, s.input)
+
+	a.Empty(err)
+}
@@ -0,0 +1,22 @@
+package otps
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	countLoginOTPsQuery = `SELECT count(*) FILTER (WHERE phone_no=$1), count(*) FILTER (WHERE ip_address=$2) FROM login_otps ` +
+		`WHERE (phone_no=$1 OR ip_address=$2) AND created_at>$3;`
+)
+
+func (o *loginOTPRepository) CountLoginOTPs(ctx context.Context, input repository.CountLoginOTPsInput) (output repository.CountLoginOTPsOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "LoginOTPRepository", "CountLoginOTPs")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := o.db.QueryRowContext(ctx, countLoginOTPsQuery, input.PhoneNo, input.IPAddress, input.CreatedAfter)
+	if err = row.Scan(&output.PhoneNoCount, &output.IPAddressCount); err != nil {
+		return repository.CountLoginOTPsOutput{}, err
+	}
+	return output, nil
+}
@@ -0,0 +1,70 @@
+package otps
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type CountLoginOTPsTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.LoginOTPRepository
+
+	input repository.CountLoginOTPsInput
+	ctx   context.Context
+}
+
+func TestCountLoginOTPsTestSuite(t *testing.T) {
+	suite.Run(t, new(CountLoginOTPsTestSuite))
+}
+
+func (s *CountLoginOTPsTestSuite) SetupTest() {
+	repo := &loginOTPRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.CountLoginOTPsInput{
+		PhoneNo:      "+6281234567890",
+		IPAddress:    "10.0.0.1",
+		CreatedAfter: time.Date(2024, 3, 16, 9, 0, 0, 0, time.UTC),
+	}
+	s.ctx = context.Background()
+}
+
+func (s *CountLoginOTPsTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *CountLoginOTPsTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(countLoginOTPsQuery)).
+		WithArgs(s.input.PhoneNo, s.input.IPAddress, s.input.CreatedAfter).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.CountLoginOTPs(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *CountLoginOTPsTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(countLoginOTPsQuery)).
+		WithArgs(s.input.PhoneNo, s.input.IPAddress, s.input.CreatedAfter).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "count"}).AddRow(2, 7))
+	res, err := s.repo.CountLoginOTPs(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(repository.CountLoginOTPsOutput{PhoneNoCount: 2, IPAddressCount: 7}, res)
+}
@@ -0,0 +1,21 @@
+package otps
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	createLoginOTPQuery = `INSERT INTO login_otps (phone_no, code_hash, ip_address, expires_at) VALUES ($1, $2, $3, $4) RETURNING id;`
+)
+
+func (o *loginOTPRepository) CreateLoginOTP(ctx context.Context, input repository.CreateLoginOTPInput) (output repository.CreateLoginOTPOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "LoginOTPRepository", "CreateLoginOTP")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := o.db.QueryRowContext(ctx, createLoginOTPQuery, input.PhoneNo, input.CodeHash, input.IPAddress, input.ExpiresAt)
+	if err = row.Scan(&output.ID); err != nil {
+		return repository.CreateLoginOTPOutput{}, err
+	}
+	return output, nil
+}
@@ -0,0 +1,71 @@
+package otps
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type CreateLoginOTPTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.LoginOTPRepository
+
+	input repository.CreateLoginOTPInput
+	ctx   context.Context
+}
+
+func TestCreateLoginOTPTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateLoginOTPTestSuite))
+}
+
+func (s *CreateLoginOTPTestSuite) SetupTest() {
+	repo := &loginOTPRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.CreateLoginOTPInput{
+		PhoneNo:   "+6281234567890",
+		CodeHash:  []byte("code-hash"),
+		IPAddress: "10.0.0.1",
+		ExpiresAt: time.Date(2024, 3, 16, 10, 0, 0, 0, time.UTC),
+	}
+	s.ctx = context.Background()
+}
+
+func (s *CreateLoginOTPTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *CreateLoginOTPTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createLoginOTPQuery)).
+		WithArgs(s.input.PhoneNo, s.input.CodeHash, s.input.IPAddress, s.input.ExpiresAt).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.CreateLoginOTP(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *CreateLoginOTPTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createLoginOTPQuery)).
+		WithArgs(s.input.PhoneNo, s.input.CodeHash, s.input.IPAddress, s.input.ExpiresAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	res, err := s.repo.CreateLoginOTP(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(uint64(42), res.ID)
+}
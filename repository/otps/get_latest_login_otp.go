@@ -0,0 +1,27 @@
+package otps
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	getLatestLoginOTPQuery = `SELECT id, phone_no, code_hash, attempts, created_at, expires_at FROM login_otps ` +
+		`WHERE phone_no=$1 AND consumed_at IS NULL ORDER BY id DESC LIMIT 1;`
+)
+
+func (o *loginOTPRepository) GetLatestLoginOTP(ctx context.Context, input repository.GetLatestLoginOTPInput) (output repository.GetLatestLoginOTPOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "LoginOTPRepository", "GetLatestLoginOTP")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := o.db.QueryRowContext(ctx, getLatestLoginOTPQuery, input.PhoneNo)
+	if err = row.Scan(&output.ID, &output.PhoneNo, &output.CodeHash, &output.Attempts, &output.CreatedAt, &output.ExpiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrorRecordNotFound
+		}
+		return repository.GetLatestLoginOTPOutput{}, err
+	}
+	return output, nil
+}
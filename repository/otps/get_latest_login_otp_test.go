@@ -0,0 +1,88 @@
+package otps
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type GetLatestLoginOTPTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.LoginOTPRepository
+
+	input   repository.GetLatestLoginOTPInput
+	output  repository.GetLatestLoginOTPOutput
+	columns []string
+	ctx     context.Context
+}
+
+func TestGetLatestLoginOTPTestSuite(t *testing.T) {
+	suite.Run(t, new(GetLatestLoginOTPTestSuite))
+}
+
+func (s *GetLatestLoginOTPTestSuite) SetupTest() {
+	repo := &loginOTPRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.GetLatestLoginOTPInput{PhoneNo: "+6281234567890"}
+	s.output = repository.GetLatestLoginOTPOutput{
+		ID:        42,
+		PhoneNo:   "+6281234567890",
+		CodeHash:  []byte("code-hash"),
+		Attempts:  1,
+		CreatedAt: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC),
+		ExpiresAt: time.Date(2024, 3, 16, 10, 0, 0, 0, time.UTC),
+	}
+	s.columns = []string{"id", "phone_no", "code_hash", "attempts", "created_at", "expires_at"}
+	s.ctx = context.Background()
+}
+
+func (s *GetLatestLoginOTPTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *GetLatestLoginOTPTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getLatestLoginOTPQuery)).WithArgs(s.input.PhoneNo).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.GetLatestLoginOTP(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *GetLatestLoginOTPTestSuite) TestNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getLatestLoginOTPQuery)).WithArgs(s.input.PhoneNo).
+		WillReturnRows(sqlmock.NewRows(s.columns))
+	res, err := s.repo.GetLatestLoginOTP(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *GetLatestLoginOTPTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getLatestLoginOTPQuery)).WithArgs(s.input.PhoneNo).
+		WillReturnRows(sqlmock.NewRows(s.columns).AddRow(
+			s.output.ID, s.output.PhoneNo, s.output.CodeHash, s.output.Attempts, s.output.CreatedAt, s.output.ExpiresAt,
+		))
+	res, err := s.repo.GetLatestLoginOTP(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, res)
+}
@@ -0,0 +1,23 @@
+// This file contains the repository implementation layer.
+package otps
+
+import (
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+// loginOTPRepository is a postgresSQL implementation of repository.LoginOTPRepository
+type loginOTPRepository struct {
+	db *sql.DB
+}
+
+func NewLoginOTPRepository(opts repository.NewRepositoryOptions) (repository.LoginOTPRepository, error) {
+	db, err := repository.OpenDatabase(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &loginOTPRepository{
+		db: db,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package phonechanges
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	// the attempt is only recorded while below the limit, so concurrent confirmations can't exceed it
+	addPhoneChangeAttemptQuery = `UPDATE user_phone_changes SET attempts=attempts+1 WHERE id=$1 AND attempts<$2 AND confirmed_at IS NULL RETURNING attempts;`
+)
+
+func (p *phoneChangeRepository) AddPhoneChangeAttempt(ctx context.Context, input repository.AddPhoneChangeAttemptInput) (output repository.AddPhoneChangeAttemptOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "PhoneChangeRepository", "AddPhoneChangeAttempt")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := p.db.QueryRowContext(ctx, addPhoneChangeAttemptQuery, input.ID, input.MaxAttempts)
+	if err = row.Scan(&output.Attempts); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrorRecordNotFound
+		}
+		return repository.AddPhoneChangeAttemptOutput{}, err
+	}
+	return output, nil
+}
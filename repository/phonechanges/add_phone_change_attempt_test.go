@@ -0,0 +1,74 @@
+package phonechanges
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type AddPhoneChangeAttemptTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.PhoneChangeRepository
+
+	input repository.AddPhoneChangeAttemptInput
+	ctx   context.Context
+}
+
+func TestAddPhoneChangeAttemptTestSuite(t *testing.T) {
+	suite.Run(t, new(AddPhoneChangeAttemptTestSuite))
+}
+
+func (s *AddPhoneChangeAttemptTestSuite) SetupTest() {
+	repo := &phoneChangeRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.AddPhoneChangeAttemptInput{ID: 42, MaxAttempts: 5}
+	s.ctx = context.Background()
+}
+
+func (s *AddPhoneChangeAttemptTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *AddPhoneChangeAttemptTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(addPhoneChangeAttemptQuery)).WithArgs(s.input.ID, s.input.MaxAttempts).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.AddPhoneChangeAttempt(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *AddPhoneChangeAttemptTestSuite) TestNoAttemptsLeft() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(addPhoneChangeAttemptQuery)).WithArgs(s.input.ID, s.input.MaxAttempts).
+		WillReturnRows(sqlmock.NewRows([]string{"attempts"}))
+	res, err := s.repo.AddPhoneChangeAttempt(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *AddPhoneChangeAttemptTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(addPhoneChangeAttemptQuery)).WithArgs(s.input.ID, s.input.MaxAttempts).
+		WillReturnRows(sqlmock.NewRows([]string{"attempts"}).AddRow(2))
+	res, err := s.repo.AddPhoneChangeAttempt(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(2, res.Attempts)
+}
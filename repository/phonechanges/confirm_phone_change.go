@@ -0,0 +1,87 @@
+package phonechanges
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/repository/audit"
+	"github.com/SawitProRecruitment/UserService/repository/userchanges"
+	"github.com/lib/pq"
+)
+
+const (
+	confirmPhoneChangeQuery = `UPDATE user_phone_changes SET confirmed_at=now() WHERE id=$1 AND confirmed_at IS NULL;`
+	// the row is locked, so that the reservation can't be taken over by another user before the commit
+	getOtherPhoneNoReservationQuery = `SELECT user_id FROM phone_no_reservations WHERE phone_no=$1 AND user_id<>$2 AND reserved_until>now() FOR UPDATE;`
+	updateUserPhoneNoQuery          = `UPDATE users SET phone_no=$1, version=version+1 WHERE id=$2 AND phone_no=$3;`
+	// the new phone number is either reserved to the user itself, or not reserved anymore
+	deletePhoneNoReservationQuery = `DELETE FROM phone_no_reservations WHERE phone_no=$1;`
+	reservePhoneNoQuery           = `INSERT INTO phone_no_reservations (phone_no, user_id, reserved_until) VALUES ($1, $2, $3) ` +
+		`ON CONFLICT (phone_no) DO UPDATE SET user_id=EXCLUDED.user_id, reserved_until=EXCLUDED.reserved_until;`
+)
+
+func (p *phoneChangeRepository) ConfirmPhoneChange(ctx context.Context, input repository.ConfirmPhoneChangeInput) (output repository.ConfirmPhoneChangeOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "PhoneChangeRepository", "ConfirmPhoneChange")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var tx *sql.Tx
+	if tx, err = p.db.BeginTx(ctx, nil); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var result sql.Result
+	if result, err = tx.ExecContext(ctx, confirmPhoneChangeQuery, input.ID); err != nil {
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected <= 0 {
+		err = repository.ErrorRecordNotFound
+		return
+	}
+
+	var reservedUserID uint64
+	err = tx.QueryRowContext(ctx, getOtherPhoneNoReservationQuery, input.PhoneNo, input.UserID).Scan(&reservedUserID)
+	if err == nil {
+		err = repository.ErrorRecordConflict
+		return
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return
+	}
+
+	if result, err = tx.ExecContext(ctx, updateUserPhoneNoQuery, input.PhoneNo, input.UserID, input.OldPhoneNo); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			if pqErr.Code == "23505" { // unique constraint violation
+				err = repository.ErrorRecordConflict
+			}
+		}
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected <= 0 {
+		err = repository.ErrorRecordNotFound
+		return
+	}
+
+	if _, err = tx.ExecContext(ctx, deletePhoneNoReservationQuery, input.PhoneNo); err != nil {
+		return
+	}
+	if _, err = tx.ExecContext(ctx, reservePhoneNoQuery, input.OldPhoneNo, input.UserID, input.ReservedUntil); err != nil {
+		return
+	}
+	if _, err = userchanges.CreateUserChangesTx(ctx, tx, input.UserChanges); err != nil {
+		return
+	}
+	if _, err = audit.CreateAuditEventTx(ctx, tx, input.AuditEvent); err != nil {
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		return
+	}
+	return output, nil
+}
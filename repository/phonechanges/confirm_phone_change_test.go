@@ -0,0 +1,193 @@
+package phonechanges
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type ConfirmPhoneChangeTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.PhoneChangeRepository
+
+	input repository.ConfirmPhoneChangeInput
+	ctx   context.Context
+}
+
+func TestConfirmPhoneChangeTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfirmPhoneChangeTestSuite))
+}
+
+func (s *ConfirmPhoneChangeTestSuite) SetupTest() {
+	repo := &phoneChangeRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.ConfirmPhoneChangeInput{
+		ID:            42,
+		UserID:        123,
+		OldPhoneNo:    "+6281234567890",
+		PhoneNo:       "+6589876543",
+		ReservedUntil: time.Date(2024, 4, 15, 10, 0, 0, 0, time.UTC),
+		UserChanges: repository.CreateUserChangesInput{
+			UserID:      123,
+			ActorUserID: 123,
+			RequestID:   "request-id",
+			Changes:     []repository.UserFieldChange{{Field: "phone_no", OldValue: "+6281234567890", NewValue: "+6589876543"}},
+		},
+		AuditEvent: repository.CreateAuditEventInput{ActorUserID: 123, Action: "user.phone_no.changed", TargetUserID: 123},
+	}
+	s.ctx = context.Background()
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestAlreadyConfirmed() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectExec(regexp.QuoteMeta(confirmPhoneChangeQuery)).WithArgs(s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.dbMock.ExpectRollback()
+	_, err := s.repo.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestReservedToAnotherUser() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectExec(regexp.QuoteMeta(confirmPhoneChangeQuery)).WithArgs(s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getOtherPhoneNoReservationQuery)).WithArgs(s.input.PhoneNo, s.input.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(456))
+	s.dbMock.ExpectRollback()
+	_, err := s.repo.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.ErrorIs(err, repository.ErrorRecordConflict)
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestPhoneNoTaken() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectExec(regexp.QuoteMeta(confirmPhoneChangeQuery)).WithArgs(s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getOtherPhoneNoReservationQuery)).WithArgs(s.input.PhoneNo, s.input.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(updateUserPhoneNoQuery)).WithArgs(s.input.PhoneNo, s.input.UserID, s.input.OldPhoneNo).
+		WillReturnError(&pq.Error{Message: "some error message here", Code: "23505"})
+	s.dbMock.ExpectRollback()
+	_, err := s.repo.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.ErrorIs(err, repository.ErrorRecordConflict)
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestPhoneNoChangedInTheMeantime() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectExec(regexp.QuoteMeta(confirmPhoneChangeQuery)).WithArgs(s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getOtherPhoneNoReservationQuery)).WithArgs(s.input.PhoneNo, s.input.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(updateUserPhoneNoQuery)).WithArgs(s.input.PhoneNo, s.input.UserID, s.input.OldPhoneNo).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.dbMock.ExpectRollback()
+	_, err := s.repo.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestReserveDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectExec(regexp.QuoteMeta(confirmPhoneChangeQuery)).WithArgs(s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getOtherPhoneNoReservationQuery)).WithArgs(s.input.PhoneNo, s.input.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(updateUserPhoneNoQuery)).WithArgs(s.input.PhoneNo, s.input.UserID, s.input.OldPhoneNo).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(deletePhoneNoReservationQuery)).WithArgs(s.input.PhoneNo).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(reservePhoneNoQuery)).WithArgs(s.input.OldPhoneNo, s.input.UserID, s.input.ReservedUntil).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	s.dbMock.ExpectRollback()
+	_, err := s.repo.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+// expectPhoneNoReplaced will expect the phone number to be replaced and the old one reserved
+func (s *ConfirmPhoneChangeTestSuite) expectPhoneNoReplaced() {
+	s.dbMock.ExpectExec(regexp.QuoteMeta(confirmPhoneChangeQuery)).WithArgs(s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getOtherPhoneNoReservationQuery)).WithArgs(s.input.PhoneNo, s.input.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(updateUserPhoneNoQuery)).WithArgs(s.input.PhoneNo, s.input.UserID, s.input.OldPhoneNo).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(deletePhoneNoReservationQuery)).WithArgs(s.input.PhoneNo).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(reservePhoneNoQuery)).WithArgs(s.input.OldPhoneNo, s.input.UserID, s.input.ReservedUntil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestUserChangesDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.expectPhoneNoReplaced()
+	s.dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_changes")).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	s.dbMock.ExpectRollback()
+	_, err := s.repo.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestAuditDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.expectPhoneNoReplaced()
+	s.dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_changes")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO audit_events")).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	s.dbMock.ExpectRollback()
+	_, err := s.repo.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.expectPhoneNoReplaced()
+	s.dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_changes")).
+		WithArgs(uint64(123), sql.NullInt64{Int64: 123, Valid: true}, "request-id", "phone_no", []byte(`"+6281234567890"`), []byte(`"+6589876543"`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO audit_events")).
+		WithArgs(sql.NullInt64{Int64: 123, Valid: true}, "user.phone_no.changed", sql.NullInt64{Int64: 123, Valid: true}, []byte(`null`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	s.dbMock.ExpectCommit()
+	_, err := s.repo.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.Empty(err)
+}
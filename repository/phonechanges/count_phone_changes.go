@@ -0,0 +1,21 @@
+package phonechanges
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	countPhoneChangesQuery = `SELECT count(*) FROM user_phone_changes WHERE user_id=$1 AND created_at>$2;`
+)
+
+func (p *phoneChangeRepository) CountPhoneChanges(ctx context.Context, input repository.CountPhoneChangesInput) (output repository.CountPhoneChangesOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "PhoneChangeRepository", "CountPhoneChanges")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := p.db.QueryRowContext(ctx, countPhoneChangesQuery, input.UserID, input.CreatedAfter)
+	if err = row.Scan(&output.Count); err != nil {
+		return repository.CountPhoneChangesOutput{}, err
+	}
+	return output, nil
+}
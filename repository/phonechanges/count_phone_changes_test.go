@@ -0,0 +1,64 @@
+package phonechanges
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type CountPhoneChangesTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.PhoneChangeRepository
+
+	input repository.CountPhoneChangesInput
+	ctx   context.Context
+}
+
+func TestCountPhoneChangesTestSuite(t *testing.T) {
+	suite.Run(t, new(CountPhoneChangesTestSuite))
+}
+
+func (s *CountPhoneChangesTestSuite) SetupTest() {
+	repo := &phoneChangeRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.CountPhoneChangesInput{UserID: 123, CreatedAfter: time.Date(2024, 3, 16, 9, 0, 0, 0, time.UTC)}
+	s.ctx = context.Background()
+}
+
+func (s *CountPhoneChangesTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *CountPhoneChangesTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(countPhoneChangesQuery)).WithArgs(s.input.UserID, s.input.CreatedAfter).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.CountPhoneChanges(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *CountPhoneChangesTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(countPhoneChangesQuery)).WithArgs(s.input.UserID, s.input.CreatedAfter).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	res, err := s.repo.CountPhoneChanges(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(3, res.Count)
+}
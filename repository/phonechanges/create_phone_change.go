@@ -0,0 +1,21 @@
+package phonechanges
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	createPhoneChangeQuery = `INSERT INTO user_phone_changes (user_id, phone_no, code_hash, expires_at) VALUES ($1, $2, $3, $4) RETURNING id;`
+)
+
+func (p *phoneChangeRepository) CreatePhoneChange(ctx context.Context, input repository.CreatePhoneChangeInput) (output repository.CreatePhoneChangeOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "PhoneChangeRepository", "CreatePhoneChange")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := p.db.QueryRowContext(ctx, createPhoneChangeQuery, input.UserID, input.PhoneNo, input.CodeHash, input.ExpiresAt)
+	if err = row.Scan(&output.ID); err != nil {
+		return repository.CreatePhoneChangeOutput{}, err
+	}
+	return output, nil
+}
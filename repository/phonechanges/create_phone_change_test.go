@@ -0,0 +1,71 @@
+package phonechanges
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type CreatePhoneChangeTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.PhoneChangeRepository
+
+	input repository.CreatePhoneChangeInput
+	ctx   context.Context
+}
+
+func TestCreatePhoneChangeTestSuite(t *testing.T) {
+	suite.Run(t, new(CreatePhoneChangeTestSuite))
+}
+
+func (s *CreatePhoneChangeTestSuite) SetupTest() {
+	repo := &phoneChangeRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.CreatePhoneChangeInput{
+		UserID:    123,
+		PhoneNo:   "+6281234567890",
+		CodeHash:  []byte("code-hash"),
+		ExpiresAt: time.Date(2024, 3, 16, 10, 0, 0, 0, time.UTC),
+	}
+	s.ctx = context.Background()
+}
+
+func (s *CreatePhoneChangeTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *CreatePhoneChangeTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createPhoneChangeQuery)).
+		WithArgs(s.input.UserID, s.input.PhoneNo, s.input.CodeHash, s.input.ExpiresAt).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.CreatePhoneChange(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *CreatePhoneChangeTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createPhoneChangeQuery)).
+		WithArgs(s.input.UserID, s.input.PhoneNo, s.input.CodeHash, s.input.ExpiresAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	res, err := s.repo.CreatePhoneChange(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(uint64(42), res.ID)
+}
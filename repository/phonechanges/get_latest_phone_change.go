@@ -0,0 +1,28 @@
+package phonechanges
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	getLatestPhoneChangeQuery = `SELECT id, user_id, phone_no, code_hash, attempts, created_at, expires_at FROM user_phone_changes ` +
+		`WHERE user_id=$1 AND confirmed_at IS NULL ORDER BY id DESC LIMIT 1;`
+)
+
+func (p *phoneChangeRepository) GetLatestPhoneChange(ctx context.Context, input repository.GetLatestPhoneChangeInput) (output repository.GetLatestPhoneChangeOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "PhoneChangeRepository", "GetLatestPhoneChange")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := p.db.QueryRowContext(ctx, getLatestPhoneChangeQuery, input.UserID)
+	err = row.Scan(&output.ID, &output.UserID, &output.PhoneNo, &output.CodeHash, &output.Attempts, &output.CreatedAt, &output.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrorRecordNotFound
+		}
+		return repository.GetLatestPhoneChangeOutput{}, err
+	}
+	return output, nil
+}
@@ -0,0 +1,89 @@
+package phonechanges
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type GetLatestPhoneChangeTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.PhoneChangeRepository
+
+	input   repository.GetLatestPhoneChangeInput
+	output  repository.GetLatestPhoneChangeOutput
+	columns []string
+	ctx     context.Context
+}
+
+func TestGetLatestPhoneChangeTestSuite(t *testing.T) {
+	suite.Run(t, new(GetLatestPhoneChangeTestSuite))
+}
+
+func (s *GetLatestPhoneChangeTestSuite) SetupTest() {
+	repo := &phoneChangeRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.GetLatestPhoneChangeInput{UserID: 123}
+	s.output = repository.GetLatestPhoneChangeOutput{
+		ID:        42,
+		UserID:    123,
+		PhoneNo:   "+6281234567890",
+		CodeHash:  []byte("code-hash"),
+		Attempts:  1,
+		CreatedAt: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC),
+		ExpiresAt: time.Date(2024, 3, 16, 10, 0, 0, 0, time.UTC),
+	}
+	s.columns = []string{"id", "user_id", "phone_no", "code_hash", "attempts", "created_at", "expires_at"}
+	s.ctx = context.Background()
+}
+
+func (s *GetLatestPhoneChangeTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *GetLatestPhoneChangeTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getLatestPhoneChangeQuery)).WithArgs(s.input.UserID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.GetLatestPhoneChange(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *GetLatestPhoneChangeTestSuite) TestNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getLatestPhoneChangeQuery)).WithArgs(s.input.UserID).
+		WillReturnRows(sqlmock.NewRows(s.columns))
+	res, err := s.repo.GetLatestPhoneChange(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *GetLatestPhoneChangeTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getLatestPhoneChangeQuery)).WithArgs(s.input.UserID).
+		WillReturnRows(sqlmock.NewRows(s.columns).AddRow(
+			s.output.ID, s.output.UserID, s.output.PhoneNo, s.output.CodeHash, s.output.Attempts, s.output.CreatedAt, s.output.ExpiresAt,
+		))
+	res, err := s.repo.GetLatestPhoneChange(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, res)
+}
@@ -0,0 +1,26 @@
+package phonechanges
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	getPhoneNoReservationQuery = `SELECT user_id, reserved_until FROM phone_no_reservations WHERE phone_no=$1 AND reserved_until>now();`
+)
+
+func (p *phoneChangeRepository) GetPhoneNoReservation(ctx context.Context, input repository.GetPhoneNoReservationInput) (output repository.GetPhoneNoReservationOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "PhoneChangeRepository", "GetPhoneNoReservation")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := p.db.QueryRowContext(ctx, getPhoneNoReservationQuery, input.PhoneNo)
+	if err = row.Scan(&output.UserID, &output.ReservedUntil); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrorRecordNotFound
+		}
+		return repository.GetPhoneNoReservationOutput{}, err
+	}
+	return output, nil
+}
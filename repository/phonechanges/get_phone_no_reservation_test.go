@@ -0,0 +1,76 @@
+package phonechanges
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type GetPhoneNoReservationTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.PhoneChangeRepository
+
+	input repository.GetPhoneNoReservationInput
+	ctx   context.Context
+}
+
+func TestGetPhoneNoReservationTestSuite(t *testing.T) {
+	suite.Run(t, new(GetPhoneNoReservationTestSuite))
+}
+
+func (s *GetPhoneNoReservationTestSuite) SetupTest() {
+	repo := &phoneChangeRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.GetPhoneNoReservationInput{PhoneNo: "+6281234567890"}
+	s.ctx = context.Background()
+}
+
+func (s *GetPhoneNoReservationTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *GetPhoneNoReservationTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getPhoneNoReservationQuery)).WithArgs(s.input.PhoneNo).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.GetPhoneNoReservation(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *GetPhoneNoReservationTestSuite) TestNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getPhoneNoReservationQuery)).WithArgs(s.input.PhoneNo).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "reserved_until"}))
+	res, err := s.repo.GetPhoneNoReservation(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *GetPhoneNoReservationTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	reservedUntil := time.Date(2024, 4, 15, 10, 0, 0, 0, time.UTC)
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getPhoneNoReservationQuery)).WithArgs(s.input.PhoneNo).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "reserved_until"}).AddRow(123, reservedUntil))
+	res, err := s.repo.GetPhoneNoReservation(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(repository.GetPhoneNoReservationOutput{UserID: 123, ReservedUntil: reservedUntil}, res)
+}
@@ -0,0 +1,36 @@
+package phonechanges
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	listPhoneChangesQuery = `SELECT id, phone_no, created_at, confirmed_at FROM user_phone_changes WHERE user_id=$1 ORDER BY id;`
+)
+
+func (p *phoneChangeRepository) ListPhoneChanges(ctx context.Context, input repository.ListPhoneChangesInput) (output repository.ListPhoneChangesOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "PhoneChangeRepository", "ListPhoneChanges")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var rows *sql.Rows
+	if rows, err = p.db.QueryContext(ctx, listPhoneChangesQuery, input.UserID); err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change repository.PhoneChange
+		var confirmedAt sql.NullTime
+		if err = rows.Scan(&change.ID, &change.PhoneNo, &change.CreatedAt, &confirmedAt); err != nil {
+			return repository.ListPhoneChangesOutput{}, err
+		}
+		change.ConfirmedAt = confirmedAt.Time
+		output.Changes = append(output.Changes, change)
+	}
+	if err = rows.Err(); err != nil {
+		return repository.ListPhoneChangesOutput{}, err
+	}
+	return output, nil
+}
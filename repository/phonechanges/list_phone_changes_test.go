@@ -0,0 +1,71 @@
+package phonechanges
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type ListPhoneChangesTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.PhoneChangeRepository
+
+	input repository.ListPhoneChangesInput
+	ctx   context.Context
+}
+
+func TestListPhoneChangesTestSuite(t *testing.T) {
+	suite.Run(t, new(ListPhoneChangesTestSuite))
+}
+
+func (s *ListPhoneChangesTestSuite) SetupTest() {
+	repo := &phoneChangeRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.ListPhoneChangesInput{UserID: 123}
+	s.ctx = context.Background()
+}
+
+func (s *ListPhoneChangesTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *ListPhoneChangesTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(listPhoneChangesQuery)).WithArgs(s.input.UserID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.ListPhoneChanges(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *ListPhoneChangesTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	createdAt := time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)
+	confirmedAt := createdAt.Add(time.Minute)
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(listPhoneChangesQuery)).WithArgs(s.input.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "phone_no", "created_at", "confirmed_at"}).
+			AddRow(7, "+6281215183301", createdAt, nil).
+			AddRow(8, "+6281215183302", createdAt, confirmedAt))
+	res, err := s.repo.ListPhoneChanges(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(repository.ListPhoneChangesOutput{Changes: []repository.PhoneChange{
+		{ID: 7, PhoneNo: "+6281215183301", CreatedAt: createdAt},
+		{ID: 8, PhoneNo: "+6281215183302", CreatedAt: createdAt, ConfirmedAt: confirmedAt},
+	}}, res)
+}
@@ -0,0 +1,23 @@
+// This file contains the repository implementation layer.
+package phonechanges
+
+import (
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+// phoneChangeRepository is a postgresSQL implementation of repository.PhoneChangeRepository
+type phoneChangeRepository struct {
+	db *sql.DB
+}
+
+func NewPhoneChangeRepository(opts repository.NewRepositoryOptions) (repository.PhoneChangeRepository, error) {
+	db, err := repository.OpenDatabase(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &phoneChangeRepository{
+		db: db,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package tokens
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	// revoking an already revoked token is a no-op, the original revocation time is kept
+	createTokenRevocationQuery = `INSERT INTO token_revocations (jti, user_id, expires_at) VALUES ($1, $2, $3) ON CONFLICT (jti) DO NOTHING;`
+)
+
+func (t *tokenRepository) CreateTokenRevocation(ctx context.Context, input repository.CreateTokenRevocationInput) (output repository.CreateTokenRevocationOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "TokenRepository", "CreateTokenRevocation")
+	defer func() { repository.EndSpan(span, err) }()
+
+	if _, err = t.db.ExecContext(ctx, createTokenRevocationQuery, input.TokenID, input.UserID, input.ExpiresAt); err != nil {
+		return
+	}
+	return output, nil
+}
@@ -0,0 +1,76 @@
+package tokens
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type CreateTokenRevocationTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.TokenRepository
+
+	input repository.CreateTokenRevocationInput
+	ctx   context.Context
+}
+
+func TestCreateTokenRevocationTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateTokenRevocationTestSuite))
+}
+
+func (s *CreateTokenRevocationTestSuite) SetupTest() {
+	repo := &tokenRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.CreateTokenRevocationInput{
+		TokenID:   "token-id",
+		UserID:    123,
+		ExpiresAt: time.Date(2024, 3, 16, 10, 0, 0, 0, time.UTC),
+	}
+	s.ctx = context.Background()
+}
+
+func (s *CreateTokenRevocationTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *CreateTokenRevocationTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(createTokenRevocationQuery)).WithArgs(s.input.TokenID, s.input.UserID, s.input.ExpiresAt).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	_, err := s.repo.CreateTokenRevocation(s.ctx, s.input)
+
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *CreateTokenRevocationTestSuite) TestAlreadyRevoked() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(createTokenRevocationQuery)).WithArgs(s.input.TokenID, s.input.UserID, s.input.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err := s.repo.CreateTokenRevocation(s.ctx, s.input)
+
+	a.Empty(err)
+}
+
+func (s *CreateTokenRevocationTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(createTokenRevocationQuery)).WithArgs(s.input.TokenID, s.input.UserID, s.input.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := s.repo.CreateTokenRevocation(s.ctx, s.input)
+
+	a.Empty(err)
+}
@@ -0,0 +1,27 @@
+package tokens
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	getTokenRevocationQuery = `SELECT jti, user_id, expires_at, revoked_at FROM token_revocations WHERE jti=$1;`
+)
+
+func (t *tokenRepository) GetTokenRevocation(ctx context.Context, input repository.GetTokenRevocationInput) (output repository.GetTokenRevocationOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "TokenRepository", "GetTokenRevocation")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := t.db.QueryRowContext(ctx, getTokenRevocationQuery, input.TokenID)
+	if err = row.Scan(&output.TokenID, &output.UserID, &output.ExpiresAt, &output.RevokedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrorRecordNotFound
+			return
+		}
+		return
+	}
+	return output, nil
+}
@@ -0,0 +1,85 @@
+package tokens
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type GetTokenRevocationTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.TokenRepository
+
+	input  repository.GetTokenRevocationInput
+	output repository.GetTokenRevocationOutput
+	ctx    context.Context
+}
+
+func TestGetTokenRevocationTestSuite(t *testing.T) {
+	suite.Run(t, new(GetTokenRevocationTestSuite))
+}
+
+func (s *GetTokenRevocationTestSuite) SetupTest() {
+	repo := &tokenRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.GetTokenRevocationInput{TokenID: "token-id"}
+	s.output = repository.GetTokenRevocationOutput{
+		TokenID:   "token-id",
+		UserID:    123,
+		ExpiresAt: time.Date(2024, 3, 16, 10, 0, 0, 0, time.UTC),
+		RevokedAt: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC),
+	}
+	s.ctx = context.Background()
+}
+
+func (s *GetTokenRevocationTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *GetTokenRevocationTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getTokenRevocationQuery)).WithArgs(s.input.TokenID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.GetTokenRevocation(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *GetTokenRevocationTestSuite) TestNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getTokenRevocationQuery)).WithArgs(s.input.TokenID).
+		WillReturnRows(sqlmock.NewRows([]string{"jti", "user_id", "expires_at", "revoked_at"}))
+	res, err := s.repo.GetTokenRevocation(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *GetTokenRevocationTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getTokenRevocationQuery)).WithArgs(s.input.TokenID).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"jti", "user_id", "expires_at", "revoked_at"}).
+				AddRow(s.output.TokenID, s.output.UserID, s.output.ExpiresAt, s.output.RevokedAt),
+		)
+	res, err := s.repo.GetTokenRevocation(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, res)
+}
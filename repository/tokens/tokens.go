@@ -0,0 +1,23 @@
+// This file contains the repository implementation layer.
+package tokens
+
+import (
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+// tokenRepository is a postgresSQL implementation of repository.TokenRepository
+type tokenRepository struct {
+	db *sql.DB
+}
+
+func NewTokenRepository(opts repository.NewRepositoryOptions) (repository.TokenRepository, error) {
+	db, err := repository.OpenDatabase(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenRepository{
+		db: db,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartSpan will start the span of a repository method, named after the repository & the statement (e.g.
+// UserRepository.GetUser). The SQL & its parameters are never recorded, as the parameters hold personal data
+func StartSpan(ctx context.Context, repository, statement string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, repository+"."+statement,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", statement),
+		))
+}
+
+// EndSpan will end the span of a repository method, the missing, conflicting & outdated records are expected outcomes
+func EndSpan(span trace.Span, err error) {
+	if errors.Is(err, ErrorRecordNotFound) || errors.Is(err, ErrorRecordConflict) || errors.Is(err, ErrorRecordOutdated) {
+		span.SetAttributes(attribute.String("db.outcome", err.Error()))
+		err = nil
+	}
+	tracing.End(span, err)
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func TestSpans(t *testing.T) {
+	a := assert.New(t)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	_, span := StartSpan(context.Background(), "TokenRepository", "GetTokenRevocation")
+	EndSpan(span, fmt.Errorf("wrapped: %w", ErrorRecordOutdated))
+	_, span = StartSpan(context.Background(), "UserExportRepository", "ClaimUserExports")
+	EndSpan(span, fmt.Errorf("connection refused"))
+
+	spans := recorder.Ended()
+	a.Len(spans, 2)
+	a.Equal("TokenRepository.GetTokenRevocation", spans[0].Name())
+	a.Equal(trace.SpanKindClient, spans[0].SpanKind())
+	a.ElementsMatch([]attribute.KeyValue{
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "GetTokenRevocation"),
+		attribute.String("db.outcome", "wrapped: "+ErrorRecordOutdated.Error()),
+	}, spans[0].Attributes())
+	a.Equal(codes.Unset, spans[0].Status().Code, "an outdated record is not a failure")
+	a.Equal("UserExportRepository.ClaimUserExports", spans[1].Name())
+	a.Equal(codes.Error, spans[1].Status().Code)
+}
@@ -1,8 +1,18 @@
 // This file contains types that are used in the repository layer.
 package repository
 
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/SawitProRecruitment/UserService/config"
+	"time"
+)
+
 type NewRepositoryOptions struct {
-	Dsn string
+	// Database contains the URL of the database and the size of the connection pool opened to it
+	Database config.Database
+	// DB is an optional shared connection pool, when set Database will be ignored
+	DB *sql.DB
 }
 
 type CreateUserInput struct {
@@ -18,22 +28,568 @@ type CreateUserOutput struct {
 type GetUserInput struct {
 	ID      uint64
 	PhoneNo string
+	// Email is matched case-insensitively, among the verified emails only
+	Email string
 }
 
 type GetUserOutput struct {
-	ID                   uint64
-	PhoneNo              string
-	FullName             string
-	PasswordHash         []byte
+	ID       uint64
+	PhoneNo  string
+	FullName string
+	// Email is empty when the user hasn't set one, EmailVerifiedAt is zero until it's verified
+	Email           string
+	EmailVerifiedAt time.Time
+	PasswordHash    []byte
+	// PinHash is empty when the user hasn't set a PIN
+	PinHash              []byte
+	PinFailedAttempts    int
 	SuccessfulLoginCount uint64
+	Status               string
+	// DeletionRequestedAt is zero when there is no pending deletion request
+	DeletionRequestedAt time.Time
+	// AvatarKey is empty when the user has no avatar
+	AvatarKey string
+	// Attributes are the custom profile attributes, empty when the user has none
+	Attributes map[string]interface{}
+	// Version is incremented by every change of the profile
+	Version   uint64
+	CreatedAt time.Time
 }
 
+// UpdateUserInput contains the fields to update, every field is optional: only the non-nil ones are updated, so that
+// zero values (e.g. an empty email) can be set as well
 type UpdateUserInput struct {
+	ID       uint64
+	PhoneNo  *string
+	FullName *string
+	// Email is stored as is, an empty email is stored as NULL. Changing it clears its verification
+	Email  *string
+	Status *string
+	// Attributes replace every custom profile attribute when not nil
+	Attributes map[string]interface{}
+	// Version is the expected current version of the user, the update is only applied when it still matches
+	// (compare-and-swap). Zero updates the user whatever its version
+	Version uint64
+}
+
+type UpdateUserOutput struct {
+	// Version is the new version of the user
+	Version uint64
+}
+
+type SetUserStatusInput struct {
+	ID   uint64
+	From string
+	To   string
+	// AuditEvent is recorded along with the status change
+	AuditEvent CreateAuditEventInput
+}
+
+type SetUserStatusOutput struct {
+	// Version is the new version of the user
+	Version uint64
+}
+
+// ListUsersInput contains the filters of ListUsers, zero value filters are ignored
+type ListUsersInput struct {
+	PhonePrefix  string
+	NameContains string
+	CreatedFrom  time.Time
+	CreatedTo    time.Time
+	Status       string
+	// BeforeID is the keyset pagination cursor, only users with smaller ID will be returned
+	BeforeID uint64
+	Limit    int
+}
+
+type ListUsersOutput struct {
+	Users []ListUsersItem
+}
+
+type ListUserPhoneNumbersInput struct {
+	// AfterID is the keyset pagination cursor, only users with greater ID will be returned
+	AfterID uint64
+	Limit   int
+}
+
+type ListUserPhoneNumbersOutput struct {
+	Users []UserPhoneNo
+}
+
+type UserPhoneNo struct {
+	ID      uint64
+	PhoneNo string
+}
+
+type ListUsersItem struct {
 	ID                   uint64
 	PhoneNo              string
 	FullName             string
 	SuccessfulLoginCount uint64
+	Status               string
+	CreatedAt            time.Time
+	Roles                []string
 }
 
-type UpdateUserOutput struct {
+type SetUserDeletionRequestInput struct {
+	ID uint64
+	// RequestedAt is the time of the deletion request, zero value will cancel the pending request
+	RequestedAt time.Time
+}
+
+type SetUserDeletionRequestOutput struct {
+}
+
+type AnonymizeUsersInput struct {
+	// DeletionRequestedBefore selects the users whose deletion is requested before this time
+	DeletionRequestedBefore time.Time
+	Limit                   int
+	// AuditAction is the action of the audit event recorded for every anonymized user
+	AuditAction string
+}
+
+type AnonymizeUsersOutput struct {
+	IDs []uint64
+}
+
+type ListAvatarDeletionsInput struct {
+	Limit int
+}
+
+type ListAvatarDeletionsOutput struct {
+	AvatarKeys []string
+}
+
+type DeleteAvatarDeletionInput struct {
+	AvatarKey string
+}
+
+type DeleteAvatarDeletionOutput struct {
+}
+
+type GetUserRolesInput struct {
+	UserID uint64
+}
+
+type GetUserRolesOutput struct {
+	Roles []string
+}
+
+type SetUserRolesInput struct {
+	UserID uint64
+	Roles  []string
+	// AuditEvent is recorded along with the roles
+	AuditEvent CreateAuditEventInput
+}
+
+type SetUserRolesOutput struct {
+}
+
+type SetUserPINInput struct {
+	ID      uint64
+	PinHash []byte
+}
+
+type SetUserPINOutput struct {
+}
+
+type VerifyUserEmailInput struct {
+	ID uint64
+	// Email must still be the current email of the user, so that links sent to a previous email are rejected
+	Email      string
+	VerifiedAt time.Time
+}
+
+type VerifyUserEmailOutput struct {
+}
+
+type AddUserPINAttemptInput struct {
+	ID          uint64
+	MaxAttempts int
+}
+
+type AddUserPINAttemptOutput struct {
+	PinFailedAttempts int
+}
+
+type IncrementUserLoginCountInput struct {
+	ID uint64
+}
+
+type IncrementUserLoginCountOutput struct {
+}
+
+type ResetUserPINFailuresInput struct {
+	ID uint64
+}
+
+type ResetUserPINFailuresOutput struct {
+}
+
+type SetUserAvatarInput struct {
+	ID uint64
+	// AvatarKey is the prefix of the avatar thumbnails in the blob store, an empty AvatarKey removes the avatar
+	AvatarKey string
+}
+
+type SetUserAvatarOutput struct {
+	// PreviousAvatarKey is the replaced avatar, empty when the user had none
+	PreviousAvatarKey string
+}
+
+type GetTokenRevocationInput struct {
+	TokenID string
+}
+
+type GetTokenRevocationOutput struct {
+	TokenID   string
+	UserID    uint64
+	ExpiresAt time.Time
+	RevokedAt time.Time
+}
+
+type CreateTokenRevocationInput struct {
+	TokenID   string
+	UserID    uint64
+	ExpiresAt time.Time
+}
+
+type CreateTokenRevocationOutput struct {
+}
+
+type GetOAuthClientInput struct {
+	ClientID string
+}
+
+type GetOAuthClientOutput struct {
+	ID               uint64
+	ClientID         string
+	Name             string
+	ClientSecretHash []byte
+}
+
+type CreateAuditEventInput struct {
+	// ActorUserID is the user performing the action
+	ActorUserID uint64
+	Action      string
+	// TargetUserID is the user affected by the action
+	TargetUserID uint64
+	Details      map[string]interface{}
+}
+
+type CreateAuditEventOutput struct {
+	ID uint64
+}
+
+type ListAuditEventsInput struct {
+	// UserID selects the events affecting the user, the events performed by the user on other users are excluded
+	UserID uint64
+}
+
+type ListAuditEventsOutput struct {
+	Events []AuditEvent
+}
+
+type AuditEvent struct {
+	ID uint64
+	// ActorUserID is zero for actions performed by the system itself
+	ActorUserID  uint64
+	Action       string
+	TargetUserID uint64
+	Details      map[string]interface{}
+	CreatedAt    time.Time
+}
+
+type CreateUserExportInput struct {
+	UserID uint64
+	Format string
+}
+
+type CreateUserExportOutput struct {
+	ID        uint64
+	CreatedAt time.Time
+}
+
+type GetUserExportInput struct {
+	ID uint64
+	// UserID must match the owner of the export
+	UserID uint64
+}
+
+type GetUserExportOutput struct {
+	ID          uint64
+	UserID      uint64
+	Format      string
+	Status      string
+	Content     []byte
+	ContentType string
+	CreatedAt   time.Time
+	// CompletedAt & ExpiresAt are zero until the export is completed
+	CompletedAt time.Time
+	ExpiresAt   time.Time
+}
+
+type ClaimUserExportsInput struct {
+	Limit int
+	// StaleAfter is how long after being claimed the exports still processing are claimed again
+	StaleAfter time.Duration
+}
+
+type ClaimUserExportsOutput struct {
+	Exports []ClaimedUserExport
+}
+
+type ClaimedUserExport struct {
+	ID     uint64
+	UserID uint64
+	Format string
+}
+
+type CompleteUserExportInput struct {
+	ID uint64
+	// Status is either ready or failed, Content & ContentType are only set when ready
+	Status      string
+	Content     []byte
+	ContentType string
+	ExpiresAt   time.Time
+}
+
+type CompleteUserExportOutput struct {
+}
+
+type DeleteExpiredUserExportsInput struct {
+	ExpiredBefore time.Time
+}
+
+type DeleteExpiredUserExportsOutput struct {
+	Count int64
+}
+
+type CreateLoginOTPInput struct {
+	PhoneNo   string
+	CodeHash  []byte
+	IPAddress string
+	ExpiresAt time.Time
+}
+
+type CreateLoginOTPOutput struct {
+	ID uint64
+}
+
+type CountLoginOTPsInput struct {
+	PhoneNo      string
+	IPAddress    string
+	CreatedAfter time.Time
+}
+
+type CountLoginOTPsOutput struct {
+	PhoneNoCount   int
+	IPAddressCount int
+}
+
+type GetLatestLoginOTPInput struct {
+	PhoneNo string
+}
+
+type GetLatestLoginOTPOutput struct {
+	ID        uint64
+	PhoneNo   string
+	CodeHash  []byte
+	Attempts  int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+type AddLoginOTPAttemptInput struct {
+	ID          uint64
+	MaxAttempts int
+}
+
+type AddLoginOTPAttemptOutput struct {
+	Attempts int
+}
+
+type ConsumeLoginOTPInput struct {
+	ID uint64
+}
+
+type ConsumeLoginOTPOutput struct {
+}
+
+type CreatePhoneChangeInput struct {
+	UserID uint64
+	// PhoneNo is the new phone number, the code is sent to
+	PhoneNo   string
+	CodeHash  []byte
+	ExpiresAt time.Time
+}
+
+type CreatePhoneChangeOutput struct {
+	ID uint64
+}
+
+type CountPhoneChangesInput struct {
+	UserID       uint64
+	CreatedAfter time.Time
+}
+
+type CountPhoneChangesOutput struct {
+	Count int
+}
+
+type GetLatestPhoneChangeInput struct {
+	UserID uint64
+}
+
+type GetLatestPhoneChangeOutput struct {
+	ID        uint64
+	UserID    uint64
+	PhoneNo   string
+	CodeHash  []byte
+	Attempts  int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+type AddPhoneChangeAttemptInput struct {
+	ID          uint64
+	MaxAttempts int
+}
+
+type AddPhoneChangeAttemptOutput struct {
+	Attempts int
+}
+
+type ConfirmPhoneChangeInput struct {
+	ID     uint64
+	UserID uint64
+	// OldPhoneNo is the current phone number of the user, it's reserved to the user until ReservedUntil
+	OldPhoneNo    string
+	PhoneNo       string
+	ReservedUntil time.Time
+	// UserChanges and AuditEvent are recorded along with the phone number
+	UserChanges CreateUserChangesInput
+	AuditEvent  CreateAuditEventInput
+}
+
+type ConfirmPhoneChangeOutput struct {
+}
+
+type GetPhoneNoReservationInput struct {
+	PhoneNo string
+}
+
+type GetPhoneNoReservationOutput struct {
+	UserID        uint64
+	ReservedUntil time.Time
+}
+
+type ListPhoneChangesInput struct {
+	UserID uint64
+}
+
+type ListPhoneChangesOutput struct {
+	Changes []PhoneChange
+}
+
+type PhoneChange struct {
+	ID      uint64
+	PhoneNo string
+	// ConfirmedAt is zero when the change was never confirmed
+	ConfirmedAt time.Time
+	CreatedAt   time.Time
+}
+
+type CreateUserDeviceInput struct {
+	UserID    uint64
+	TokenHash string
+}
+
+type CreateUserDeviceOutput struct {
+	ID uint64
+}
+
+type GetUserDeviceInput struct {
+	UserID    uint64
+	TokenHash string
+}
+
+type GetUserDeviceOutput struct {
+	ID        uint64
+	UserID    uint64
+	CreatedAt time.Time
+}
+
+type ListUserDevicesInput struct {
+	UserID uint64
+}
+
+type ListUserDevicesOutput struct {
+	Devices []UserDevice
+}
+
+type UserDevice struct {
+	ID        uint64
+	CreatedAt time.Time
+}
+
+type GetLatestAttributeSchemaInput struct {
+}
+
+type GetLatestAttributeSchemaOutput struct {
+	ID uint64
+	// Schema is the JSON Schema document
+	Schema    json.RawMessage
+	CreatedBy uint64
+	CreatedAt time.Time
+}
+
+type CreateAttributeSchemaInput struct {
+	Schema json.RawMessage
+	// CreatedBy is the admin creating the schema
+	CreatedBy uint64
+}
+
+type CreateAttributeSchemaOutput struct {
+	ID        uint64
+	CreatedAt time.Time
+}
+
+type CreateUserChangesInput struct {
+	UserID uint64
+	// ActorUserID is the user performing the changes, zero for changes made by the system itself
+	ActorUserID uint64
+	// RequestID is the ID of the request performing the changes, empty outside of a request
+	RequestID string
+	Changes   []UserFieldChange
+}
+
+// UserFieldChange is the change of a single profile field, the values are encoded as JSON
+type UserFieldChange struct {
+	Field string
+	// OldValue & NewValue are nil when the field was, or is now unset
+	OldValue interface{}
+	NewValue interface{}
+}
+
+type CreateUserChangesOutput struct{}
+
+type ListUserChangesInput struct {
+	UserID uint64
+	// BeforeID is the keyset pagination cursor, only changes with smaller ID will be returned
+	BeforeID uint64
+	Limit    int
+}
+
+type ListUserChangesOutput struct {
+	Changes []UserChange
+}
+
+type UserChange struct {
+	ID uint64
+	UserFieldChange
+	// ActorUserID is zero for changes made by the system itself
+	ActorUserID uint64
+	RequestID   string
+	CreatedAt   time.Time
 }
@@ -0,0 +1,72 @@
+package userchanges
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"strings"
+)
+
+const (
+	// the user, actor & request are shared by every row, each change adds its field, old & new value
+	createUserChangesQuery = `INSERT INTO user_changes (user_id, actor_user_id, request_id, field, old_value, new_value) VALUES %s;`
+)
+
+// execer is either the database connection pool, or a transaction
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func (c *userChangeRepository) CreateUserChanges(ctx context.Context, input repository.CreateUserChangesInput) (output repository.CreateUserChangesOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserChangeRepository", "CreateUserChanges")
+	defer func() { repository.EndSpan(span, err) }()
+
+	return createUserChanges(ctx, c.db, input)
+}
+
+// CreateUserChangesTx will record the changes in the transaction applying them, for the other repositories, so that
+// the history is recorded if and only if the changes are committed
+func CreateUserChangesTx(ctx context.Context, tx *sql.Tx, input repository.CreateUserChangesInput) (output repository.CreateUserChangesOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserChangeRepository", "CreateUserChanges")
+	defer func() { repository.EndSpan(span, err) }()
+
+	return createUserChanges(ctx, tx, input)
+}
+
+func createUserChanges(ctx context.Context, db execer, input repository.CreateUserChangesInput) (output repository.CreateUserChangesOutput, err error) {
+	if len(input.Changes) == 0 {
+		return output, nil
+	}
+
+	var values []string
+	params := []interface{}{input.UserID, sql.NullInt64{Int64: int64(input.ActorUserID), Valid: input.ActorUserID != 0}, input.RequestID}
+	id := len(params) + 1
+	for _, change := range input.Changes {
+		var oldValue, newValue interface{}
+		if oldValue, err = marshalValue(change.OldValue); err != nil {
+			return
+		}
+		if newValue, err = marshalValue(change.NewValue); err != nil {
+			return
+		}
+		values = append(values, fmt.Sprintf("($1, $2, $3, $%d, $%d, $%d)", id, id+1, id+2))
+		params = append(params, change.Field, oldValue, newValue)
+		id += 3
+	}
+
+	query := fmt.Sprintf(createUserChangesQuery, strings.Join(values, ", "))
+	if _, err = db.ExecContext(ctx, query, params...); err != nil {
+		return
+	}
+	return output, nil
+}
+
+// marshalValue will encode the value as JSON, the nil value is stored as NULL rather than the JSON null
+func marshalValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
@@ -0,0 +1,103 @@
+package userchanges
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type CreateUserChangesTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserChangeRepository
+
+	input repository.CreateUserChangesInput
+	query string
+	ctx   context.Context
+}
+
+func TestCreateUserChangesTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateUserChangesTestSuite))
+}
+
+func (s *CreateUserChangesTestSuite) SetupTest() {
+	repo := &userChangeRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.CreateUserChangesInput{
+		UserID:      123,
+		ActorUserID: 123,
+		RequestID:   "request-id",
+		Changes: []repository.UserFieldChange{
+			{Field: "full_name", OldValue: "John Smith", NewValue: "John Doe"},
+			{Field: "attributes.estate_code", NewValue: "KAL-01"},
+		},
+	}
+	s.query = "INSERT INTO user_changes (user_id, actor_user_id, request_id, field, old_value, new_value) " +
+		"VALUES ($1, $2, $3, $4, $5, $6), ($1, $2, $3, $7, $8, $9);"
+	s.ctx = context.Background()
+}
+
+func (s *CreateUserChangesTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *CreateUserChangesTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(s.query)).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.CreateUserChanges(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *CreateUserChangesTestSuite) TestNoChanges() {
+	a := assert.New(s.T())
+
+	s.input.Changes = nil
+	res, err := s.repo.CreateUserChanges(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Empty(res)
+}
+
+func (s *CreateUserChangesTestSuite) TestSystemActorSuccess() {
+	a := assert.New(s.T())
+
+	s.input.ActorUserID = 0
+	s.input.RequestID = ""
+	s.input.Changes = s.input.Changes[:1]
+	s.dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_changes (user_id, actor_user_id, request_id, field, old_value, new_value) VALUES ($1, $2, $3, $4, $5, $6);")).
+		WithArgs(s.input.UserID, sql.NullInt64{}, "", "full_name", []byte(`"John Smith"`), []byte(`"John Doe"`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	res, err := s.repo.CreateUserChanges(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Empty(res)
+}
+
+func (s *CreateUserChangesTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(s.query)).
+		WithArgs(s.input.UserID, sql.NullInt64{Int64: 123, Valid: true}, s.input.RequestID,
+			"full_name", []byte(`"John Smith"`), []byte(`"John Doe"`),
+			"attributes.estate_code", nil, []byte(`"KAL-01"`)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	res, err := s.repo.CreateUserChanges(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Empty(res)
+}
@@ -0,0 +1,54 @@
+package userchanges
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	listUserChangesQuery = `SELECT id, actor_user_id, request_id, field, old_value, new_value, created_at FROM user_changes ` +
+		`WHERE user_id=$1 AND ($2=0 OR id<$2) ORDER BY id DESC LIMIT $3;`
+)
+
+func (c *userChangeRepository) ListUserChanges(ctx context.Context, input repository.ListUserChangesInput) (output repository.ListUserChangesOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserChangeRepository", "ListUserChanges")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var rows *sql.Rows
+	if rows, err = c.db.QueryContext(ctx, listUserChangesQuery, input.UserID, input.BeforeID, input.Limit); err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change repository.UserChange
+		var actorUserID sql.NullInt64
+		var oldValue, newValue []byte
+		if err = rows.Scan(&change.ID, &actorUserID, &change.RequestID, &change.Field, &oldValue, &newValue, &change.CreatedAt); err != nil {
+			return repository.ListUserChangesOutput{}, err
+		}
+		change.ActorUserID = uint64(actorUserID.Int64)
+		if change.OldValue, err = unmarshalValue(oldValue); err != nil {
+			return repository.ListUserChangesOutput{}, err
+		}
+		if change.NewValue, err = unmarshalValue(newValue); err != nil {
+			return repository.ListUserChangesOutput{}, err
+		}
+		output.Changes = append(output.Changes, change)
+	}
+	if err = rows.Err(); err != nil {
+		return repository.ListUserChangesOutput{}, err
+	}
+	return output, nil
+}
+
+// unmarshalValue will decode the JSON value, NULL is decoded as nil
+func unmarshalValue(raw []byte) (value interface{}, err error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	err = json.Unmarshal(raw, &value)
+	return
+}
@@ -0,0 +1,82 @@
+package userchanges
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type ListUserChangesTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserChangeRepository
+
+	input   repository.ListUserChangesInput
+	columns []string
+	ctx     context.Context
+}
+
+func TestListUserChangesTestSuite(t *testing.T) {
+	suite.Run(t, new(ListUserChangesTestSuite))
+}
+
+func (s *ListUserChangesTestSuite) SetupTest() {
+	repo := &userChangeRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.ListUserChangesInput{UserID: 123, BeforeID: 10, Limit: 21}
+	s.columns = []string{"id", "actor_user_id", "request_id", "field", "old_value", "new_value", "created_at"}
+	s.ctx = context.Background()
+}
+
+func (s *ListUserChangesTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *ListUserChangesTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(listUserChangesQuery)).WithArgs(s.input.UserID, s.input.BeforeID, s.input.Limit).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.ListUserChanges(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *ListUserChangesTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	createdAt := time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(listUserChangesQuery)).WithArgs(s.input.UserID, s.input.BeforeID, s.input.Limit).
+		WillReturnRows(sqlmock.NewRows(s.columns).
+			AddRow(9, 123, "request-id", "attributes.estate_code", nil, []byte(`"KAL-01"`), createdAt).
+			AddRow(8, nil, "", "phone_no", []byte(`"+6281315184400"`), nil, createdAt))
+	res, err := s.repo.ListUserChanges(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(repository.ListUserChangesOutput{Changes: []repository.UserChange{
+		{
+			ID:              9,
+			UserFieldChange: repository.UserFieldChange{Field: "attributes.estate_code", NewValue: "KAL-01"},
+			ActorUserID:     123,
+			RequestID:       "request-id",
+			CreatedAt:       createdAt,
+		},
+		{
+			ID:              8,
+			UserFieldChange: repository.UserFieldChange{Field: "phone_no", OldValue: "+6281315184400"},
+			CreatedAt:       createdAt,
+		},
+	}}, res)
+}
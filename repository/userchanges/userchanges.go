@@ -0,0 +1,23 @@
+// This file contains the repository implementation layer.
+package userchanges
+
+import (
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+// userChangeRepository is a postgresSQL implementation of repository.UserChangeRepository
+type userChangeRepository struct {
+	db *sql.DB
+}
+
+func NewUserChangeRepository(opts repository.NewRepositoryOptions) (repository.UserChangeRepository, error) {
+	db, err := repository.OpenDatabase(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userChangeRepository{
+		db: db,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	// the attempt is only recorded while below the limit, so concurrent logins can't exceed it
+	addUserPINAttemptQuery = `UPDATE users SET pin_failed_attempts=pin_failed_attempts+1 WHERE id=$1 AND pin_failed_attempts<$2 RETURNING pin_failed_attempts;`
+)
+
+func (u *userRepository) AddUserPINAttempt(ctx context.Context, input repository.AddUserPINAttemptInput) (output repository.AddUserPINAttemptOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "AddUserPINAttempt")
+	defer func() { repository.EndSpan(span, err) }()
+
+	row := u.db.QueryRowContext(ctx, addUserPINAttemptQuery, input.ID, input.MaxAttempts)
+	if err = row.Scan(&output.PinFailedAttempts); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrorRecordNotFound
+		}
+		return repository.AddUserPINAttemptOutput{}, err
+	}
+	return output, nil
+}
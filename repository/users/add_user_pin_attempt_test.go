@@ -0,0 +1,74 @@
+package users
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type AddUserPINAttemptTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input repository.AddUserPINAttemptInput
+	ctx   context.Context
+}
+
+func TestAddUserPINAttemptTestSuite(t *testing.T) {
+	suite.Run(t, new(AddUserPINAttemptTestSuite))
+}
+
+func (s *AddUserPINAttemptTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.AddUserPINAttemptInput{ID: 123, MaxAttempts: 3}
+	s.ctx = context.Background()
+}
+
+func (s *AddUserPINAttemptTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *AddUserPINAttemptTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(addUserPINAttemptQuery)).WithArgs(s.input.ID, s.input.MaxAttempts).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.AddUserPINAttempt(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *AddUserPINAttemptTestSuite) TestRecordNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(addUserPINAttemptQuery)).WithArgs(s.input.ID, s.input.MaxAttempts).
+		WillReturnRows(sqlmock.NewRows([]string{"pin_failed_attempts"}))
+	res, err := s.repo.AddUserPINAttempt(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *AddUserPINAttemptTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(addUserPINAttemptQuery)).WithArgs(s.input.ID, s.input.MaxAttempts).
+		WillReturnRows(sqlmock.NewRows([]string{"pin_failed_attempts"}).AddRow(2))
+	res, err := s.repo.AddUserPINAttempt(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(2, res.PinFailedAttempts)
+}
@@ -0,0 +1,103 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+)
+
+const (
+	// the phone number is replaced by a placeholder derived from the ID, keeping the unique constraint while freeing
+	// the number for re-registration. SKIP LOCKED allows multiple instances to run the anonymization concurrently.
+	// The phone numbers & avatar keys are returned from the selected rows, since RETURNING only sees the updated values.
+	anonymizeUsersQuery = `UPDATE users SET phone_no='deleted:' || users.id, full_name='', email=NULL, email_verified_at=NULL, password_hash=NULL, pin_hash=NULL, ` +
+		`avatar_key=NULL, attributes='{}', status='deactivated', deletion_requested_at=NULL, version=users.version+1 ` +
+		`FROM (SELECT id, phone_no, avatar_key FROM users WHERE deletion_requested_at<=$1 AND status<>'deactivated' ` +
+		`ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED) target WHERE users.id=target.id RETURNING users.id, target.phone_no, target.avatar_key;`
+	// the profile history keeps which fields were changed, but not their values
+	eraseAnonymizedUserChangesQuery = `UPDATE user_changes SET old_value=NULL, new_value=NULL WHERE user_id=ANY($1);`
+	// the login codes are kept per phone number, with the IP addresses they were requested from
+	deleteAnonymizedLoginOTPsQuery           = `DELETE FROM login_otps WHERE phone_no=ANY($1);`
+	deleteAnonymizedPhoneChangesQuery        = `DELETE FROM user_phone_changes WHERE user_id=ANY($1);`
+	deleteAnonymizedPhoneNoReservationsQuery = `DELETE FROM phone_no_reservations WHERE user_id=ANY($1);`
+	deleteAnonymizedUserDevicesQuery         = `DELETE FROM user_devices WHERE user_id=ANY($1);`
+	queueAvatarDeletionsQuery                = `INSERT INTO avatar_deletions (avatar_key) SELECT unnest($1::VARCHAR[]) ON CONFLICT DO NOTHING;`
+	// the exports requested during the grace period contain the whole profile and its history
+	deleteAnonymizedUserExportsQuery = `DELETE FROM user_exports WHERE user_id=ANY($1);`
+	// audit events are performed by the system itself, hence without actor
+	createAnonymizedAuditEventsQuery = `INSERT INTO audit_events (action, target_user_id) SELECT $1, unnest($2::BIGINT[]);`
+)
+
+func (u *userRepository) AnonymizeUsers(ctx context.Context, input repository.AnonymizeUsersInput) (output repository.AnonymizeUsersOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "AnonymizeUsers")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var tx *sql.Tx
+	if tx, err = u.db.BeginTx(ctx, nil); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var ids pq.Int64Array
+	var phoneNumbers, avatarKeys pq.StringArray
+	if ids, phoneNumbers, avatarKeys, err = anonymizeUsers(ctx, tx, input); err != nil {
+		return repository.AnonymizeUsersOutput{}, err
+	}
+
+	if len(ids) > 0 {
+		for _, query := range []string{eraseAnonymizedUserChangesQuery, deleteAnonymizedPhoneChangesQuery, deleteAnonymizedPhoneNoReservationsQuery,
+			deleteAnonymizedUserDevicesQuery, deleteAnonymizedUserExportsQuery} {
+			if _, err = tx.ExecContext(ctx, query, ids); err != nil {
+				return
+			}
+		}
+		if _, err = tx.ExecContext(ctx, deleteAnonymizedLoginOTPsQuery, phoneNumbers); err != nil {
+			return
+		}
+		if len(avatarKeys) > 0 {
+			if _, err = tx.ExecContext(ctx, queueAvatarDeletionsQuery, avatarKeys); err != nil {
+				return
+			}
+		}
+		if _, err = tx.ExecContext(ctx, createAnonymizedAuditEventsQuery, input.AuditAction, ids); err != nil {
+			return
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return
+	}
+	for _, id := range ids {
+		output.IDs = append(output.IDs, uint64(id))
+	}
+	return output, nil
+}
+
+// anonymizeUsers will anonymize the users pending deletion, and return their IDs, the phone numbers & avatar keys they had
+func anonymizeUsers(ctx context.Context, tx *sql.Tx, input repository.AnonymizeUsersInput) (ids pq.Int64Array, phoneNumbers, avatarKeys pq.StringArray, err error) {
+	var rows *sql.Rows
+	if rows, err = tx.QueryContext(ctx, anonymizeUsersQuery, input.DeletionRequestedBefore, input.Limit); err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var phoneNo string
+		var avatarKey sql.NullString
+		if err = rows.Scan(&id, &phoneNo, &avatarKey); err != nil {
+			return nil, nil, nil, err
+		}
+		ids = append(ids, id)
+		phoneNumbers = append(phoneNumbers, phoneNo)
+		if avatarKey.Valid {
+			avatarKeys = append(avatarKeys, avatarKey.String)
+		}
+	}
+	return ids, phoneNumbers, avatarKeys, rows.Err()
+}
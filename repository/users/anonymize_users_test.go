@@ -0,0 +1,129 @@
+package users
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type AnonymizeUsersTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input repository.AnonymizeUsersInput
+	ctx   context.Context
+}
+
+func TestAnonymizeUsersTestSuite(t *testing.T) {
+	suite.Run(t, new(AnonymizeUsersTestSuite))
+}
+
+func (s *AnonymizeUsersTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.AnonymizeUsersInput{DeletionRequestedBefore: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC), Limit: 100, AuditAction: "user.anonymized"}
+	s.ctx = context.Background()
+}
+
+func (s *AnonymizeUsersTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// expectPersonalDataDeleted will expect the personal data kept outside of the users table to be deleted
+func (s *AnonymizeUsersTestSuite) expectPersonalDataDeleted(ids pq.Int64Array, phoneNumbers pq.StringArray) {
+	for _, query := range []string{deleteAnonymizedPhoneChangesQuery, deleteAnonymizedPhoneNoReservationsQuery, deleteAnonymizedUserDevicesQuery, deleteAnonymizedUserExportsQuery} {
+		s.dbMock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(ids).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	s.dbMock.ExpectExec(regexp.QuoteMeta(deleteAnonymizedLoginOTPsQuery)).WithArgs(phoneNumbers).WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (s *AnonymizeUsersTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(anonymizeUsersQuery)).WithArgs(s.input.DeletionRequestedBefore, s.input.Limit).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	s.dbMock.ExpectRollback()
+	res, err := s.repo.AnonymizeUsers(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *AnonymizeUsersTestSuite) TestNothingToAnonymize() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(anonymizeUsersQuery)).WithArgs(s.input.DeletionRequestedBefore, s.input.Limit).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "phone_no", "avatar_key"}))
+	s.dbMock.ExpectCommit()
+	res, err := s.repo.AnonymizeUsers(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Empty(res.IDs)
+}
+
+func (s *AnonymizeUsersTestSuite) TestEraseUserChangesError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(anonymizeUsersQuery)).WithArgs(s.input.DeletionRequestedBefore, s.input.Limit).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "phone_no", "avatar_key"}).AddRow(12, "+6281215183300", nil))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(eraseAnonymizedUserChangesQuery)).WithArgs(pq.Int64Array{12}).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	s.dbMock.ExpectRollback()
+	res, err := s.repo.AnonymizeUsers(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *AnonymizeUsersTestSuite) TestAuditError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(anonymizeUsersQuery)).WithArgs(s.input.DeletionRequestedBefore, s.input.Limit).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "phone_no", "avatar_key"}).AddRow(12, "+6281215183300", nil))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(eraseAnonymizedUserChangesQuery)).WithArgs(pq.Int64Array{12}).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	s.expectPersonalDataDeleted(pq.Int64Array{12}, pq.StringArray{"+6281215183300"})
+	s.dbMock.ExpectExec(regexp.QuoteMeta(createAnonymizedAuditEventsQuery)).WithArgs(s.input.AuditAction, pq.Int64Array{12}).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	s.dbMock.ExpectRollback()
+	res, err := s.repo.AnonymizeUsers(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *AnonymizeUsersTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(anonymizeUsersQuery)).WithArgs(s.input.DeletionRequestedBefore, s.input.Limit).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "phone_no", "avatar_key"}).AddRow(12, "+6281215183300", nil).AddRow(42, "+6281215183301", "avatars/42/random-key"))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(eraseAnonymizedUserChangesQuery)).WithArgs(pq.Int64Array{12, 42}).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	s.expectPersonalDataDeleted(pq.Int64Array{12, 42}, pq.StringArray{"+6281215183300", "+6281215183301"})
+	s.dbMock.ExpectExec(regexp.QuoteMeta(queueAvatarDeletionsQuery)).WithArgs(pq.StringArray{"avatars/42/random-key"}).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(createAnonymizedAuditEventsQuery)).WithArgs(s.input.AuditAction, pq.Int64Array{12, 42}).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.dbMock.ExpectCommit()
+	res, err := s.repo.AnonymizeUsers(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal([]uint64{12, 42}, res.IDs)
+}
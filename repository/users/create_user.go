@@ -9,10 +9,15 @@ import (
 )
 
 const (
-	createUserQuery = `INSERT INTO "users" (phone_no, full_name, password_hash, successful_login_count) VALUES ($1, $2, $3, 0) RETURNING id;`
+	// phone numbers released by a phone number change are reserved to their previous owner for a while
+	createUserQuery = `INSERT INTO "users" (phone_no, full_name, password_hash, successful_login_count) SELECT $1, $2, $3, 0 ` +
+		`WHERE NOT EXISTS (SELECT 1 FROM phone_no_reservations WHERE phone_no=$1 AND reserved_until>now()) RETURNING id;`
 )
 
 func (u *userRepository) CreateUser(ctx context.Context, input repository.CreateUserInput) (output repository.CreateUserOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "CreateUser")
+	defer func() { repository.EndSpan(span, err) }()
+
 	var result *sql.Rows
 	if result, err = u.db.QueryContext(ctx, createUserQuery, input.PhoneNo, input.FullName, input.PasswordHash); err != nil {
 		var pqErr *pq.Error
@@ -24,7 +29,14 @@ func (u *userRepository) CreateUser(ctx context.Context, input repository.Create
 		return
 	}
 
-	result.Next()
+	defer result.Close()
+
+	if !result.Next() {
+		if err = result.Err(); err == nil {
+			err = repository.ErrorRecordConflict
+		}
+		return
+	}
 	if err = result.Scan(&output.ID); err != nil {
 		return
 	}
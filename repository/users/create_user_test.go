@@ -66,6 +66,17 @@ func (s *CreateUserTestSuite) TestRecordConflictError() {
 	a.ErrorIs(err, repository.ErrorRecordConflict)
 }
 
+func (s *CreateUserTestSuite) TestPhoneNoReserved() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(createUserQuery)).WithArgs(s.input.PhoneNo, s.input.FullName, s.input.PasswordHash).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	res, err := s.repo.CreateUser(s.ctx, s.input)
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordConflict)
+}
+
 func (s *CreateUserTestSuite) TestSuccess() {
 	a := assert.New(s.T())
 
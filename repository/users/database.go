@@ -0,0 +1,11 @@
+package users
+
+import "context"
+
+func (u *userRepository) Ping(ctx context.Context) error {
+	return u.db.PingContext(ctx)
+}
+
+func (u *userRepository) Close() error {
+	return u.db.Close()
+}
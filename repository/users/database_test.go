@@ -0,0 +1,27 @@
+package users
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	a := assert.New(t)
+
+	repo := &userRepository{}
+	var dbMock sqlmock.Sqlmock
+	repo.db, dbMock, _ = sqlmock.New(sqlmock.MonitorPingsOption(true))
+
+	dbMock.ExpectPing()
+	a.NoError(repo.Ping(context.Background()))
+
+	dbMock.ExpectPing().WillReturnError(&pq.Error{Message: "connection refused"})
+	a.EqualError(repo.Ping(context.Background()), "pq: connection refused")
+
+	dbMock.ExpectClose()
+	a.NoError(repo.Close())
+	a.NoError(dbMock.ExpectationsWereMet())
+}
@@ -0,0 +1,20 @@
+package users
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	deleteAvatarDeletionQuery = `DELETE FROM avatar_deletions WHERE avatar_key=$1;`
+)
+
+func (u *userRepository) DeleteAvatarDeletion(ctx context.Context, input repository.DeleteAvatarDeletionInput) (output repository.DeleteAvatarDeletionOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "DeleteAvatarDeletion")
+	defer func() { repository.EndSpan(span, err) }()
+
+	if _, err = u.db.ExecContext(ctx, deleteAvatarDeletionQuery, input.AvatarKey); err != nil {
+		return
+	}
+	return output, nil
+}
@@ -0,0 +1,61 @@
+package users
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type DeleteAvatarDeletionTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input repository.DeleteAvatarDeletionInput
+	ctx   context.Context
+}
+
+func TestDeleteAvatarDeletionTestSuite(t *testing.T) {
+	suite.Run(t, new(DeleteAvatarDeletionTestSuite))
+}
+
+func (s *DeleteAvatarDeletionTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.DeleteAvatarDeletionInput{AvatarKey: "avatars/42/random-key"}
+	s.ctx = context.Background()
+}
+
+func (s *DeleteAvatarDeletionTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *DeleteAvatarDeletionTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(deleteAvatarDeletionQuery)).WithArgs(s.input.AvatarKey).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	_, err := s.repo.DeleteAvatarDeletion(s.ctx, s.input)
+
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *DeleteAvatarDeletionTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(deleteAvatarDeletionQuery)).WithArgs(s.input.AvatarKey).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := s.repo.DeleteAvatarDeletion(s.ctx, s.input)
+
+	a.Empty(err)
+}
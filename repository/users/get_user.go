@@ -3,29 +3,46 @@ package users
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"github.com/SawitProRecruitment/UserService/repository"
 )
 
 const (
-	getUserByIDQuery      = `SELECT id, phone_no, full_name, password_hash, successful_login_count FROM users WHERE id=$1;`
-	getUserByPhoneNoQuery = `SELECT id, phone_no, full_name, password_hash, successful_login_count FROM users WHERE phone_no=$1;`
+	getUserByIDQuery      = `SELECT id, phone_no, full_name, email, email_verified_at, password_hash, pin_hash, pin_failed_attempts, successful_login_count, status, deletion_requested_at, avatar_key, attributes, version, created_at FROM users WHERE id=$1;`
+	getUserByPhoneNoQuery = `SELECT id, phone_no, full_name, email, email_verified_at, password_hash, pin_hash, pin_failed_attempts, successful_login_count, status, deletion_requested_at, avatar_key, attributes, version, created_at FROM users WHERE phone_no=$1;`
+	getUserByEmailQuery   = `SELECT id, phone_no, full_name, email, email_verified_at, password_hash, pin_hash, pin_failed_attempts, successful_login_count, status, deletion_requested_at, avatar_key, attributes, version, created_at FROM users WHERE LOWER(email)=LOWER($1) AND email_verified_at IS NOT NULL;`
 )
 
 func (u *userRepository) GetUser(ctx context.Context, input repository.GetUserInput) (output repository.GetUserOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "GetUser")
+	defer func() { repository.EndSpan(span, err) }()
+
 	var row *sql.Row
 	if input.PhoneNo != "" {
 		row = u.db.QueryRowContext(ctx, getUserByPhoneNoQuery, input.PhoneNo)
+	} else if input.Email != "" {
+		row = u.db.QueryRowContext(ctx, getUserByEmailQuery, input.Email)
 	} else {
 		row = u.db.QueryRowContext(ctx, getUserByIDQuery, input.ID)
 	}
 
-	if err = row.Scan(&output.ID, &output.PhoneNo, &output.FullName, &output.PasswordHash, &output.SuccessfulLoginCount); err != nil {
+	var email, avatarKey sql.NullString
+	var emailVerifiedAt, deletionRequestedAt sql.NullTime
+	var attributes []byte
+	if err = row.Scan(&output.ID, &output.PhoneNo, &output.FullName, &email, &emailVerifiedAt, &output.PasswordHash, &output.PinHash, &output.PinFailedAttempts, &output.SuccessfulLoginCount, &output.Status, &deletionRequestedAt, &avatarKey, &attributes, &output.Version, &output.CreatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = repository.ErrorRecordNotFound
 			return
 		}
-		return
+		return repository.GetUserOutput{}, err
+	}
+	output.Email = email.String
+	output.EmailVerifiedAt = emailVerifiedAt.Time
+	output.DeletionRequestedAt = deletionRequestedAt.Time
+	output.AvatarKey = avatarKey.String
+	if err = json.Unmarshal(attributes, &output.Attributes); err != nil {
+		return repository.GetUserOutput{}, err
 	}
 
 	return output, nil
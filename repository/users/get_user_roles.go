@@ -0,0 +1,34 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	getUserRolesQuery = `SELECT role FROM user_roles WHERE user_id=$1 ORDER BY role;`
+)
+
+func (u *userRepository) GetUserRoles(ctx context.Context, input repository.GetUserRolesInput) (output repository.GetUserRolesOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "GetUserRoles")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var rows *sql.Rows
+	if rows, err = u.db.QueryContext(ctx, getUserRolesQuery, input.UserID); err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var role string
+		if err = rows.Scan(&role); err != nil {
+			return repository.GetUserRolesOutput{}, err
+		}
+		output.Roles = append(output.Roles, role)
+	}
+	if err = rows.Err(); err != nil {
+		return repository.GetUserRolesOutput{}, err
+	}
+	return output, nil
+}
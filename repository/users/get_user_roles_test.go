@@ -0,0 +1,74 @@
+package users
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type GetUserRolesTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input repository.GetUserRolesInput
+	ctx   context.Context
+}
+
+func TestGetUserRolesTestSuite(t *testing.T) {
+	suite.Run(t, new(GetUserRolesTestSuite))
+}
+
+func (s *GetUserRolesTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.GetUserRolesInput{UserID: 123}
+	s.ctx = context.Background()
+}
+
+func (s *GetUserRolesTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *GetUserRolesTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserRolesQuery)).WithArgs(s.input.UserID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.GetUserRoles(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *GetUserRolesTestSuite) TestNoRoles() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserRolesQuery)).WithArgs(s.input.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}))
+	res, err := s.repo.GetUserRoles(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Empty(res.Roles)
+}
+
+func (s *GetUserRolesTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserRolesQuery)).WithArgs(s.input.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow("admin").AddRow("farmer"))
+	res, err := s.repo.GetUserRoles(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal([]string{"admin", "farmer"}, res.Roles)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"regexp"
 	"testing"
+	"time"
 )
 
 type GetUserTestSuite struct {
@@ -38,8 +39,17 @@ func (s *GetUserTestSuite) SetupTest() {
 		ID:                   12,
 		PhoneNo:              "+6281215182299",
 		FullName:             "John Smith",
+		Email:                "john.smith@example.com",
+		EmailVerifiedAt:      time.Date(2024, 3, 17, 9, 55, 0, 0, time.UTC),
 		PasswordHash:         []byte("random-salted-password-hash"),
+		PinHash:              []byte("random-salted-pin-hash"),
+		PinFailedAttempts:    1,
 		SuccessfulLoginCount: 2,
+		Status:               "active",
+		AvatarKey:            "avatars/12/random-key",
+		Attributes:           map[string]interface{}{"estate_code": "KAL-01"},
+		Version:              5,
+		CreatedAt:            time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC),
 	}
 	s.ctx = context.Background()
 }
@@ -65,7 +75,7 @@ func (s *GetUserTestSuite) TestGetByPhoneNoNotFound() {
 	a := assert.New(s.T())
 
 	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserByPhoneNoQuery)).WithArgs(s.input.PhoneNo).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "phone_no", "full_name", "password_hash", "successful_login_count"}))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "phone_no", "full_name", "email", "email_verified_at", "password_hash", "pin_hash", "pin_failed_attempts", "successful_login_count", "status", "deletion_requested_at", "avatar_key", "attributes", "version", "created_at"}))
 
 	res, err := s.repo.GetUser(s.ctx, s.input)
 	a.Empty(res)
@@ -77,8 +87,8 @@ func (s *GetUserTestSuite) TestSuccessGetByPhoneNo() {
 
 	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserByPhoneNoQuery)).WithArgs(s.input.PhoneNo).
 		WillReturnRows(
-			sqlmock.NewRows([]string{"id", "phone_no", "full_name", "password_hash", "successful_login_count"}).
-				AddRow(s.output.ID, s.output.PhoneNo, s.output.FullName, s.output.PasswordHash, s.output.SuccessfulLoginCount),
+			sqlmock.NewRows([]string{"id", "phone_no", "full_name", "email", "email_verified_at", "password_hash", "pin_hash", "pin_failed_attempts", "successful_login_count", "status", "deletion_requested_at", "avatar_key", "attributes", "version", "created_at"}).
+				AddRow(s.output.ID, s.output.PhoneNo, s.output.FullName, s.output.Email, s.output.EmailVerifiedAt, s.output.PasswordHash, s.output.PinHash, s.output.PinFailedAttempts, s.output.SuccessfulLoginCount, s.output.Status, nil, s.output.AvatarKey, []byte(`{"estate_code":"KAL-01"}`), s.output.Version, s.output.CreatedAt),
 		)
 
 	res, err := s.repo.GetUser(s.ctx, s.input)
@@ -105,7 +115,7 @@ func (s *GetUserTestSuite) TestGetByIDNotFound() {
 	s.input.PhoneNo = ""
 	s.input.ID = 123
 	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserByIDQuery)).WithArgs(s.input.ID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "phone_no", "full_name", "password_hash", "successful_login_count"}))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "phone_no", "full_name", "email", "email_verified_at", "password_hash", "pin_hash", "pin_failed_attempts", "successful_login_count", "status", "deletion_requested_at", "avatar_key", "attributes", "version", "created_at"}))
 
 	res, err := s.repo.GetUser(s.ctx, s.input)
 	a.Empty(res)
@@ -119,8 +129,38 @@ func (s *GetUserTestSuite) TestSuccessGetByID() {
 	s.input.ID = 123
 	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserByIDQuery)).WithArgs(s.input.ID).
 		WillReturnRows(
-			sqlmock.NewRows([]string{"id", "phone_no", "full_name", "password_hash", "successful_login_count"}).
-				AddRow(s.output.ID, s.output.PhoneNo, s.output.FullName, s.output.PasswordHash, s.output.SuccessfulLoginCount),
+			sqlmock.NewRows([]string{"id", "phone_no", "full_name", "email", "email_verified_at", "password_hash", "pin_hash", "pin_failed_attempts", "successful_login_count", "status", "deletion_requested_at", "avatar_key", "attributes", "version", "created_at"}).
+				AddRow(s.output.ID, s.output.PhoneNo, s.output.FullName, s.output.Email, s.output.EmailVerifiedAt, s.output.PasswordHash, s.output.PinHash, s.output.PinFailedAttempts, s.output.SuccessfulLoginCount, s.output.Status, nil, s.output.AvatarKey, []byte(`{"estate_code":"KAL-01"}`), s.output.Version, s.output.CreatedAt),
+		)
+
+	res, err := s.repo.GetUser(s.ctx, s.input)
+	a.Empty(err)
+	a.Equal(s.output, res)
+}
+
+func (s *GetUserTestSuite) TestGetByEmailNotFound() {
+	a := assert.New(s.T())
+
+	s.input.PhoneNo = ""
+	s.input.Email = "John.Smith@example.com"
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserByEmailQuery)).WithArgs(s.input.Email).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "phone_no", "full_name", "email", "email_verified_at", "password_hash", "pin_hash", "pin_failed_attempts", "successful_login_count", "status", "deletion_requested_at", "avatar_key", "attributes", "version", "created_at"}))
+
+	res, err := s.repo.GetUser(s.ctx, s.input)
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *GetUserTestSuite) TestSuccessGetByEmail() {
+	a := assert.New(s.T())
+
+	s.input.PhoneNo = ""
+	s.input.Email = "John.Smith@example.com"
+	s.output.EmailVerifiedAt = time.Time{}
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(getUserByEmailQuery)).WithArgs(s.input.Email).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "phone_no", "full_name", "email", "email_verified_at", "password_hash", "pin_hash", "pin_failed_attempts", "successful_login_count", "status", "deletion_requested_at", "avatar_key", "attributes", "version", "created_at"}).
+				AddRow(s.output.ID, s.output.PhoneNo, s.output.FullName, s.output.Email, nil, s.output.PasswordHash, s.output.PinHash, s.output.PinFailedAttempts, s.output.SuccessfulLoginCount, s.output.Status, nil, s.output.AvatarKey, []byte(`{"estate_code":"KAL-01"}`), s.output.Version, s.output.CreatedAt),
 		)
 
 	res, err := s.repo.GetUser(s.ctx, s.input)
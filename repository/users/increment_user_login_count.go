@@ -0,0 +1,30 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	// incremented in place, so that concurrent logins are all counted. The count is part of the profile, so the
+	// version is bumped as well for its ETag to change
+	incrementUserLoginCountQuery = `UPDATE users SET successful_login_count=successful_login_count+1, version=version+1 WHERE id=$1;`
+)
+
+func (u *userRepository) IncrementUserLoginCount(ctx context.Context, input repository.IncrementUserLoginCountInput) (output repository.IncrementUserLoginCountOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "IncrementUserLoginCount")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var result sql.Result
+	if result, err = u.db.ExecContext(ctx, incrementUserLoginCountQuery, input.ID); err != nil {
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected <= 0 {
+		err = repository.ErrorRecordNotFound
+		return
+	}
+
+	return output, nil
+}
@@ -0,0 +1,71 @@
+package users
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type IncrementUserLoginCountTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input repository.IncrementUserLoginCountInput
+	ctx   context.Context
+}
+
+func TestIncrementUserLoginCountTestSuite(t *testing.T) {
+	suite.Run(t, new(IncrementUserLoginCountTestSuite))
+}
+
+func (s *IncrementUserLoginCountTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.IncrementUserLoginCountInput{ID: 123}
+	s.ctx = context.Background()
+}
+
+func (s *IncrementUserLoginCountTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *IncrementUserLoginCountTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(incrementUserLoginCountQuery)).WithArgs(s.input.ID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	_, err := s.repo.IncrementUserLoginCount(s.ctx, s.input)
+
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *IncrementUserLoginCountTestSuite) TestRecordNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(incrementUserLoginCountQuery)).WithArgs(s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err := s.repo.IncrementUserLoginCount(s.ctx, s.input)
+
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *IncrementUserLoginCountTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(incrementUserLoginCountQuery)).WithArgs(s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := s.repo.IncrementUserLoginCount(s.ctx, s.input)
+
+	a.Empty(err)
+}
@@ -0,0 +1,34 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	listAvatarDeletionsQuery = `SELECT avatar_key FROM avatar_deletions ORDER BY created_at LIMIT $1;`
+)
+
+func (u *userRepository) ListAvatarDeletions(ctx context.Context, input repository.ListAvatarDeletionsInput) (output repository.ListAvatarDeletionsOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "ListAvatarDeletions")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var rows *sql.Rows
+	if rows, err = u.db.QueryContext(ctx, listAvatarDeletionsQuery, input.Limit); err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var avatarKey string
+		if err = rows.Scan(&avatarKey); err != nil {
+			return repository.ListAvatarDeletionsOutput{}, err
+		}
+		output.AvatarKeys = append(output.AvatarKeys, avatarKey)
+	}
+	if err = rows.Err(); err != nil {
+		return repository.ListAvatarDeletionsOutput{}, err
+	}
+	return output, nil
+}
@@ -0,0 +1,63 @@
+package users
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type ListAvatarDeletionsTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input repository.ListAvatarDeletionsInput
+	ctx   context.Context
+}
+
+func TestListAvatarDeletionsTestSuite(t *testing.T) {
+	suite.Run(t, new(ListAvatarDeletionsTestSuite))
+}
+
+func (s *ListAvatarDeletionsTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.ListAvatarDeletionsInput{Limit: 100}
+	s.ctx = context.Background()
+}
+
+func (s *ListAvatarDeletionsTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *ListAvatarDeletionsTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(listAvatarDeletionsQuery)).WithArgs(s.input.Limit).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.ListAvatarDeletions(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *ListAvatarDeletionsTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(listAvatarDeletionsQuery)).WithArgs(s.input.Limit).
+		WillReturnRows(sqlmock.NewRows([]string{"avatar_key"}).AddRow("avatars/12/random-key").AddRow("avatars/42/random-key"))
+	res, err := s.repo.ListAvatarDeletions(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal([]string{"avatars/12/random-key", "avatars/42/random-key"}, res.AvatarKeys)
+}
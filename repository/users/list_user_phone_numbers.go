@@ -0,0 +1,35 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	listUserPhoneNumbersQuery = `SELECT id, phone_no FROM users WHERE id>$1 AND phone_no NOT LIKE 'deleted:%' ORDER BY id LIMIT $2;`
+)
+
+func (u *userRepository) ListUserPhoneNumbers(ctx context.Context, input repository.ListUserPhoneNumbersInput) (output repository.ListUserPhoneNumbersOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "ListUserPhoneNumbers")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var rows *sql.Rows
+	if rows, err = u.db.QueryContext(ctx, listUserPhoneNumbersQuery, input.AfterID, input.Limit); err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item repository.UserPhoneNo
+		if err = rows.Scan(&item.ID, &item.PhoneNo); err != nil {
+			return repository.ListUserPhoneNumbersOutput{}, err
+		}
+		output.Users = append(output.Users, item)
+	}
+	if err = rows.Err(); err != nil {
+		return repository.ListUserPhoneNumbersOutput{}, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,66 @@
+package users
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type ListUserPhoneNumbersTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input repository.ListUserPhoneNumbersInput
+	ctx   context.Context
+}
+
+func TestListUserPhoneNumbersTestSuite(t *testing.T) {
+	suite.Run(t, new(ListUserPhoneNumbersTestSuite))
+}
+
+func (s *ListUserPhoneNumbersTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.ListUserPhoneNumbersInput{AfterID: 10, Limit: 2}
+	s.ctx = context.Background()
+}
+
+func (s *ListUserPhoneNumbersTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *ListUserPhoneNumbersTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(listUserPhoneNumbersQuery)).WithArgs(s.input.AfterID, s.input.Limit).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.ListUserPhoneNumbers(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *ListUserPhoneNumbersTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(listUserPhoneNumbersQuery)).WithArgs(s.input.AfterID, s.input.Limit).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "phone_no"}).AddRow(11, "+62 812151833").AddRow(12, "+6281215183300"))
+	res, err := s.repo.ListUserPhoneNumbers(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(repository.ListUserPhoneNumbersOutput{Users: []repository.UserPhoneNo{
+		{ID: 11, PhoneNo: "+62 812151833"},
+		{ID: 12, PhoneNo: "+6281215183300"},
+	}}, res)
+}
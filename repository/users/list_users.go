@@ -0,0 +1,84 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"strings"
+)
+
+const (
+	listUsersQuery = `SELECT id, phone_no, full_name, successful_login_count, status, created_at, ` +
+		`ARRAY(SELECT role FROM user_roles WHERE user_roles.user_id = users.id ORDER BY role) ` +
+		`FROM users%s ORDER BY id DESC LIMIT $%d;`
+)
+
+var (
+	likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`) // escape LIKE wildcards in user provided text
+)
+
+func (u *userRepository) ListUsers(ctx context.Context, input repository.ListUsersInput) (output repository.ListUsersOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "ListUsers")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var conditions []string
+	var params []interface{}
+	id := 1
+	if input.PhonePrefix != "" {
+		conditions = append(conditions, fmt.Sprintf("phone_no LIKE $%d", id))
+		params = append(params, likeEscaper.Replace(input.PhonePrefix)+"%")
+		id += 1
+	}
+	if input.NameContains != "" {
+		conditions = append(conditions, fmt.Sprintf("full_name ILIKE $%d", id))
+		params = append(params, "%"+likeEscaper.Replace(input.NameContains)+"%")
+		id += 1
+	}
+	if !input.CreatedFrom.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("created_at>=$%d", id))
+		params = append(params, input.CreatedFrom)
+		id += 1
+	}
+	if !input.CreatedTo.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("created_at<$%d", id))
+		params = append(params, input.CreatedTo)
+		id += 1
+	}
+	if input.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("status=$%d", id))
+		params = append(params, input.Status)
+		id += 1
+	}
+	if input.BeforeID != 0 {
+		conditions = append(conditions, fmt.Sprintf("id<$%d", id))
+		params = append(params, input.BeforeID)
+		id += 1
+	}
+
+	var where string
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query := fmt.Sprintf(listUsersQuery, where, id)
+	params = append(params, input.Limit)
+
+	var rows *sql.Rows
+	if rows, err = u.db.QueryContext(ctx, query, params...); err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item repository.ListUsersItem
+		if err = rows.Scan(&item.ID, &item.PhoneNo, &item.FullName, &item.SuccessfulLoginCount, &item.Status, &item.CreatedAt, (*pq.StringArray)(&item.Roles)); err != nil {
+			return repository.ListUsersOutput{}, err
+		}
+		output.Users = append(output.Users, item)
+	}
+	if err = rows.Err(); err != nil {
+		return repository.ListUsersOutput{}, err
+	}
+	return output, nil
+}
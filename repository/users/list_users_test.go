@@ -0,0 +1,97 @@
+package users
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type ListUsersTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input   repository.ListUsersInput
+	output  repository.ListUsersOutput
+	columns []string
+	ctx     context.Context
+}
+
+func TestListUsersTestSuite(t *testing.T) {
+	suite.Run(t, new(ListUsersTestSuite))
+}
+
+func (s *ListUsersTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.ListUsersInput{
+		PhonePrefix:  "+62812",
+		NameContains: "100%_smith",
+		CreatedFrom:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		CreatedTo:    time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		Status:       "suspended",
+		BeforeID:     100,
+		Limit:        2,
+	}
+	s.output = repository.ListUsersOutput{Users: []repository.ListUsersItem{
+		{ID: 99, PhoneNo: "+6281215182299", FullName: "John Smith", SuccessfulLoginCount: 2, Status: "suspended", CreatedAt: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC), Roles: []string{"admin", "farmer"}},
+		{ID: 42, PhoneNo: "+6281215182242", FullName: "Jane Smith", SuccessfulLoginCount: 0, Status: "suspended", CreatedAt: time.Date(2024, 3, 2, 9, 55, 0, 0, time.UTC), Roles: []string{}},
+	}}
+	s.columns = []string{"id", "phone_no", "full_name", "successful_login_count", "status", "created_at", "roles"}
+	s.ctx = context.Background()
+}
+
+func (s *ListUsersTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *ListUsersTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.input = repository.ListUsersInput{Limit: 20}
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("FROM users ORDER BY id DESC LIMIT $1;")).WithArgs(20).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.ListUsers(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *ListUsersTestSuite) TestEmptyResult() {
+	a := assert.New(s.T())
+
+	s.input = repository.ListUsersInput{Limit: 20}
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("FROM users ORDER BY id DESC LIMIT $1;")).WithArgs(20).
+		WillReturnRows(sqlmock.NewRows(s.columns))
+	res, err := s.repo.ListUsers(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Empty(res.Users)
+}
+
+func (s *ListUsersTestSuite) TestSuccessWithAllFilters() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("FROM users WHERE phone_no LIKE $1 AND full_name ILIKE $2 AND created_at>=$3 AND created_at<$4 AND status=$5 AND id<$6 ORDER BY id DESC LIMIT $7;")).
+		WithArgs("+62812%", `%100\%\_smith%`, s.input.CreatedFrom, s.input.CreatedTo, s.input.Status, s.input.BeforeID, s.input.Limit).
+		WillReturnRows(
+			sqlmock.NewRows(s.columns).
+				AddRow(99, "+6281215182299", "John Smith", 2, "suspended", s.output.Users[0].CreatedAt, "{admin,farmer}").
+				AddRow(42, "+6281215182242", "Jane Smith", 0, "suspended", s.output.Users[1].CreatedAt, "{}"),
+		)
+	res, err := s.repo.ListUsers(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, res)
+}
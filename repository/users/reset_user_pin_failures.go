@@ -0,0 +1,28 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	resetUserPINFailuresQuery = `UPDATE users SET pin_failed_attempts=0 WHERE id=$1;`
+)
+
+func (u *userRepository) ResetUserPINFailures(ctx context.Context, input repository.ResetUserPINFailuresInput) (output repository.ResetUserPINFailuresOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "ResetUserPINFailures")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var result sql.Result
+	if result, err = u.db.ExecContext(ctx, resetUserPINFailuresQuery, input.ID); err != nil {
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected <= 0 {
+		err = repository.ErrorRecordNotFound
+		return
+	}
+
+	return output, nil
+}
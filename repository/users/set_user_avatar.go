@@ -0,0 +1,38 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	// the previous avatar is selected and queued for removal in the same statement, so that concurrent uploads never
+	// lose track of a blob, and a failed removal is retried from the queue
+	setUserAvatarQuery = `WITH previous AS (SELECT id, avatar_key FROM users WHERE id=$2 FOR UPDATE), ` +
+		`updated AS (UPDATE users SET avatar_key=$1, version=users.version+1 FROM previous WHERE users.id=previous.id RETURNING previous.avatar_key), ` +
+		`queued AS (INSERT INTO avatar_deletions (avatar_key) SELECT avatar_key FROM updated WHERE avatar_key IS NOT NULL ON CONFLICT DO NOTHING) ` +
+		`SELECT avatar_key FROM updated;`
+)
+
+func (u *userRepository) SetUserAvatar(ctx context.Context, input repository.SetUserAvatarInput) (output repository.SetUserAvatarOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "SetUserAvatar")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var avatarKey sql.NullString
+	if input.AvatarKey != "" {
+		avatarKey = sql.NullString{String: input.AvatarKey, Valid: true}
+	}
+
+	var previous sql.NullString
+	if err = u.db.QueryRowContext(ctx, setUserAvatarQuery, avatarKey, input.ID).Scan(&previous); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrorRecordNotFound
+			return
+		}
+		return
+	}
+
+	return repository.SetUserAvatarOutput{PreviousAvatarKey: previous.String}, nil
+}
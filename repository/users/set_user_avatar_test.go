@@ -0,0 +1,86 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type SetUserAvatarTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input repository.SetUserAvatarInput
+	ctx   context.Context
+}
+
+func TestSetUserAvatarTestSuite(t *testing.T) {
+	suite.Run(t, new(SetUserAvatarTestSuite))
+}
+
+func (s *SetUserAvatarTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.SetUserAvatarInput{ID: 123, AvatarKey: "avatars/123/new-key"}
+	s.ctx = context.Background()
+}
+
+func (s *SetUserAvatarTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *SetUserAvatarTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(setUserAvatarQuery)).WithArgs(s.input.AvatarKey, s.input.ID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	res, err := s.repo.SetUserAvatar(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *SetUserAvatarTestSuite) TestRecordNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(setUserAvatarQuery)).WithArgs(s.input.AvatarKey, s.input.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"avatar_key"}))
+	_, err := s.repo.SetUserAvatar(s.ctx, s.input)
+
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *SetUserAvatarTestSuite) TestRemove() {
+	a := assert.New(s.T())
+
+	s.input.AvatarKey = ""
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(setUserAvatarQuery)).WithArgs(sql.NullString{}, s.input.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"avatar_key"}).AddRow("avatars/123/old-key"))
+	res, err := s.repo.SetUserAvatar(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal("avatars/123/old-key", res.PreviousAvatarKey)
+}
+
+func (s *SetUserAvatarTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(setUserAvatarQuery)).WithArgs(s.input.AvatarKey, s.input.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"avatar_key"}).AddRow(nil))
+	res, err := s.repo.SetUserAvatar(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Empty(res.PreviousAvatarKey)
+}
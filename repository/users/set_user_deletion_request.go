@@ -0,0 +1,30 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	setUserDeletionRequestQuery = `UPDATE users SET deletion_requested_at=$1 WHERE id=$2;`
+)
+
+func (u *userRepository) SetUserDeletionRequest(ctx context.Context, input repository.SetUserDeletionRequestInput) (output repository.SetUserDeletionRequestOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "SetUserDeletionRequest")
+	defer func() { repository.EndSpan(span, err) }()
+
+	requestedAt := sql.NullTime{Time: input.RequestedAt, Valid: !input.RequestedAt.IsZero()}
+
+	var result sql.Result
+	if result, err = u.db.ExecContext(ctx, setUserDeletionRequestQuery, requestedAt, input.ID); err != nil {
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected <= 0 {
+		err = repository.ErrorRecordNotFound
+		return
+	}
+
+	return output, nil
+}
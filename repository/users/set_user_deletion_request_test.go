@@ -0,0 +1,83 @@
+package users
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type SetUserDeletionRequestTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input repository.SetUserDeletionRequestInput
+	ctx   context.Context
+}
+
+func TestSetUserDeletionRequestTestSuite(t *testing.T) {
+	suite.Run(t, new(SetUserDeletionRequestTestSuite))
+}
+
+func (s *SetUserDeletionRequestTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.SetUserDeletionRequestInput{ID: 123, RequestedAt: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)}
+	s.ctx = context.Background()
+}
+
+func (s *SetUserDeletionRequestTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *SetUserDeletionRequestTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(setUserDeletionRequestQuery)).WithArgs(s.input.RequestedAt, s.input.ID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	_, err := s.repo.SetUserDeletionRequest(s.ctx, s.input)
+
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *SetUserDeletionRequestTestSuite) TestRecordNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(setUserDeletionRequestQuery)).WithArgs(s.input.RequestedAt, s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err := s.repo.SetUserDeletionRequest(s.ctx, s.input)
+
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *SetUserDeletionRequestTestSuite) TestCancel() {
+	a := assert.New(s.T())
+
+	s.input.RequestedAt = time.Time{}
+	s.dbMock.ExpectExec(regexp.QuoteMeta(setUserDeletionRequestQuery)).WithArgs(nil, s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := s.repo.SetUserDeletionRequest(s.ctx, s.input)
+
+	a.Empty(err)
+}
+
+func (s *SetUserDeletionRequestTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(setUserDeletionRequestQuery)).WithArgs(s.input.RequestedAt, s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := s.repo.SetUserDeletionRequest(s.ctx, s.input)
+
+	a.Empty(err)
+}
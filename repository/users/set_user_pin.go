@@ -0,0 +1,33 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	setUserPINQuery = `UPDATE users SET pin_hash=$1, pin_failed_attempts=0 WHERE id=$2;`
+)
+
+func (u *userRepository) SetUserPIN(ctx context.Context, input repository.SetUserPINInput) (output repository.SetUserPINOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "SetUserPIN")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var pinHash []byte
+	if len(input.PinHash) > 0 {
+		pinHash = input.PinHash
+	}
+
+	var result sql.Result
+	if result, err = u.db.ExecContext(ctx, setUserPINQuery, pinHash, input.ID); err != nil {
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected <= 0 {
+		err = repository.ErrorRecordNotFound
+		return
+	}
+
+	return output, nil
+}
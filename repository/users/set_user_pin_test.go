@@ -0,0 +1,82 @@
+package users
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type SetUserPINTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input repository.SetUserPINInput
+	ctx   context.Context
+}
+
+func TestSetUserPINTestSuite(t *testing.T) {
+	suite.Run(t, new(SetUserPINTestSuite))
+}
+
+func (s *SetUserPINTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.SetUserPINInput{ID: 123, PinHash: []byte("pin-hash")}
+	s.ctx = context.Background()
+}
+
+func (s *SetUserPINTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *SetUserPINTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(setUserPINQuery)).WithArgs(s.input.PinHash, s.input.ID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	_, err := s.repo.SetUserPIN(s.ctx, s.input)
+
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *SetUserPINTestSuite) TestRecordNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(setUserPINQuery)).WithArgs(s.input.PinHash, s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err := s.repo.SetUserPIN(s.ctx, s.input)
+
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *SetUserPINTestSuite) TestRemove() {
+	a := assert.New(s.T())
+
+	s.input.PinHash = nil
+	s.dbMock.ExpectExec(regexp.QuoteMeta(setUserPINQuery)).WithArgs([]byte(nil), s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := s.repo.SetUserPIN(s.ctx, s.input)
+
+	a.Empty(err)
+}
+
+func (s *SetUserPINTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(setUserPINQuery)).WithArgs(s.input.PinHash, s.input.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := s.repo.SetUserPIN(s.ctx, s.input)
+
+	a.Empty(err)
+}
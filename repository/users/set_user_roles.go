@@ -0,0 +1,54 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/repository/audit"
+	"github.com/lib/pq"
+)
+
+const (
+	deleteUserRolesQuery = `DELETE FROM user_roles WHERE user_id=$1;`
+	insertUserRolesQuery = `INSERT INTO user_roles (user_id, role) SELECT $1, unnest($2::VARCHAR[]);`
+)
+
+func (u *userRepository) SetUserRoles(ctx context.Context, input repository.SetUserRolesInput) (output repository.SetUserRolesOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "SetUserRoles")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var tx *sql.Tx
+	if tx, err = u.db.BeginTx(ctx, nil); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, deleteUserRolesQuery, input.UserID); err != nil {
+		return
+	}
+	if len(input.Roles) > 0 {
+		if _, err = tx.ExecContext(ctx, insertUserRolesQuery, input.UserID, pq.Array(input.Roles)); err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) {
+				if pqErr.Code == "23503" { // foreign key violation, user doesn't exist
+					err = repository.ErrorRecordNotFound
+				}
+			}
+			return
+		}
+	}
+
+	if _, err = audit.CreateAuditEventTx(ctx, tx, input.AuditEvent); err != nil {
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		return
+	}
+	return output, nil
+}
@@ -0,0 +1,123 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type SetUserRolesTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input repository.SetUserRolesInput
+	ctx   context.Context
+}
+
+func TestSetUserRolesTestSuite(t *testing.T) {
+	suite.Run(t, new(SetUserRolesTestSuite))
+}
+
+func (s *SetUserRolesTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.SetUserRolesInput{
+		UserID:     123,
+		Roles:      []string{"farmer", "support"},
+		AuditEvent: repository.CreateAuditEventInput{ActorUserID: 1, Action: "user.roles.set", TargetUserID: 123},
+	}
+	s.ctx = context.Background()
+}
+
+func (s *SetUserRolesTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *SetUserRolesTestSuite) TestDeleteDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectExec(regexp.QuoteMeta(deleteUserRolesQuery)).WithArgs(s.input.UserID).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	s.dbMock.ExpectRollback()
+	_, err := s.repo.SetUserRoles(s.ctx, s.input)
+
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *SetUserRolesTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectExec(regexp.QuoteMeta(deleteUserRolesQuery)).WithArgs(s.input.UserID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(insertUserRolesQuery)).WithArgs(s.input.UserID, pq.Array(s.input.Roles)).
+		WillReturnError(&pq.Error{Message: "some error message here", Code: "23503"})
+	s.dbMock.ExpectRollback()
+	_, err := s.repo.SetUserRoles(s.ctx, s.input)
+
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *SetUserRolesTestSuite) TestRemoveAllRoles() {
+	a := assert.New(s.T())
+
+	s.input.Roles = nil
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectExec(regexp.QuoteMeta(deleteUserRolesQuery)).WithArgs(s.input.UserID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.expectAuditEvent()
+	s.dbMock.ExpectCommit()
+	_, err := s.repo.SetUserRoles(s.ctx, s.input)
+
+	a.Empty(err)
+}
+
+func (s *SetUserRolesTestSuite) TestAuditError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectExec(regexp.QuoteMeta(deleteUserRolesQuery)).WithArgs(s.input.UserID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(insertUserRolesQuery)).WithArgs(s.input.UserID, pq.Array(s.input.Roles)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO audit_events")).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	s.dbMock.ExpectRollback()
+	_, err := s.repo.SetUserRoles(s.ctx, s.input)
+
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *SetUserRolesTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectExec(regexp.QuoteMeta(deleteUserRolesQuery)).WithArgs(s.input.UserID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.dbMock.ExpectExec(regexp.QuoteMeta(insertUserRolesQuery)).WithArgs(s.input.UserID, pq.Array(s.input.Roles)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.expectAuditEvent()
+	s.dbMock.ExpectCommit()
+	_, err := s.repo.SetUserRoles(s.ctx, s.input)
+
+	a.Empty(err)
+}
+
+func (s *SetUserRolesTestSuite) expectAuditEvent() {
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO audit_events")).
+		WithArgs(sql.NullInt64{Int64: 1, Valid: true}, "user.roles.set", sql.NullInt64{Int64: 123, Valid: true}, []byte(`null`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+}
@@ -0,0 +1,45 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/repository/audit"
+)
+
+const (
+	// the status is compared in the same statement, so that concurrent changes can't both apply
+	setUserStatusQuery = `UPDATE users SET status=$1, version=version+1 WHERE id=$2 AND status=$3 RETURNING version;`
+)
+
+func (u *userRepository) SetUserStatus(ctx context.Context, input repository.SetUserStatusInput) (output repository.SetUserStatusOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "SetUserStatus")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var tx *sql.Tx
+	if tx, err = u.db.BeginTx(ctx, nil); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = tx.QueryRowContext(ctx, setUserStatusQuery, input.To, input.ID, input.From).Scan(&output.Version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrorRecordOutdated
+		}
+		return repository.SetUserStatusOutput{}, err
+	}
+
+	if _, err = audit.CreateAuditEventTx(ctx, tx, input.AuditEvent); err != nil {
+		return repository.SetUserStatusOutput{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return repository.SetUserStatusOutput{}, err
+	}
+	return output, nil
+}
@@ -0,0 +1,109 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+)
+
+type SetUserStatusTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input repository.SetUserStatusInput
+	ctx   context.Context
+}
+
+func TestSetUserStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(SetUserStatusTestSuite))
+}
+
+func (s *SetUserStatusTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.SetUserStatusInput{
+		ID:   123,
+		From: "active",
+		To:   "suspended",
+		AuditEvent: repository.CreateAuditEventInput{
+			ActorUserID:  1,
+			Action:       "user.suspended",
+			TargetUserID: 123,
+			Details:      map[string]interface{}{"reason": "fraud"},
+		},
+	}
+	s.ctx = context.Background()
+}
+
+func (s *SetUserStatusTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *SetUserStatusTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(setUserStatusQuery)).WithArgs(s.input.To, s.input.ID, s.input.From).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	s.dbMock.ExpectRollback()
+	res, err := s.repo.SetUserStatus(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *SetUserStatusTestSuite) TestStatusChanged() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(setUserStatusQuery)).WithArgs(s.input.To, s.input.ID, s.input.From).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	s.dbMock.ExpectRollback()
+	res, err := s.repo.SetUserStatus(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorIs(err, repository.ErrorRecordOutdated)
+}
+
+func (s *SetUserStatusTestSuite) TestAuditError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(setUserStatusQuery)).WithArgs(s.input.To, s.input.ID, s.input.From).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO audit_events")).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	s.dbMock.ExpectRollback()
+	res, err := s.repo.SetUserStatus(s.ctx, s.input)
+
+	a.Empty(res)
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *SetUserStatusTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectQuery(regexp.QuoteMeta(setUserStatusQuery)).WithArgs(s.input.To, s.input.ID, s.input.From).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO audit_events")).
+		WithArgs(sql.NullInt64{Int64: 1, Valid: true}, "user.suspended", sql.NullInt64{Int64: 123, Valid: true}, []byte(`{"reason":"fraud"}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	s.dbMock.ExpectCommit()
+	res, err := s.repo.SetUserStatus(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(repository.SetUserStatusOutput{Version: 4}, res)
+}
@@ -0,0 +1,50 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"regexp"
+	"testing"
+)
+
+func TestTracing(t *testing.T) {
+	a := assert.New(t)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	repo := &userRepository{}
+	var dbMock sqlmock.Sqlmock
+	repo.db, dbMock, _ = sqlmock.New()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta(getUserByPhoneNoQuery)).WithArgs("+6281234567890").WillReturnError(sql.ErrNoRows)
+	_, err := repo.GetUser(context.Background(), repository.GetUserInput{PhoneNo: "+6281234567890"})
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta(getUserByPhoneNoQuery)).WithArgs("+6281234567890").
+		WillReturnError(&pq.Error{Message: "connection refused"})
+	_, err = repo.GetUser(context.Background(), repository.GetUserInput{PhoneNo: "+6281234567890"})
+	a.Error(err)
+
+	spans := recorder.Ended()
+	a.Len(spans, 2)
+	a.Equal("UserRepository.GetUser", spans[0].Name())
+	a.Equal(trace.SpanKindClient, spans[0].SpanKind())
+	a.ElementsMatch([]attribute.KeyValue{
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "GetUser"),
+		attribute.String("db.outcome", "record not found"),
+	}, spans[0].Attributes(), "neither the SQL nor its parameters are recorded")
+	a.Equal(codes.Unset, spans[0].Status().Code, "a missing record is not a failure")
+	a.Equal(codes.Error, spans[1].Status().Code)
+}
@@ -3,6 +3,7 @@ package users
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/SawitProRecruitment/UserService/repository"
@@ -11,42 +12,66 @@ import (
 )
 
 func (u *userRepository) UpdateUser(ctx context.Context, input repository.UpdateUserInput) (output repository.UpdateUserOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "UpdateUser")
+	defer func() { repository.EndSpan(span, err) }()
+
 	var updates []string
 	var params []interface{}
 	id := 1
-	if input.FullName != "" {
+	if input.FullName != nil {
 		updates = append(updates, fmt.Sprintf("full_name=$%d", id))
-		params = append(params, input.FullName)
+		params = append(params, *input.FullName)
 		id += 1
 	}
-	if input.PhoneNo != "" {
+	if input.PhoneNo != nil {
 		updates = append(updates, fmt.Sprintf("phone_no=$%d", id))
-		params = append(params, input.PhoneNo)
+		params = append(params, *input.PhoneNo)
+		id += 1
+	}
+	if input.Email != nil {
+		updates = append(updates, fmt.Sprintf("email=$%d, email_verified_at=NULL", id))
+		params = append(params, sql.NullString{String: *input.Email, Valid: *input.Email != ""})
 		id += 1
 	}
-	if input.SuccessfulLoginCount != 0 {
-		updates = append(updates, fmt.Sprintf("successful_login_count=$%d", id))
-		params = append(params, input.SuccessfulLoginCount)
+	if input.Status != nil {
+		updates = append(updates, fmt.Sprintf("status=$%d", id))
+		params = append(params, *input.Status)
+		id += 1
+	}
+	if input.Attributes != nil {
+		var attributes []byte
+		if attributes, err = json.Marshal(input.Attributes); err != nil {
+			return
+		}
+		updates = append(updates, fmt.Sprintf("attributes=$%d", id))
+		params = append(params, attributes)
 		id += 1
 	}
 
+	updates = append(updates, "version=version+1")
 	query := fmt.Sprintf("UPDATE users SET %s WHERE id=$%d", strings.Join(updates, ", "), id)
 	params = append(params, input.ID)
-	var result sql.Result
-	if result, err = u.db.ExecContext(ctx, query, params...); err != nil {
+	if input.Version != 0 {
+		id += 1
+		query += fmt.Sprintf(" AND version=$%d", id)
+		params = append(params, input.Version)
+	}
+	query += " RETURNING version;"
+	if err = u.db.QueryRowContext(ctx, query, params...).Scan(&output.Version); err != nil {
 		var pqErr *pq.Error
 		if errors.As(err, &pqErr) {
 			if pqErr.Code == "23505" { // unique constraint violation
 				err = repository.ErrorRecordConflict
 			}
+		} else if errors.Is(err, sql.ErrNoRows) {
+			if input.Version != 0 {
+				err = repository.ErrorRecordOutdated
+			} else {
+				err = repository.ErrorRecordNotFound
+			}
 		}
 		return
 	}
 
-	if affected, _ := result.RowsAffected(); affected <= 0 {
-		err = repository.ErrorRecordNotFound
-		return
-	}
-
 	return output, nil
 }
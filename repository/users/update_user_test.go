@@ -30,11 +30,11 @@ func (s *UpdateUserTestSuite) SetupTest() {
 	repo.db, s.dbMock, _ = sqlmock.New()
 	s.repo = repo
 
+	phoneNo, fullName := "+6281315184400", "John Smith"
 	s.input = repository.UpdateUserInput{
-		ID:                   123,
-		PhoneNo:              "+6281315184400",
-		FullName:             "John Smith",
-		SuccessfulLoginCount: 2,
+		ID:       123,
+		PhoneNo:  &phoneNo,
+		FullName: &fullName,
 	}
 	s.ctx = context.Background()
 }
@@ -48,10 +48,9 @@ func (s *UpdateUserTestSuite) TearDownTest() {
 func (s *UpdateUserTestSuite) TestDatabaseError() {
 	a := assert.New(s.T())
 
-	s.input.FullName = ""
-	s.input.PhoneNo = ""
-	s.dbMock.ExpectExec(regexp.QuoteMeta("UPDATE users SET successful_login_count=$1 WHERE id=$2")).
-		WithArgs(s.input.SuccessfulLoginCount, s.input.ID).
+	s.input.PhoneNo = nil
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET full_name=$1, version=version+1 WHERE id=$2 RETURNING version;")).
+		WithArgs(*s.input.FullName, s.input.ID).
 		WillReturnError(&pq.Error{Message: "some error message here"})
 
 	_, err := s.repo.UpdateUser(s.ctx, s.input)
@@ -61,9 +60,8 @@ func (s *UpdateUserTestSuite) TestDatabaseError() {
 func (s *UpdateUserTestSuite) TestRecordConflictError() {
 	a := assert.New(s.T())
 
-	s.input.SuccessfulLoginCount = 0
-	s.dbMock.ExpectExec(regexp.QuoteMeta("UPDATE users SET full_name=$1, phone_no=$2 WHERE id=$3")).
-		WithArgs(s.input.FullName, s.input.PhoneNo, s.input.ID).
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET full_name=$1, phone_no=$2, version=version+1 WHERE id=$3 RETURNING version;")).
+		WithArgs(*s.input.FullName, *s.input.PhoneNo, s.input.ID).
 		WillReturnError(&pq.Error{Message: "some error message here", Code: "23505"})
 
 	_, err := s.repo.UpdateUser(s.ctx, s.input)
@@ -73,11 +71,10 @@ func (s *UpdateUserTestSuite) TestRecordConflictError() {
 func (s *UpdateUserTestSuite) TestRecordNotFound() {
 	a := assert.New(s.T())
 
-	s.input.SuccessfulLoginCount = 0
-	s.input.FullName = ""
-	s.dbMock.ExpectExec(regexp.QuoteMeta("UPDATE users SET phone_no=$1 WHERE id=$2")).
-		WithArgs(s.input.PhoneNo, s.input.ID).
-		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.input.FullName = nil
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET phone_no=$1, version=version+1 WHERE id=$2 RETURNING version;")).
+		WithArgs(*s.input.PhoneNo, s.input.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
 
 	_, err := s.repo.UpdateUser(s.ctx, s.input)
 	a.ErrorIs(err, repository.ErrorRecordNotFound)
@@ -86,11 +83,112 @@ func (s *UpdateUserTestSuite) TestRecordNotFound() {
 func (s *UpdateUserTestSuite) TestSuccess() {
 	a := assert.New(s.T())
 
-	s.input.SuccessfulLoginCount = 0
-	s.input.FullName = ""
-	s.dbMock.ExpectExec(regexp.QuoteMeta("UPDATE users SET phone_no=$1 WHERE id=$2")).
-		WithArgs(s.input.PhoneNo, s.input.ID).
-		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.input.FullName = nil
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET phone_no=$1, version=version+1 WHERE id=$2 RETURNING version;")).
+		WithArgs(*s.input.PhoneNo, s.input.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+
+	output, err := s.repo.UpdateUser(s.ctx, s.input)
+	a.Empty(err)
+	a.Equal(uint64(4), output.Version)
+}
+
+func (s *UpdateUserTestSuite) TestVersionMismatch() {
+	a := assert.New(s.T())
+
+	s.input = repository.UpdateUserInput{ID: s.input.ID, FullName: s.input.FullName, Version: 3}
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET full_name=$1, version=version+1 WHERE id=$2 AND version=$3 RETURNING version;")).
+		WithArgs(*s.input.FullName, s.input.ID, s.input.Version).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+
+	_, err := s.repo.UpdateUser(s.ctx, s.input)
+	a.ErrorIs(err, repository.ErrorRecordOutdated)
+}
+
+func (s *UpdateUserTestSuite) TestVersionMatch() {
+	a := assert.New(s.T())
+
+	s.input = repository.UpdateUserInput{ID: s.input.ID, FullName: s.input.FullName, Version: 3}
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET full_name=$1, version=version+1 WHERE id=$2 AND version=$3 RETURNING version;")).
+		WithArgs(*s.input.FullName, s.input.ID, s.input.Version).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+
+	output, err := s.repo.UpdateUser(s.ctx, s.input)
+	a.Empty(err)
+	a.Equal(uint64(4), output.Version)
+}
+
+func (s *UpdateUserTestSuite) TestUpdateStatus() {
+	a := assert.New(s.T())
+
+	status := "suspended"
+	s.input = repository.UpdateUserInput{ID: s.input.ID, Status: &status}
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET status=$1, version=version+1 WHERE id=$2 RETURNING version;")).
+		WithArgs(*s.input.Status, s.input.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+
+	_, err := s.repo.UpdateUser(s.ctx, s.input)
+	a.Empty(err)
+}
+
+func (s *UpdateUserTestSuite) TestUpdateEmail() {
+	a := assert.New(s.T())
+
+	email := "john.smith@example.com"
+	s.input = repository.UpdateUserInput{ID: s.input.ID, Email: &email}
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET email=$1, email_verified_at=NULL, version=version+1 WHERE id=$2 RETURNING version;")).
+		WithArgs(*s.input.Email, s.input.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+
+	_, err := s.repo.UpdateUser(s.ctx, s.input)
+	a.Empty(err)
+}
+
+func (s *UpdateUserTestSuite) TestClearEmail() {
+	a := assert.New(s.T())
+
+	email := ""
+	s.input = repository.UpdateUserInput{ID: s.input.ID, Email: &email}
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET email=$1, email_verified_at=NULL, version=version+1 WHERE id=$2 RETURNING version;")).
+		WithArgs(nil, s.input.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+
+	_, err := s.repo.UpdateUser(s.ctx, s.input)
+	a.Empty(err)
+}
+
+func (s *UpdateUserTestSuite) TestUpdateEmptyFullName() {
+	a := assert.New(s.T())
+
+	fullName := ""
+	s.input = repository.UpdateUserInput{ID: s.input.ID, FullName: &fullName}
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET full_name=$1, version=version+1 WHERE id=$2 RETURNING version;")).
+		WithArgs("", s.input.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+
+	_, err := s.repo.UpdateUser(s.ctx, s.input)
+	a.Empty(err)
+}
+
+func (s *UpdateUserTestSuite) TestUpdateAttributes() {
+	a := assert.New(s.T())
+
+	s.input = repository.UpdateUserInput{ID: s.input.ID, Attributes: map[string]interface{}{"estate_code": "KAL-01"}}
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET attributes=$1, version=version+1 WHERE id=$2 RETURNING version;")).
+		WithArgs([]byte(`{"estate_code":"KAL-01"}`), s.input.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+
+	_, err := s.repo.UpdateUser(s.ctx, s.input)
+	a.Empty(err)
+}
+
+func (s *UpdateUserTestSuite) TestClearAttributes() {
+	a := assert.New(s.T())
+
+	s.input = repository.UpdateUserInput{ID: s.input.ID, Attributes: map[string]interface{}{}}
+	s.dbMock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET attributes=$1, version=version+1 WHERE id=$2 RETURNING version;")).
+		WithArgs([]byte(`{}`), s.input.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
 
 	_, err := s.repo.UpdateUser(s.ctx, s.input)
 	a.Empty(err)
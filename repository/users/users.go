@@ -4,8 +4,6 @@ package users
 import (
 	"database/sql"
 	"github.com/SawitProRecruitment/UserService/repository"
-
-	_ "github.com/lib/pq"
 )
 
 // userRepository is a postgresSQL implementation of repository.UserRepository
@@ -14,7 +12,7 @@ type userRepository struct {
 }
 
 func NewUserRepository(opts repository.NewRepositoryOptions) (repository.UserRepository, error) {
-	db, err := sql.Open("postgres", opts.Dsn)
+	db, err := repository.OpenDatabase(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,36 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+)
+
+const (
+	verifyUserEmailQuery = `UPDATE users SET email_verified_at=$1, version=version+1 WHERE id=$2 AND LOWER(email)=LOWER($3);`
+)
+
+func (u *userRepository) VerifyUserEmail(ctx context.Context, input repository.VerifyUserEmailInput) (output repository.VerifyUserEmailOutput, err error) {
+	ctx, span := repository.StartSpan(ctx, "UserRepository", "VerifyUserEmail")
+	defer func() { repository.EndSpan(span, err) }()
+
+	var result sql.Result
+	if result, err = u.db.ExecContext(ctx, verifyUserEmailQuery, input.VerifiedAt, input.ID, input.Email); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			if pqErr.Code == "23505" { // unique constraint violation, the email is verified by another user
+				err = repository.ErrorRecordConflict
+			}
+		}
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected <= 0 {
+		err = repository.ErrorRecordNotFound
+		return
+	}
+
+	return output, nil
+}
@@ -0,0 +1,86 @@
+package users
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type VerifyUserEmailTestSuite struct {
+	suite.Suite
+
+	dbMock sqlmock.Sqlmock
+	repo   repository.UserRepository
+
+	input repository.VerifyUserEmailInput
+	ctx   context.Context
+}
+
+func TestVerifyUserEmailTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifyUserEmailTestSuite))
+}
+
+func (s *VerifyUserEmailTestSuite) SetupTest() {
+	repo := &userRepository{}
+	repo.db, s.dbMock, _ = sqlmock.New()
+	s.repo = repo
+
+	s.input = repository.VerifyUserEmailInput{
+		ID:         123,
+		Email:      "john.smith@example.com",
+		VerifiedAt: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC),
+	}
+	s.ctx = context.Background()
+}
+
+func (s *VerifyUserEmailTestSuite) TearDownTest() {
+	if err := s.dbMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *VerifyUserEmailTestSuite) TestDatabaseError() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(verifyUserEmailQuery)).WithArgs(s.input.VerifiedAt, s.input.ID, s.input.Email).
+		WillReturnError(&pq.Error{Message: "some error message here"})
+	_, err := s.repo.VerifyUserEmail(s.ctx, s.input)
+
+	a.ErrorContains(err, "pq: some error message here")
+}
+
+func (s *VerifyUserEmailTestSuite) TestEmailTaken() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(verifyUserEmailQuery)).WithArgs(s.input.VerifiedAt, s.input.ID, s.input.Email).
+		WillReturnError(&pq.Error{Message: "some error message here", Code: "23505"})
+	_, err := s.repo.VerifyUserEmail(s.ctx, s.input)
+
+	a.ErrorIs(err, repository.ErrorRecordConflict)
+}
+
+func (s *VerifyUserEmailTestSuite) TestEmailChanged() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(verifyUserEmailQuery)).WithArgs(s.input.VerifiedAt, s.input.ID, s.input.Email).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err := s.repo.VerifyUserEmail(s.ctx, s.input)
+
+	a.ErrorIs(err, repository.ErrorRecordNotFound)
+}
+
+func (s *VerifyUserEmailTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.dbMock.ExpectExec(regexp.QuoteMeta(verifyUserEmailQuery)).WithArgs(s.input.VerifiedAt, s.input.ID, s.input.Email).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := s.repo.VerifyUserEmail(s.ctx, s.input)
+
+	a.Empty(err)
+}
@@ -0,0 +1,14 @@
+// This file contains the interfaces for the SMS layer.
+// The SMS layer is responsible for delivering text messages to the users phone, through the configured provider.
+// For testing purpose we will generate mock implementations of these
+// interfaces using mockgen. See the Makefile for more information.
+package sms
+
+import "context"
+
+// Sender is an interface to deliver text messages to a phone number
+type Sender interface {
+	// SendSMS will deliver the message to the phone number (in E.164 format, e.g. `+6281234567890`)
+	// Will return error when the provider rejects the message
+	SendSMS(ctx context.Context, input SendSMSInput) (output SendSMSOutput, err error)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sms/interfaces.go
+
+// Package sms is a generated GoMock package.
+package sms
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSender is a mock of Sender interface.
+type MockSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockSenderMockRecorder
+}
+
+// MockSenderMockRecorder is the mock recorder for MockSender.
+type MockSenderMockRecorder struct {
+	mock *MockSender
+}
+
+// NewMockSender creates a new mock instance.
+func NewMockSender(ctrl *gomock.Controller) *MockSender {
+	mock := &MockSender{ctrl: ctrl}
+	mock.recorder = &MockSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSender) EXPECT() *MockSenderMockRecorder {
+	return m.recorder
+}
+
+// SendSMS mocks base method.
+func (m *MockSender) SendSMS(ctx context.Context, input SendSMSInput) (SendSMSOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendSMS", ctx, input)
+	ret0, _ := ret[0].(SendSMSOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendSMS indicates an expected call of SendSMS.
+func (mr *MockSenderMockRecorder) SendSMS(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSMS", reflect.TypeOf((*MockSender)(nil).SendSMS), ctx, input)
+}
@@ -0,0 +1,22 @@
+// Package logsender is an implementation of sms.Sender which writes the messages to the log instead of sending them.
+// It's meant for local development only.
+package logsender
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/sms"
+	"log"
+)
+
+// logSender is an implementation of sms.Sender writing every message to the standard logger
+type logSender struct {
+}
+
+func NewLogSender() sms.Sender {
+	return &logSender{}
+}
+
+func (l *logSender) SendSMS(ctx context.Context, input sms.SendSMSInput) (output sms.SendSMSOutput, err error) {
+	log.Printf("sms to %s: %s", input.PhoneNo, input.Message)
+	return output, nil
+}
@@ -0,0 +1,12 @@
+// This file contains types that are used by the SMS layer.
+package sms
+
+type SendSMSInput struct {
+	PhoneNo string
+	Message string
+}
+
+type SendSMSOutput struct {
+	// MessageID is the provider reference of the sent message, if any
+	MessageID string
+}
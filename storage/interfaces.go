@@ -0,0 +1,21 @@
+// This file contains the interfaces for the storage layer.
+// The storage layer is responsible for keeping binary objects (blobs) such as images, through the configured provider.
+// For testing purpose we will generate mock implementations of these
+// interfaces using mockgen. See the Makefile for more information.
+package storage
+
+import "context"
+
+// BlobStore is an interface to store blobs by key, and serve them publicly by URL
+type BlobStore interface {
+	// PutBlob will store the content under the key, replacing the existing blob of the key if any
+	// Will return error when the provider fails to store the content
+	PutBlob(ctx context.Context, input PutBlobInput) (output PutBlobOutput, err error)
+
+	// DeleteBlob will delete the blob of the key, deleting a missing blob is not an error
+	// Will return error when the provider fails to delete the blob
+	DeleteBlob(ctx context.Context, input DeleteBlobInput) (output DeleteBlobOutput, err error)
+
+	// GetBlobURL will return the public URL the blob of the key is served from
+	GetBlobURL(ctx context.Context, input GetBlobURLInput) (output GetBlobURLOutput, err error)
+}
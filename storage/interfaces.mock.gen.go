@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: storage/interfaces.go
+
+// Package storage is a generated GoMock package.
+package storage
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBlobStore is a mock of BlobStore interface.
+type MockBlobStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobStoreMockRecorder
+}
+
+// MockBlobStoreMockRecorder is the mock recorder for MockBlobStore.
+type MockBlobStoreMockRecorder struct {
+	mock *MockBlobStore
+}
+
+// NewMockBlobStore creates a new mock instance.
+func NewMockBlobStore(ctrl *gomock.Controller) *MockBlobStore {
+	mock := &MockBlobStore{ctrl: ctrl}
+	mock.recorder = &MockBlobStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobStore) EXPECT() *MockBlobStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteBlob mocks base method.
+func (m *MockBlobStore) DeleteBlob(ctx context.Context, input DeleteBlobInput) (DeleteBlobOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlob", ctx, input)
+	ret0, _ := ret[0].(DeleteBlobOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBlob indicates an expected call of DeleteBlob.
+func (mr *MockBlobStoreMockRecorder) DeleteBlob(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlob", reflect.TypeOf((*MockBlobStore)(nil).DeleteBlob), ctx, input)
+}
+
+// GetBlobURL mocks base method.
+func (m *MockBlobStore) GetBlobURL(ctx context.Context, input GetBlobURLInput) (GetBlobURLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlobURL", ctx, input)
+	ret0, _ := ret[0].(GetBlobURLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlobURL indicates an expected call of GetBlobURL.
+func (mr *MockBlobStoreMockRecorder) GetBlobURL(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlobURL", reflect.TypeOf((*MockBlobStore)(nil).GetBlobURL), ctx, input)
+}
+
+// PutBlob mocks base method.
+func (m *MockBlobStore) PutBlob(ctx context.Context, input PutBlobInput) (PutBlobOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutBlob", ctx, input)
+	ret0, _ := ret[0].(PutBlobOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutBlob indicates an expected call of PutBlob.
+func (mr *MockBlobStoreMockRecorder) PutBlob(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBlob", reflect.TypeOf((*MockBlobStore)(nil).PutBlob), ctx, input)
+}
@@ -0,0 +1,97 @@
+// Package localstore is an implementation of storage.BlobStore which keeps every blob as a file in a directory,
+// the directory is expected to be served as static files under the base URL. It's meant for local development and
+// single instance deployments, an S3 compatible implementation is needed to run multiple instances.
+package localstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/storage"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// localStore is an implementation of storage.BlobStore keeping every blob in dir
+type localStore struct {
+	dir     string
+	baseURL string
+}
+
+type NewLocalStoreOptions struct {
+	// Dir is the directory receiving the blobs, created when missing
+	Dir string
+	// BaseURL is the public URL the directory is served from, e.g. `http://localhost:1323/blobs`
+	BaseURL string
+}
+
+func NewLocalStore(opts NewLocalStoreOptions) (storage.BlobStore, error) {
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &localStore{dir: opts.Dir, baseURL: strings.TrimSuffix(opts.BaseURL, "/")}, nil
+}
+
+func (l *localStore) PutBlob(ctx context.Context, input storage.PutBlobInput) (output storage.PutBlobOutput, err error) {
+	var file string
+	if file, err = l.path(input.Key); err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return
+	}
+
+	// the content is written aside then renamed, so that a blob is never served half written
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(input.Content); err != nil {
+		_ = tmp.Close()
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		return
+	}
+	if err = os.Chmod(tmp.Name(), 0o644); err != nil {
+		return
+	}
+	if err = os.Rename(tmp.Name(), file); err != nil {
+		return
+	}
+	return output, nil
+}
+
+func (l *localStore) DeleteBlob(ctx context.Context, input storage.DeleteBlobInput) (output storage.DeleteBlobOutput, err error) {
+	var file string
+	if file, err = l.path(input.Key); err != nil {
+		return
+	}
+	if err = os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	return output, nil
+}
+
+func (l *localStore) GetBlobURL(ctx context.Context, input storage.GetBlobURLInput) (output storage.GetBlobURLOutput, err error) {
+	if _, err = l.path(input.Key); err != nil {
+		return
+	}
+	return storage.GetBlobURLOutput{URL: l.baseURL + "/" + input.Key}, nil
+}
+
+// path will return the file of the key, keys escaping the directory are rejected
+func (l *localStore) path(key string) (string, error) {
+	if key == "" || path.IsAbs(key) || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." ||
+		strings.ContainsRune(key, '\\') {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
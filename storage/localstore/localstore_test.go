@@ -0,0 +1,55 @@
+package localstore
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/storage"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPutGetDeleteBlob(t *testing.T) {
+	a := assert.New(t)
+	ctx := context.Background()
+
+	dir := filepath.Join(t.TempDir(), "blobs")
+	store, err := NewLocalStore(NewLocalStoreOptions{Dir: dir, BaseURL: "http://localhost:1323/blobs/"})
+	a.Empty(err)
+
+	_, err = store.PutBlob(ctx, storage.PutBlobInput{Key: "avatars/123/abc/512.jpg", ContentType: "image/jpeg", Content: []byte("first")})
+	a.Empty(err)
+	_, err = store.PutBlob(ctx, storage.PutBlobInput{Key: "avatars/123/abc/512.jpg", ContentType: "image/jpeg", Content: []byte("second")})
+	a.Empty(err)
+	content, err := os.ReadFile(filepath.Join(dir, "avatars", "123", "abc", "512.jpg"))
+	a.Empty(err)
+	a.Equal("second", string(content))
+
+	url, err := store.GetBlobURL(ctx, storage.GetBlobURLInput{Key: "avatars/123/abc/512.jpg"})
+	a.Empty(err)
+	a.Equal("http://localhost:1323/blobs/avatars/123/abc/512.jpg", url.URL)
+
+	_, err = store.DeleteBlob(ctx, storage.DeleteBlobInput{Key: "avatars/123/abc/512.jpg"})
+	a.Empty(err)
+	_, err = os.Stat(filepath.Join(dir, "avatars", "123", "abc", "512.jpg"))
+	a.ErrorIs(err, os.ErrNotExist)
+
+	// deleting a missing blob is not an error
+	_, err = store.DeleteBlob(ctx, storage.DeleteBlobInput{Key: "avatars/123/abc/512.jpg"})
+	a.Empty(err)
+}
+
+func TestInvalidKey(t *testing.T) {
+	a := assert.New(t)
+	ctx := context.Background()
+
+	store, err := NewLocalStore(NewLocalStoreOptions{Dir: t.TempDir(), BaseURL: "http://localhost:1323/blobs"})
+	a.Empty(err)
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "avatars/../../secret", "avatars//123", `avatars\123`} {
+		_, err = store.PutBlob(ctx, storage.PutBlobInput{Key: key, Content: []byte("content")})
+		a.ErrorIs(err, ErrInvalidKey, key)
+		_, err = store.DeleteBlob(ctx, storage.DeleteBlobInput{Key: key})
+		a.ErrorIs(err, ErrInvalidKey, key)
+	}
+}
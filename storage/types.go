@@ -0,0 +1,27 @@
+// This file contains types that are used by the storage layer.
+package storage
+
+type PutBlobInput struct {
+	// Key is a slash separated path, e.g. `avatars/123/abcdef/512.jpg`
+	Key         string
+	ContentType string
+	Content     []byte
+}
+
+type PutBlobOutput struct {
+}
+
+type DeleteBlobInput struct {
+	Key string
+}
+
+type DeleteBlobOutput struct {
+}
+
+type GetBlobURLInput struct {
+	Key string
+}
+
+type GetBlobURLOutput struct {
+	URL string
+}
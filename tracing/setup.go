@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"io"
+	"os"
+)
+
+const (
+	// ExporterNone disables the tracing, the trace context of the requests is still propagated
+	ExporterNone = "none"
+	// ExporterOTLP sends the spans to an OpenTelemetry collector, with OTLP over HTTP
+	ExporterOTLP = "otlp"
+	// ExporterStdout writes the spans as JSON to stdout or a file, for local testing
+	ExporterStdout = "stdout"
+
+	serviceName = "user-service"
+)
+
+// Exporters are the supported values of SetupOptions.Exporter
+var Exporters = []string{ExporterNone, ExporterOTLP, ExporterStdout}
+
+type SetupOptions struct {
+	// Exporter is one of Exporters
+	Exporter string
+	// OTLPEndpoint is the host & port of the collector, e.g. localhost:4318
+	OTLPEndpoint string
+	// OTLPInsecure sends the spans over plain HTTP, instead of HTTPS
+	OTLPInsecure bool
+	// File is where the stdout exporter writes the spans, stdout when empty
+	File string
+}
+
+// Setup will install the propagator & tracer provider used by Start, every span is sampled unless the caller's trace
+// isn't. The returned shutdown function flushes the pending spans, it's meant to be called once the server is stopped
+func Setup(ctx context.Context, opts SetupOptions) (shutdown func(ctx context.Context) error, err error) {
+	otel.SetTextMapPropagator(Propagator)
+	if opts.Exporter == ExporterNone {
+		return func(ctx context.Context) error { return nil }, nil
+	}
+
+	var exporter sdktrace.SpanExporter
+	var closer io.Closer
+	switch opts.Exporter {
+	case ExporterOTLP:
+		clientOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(opts.OTLPEndpoint)}
+		if opts.OTLPInsecure {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		}
+		// the exporter connects lazily, an unreachable collector only drops the spans
+		exporter, err = otlptracehttp.New(ctx, clientOpts...)
+	case ExporterStdout:
+		var w io.Writer = os.Stdout
+		if opts.File != "" {
+			var f *os.File
+			if f, err = os.OpenFile(opts.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644); err != nil {
+				return nil, fmt.Errorf("failed to open the trace file: %w", err)
+			}
+			w, closer = f, f
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(w))
+	default:
+		err = fmt.Errorf("unsupported trace exporter %q", opts.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", serviceName)))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			if closeErr := closer.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
@@ -0,0 +1,37 @@
+// Package tracing creates the OpenTelemetry spans of the service, from the HTTP requests down to the database
+// queries. The trace context is propagated through context.Context, and with the W3C `traceparent` header in & out
+// of the service
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the spans created by the service, as opposed to the ones of its libraries
+const instrumentationName = "github.com/SawitProRecruitment/UserService"
+
+// Propagator is the W3C Trace Context propagator, reading & writing the `traceparent` & `tracestate` headers
+var Propagator propagation.TextMapPropagator = propagation.TraceContext{}
+
+// Start will start a span as a child of the span of ctx, if any. The span is a no-op until Setup is called, or when
+// it's not sampled, in which case ctx is returned as is since it already carries the trace context to propagate
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	spanCtx, span := otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+	if !span.IsRecording() {
+		return ctx, span
+	}
+	return spanCtx, span
+}
+
+// End will end the span, marking it as failed when err is set
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+	"testing"
+)
+
+func TestStartWithoutProvider(t *testing.T) {
+	a := assert.New(t)
+	ctx := context.Background()
+
+	spanCtx, span := Start(ctx, "test")
+	End(span, nil)
+
+	a.Equal(ctx, spanCtx, "the context is left untouched while tracing is disabled")
+	a.False(span.IsRecording())
+}
+
+func TestStartEnd(t *testing.T) {
+	a := assert.New(t)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	// the global provider delegates to the first one set, it can only be reset to a no-op provider
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child")
+	End(child, fmt.Errorf("pq: connection refused"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	a.Len(spans, 2)
+	a.Equal("child", spans[0].Name())
+	a.Equal(trace.SpanContextFromContext(ctx).SpanID(), spans[0].Parent().SpanID())
+	a.Equal(codes.Error, spans[0].Status().Code)
+	a.Equal("pq: connection refused", spans[0].Status().Description)
+	a.Equal(codes.Unset, spans[1].Status().Code)
+}
+
+func TestSetupStdout(t *testing.T) {
+	a := assert.New(t)
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	_, err := Setup(context.Background(), SetupOptions{Exporter: "zipkin"})
+	a.EqualError(err, `unsupported trace exporter "zipkin"`)
+
+	file := t.TempDir() + "/spans.json"
+	shutdown, err := Setup(context.Background(), SetupOptions{Exporter: ExporterStdout, File: file})
+	a.NoError(err)
+	_, span := Start(context.Background(), "exported")
+	End(span, nil)
+	a.NoError(shutdown(context.Background()))
+
+	content, _ := os.ReadFile(file)
+	a.Contains(string(content), `"Name":"exported"`)
+	a.Contains(string(content), `"Value":"user-service"`)
+}
@@ -1,10 +1,48 @@
 package usecase
 
 import (
-	"errors"
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"net/http"
 	"strings"
 )
 
+const (
+	// ValidationErrorsCode is the code of ValidationErrors, each field error has its own code too
+	ValidationErrorsCode = "validation.failed"
+	// InternalErrorCode is the code of the errors unknown by the clients, e.g. a database failure
+	InternalErrorCode = "internal"
+	// InvalidFieldCode is the code of the field errors that don't have one, e.g. the JSON Schema violations
+	InvalidFieldCode = "validation.invalid"
+)
+
+// Error is an error known by the clients: its code is stable & machine-readable, unlike its message which is
+// localized, and its status is the HTTP status it's rendered with
+type Error struct {
+	Status  int
+	Code    string
+	Message *i18n.Error
+}
+
+func (e *Error) Error() string {
+	return e.Message.Error()
+}
+
+// Localize will return the message of the error in the specified language
+func (e *Error) Localize(lang string) string {
+	return e.Message.Localize(lang)
+}
+
+// NewError will create an error rendered with the HTTP status, the message is the one of the i18n key
+func NewError(status int, code, key string) *Error {
+	return &Error{Status: status, Code: code, Message: i18n.NewError(key, nil)}
+}
+
+// NewFieldError will create an error of a field of ValidationErrors, its code is prefixed by the field, e.g.
+// `phone_no.invalid_country_code`
+func NewFieldError(code, key string, params i18n.Params) *Error {
+	return &Error{Status: http.StatusBadRequest, Code: code, Message: i18n.NewError(key, params)}
+}
+
 // ValidationErrors is an implementation of error that contains a map of validation errors
 type ValidationErrors struct {
 	// fieldErrors contains a map of field name, to the errors on that field
@@ -25,13 +63,65 @@ func (e ValidationErrors) GetErrors() map[string][]error {
 	return e.fieldErrors
 }
 
+// FieldErrorCode will return the code of an error of ValidationErrors
+func FieldErrorCode(err error) string {
+	if e, ok := err.(*Error); ok {
+		return e.Code
+	}
+	return InvalidFieldCode
+}
+
 func NewValidationError(errors map[string][]error) error {
 	return ValidationErrors{fieldErrors: errors}
 }
 
+// the errors know the HTTP status & code they're rendered with, their messages are defined by the i18n catalogs so that
+// they're rendered in the language of the user
 var (
-	UserInvalidLogin  = errors.New("invalid phone number or password")
-	UserInvalidToken  = errors.New("invalid / expired token, please login again")
-	UserNotFoundError = errors.New("user not found")
-	UserConflictError = errors.New("user record conflict, phone number must be unique")
+	UserInvalidLogin = NewError(http.StatusBadRequest, "auth.invalid_credentials", "user.invalid_login")
+	// UserInvalidLoginOTP is returned for wrong, expired, already used or too many times attempted login codes
+	UserInvalidLoginOTP = NewError(http.StatusBadRequest, "auth.invalid_login_code", "user.invalid_login_otp")
+	// UserLoginOTPThrottled is returned when too many login codes are requested for a phone number or an IP address
+	UserLoginOTPThrottled = NewError(http.StatusTooManyRequests, "auth.login_code_throttled", "user.login_otp_throttled")
+	// UserInvalidPINLogin is returned for wrong PINs, users without a PIN, and devices that are not enrolled
+	UserInvalidPINLogin = NewError(http.StatusBadRequest, "auth.invalid_pin_login", "user.invalid_pin_login")
+	// UserPINLocked is returned once too many wrong PINs are entered, a password login lifts the lockout
+	UserPINLocked = NewError(http.StatusForbidden, "auth.pin_locked", "user.pin_locked")
+	// UserInvalidEmailVerification is returned for tampered or expired links, or links sent to a previous email
+	UserInvalidEmailVerification = NewError(http.StatusBadRequest, "email.invalid_verification_link", "user.invalid_email_link")
+	// UserEmailTaken is returned when verifying an email that has been verified by another user in the meantime
+	UserEmailTaken = NewError(http.StatusConflict, "email.taken", "user.email_taken")
+	// UserEmailNotSet is returned when requesting an email verification without an email on the account
+	UserEmailNotSet = NewError(http.StatusBadRequest, "email.not_set", "user.email_not_set")
+	// UserInvalidPhoneChangeCode is returned for wrong, expired, already used or too many times attempted codes
+	UserInvalidPhoneChangeCode = NewError(http.StatusBadRequest, "phone_no.invalid_change_code", "user.invalid_phone_code")
+	// UserPhoneChangeThrottled is returned when too many phone number changes are requested by a user
+	UserPhoneChangeThrottled = NewError(http.StatusTooManyRequests, "phone_no.change_throttled", "user.phone_change_throttled")
+	// AttributeSchemaNotFound is returned when no attribute schema is defined yet
+	AttributeSchemaNotFound = NewError(http.StatusNotFound, "attribute_schema.not_found", "attribute_schema.not_found")
+	// UserInvalidPassword is returned when re-confirming the password of an already logged-in user fails
+	UserInvalidPassword = NewError(http.StatusBadRequest, "auth.invalid_password", "user.invalid_password")
+	UserInvalidToken    = NewError(http.StatusForbidden, "auth.invalid_token", "user.invalid_token")
+	// UserTokenExpired is returned for a valid token used after its expiry, the client should login again
+	UserTokenExpired   = NewError(http.StatusForbidden, "auth.token_expired", "user.invalid_token")
+	UserNotFoundError  = NewError(http.StatusNotFound, "user.not_found", "user.not_found")
+	UserConflictError  = NewError(http.StatusConflict, "user.conflict", "user.conflict")
+	UserForbidden      = NewError(http.StatusForbidden, "auth.forbidden", "user.forbidden")
+	UserSuspended      = NewError(http.StatusForbidden, "user.suspended", "user.suspended")
+	UserExportNotFound = NewError(http.StatusNotFound, "export.not_found", "user.export_not_found")
+	// UserStatusConflict is returned when changing the account status from a status that doesn't allow it
+	UserStatusConflict = NewError(http.StatusConflict, "user.status_conflict", "user.status_conflict")
+	// UserImpersonationForbidden is returned when an impersonation token is used outside the read-only operations
+	UserImpersonationForbidden = NewError(http.StatusForbidden, "impersonation.forbidden", "user.impersonation_denied")
+	// UserNotImpersonating is returned when stopping an impersonation with a regular user token
+	UserNotImpersonating = NewError(http.StatusBadRequest, "impersonation.not_impersonating", "user.not_impersonating")
+	UserInvalidScope     = NewError(http.StatusBadRequest, "auth.invalid_scope", "user.invalid_scope")
+	// UserInsufficientScope is returned when a valid token is used to access a resource outside its scope
+	UserInsufficientScope = NewError(http.StatusForbidden, "auth.insufficient_scope", "user.insufficient_scope")
+	// UserVersionMismatch is returned when the profile was changed since the version the update is based on
+	UserVersionMismatch = NewError(http.StatusPreconditionFailed, "user.version_mismatch", "user.version_mismatch")
+	// UserPatchConflict is returned when a JSON Patch can't be applied to the current profile, e.g. a failed test
+	UserPatchConflict = NewError(http.StatusConflict, "user.patch_conflict", "user.patch_conflict")
+
+	ClientInvalidCredentials = NewError(http.StatusUnauthorized, "client.invalid_credentials", "client.invalid_credentials")
 )
@@ -17,10 +17,128 @@ type UserUsecases interface {
 	// Will return a JWT Token if successful
 	LoginUser(ctx context.Context, input LoginUserInput) (output LoginUserOutput, err error)
 
-	// ValidateUserToken will validate a users JWT Token and return the UserID contained in the token
+	// LoginUserPIN will login with the users PIN, only from a device enrolled by LoginUser
+	// The PIN login is locked after a few consecutive wrong PINs, until the next password login
+	LoginUserPIN(ctx context.Context, input LoginUserPINInput) (output LoginUserPINOutput, err error)
+
+	// RequestLoginOTP will send a one-time login code by SMS to the phone number, if it belongs to a user
+	// The result doesn't disclose whether the phone number is registered
+	RequestLoginOTP(ctx context.Context, input RequestLoginOTPInput) (output RequestLoginOTPOutput, err error)
+
+	// VerifyLoginOTP will exchange the one-time login code sent by RequestLoginOTP for the same JWT Token as LoginUser
+	VerifyLoginOTP(ctx context.Context, input VerifyLoginOTPInput) (output VerifyLoginOTPOutput, err error)
+
+	// ValidateUserToken will validate a users JWT Token and return the UserID, Roles & Scopes contained in the token
+	// Tokens of users that are no longer active are rejected
 	ValidateUserToken(ctx context.Context, input ValidateUserTokenInput) (output ValidateUserTokenOutput, err error)
 
 	GetUserProfile(ctx context.Context, input GetUserProfileInput) (output GetUserProfileOutput, err error)
 
 	UpdateUserProfile(ctx context.Context, input UpdateUserProfileInput) (output UpdateUserProfileOutput, err error)
+
+	// PatchUserProfile will apply a JSON Merge Patch or a JSON Patch to the profile of the user, the changed fields are
+	// updated like UpdateUserProfile does, so that fields can be removed as well (e.g. the email)
+	PatchUserProfile(ctx context.Context, input PatchUserProfileInput) (output PatchUserProfileOutput, err error)
+
+	// ListUserChanges will return the field-level history of the profile of the user, newest first, using cursor
+	// based pagination. Sensitive values (e.g. the phone number) are masked
+	ListUserChanges(ctx context.Context, input ListUserChangesInput) (output ListUserChangesOutput, err error)
+
+	// RequestPhoneChange will send a confirmation code by SMS to the new phone number of the user
+	// The phone number isn't changed until the code is confirmed with ConfirmPhoneChange
+	RequestPhoneChange(ctx context.Context, input RequestPhoneChangeInput) (output RequestPhoneChangeOutput, err error)
+
+	// ConfirmPhoneChange will replace the phone number of the user with the one confirmed by the code sent by
+	// RequestPhoneChange, notify the old phone number, and reserve it to the user during the cooldown period
+	ConfirmPhoneChange(ctx context.Context, input ConfirmPhoneChangeInput) (output ConfirmPhoneChangeOutput, err error)
+
+	// UploadUserAvatar will replace the avatar of the user with thumbnails of the uploaded image, stripped of its metadata
+	UploadUserAvatar(ctx context.Context, input UploadUserAvatarInput) (output UploadUserAvatarOutput, err error)
+
+	// RemoveUserAvatar will remove the avatar of the user, removing a missing avatar is not an error
+	RemoveUserAvatar(ctx context.Context, input RemoveUserAvatarInput) (output RemoveUserAvatarOutput, err error)
+
+	// RequestEmailVerification will send a new verification link to the unverified email of the user
+	RequestEmailVerification(ctx context.Context, input RequestEmailVerificationInput) (output RequestEmailVerificationOutput, err error)
+
+	// VerifyUserEmail will verify the email of the signed link sent by UpdateUserProfile or RequestEmailVerification
+	// Links sent to a previous email of the user are rejected
+	VerifyUserEmail(ctx context.Context, input VerifyUserEmailInput) (output VerifyUserEmailOutput, err error)
+
+	// SetUserPIN will validate and set the PIN used for PIN login after re-confirming the password
+	SetUserPIN(ctx context.Context, input SetUserPINInput) (output SetUserPINOutput, err error)
+
+	// RemoveUserPIN will remove the PIN of the user, disabling PIN login
+	RemoveUserPIN(ctx context.Context, input RemoveUserPINInput) (output RemoveUserPINOutput, err error)
+
+	// DeleteUser will schedule the deletion of the user account after re-confirming the password
+	// The deletion can be cancelled by logging in before the grace period is over
+	DeleteUser(ctx context.Context, input DeleteUserInput) (output DeleteUserOutput, err error)
+
+	// RequestUserExport will schedule the generation of an archive containing every personal data held about the user
+	RequestUserExport(ctx context.Context, input RequestUserExportInput) (output RequestUserExportOutput, err error)
+
+	// GetUserExport will return the status of an export owned by the user, including its content once ready
+	// Expired exports are reported as not found
+	GetUserExport(ctx context.Context, input GetUserExportInput) (output GetUserExportOutput, err error)
+
+	// ProcessUserExports will generate the pending exports, and delete the expired ones
+	// Meant to be called periodically by a background worker
+	ProcessUserExports(ctx context.Context, input ProcessUserExportsInput) (output ProcessUserExportsOutput, err error)
+
+	// AnonymizeDeletedUsers will erase the personal data of users whose deletion grace period is over
+	// Meant to be called periodically by a background worker
+	AnonymizeDeletedUsers(ctx context.Context, input AnonymizeDeletedUsersInput) (output AnonymizeDeletedUsersOutput, err error)
+
+	// NormalizeUserPhoneNumbers will convert the stored phone numbers into their canonical E.164 format
+	// Meant to be run once, numbers that can't be parsed or would collide with another user are reported instead
+	NormalizeUserPhoneNumbers(ctx context.Context, input NormalizeUserPhoneNumbersInput) (output NormalizeUserPhoneNumbersOutput, err error)
+
+	// CheckReadiness will check that the service can serve requests, i.e. the database is reachable and the JWT
+	// signing key is loaded. The failed checks are reported on the output, not as an error
+	CheckReadiness(ctx context.Context, input CheckReadinessInput) (output CheckReadinessOutput, err error)
+
+	// Close will release the resources shared by the usecases, e.g. the database connection pool
+	// Meant to be called once on shutdown, after the last request is served and the background workers are stopped
+	Close() error
+
+	// IntrospectUserToken will authenticate the requesting OAuth client, then validate the users JWT Token
+	// including its revocation status. Details of inactive tokens will never be returned
+	IntrospectUserToken(ctx context.Context, input IntrospectUserTokenInput) (output IntrospectUserTokenOutput, err error)
+
+	// ListUsers will search users matching the filters, newest first, using cursor based pagination
+	// Authorization (admin only) must be enforced by the caller
+	ListUsers(ctx context.Context, input ListUsersInput) (output ListUsersOutput, err error)
+
+	// GetUserDetail will return the full record of a user, excluding credentials
+	// Authorization (admin only) must be enforced by the caller
+	GetUserDetail(ctx context.Context, input GetUserDetailInput) (output GetUserDetailOutput, err error)
+
+	// SuspendUser will block an active user from logging in and using already issued tokens
+	// Authorization (admin only) must be enforced by the caller
+	SuspendUser(ctx context.Context, input SuspendUserInput) (output SuspendUserOutput, err error)
+
+	// ReactivateUser will lift the suspension of a user
+	// Authorization (admin only) must be enforced by the caller
+	ReactivateUser(ctx context.Context, input ReactivateUserInput) (output ReactivateUserOutput, err error)
+
+	// StartImpersonation will issue a short-lived, read-only token for the user, whose `act` claim identifies the admin
+	// Authorization (admin only) must be enforced by the caller
+	StartImpersonation(ctx context.Context, input StartImpersonationInput) (output StartImpersonationOutput, err error)
+
+	// StopImpersonation will revoke the specified impersonation token, and record the end of the impersonation
+	StopImpersonation(ctx context.Context, input StopImpersonationInput) (output StopImpersonationOutput, err error)
+
+	// SetUserRoles will replace the roles of the target user, and record the change in the audit log
+	// Authorization (admin only) must be enforced by the caller
+	SetUserRoles(ctx context.Context, input SetUserRolesInput) (output SetUserRolesOutput, err error)
+
+	// GetAttributeSchema will return the JSON Schema in effect for the custom profile attributes
+	// Authorization (admin only) must be enforced by the caller
+	GetAttributeSchema(ctx context.Context, input GetAttributeSchemaInput) (output GetAttributeSchemaOutput, err error)
+
+	// SetAttributeSchema will replace the JSON Schema of the custom profile attributes, and record the change in the
+	// audit log. Attributes already stored are only validated against the new schema once they're updated.
+	// Authorization (admin only) must be enforced by the caller
+	SetAttributeSchema(ctx context.Context, input SetAttributeSchemaInput) (output SetAttributeSchemaOutput, err error)
 }
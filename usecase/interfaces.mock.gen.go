@@ -34,6 +34,125 @@ func (m *MockUserUsecases) EXPECT() *MockUserUsecasesMockRecorder {
 	return m.recorder
 }
 
+// AnonymizeDeletedUsers mocks base method.
+func (m *MockUserUsecases) AnonymizeDeletedUsers(ctx context.Context, input AnonymizeDeletedUsersInput) (AnonymizeDeletedUsersOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeDeletedUsers", ctx, input)
+	ret0, _ := ret[0].(AnonymizeDeletedUsersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeDeletedUsers indicates an expected call of AnonymizeDeletedUsers.
+func (mr *MockUserUsecasesMockRecorder) AnonymizeDeletedUsers(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeDeletedUsers", reflect.TypeOf((*MockUserUsecases)(nil).AnonymizeDeletedUsers), ctx, input)
+}
+
+// CheckReadiness mocks base method.
+func (m *MockUserUsecases) CheckReadiness(ctx context.Context, input CheckReadinessInput) (CheckReadinessOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckReadiness", ctx, input)
+	ret0, _ := ret[0].(CheckReadinessOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckReadiness indicates an expected call of CheckReadiness.
+func (mr *MockUserUsecasesMockRecorder) CheckReadiness(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckReadiness", reflect.TypeOf((*MockUserUsecases)(nil).CheckReadiness), ctx, input)
+}
+
+// Close mocks base method.
+func (m *MockUserUsecases) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockUserUsecasesMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockUserUsecases)(nil).Close))
+}
+
+// ConfirmPhoneChange mocks base method.
+func (m *MockUserUsecases) ConfirmPhoneChange(ctx context.Context, input ConfirmPhoneChangeInput) (ConfirmPhoneChangeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPhoneChange", ctx, input)
+	ret0, _ := ret[0].(ConfirmPhoneChangeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPhoneChange indicates an expected call of ConfirmPhoneChange.
+func (mr *MockUserUsecasesMockRecorder) ConfirmPhoneChange(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPhoneChange", reflect.TypeOf((*MockUserUsecases)(nil).ConfirmPhoneChange), ctx, input)
+}
+
+// DeleteUser mocks base method.
+func (m *MockUserUsecases) DeleteUser(ctx context.Context, input DeleteUserInput) (DeleteUserOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, input)
+	ret0, _ := ret[0].(DeleteUserOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserUsecasesMockRecorder) DeleteUser(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserUsecases)(nil).DeleteUser), ctx, input)
+}
+
+// GetAttributeSchema mocks base method.
+func (m *MockUserUsecases) GetAttributeSchema(ctx context.Context, input GetAttributeSchemaInput) (GetAttributeSchemaOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttributeSchema", ctx, input)
+	ret0, _ := ret[0].(GetAttributeSchemaOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttributeSchema indicates an expected call of GetAttributeSchema.
+func (mr *MockUserUsecasesMockRecorder) GetAttributeSchema(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributeSchema", reflect.TypeOf((*MockUserUsecases)(nil).GetAttributeSchema), ctx, input)
+}
+
+// GetUserDetail mocks base method.
+func (m *MockUserUsecases) GetUserDetail(ctx context.Context, input GetUserDetailInput) (GetUserDetailOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDetail", ctx, input)
+	ret0, _ := ret[0].(GetUserDetailOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDetail indicates an expected call of GetUserDetail.
+func (mr *MockUserUsecasesMockRecorder) GetUserDetail(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDetail", reflect.TypeOf((*MockUserUsecases)(nil).GetUserDetail), ctx, input)
+}
+
+// GetUserExport mocks base method.
+func (m *MockUserUsecases) GetUserExport(ctx context.Context, input GetUserExportInput) (GetUserExportOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserExport", ctx, input)
+	ret0, _ := ret[0].(GetUserExportOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserExport indicates an expected call of GetUserExport.
+func (mr *MockUserUsecasesMockRecorder) GetUserExport(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserExport", reflect.TypeOf((*MockUserUsecases)(nil).GetUserExport), ctx, input)
+}
+
 // GetUserProfile mocks base method.
 func (m *MockUserUsecases) GetUserProfile(ctx context.Context, input GetUserProfileInput) (GetUserProfileOutput, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +168,51 @@ func (mr *MockUserUsecasesMockRecorder) GetUserProfile(ctx, input interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockUserUsecases)(nil).GetUserProfile), ctx, input)
 }
 
+// IntrospectUserToken mocks base method.
+func (m *MockUserUsecases) IntrospectUserToken(ctx context.Context, input IntrospectUserTokenInput) (IntrospectUserTokenOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IntrospectUserToken", ctx, input)
+	ret0, _ := ret[0].(IntrospectUserTokenOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntrospectUserToken indicates an expected call of IntrospectUserToken.
+func (mr *MockUserUsecasesMockRecorder) IntrospectUserToken(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntrospectUserToken", reflect.TypeOf((*MockUserUsecases)(nil).IntrospectUserToken), ctx, input)
+}
+
+// ListUserChanges mocks base method.
+func (m *MockUserUsecases) ListUserChanges(ctx context.Context, input ListUserChangesInput) (ListUserChangesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserChanges", ctx, input)
+	ret0, _ := ret[0].(ListUserChangesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserChanges indicates an expected call of ListUserChanges.
+func (mr *MockUserUsecasesMockRecorder) ListUserChanges(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserChanges", reflect.TypeOf((*MockUserUsecases)(nil).ListUserChanges), ctx, input)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserUsecases) ListUsers(ctx context.Context, input ListUsersInput) (ListUsersOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, input)
+	ret0, _ := ret[0].(ListUsersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserUsecasesMockRecorder) ListUsers(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserUsecases)(nil).ListUsers), ctx, input)
+}
+
 // LoginUser mocks base method.
 func (m *MockUserUsecases) LoginUser(ctx context.Context, input LoginUserInput) (LoginUserOutput, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +228,81 @@ func (mr *MockUserUsecasesMockRecorder) LoginUser(ctx, input interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockUserUsecases)(nil).LoginUser), ctx, input)
 }
 
+// LoginUserPIN mocks base method.
+func (m *MockUserUsecases) LoginUserPIN(ctx context.Context, input LoginUserPINInput) (LoginUserPINOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginUserPIN", ctx, input)
+	ret0, _ := ret[0].(LoginUserPINOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginUserPIN indicates an expected call of LoginUserPIN.
+func (mr *MockUserUsecasesMockRecorder) LoginUserPIN(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUserPIN", reflect.TypeOf((*MockUserUsecases)(nil).LoginUserPIN), ctx, input)
+}
+
+// NormalizeUserPhoneNumbers mocks base method.
+func (m *MockUserUsecases) NormalizeUserPhoneNumbers(ctx context.Context, input NormalizeUserPhoneNumbersInput) (NormalizeUserPhoneNumbersOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NormalizeUserPhoneNumbers", ctx, input)
+	ret0, _ := ret[0].(NormalizeUserPhoneNumbersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NormalizeUserPhoneNumbers indicates an expected call of NormalizeUserPhoneNumbers.
+func (mr *MockUserUsecasesMockRecorder) NormalizeUserPhoneNumbers(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormalizeUserPhoneNumbers", reflect.TypeOf((*MockUserUsecases)(nil).NormalizeUserPhoneNumbers), ctx, input)
+}
+
+// PatchUserProfile mocks base method.
+func (m *MockUserUsecases) PatchUserProfile(ctx context.Context, input PatchUserProfileInput) (PatchUserProfileOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchUserProfile", ctx, input)
+	ret0, _ := ret[0].(PatchUserProfileOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchUserProfile indicates an expected call of PatchUserProfile.
+func (mr *MockUserUsecasesMockRecorder) PatchUserProfile(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchUserProfile", reflect.TypeOf((*MockUserUsecases)(nil).PatchUserProfile), ctx, input)
+}
+
+// ProcessUserExports mocks base method.
+func (m *MockUserUsecases) ProcessUserExports(ctx context.Context, input ProcessUserExportsInput) (ProcessUserExportsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessUserExports", ctx, input)
+	ret0, _ := ret[0].(ProcessUserExportsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessUserExports indicates an expected call of ProcessUserExports.
+func (mr *MockUserUsecasesMockRecorder) ProcessUserExports(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessUserExports", reflect.TypeOf((*MockUserUsecases)(nil).ProcessUserExports), ctx, input)
+}
+
+// ReactivateUser mocks base method.
+func (m *MockUserUsecases) ReactivateUser(ctx context.Context, input ReactivateUserInput) (ReactivateUserOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateUser", ctx, input)
+	ret0, _ := ret[0].(ReactivateUserOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReactivateUser indicates an expected call of ReactivateUser.
+func (mr *MockUserUsecasesMockRecorder) ReactivateUser(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateUser", reflect.TypeOf((*MockUserUsecases)(nil).ReactivateUser), ctx, input)
+}
+
 // RegisterUser mocks base method.
 func (m *MockUserUsecases) RegisterUser(ctx context.Context, input RegisterUserInput) (RegisterUserOutput, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +318,186 @@ func (mr *MockUserUsecasesMockRecorder) RegisterUser(ctx, input interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserUsecases)(nil).RegisterUser), ctx, input)
 }
 
+// RemoveUserAvatar mocks base method.
+func (m *MockUserUsecases) RemoveUserAvatar(ctx context.Context, input RemoveUserAvatarInput) (RemoveUserAvatarOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserAvatar", ctx, input)
+	ret0, _ := ret[0].(RemoveUserAvatarOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveUserAvatar indicates an expected call of RemoveUserAvatar.
+func (mr *MockUserUsecasesMockRecorder) RemoveUserAvatar(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserAvatar", reflect.TypeOf((*MockUserUsecases)(nil).RemoveUserAvatar), ctx, input)
+}
+
+// RemoveUserPIN mocks base method.
+func (m *MockUserUsecases) RemoveUserPIN(ctx context.Context, input RemoveUserPINInput) (RemoveUserPINOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserPIN", ctx, input)
+	ret0, _ := ret[0].(RemoveUserPINOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveUserPIN indicates an expected call of RemoveUserPIN.
+func (mr *MockUserUsecasesMockRecorder) RemoveUserPIN(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserPIN", reflect.TypeOf((*MockUserUsecases)(nil).RemoveUserPIN), ctx, input)
+}
+
+// RequestEmailVerification mocks base method.
+func (m *MockUserUsecases) RequestEmailVerification(ctx context.Context, input RequestEmailVerificationInput) (RequestEmailVerificationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmailVerification", ctx, input)
+	ret0, _ := ret[0].(RequestEmailVerificationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestEmailVerification indicates an expected call of RequestEmailVerification.
+func (mr *MockUserUsecasesMockRecorder) RequestEmailVerification(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailVerification", reflect.TypeOf((*MockUserUsecases)(nil).RequestEmailVerification), ctx, input)
+}
+
+// RequestLoginOTP mocks base method.
+func (m *MockUserUsecases) RequestLoginOTP(ctx context.Context, input RequestLoginOTPInput) (RequestLoginOTPOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestLoginOTP", ctx, input)
+	ret0, _ := ret[0].(RequestLoginOTPOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestLoginOTP indicates an expected call of RequestLoginOTP.
+func (mr *MockUserUsecasesMockRecorder) RequestLoginOTP(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestLoginOTP", reflect.TypeOf((*MockUserUsecases)(nil).RequestLoginOTP), ctx, input)
+}
+
+// RequestPhoneChange mocks base method.
+func (m *MockUserUsecases) RequestPhoneChange(ctx context.Context, input RequestPhoneChangeInput) (RequestPhoneChangeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPhoneChange", ctx, input)
+	ret0, _ := ret[0].(RequestPhoneChangeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestPhoneChange indicates an expected call of RequestPhoneChange.
+func (mr *MockUserUsecasesMockRecorder) RequestPhoneChange(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPhoneChange", reflect.TypeOf((*MockUserUsecases)(nil).RequestPhoneChange), ctx, input)
+}
+
+// RequestUserExport mocks base method.
+func (m *MockUserUsecases) RequestUserExport(ctx context.Context, input RequestUserExportInput) (RequestUserExportOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestUserExport", ctx, input)
+	ret0, _ := ret[0].(RequestUserExportOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestUserExport indicates an expected call of RequestUserExport.
+func (mr *MockUserUsecasesMockRecorder) RequestUserExport(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestUserExport", reflect.TypeOf((*MockUserUsecases)(nil).RequestUserExport), ctx, input)
+}
+
+// SetAttributeSchema mocks base method.
+func (m *MockUserUsecases) SetAttributeSchema(ctx context.Context, input SetAttributeSchemaInput) (SetAttributeSchemaOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAttributeSchema", ctx, input)
+	ret0, _ := ret[0].(SetAttributeSchemaOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAttributeSchema indicates an expected call of SetAttributeSchema.
+func (mr *MockUserUsecasesMockRecorder) SetAttributeSchema(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAttributeSchema", reflect.TypeOf((*MockUserUsecases)(nil).SetAttributeSchema), ctx, input)
+}
+
+// SetUserPIN mocks base method.
+func (m *MockUserUsecases) SetUserPIN(ctx context.Context, input SetUserPINInput) (SetUserPINOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserPIN", ctx, input)
+	ret0, _ := ret[0].(SetUserPINOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserPIN indicates an expected call of SetUserPIN.
+func (mr *MockUserUsecasesMockRecorder) SetUserPIN(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserPIN", reflect.TypeOf((*MockUserUsecases)(nil).SetUserPIN), ctx, input)
+}
+
+// SetUserRoles mocks base method.
+func (m *MockUserUsecases) SetUserRoles(ctx context.Context, input SetUserRolesInput) (SetUserRolesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRoles", ctx, input)
+	ret0, _ := ret[0].(SetUserRolesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserRoles indicates an expected call of SetUserRoles.
+func (mr *MockUserUsecasesMockRecorder) SetUserRoles(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRoles", reflect.TypeOf((*MockUserUsecases)(nil).SetUserRoles), ctx, input)
+}
+
+// StartImpersonation mocks base method.
+func (m *MockUserUsecases) StartImpersonation(ctx context.Context, input StartImpersonationInput) (StartImpersonationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImpersonation", ctx, input)
+	ret0, _ := ret[0].(StartImpersonationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartImpersonation indicates an expected call of StartImpersonation.
+func (mr *MockUserUsecasesMockRecorder) StartImpersonation(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImpersonation", reflect.TypeOf((*MockUserUsecases)(nil).StartImpersonation), ctx, input)
+}
+
+// StopImpersonation mocks base method.
+func (m *MockUserUsecases) StopImpersonation(ctx context.Context, input StopImpersonationInput) (StopImpersonationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopImpersonation", ctx, input)
+	ret0, _ := ret[0].(StopImpersonationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopImpersonation indicates an expected call of StopImpersonation.
+func (mr *MockUserUsecasesMockRecorder) StopImpersonation(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopImpersonation", reflect.TypeOf((*MockUserUsecases)(nil).StopImpersonation), ctx, input)
+}
+
+// SuspendUser mocks base method.
+func (m *MockUserUsecases) SuspendUser(ctx context.Context, input SuspendUserInput) (SuspendUserOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuspendUser", ctx, input)
+	ret0, _ := ret[0].(SuspendUserOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuspendUser indicates an expected call of SuspendUser.
+func (mr *MockUserUsecasesMockRecorder) SuspendUser(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendUser", reflect.TypeOf((*MockUserUsecases)(nil).SuspendUser), ctx, input)
+}
+
 // UpdateUserProfile mocks base method.
 func (m *MockUserUsecases) UpdateUserProfile(ctx context.Context, input UpdateUserProfileInput) (UpdateUserProfileOutput, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +513,21 @@ func (mr *MockUserUsecasesMockRecorder) UpdateUserProfile(ctx, input interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUserUsecases)(nil).UpdateUserProfile), ctx, input)
 }
 
+// UploadUserAvatar mocks base method.
+func (m *MockUserUsecases) UploadUserAvatar(ctx context.Context, input UploadUserAvatarInput) (UploadUserAvatarOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadUserAvatar", ctx, input)
+	ret0, _ := ret[0].(UploadUserAvatarOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadUserAvatar indicates an expected call of UploadUserAvatar.
+func (mr *MockUserUsecasesMockRecorder) UploadUserAvatar(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadUserAvatar", reflect.TypeOf((*MockUserUsecases)(nil).UploadUserAvatar), ctx, input)
+}
+
 // ValidateUserToken mocks base method.
 func (m *MockUserUsecases) ValidateUserToken(ctx context.Context, input ValidateUserTokenInput) (ValidateUserTokenOutput, error) {
 	m.ctrl.T.Helper()
@@ -108,3 +542,33 @@ func (mr *MockUserUsecasesMockRecorder) ValidateUserToken(ctx, input interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateUserToken", reflect.TypeOf((*MockUserUsecases)(nil).ValidateUserToken), ctx, input)
 }
+
+// VerifyLoginOTP mocks base method.
+func (m *MockUserUsecases) VerifyLoginOTP(ctx context.Context, input VerifyLoginOTPInput) (VerifyLoginOTPOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyLoginOTP", ctx, input)
+	ret0, _ := ret[0].(VerifyLoginOTPOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyLoginOTP indicates an expected call of VerifyLoginOTP.
+func (mr *MockUserUsecasesMockRecorder) VerifyLoginOTP(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLoginOTP", reflect.TypeOf((*MockUserUsecases)(nil).VerifyLoginOTP), ctx, input)
+}
+
+// VerifyUserEmail mocks base method.
+func (m *MockUserUsecases) VerifyUserEmail(ctx context.Context, input VerifyUserEmailInput) (VerifyUserEmailOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUserEmail", ctx, input)
+	ret0, _ := ret[0].(VerifyUserEmailOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyUserEmail indicates an expected call of VerifyUserEmail.
+func (mr *MockUserUsecasesMockRecorder) VerifyUserEmail(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserEmail", reflect.TypeOf((*MockUserUsecases)(nil).VerifyUserEmail), ctx, input)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import "context"
+
+const (
+	RoleFarmer        = "farmer"
+	RoleEstateManager = "estate_manager"
+	RoleSupport       = "support"
+	RoleAdmin         = "admin"
+)
+
+const (
+	ScopeProfileRead  = "profile:read"
+	ScopeProfileWrite = "profile:write"
+	ScopeAdminUsers   = "admin:users"
+)
+
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+	// UserStatusDeactivated accounts are closed for good, and can't be reactivated
+	UserStatusDeactivated = "deactivated"
+)
+
+// UserStatuses contains every user account status
+var UserStatuses = []string{UserStatusActive, UserStatusSuspended, UserStatusDeactivated}
+
+// Roles contains every role that can be assigned to a user
+var Roles = []string{RoleFarmer, RoleEstateManager, RoleSupport, RoleAdmin}
+
+// ScopesForRoles will return every scope that can be granted to a user with the specified roles
+func ScopesForRoles(roles []string) []string {
+	scopes := []string{ScopeProfileRead, ScopeProfileWrite}
+	if (Principal{Roles: roles}).HasAnyRole(RoleAdmin) {
+		scopes = append(scopes, ScopeAdminUsers)
+	}
+	return scopes
+}
+
+// Principal is the authenticated user performing the current request, as identified by a validated JWT Token
+type Principal struct {
+	UserID uint64
+	Roles  []string
+	Scopes []string
+	// ActorUserID is the admin impersonating the user, zero when the user is acting on their own
+	ActorUserID uint64
+	// Language is the preferred language of the user for messages, empty when the user has no supported preference
+	Language string
+}
+
+// IsImpersonated will return true if the principal token is an impersonation token
+func (p Principal) IsImpersonated() bool {
+	return p.ActorUserID != 0
+}
+
+// HasScopes will return true if the principal token is granted every one of the specified scopes
+func (p Principal) HasScopes(scopes ...string) bool {
+	granted := map[string]bool{}
+	for _, scope := range p.Scopes {
+		granted[scope] = true
+	}
+	for _, scope := range scopes {
+		if !granted[scope] {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAnyRole will return true if the principal is assigned at least one of the specified roles
+func (p Principal) HasAnyRole(roles ...string) bool {
+	for _, required := range roles {
+		for _, role := range p.Roles {
+			if role == required {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type principalContextKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying the authenticated principal
+func ContextWithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated principal stored in ctx, if any
+func PrincipalFromContext(ctx context.Context) (principal Principal, ok bool) {
+	principal, ok = ctx.Value(principalContextKey{}).(Principal)
+	return
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import "context"
+
+type requestIDContextKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the ID of the current request, used to correlate the records
+// written by the request (e.g. the profile changes) with the request logs
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext returns the ID of the current request stored in ctx, empty outside of a request
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
@@ -1,6 +1,11 @@
 // This file contains types that are used in the repository layer.
 package usecase
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type RegisterUserInput struct {
 	PhoneNo  string
 	FullName string
@@ -12,12 +17,54 @@ type RegisterUserOutput struct {
 }
 
 type LoginUserInput struct {
+	// PhoneNo or Email identifies the user, the email must be verified
 	PhoneNo  string
+	Email    string
 	Password string
+	// Scope is an optional space separated list of requested scopes, defaults to every scope granted to the user
+	Scope string
+	// EnrollDevice will enroll the device for PIN login, returning its DeviceToken
+	EnrollDevice bool
 }
 
 type LoginUserOutput struct {
 	JwtToken string
+	// DeviceToken identifies the enrolled device on PIN login, only set when EnrollDevice is requested
+	DeviceToken string
+}
+
+type LoginUserPINInput struct {
+	PhoneNo string
+	PIN     string
+	// DeviceToken is returned by LoginUser when enrolling the device
+	DeviceToken string
+	// Scope is an optional space separated list of requested scopes, defaults to every scope granted to the user
+	Scope string
+}
+
+type LoginUserPINOutput struct {
+	JwtToken string
+}
+
+type RequestLoginOTPInput struct {
+	PhoneNo string
+	// IPAddress is the client requesting the code, used for throttling
+	IPAddress string
+}
+
+type RequestLoginOTPOutput struct {
+	ExpiresAt time.Time
+}
+
+type VerifyLoginOTPInput struct {
+	PhoneNo string
+	Code    string
+	// Scope is an optional space separated list of requested scopes, defaults to every scope granted to the user
+	Scope string
+}
+
+type VerifyLoginOTPOutput struct {
+	JwtToken string
 }
 
 type ValidateUserTokenInput struct {
@@ -26,6 +73,12 @@ type ValidateUserTokenInput struct {
 
 type ValidateUserTokenOutput struct {
 	UserID uint64
+	Roles  []string
+	Scopes []string
+	// ActorUserID is the admin impersonating the user, zero for regular user tokens
+	ActorUserID uint64
+	// Language is the `preferred_language` profile attribute, empty when it's not set or not supported
+	Language string
 }
 
 type GetUserProfileInput struct {
@@ -36,13 +89,407 @@ type GetUserProfileOutput struct {
 	UserID               uint64
 	PhoneNo              string
 	FullName             string
+	Email                string
+	EmailVerified        bool
 	SuccessfulLoginCount uint64
+	// AvatarURL & AvatarThumbnailURL are empty when the user has no avatar
+	AvatarURL          string
+	AvatarThumbnailURL string
+	// Attributes are the custom profile attributes, defined by the attribute schema
+	Attributes map[string]interface{}
+	// Version is incremented by every change of the profile
+	Version uint64
 }
 
 type UpdateUserProfileInput struct {
-	UserID   uint64
+	UserID uint64
+	// PhoneNo is rejected, the phone number can only be changed with RequestPhoneChange & ConfirmPhoneChange
 	PhoneNo  *string
 	FullName *string
+	// Email is unverified once changed, a verification link is sent to the new email. An empty email removes it
+	Email *string
+	// Attributes are merged into the current custom profile attributes, a nil value removes the attribute.
+	// The resulting attributes must be valid against the attribute schema.
+	Attributes map[string]interface{}
+	// Version is the version of the profile the update is based on, the update is rejected when the profile was
+	// changed since. Zero skips the check
+	Version uint64
+}
+
+type UpdateUserProfileOutput struct {
+	// Version is the version of the updated profile
+	Version uint64
+}
+
+const (
+	// PatchFormatMergePatch is a JSON Merge Patch (RFC 7396), a null member removes the field
+	PatchFormatMergePatch = "merge-patch"
+	// PatchFormatJSONPatch is a JSON Patch (RFC 6902), a list of operations applied in order
+	PatchFormatJSONPatch = "json-patch"
+)
+
+type PatchUserProfileInput struct {
+	UserID uint64
+	// Format is either PatchFormatMergePatch or PatchFormatJSONPatch
+	Format string
+	// Patch applies to the profile document `{"full_name", "phone_no", "email", "attributes"}`, the email is only
+	// present when set. The patched document is validated like UpdateUserProfileInput
+	Patch []byte
+	// Version is the version of the profile the patch is based on, the patch is rejected when the profile was
+	// changed since. Zero skips the check
+	Version uint64
+}
+
+type PatchUserProfileOutput struct {
+	// Version is the version of the patched profile
+	Version uint64
+}
+
+type RequestPhoneChangeInput struct {
+	UserID uint64
+	// PhoneNo is the new phone number, the confirmation code is sent to
+	PhoneNo string
+}
+
+type RequestPhoneChangeOutput struct {
+	ExpiresAt time.Time
+}
+
+type ConfirmPhoneChangeInput struct {
+	UserID uint64
+	Code   string
+}
+
+type ConfirmPhoneChangeOutput struct {
+	PhoneNo string
+}
+
+type UploadUserAvatarInput struct {
+	UserID uint64
+	// Content is the uploaded JPEG, PNG or WebP image
+	Content []byte
+}
+
+type UploadUserAvatarOutput struct {
+	AvatarURL          string
+	AvatarThumbnailURL string
+}
+
+type RemoveUserAvatarInput struct {
+	UserID uint64
+}
+
+type RemoveUserAvatarOutput struct{}
+
+type IntrospectUserTokenInput struct {
+	ClientID     string
+	ClientSecret string
+	Token        string
+}
+
+// IntrospectUserTokenOutput follows RFC 7662 introspection response, all fields besides Active will be empty for inactive tokens
+type IntrospectUserTokenOutput struct {
+	Active    bool
+	Subject   string
+	ExpiresAt time.Time
+	IssuedAt  time.Time
+	Scope     string
+	ClientID  string
+	// ActorSubject is the `act` claim subject of impersonation tokens
+	ActorSubject string
+}
+
+// UserDetail is the full user record as seen by admins, excluding credentials
+type UserDetail struct {
+	UserID               uint64
+	PhoneNo              string
+	FullName             string
+	SuccessfulLoginCount uint64
+	Roles                []string
+	Status               string
+	CreatedAt            time.Time
+}
+
+// ListUsersInput contains the user search filters, zero value filters are ignored
+type ListUsersInput struct {
+	PhonePrefix string
+	Name        string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Status      string
+	// Cursor is the NextCursor of the previous page, empty for the first page
+	Cursor string
+	// Limit is the page size, defaults to 20
+	Limit int
+}
+
+type ListUsersOutput struct {
+	Users []UserDetail
+	// NextCursor is empty on the last page
+	NextCursor string
+}
+
+type GetUserDetailInput struct {
+	UserID uint64
+}
+
+type GetUserDetailOutput struct {
+	UserDetail
+}
+
+type RequestEmailVerificationInput struct {
+	UserID uint64
+}
+
+type RequestEmailVerificationOutput struct {
+}
+
+type VerifyUserEmailInput struct {
+	// Token is the signed token of the verification link
+	Token string
+}
+
+type VerifyUserEmailOutput struct {
+	UserID uint64
+	Email  string
+}
+
+type DeleteUserInput struct {
+	UserID uint64
+	// Password must be re-confirmed before deleting the account
+	Password string
+}
+
+type SetUserPINInput struct {
+	UserID uint64
+	// Password must be re-confirmed before setting the PIN
+	Password string
+	PIN      string
 }
 
-type UpdateUserProfileOutput struct{}
+type SetUserPINOutput struct {
+}
+
+type RemoveUserPINInput struct {
+	UserID uint64
+}
+
+type RemoveUserPINOutput struct {
+}
+
+type DeleteUserOutput struct {
+	// DeletionDueAt is the end of the grace period, logging in before this time cancels the deletion
+	DeletionDueAt time.Time
+}
+
+type AnonymizeDeletedUsersInput struct {
+	// Limit is the maximum number of users anonymized in one go
+	Limit int
+}
+
+type AnonymizeDeletedUsersOutput struct {
+	UserIDs []uint64
+}
+
+type NormalizeUserPhoneNumbersInput struct {
+	// DryRun will only report the changes, without updating any user
+	DryRun bool
+}
+
+type NormalizeUserPhoneNumbersOutput struct {
+	Normalized []UserPhoneNoChange
+	// Collisions are the users whose normalized phone number already belongs to another user, they're left as is
+	Collisions []UserPhoneNoCollision
+	// Invalid are the users whose phone number can't be parsed, they're left as is
+	Invalid []UserPhoneNoInvalid
+}
+
+type UserPhoneNoChange struct {
+	UserID            uint64
+	PhoneNo           string
+	NormalizedPhoneNo string
+}
+
+type UserPhoneNoCollision struct {
+	UserID            uint64
+	PhoneNo           string
+	NormalizedPhoneNo string
+	// ConflictingUserID is the user owning the normalized phone number, zero when unknown
+	ConflictingUserID uint64
+}
+
+type UserPhoneNoInvalid struct {
+	UserID  uint64
+	PhoneNo string
+	Reason  string
+}
+
+const (
+	UserExportFormatJSON = "json"
+	// UserExportFormatZip is a zip archive containing one CSV file per section
+	UserExportFormatZip = "zip"
+
+	UserExportStatusPending    = "pending"
+	UserExportStatusProcessing = "processing"
+	UserExportStatusReady      = "ready"
+	UserExportStatusFailed     = "failed"
+)
+
+type RequestUserExportInput struct {
+	UserID uint64
+	// Format defaults to UserExportFormatJSON
+	Format string
+}
+
+type RequestUserExportOutput struct {
+	ExportID  uint64
+	Status    string
+	CreatedAt time.Time
+}
+
+type GetUserExportInput struct {
+	UserID   uint64
+	ExportID uint64
+}
+
+type GetUserExportOutput struct {
+	ExportID  uint64
+	Format    string
+	Status    string
+	CreatedAt time.Time
+	// ExpiresAt, Content, ContentType & FileName are only set once the export is completed
+	ExpiresAt   time.Time
+	Content     []byte
+	ContentType string
+	FileName    string
+}
+
+type ProcessUserExportsInput struct {
+	// Limit is the maximum number of exports generated in one go
+	Limit int
+}
+
+type ProcessUserExportsOutput struct {
+	ReadyExportIDs  []uint64
+	FailedExportIDs []uint64
+	// ExpiredCount is the number of deleted expired exports
+	ExpiredCount int64
+}
+
+type CheckReadinessInput struct{}
+
+type CheckReadinessOutput struct {
+	// Checks are the errors of the checks by name, nil when the check passed
+	Checks map[string]error
+}
+
+// Ready will return true when every check passed
+func (o CheckReadinessOutput) Ready() bool {
+	for _, err := range o.Checks {
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+type SuspendUserInput struct {
+	// ActorUserID is the admin suspending the user, recorded in the audit trail
+	ActorUserID uint64
+	UserID      uint64
+	Reason      string
+}
+
+type SuspendUserOutput struct {
+	UserID uint64
+	Status string
+}
+
+type ReactivateUserInput struct {
+	// ActorUserID is the admin reactivating the user, recorded in the audit trail
+	ActorUserID uint64
+	UserID      uint64
+}
+
+type ReactivateUserOutput struct {
+	UserID uint64
+	Status string
+}
+
+type StartImpersonationInput struct {
+	// ActorUserID is the admin impersonating the user, recorded in the token `act` claim and the audit trail
+	ActorUserID uint64
+	UserID      uint64
+	Reason      string
+}
+
+type StartImpersonationOutput struct {
+	JwtToken  string
+	ExpiresAt time.Time
+}
+
+type StopImpersonationInput struct {
+	JwtToken string
+}
+
+type StopImpersonationOutput struct {
+}
+
+type SetUserRolesInput struct {
+	// ActorUserID is the admin performing the role assignment
+	ActorUserID uint64
+	UserID      uint64
+	Roles       []string
+}
+
+type SetUserRolesOutput struct {
+	UserID uint64
+	Roles  []string
+}
+
+type GetAttributeSchemaInput struct{}
+
+type GetAttributeSchemaOutput struct {
+	Schema    json.RawMessage
+	UpdatedBy uint64
+	UpdatedAt time.Time
+}
+
+type SetAttributeSchemaInput struct {
+	// ActorUserID is the admin replacing the schema
+	ActorUserID uint64
+	Schema      json.RawMessage
+}
+
+type SetAttributeSchemaOutput struct {
+	Schema    json.RawMessage
+	UpdatedBy uint64
+	UpdatedAt time.Time
+}
+
+type ListUserChangesInput struct {
+	UserID uint64
+	// Cursor is the NextCursor of the previous page, empty for the first page
+	Cursor string
+	// Limit is the page size, defaults to 20
+	Limit int
+}
+
+type ListUserChangesOutput struct {
+	Changes []UserChange
+	// NextCursor is empty on the last page
+	NextCursor string
+}
+
+// UserChange is the change of a single profile field, e.g. `full_name` or `attributes.estate_code`
+type UserChange struct {
+	ID    uint64
+	Field string
+	// OldValue & NewValue are nil when the field was, or is now unset. Sensitive values are masked
+	OldValue interface{}
+	NewValue interface{}
+	// ActorUserID is zero for changes made by the system itself
+	ActorUserID uint64
+	RequestID   string
+	ChangedAt   time.Time
+}
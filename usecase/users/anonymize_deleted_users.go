@@ -0,0 +1,64 @@
+package users
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"time"
+)
+
+const (
+	auditActionAnonymizeUser = "user.anonymized"
+
+	defaultAnonymizeDeletedUsersLimit = 100
+)
+
+func (u *userUsecases) AnonymizeDeletedUsers(ctx context.Context, input usecase.AnonymizeDeletedUsersInput) (output usecase.AnonymizeDeletedUsersOutput, err error) {
+	ctx, span := startSpan(ctx, "AnonymizeDeletedUsers")
+	defer func() { endSpan(span, err) }()
+
+	if input.Limit <= 0 {
+		input.Limit = defaultAnonymizeDeletedUsersLimit
+	}
+
+	// anonymized users are deactivated, so every token issued to them is rejected from now on
+	var resp repository.AnonymizeUsersOutput
+	resp, err = u.userRepo.AnonymizeUsers(ctx, repository.AnonymizeUsersInput{
+		DeletionRequestedBefore: time.Now().Add(-u.deletionGracePeriod),
+		Limit:                   input.Limit,
+		AuditAction:             auditActionAnonymizeUser,
+	})
+	if err != nil {
+		return
+	}
+
+	if err = u.deleteQueuedAvatars(ctx, input.Limit); err != nil {
+		return
+	}
+
+	return usecase.AnonymizeDeletedUsersOutput{UserIDs: resp.IDs}, nil
+}
+
+// deleteQueuedAvatars will remove the queued avatars from the blob store. An avatar is only dequeued once removed, so
+// the failed removals are retried by the next run
+func (u *userUsecases) deleteQueuedAvatars(ctx context.Context, limit int) (err error) {
+	var queued repository.ListAvatarDeletionsOutput
+	if queued, err = u.userRepo.ListAvatarDeletions(ctx, repository.ListAvatarDeletionsInput{Limit: limit}); err != nil {
+		return
+	}
+
+	// a failed removal doesn't hold back the following ones, the first failure is reported
+	var firstErr error
+	for _, avatarKey := range queued.AvatarKeys {
+		if err = u.deleteAvatar(ctx, avatarKey); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if _, err = u.userRepo.DeleteAvatarDeletion(ctx, repository.DeleteAvatarDeletionInput{AvatarKey: avatarKey}); err != nil {
+			return
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,118 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/storage"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type AnonymizeDeletedUsersTestSuite struct {
+	suite.Suite
+
+	gomock    *gomock.Controller
+	repo      *repository.MockUserRepository
+	blobStore *storage.MockBlobStore
+
+	usecase usecase.UserUsecases
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestAnonymizeDeletedUsersTestSuite(t *testing.T) {
+	suite.Run(t, new(AnonymizeDeletedUsersTestSuite))
+}
+
+func (s *AnonymizeDeletedUsersTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.blobStore = storage.NewMockBlobStore(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		UserRepo:  s.repo,
+		BlobStore: s.blobStore,
+		Users:     config.Users{DeletionGracePeriod: 14 * 24 * time.Hour},
+	})
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *AnonymizeDeletedUsersTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *AnonymizeDeletedUsersTestSuite) TestRepositoryError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().AnonymizeUsers(s.ctx, gomock.Any()).Return(repository.AnonymizeUsersOutput{}, s.mockErr)
+
+	out, err := s.usecase.AnonymizeDeletedUsers(s.ctx, usecase.AnonymizeDeletedUsersInput{})
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *AnonymizeDeletedUsersTestSuite) TestListAvatarDeletionsError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().AnonymizeUsers(s.ctx, gomock.Any()).Return(repository.AnonymizeUsersOutput{IDs: []uint64{12}}, nil)
+	s.repo.EXPECT().ListAvatarDeletions(s.ctx, repository.ListAvatarDeletionsInput{Limit: 100}).
+		Return(repository.ListAvatarDeletionsOutput{}, s.mockErr)
+
+	out, err := s.usecase.AnonymizeDeletedUsers(s.ctx, usecase.AnonymizeDeletedUsersInput{})
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *AnonymizeDeletedUsersTestSuite) TestBlobStoreError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().AnonymizeUsers(s.ctx, gomock.Any()).Return(repository.AnonymizeUsersOutput{IDs: []uint64{12}}, nil)
+	s.repo.EXPECT().ListAvatarDeletions(s.ctx, gomock.Any()).
+		Return(repository.ListAvatarDeletionsOutput{AvatarKeys: []string{"avatars/12/random-key", "avatars/42/random-key"}}, nil)
+	s.blobStore.EXPECT().DeleteBlob(s.ctx, storage.DeleteBlobInput{Key: "avatars/12/random-key/512.jpg"}).
+		Return(storage.DeleteBlobOutput{}, s.mockErr)
+	// the failed avatar stays queued, the following ones are still removed
+	s.blobStore.EXPECT().DeleteBlob(s.ctx, storage.DeleteBlobInput{Key: "avatars/42/random-key/512.jpg"}).Return(storage.DeleteBlobOutput{}, nil)
+	s.blobStore.EXPECT().DeleteBlob(s.ctx, storage.DeleteBlobInput{Key: "avatars/42/random-key/128.jpg"}).Return(storage.DeleteBlobOutput{}, nil)
+	s.repo.EXPECT().DeleteAvatarDeletion(s.ctx, repository.DeleteAvatarDeletionInput{AvatarKey: "avatars/42/random-key"}).
+		Return(repository.DeleteAvatarDeletionOutput{}, nil)
+
+	out, err := s.usecase.AnonymizeDeletedUsers(s.ctx, usecase.AnonymizeDeletedUsersInput{})
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *AnonymizeDeletedUsersTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().AnonymizeUsers(s.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input repository.AnonymizeUsersInput) (repository.AnonymizeUsersOutput, error) {
+			a.Equal(100, input.Limit)
+			a.Equal("user.anonymized", input.AuditAction)
+			a.WithinDuration(time.Now().Add(-14*24*time.Hour), input.DeletionRequestedBefore, time.Minute)
+			return repository.AnonymizeUsersOutput{IDs: []uint64{12, 42}}, nil
+		})
+	s.repo.EXPECT().ListAvatarDeletions(s.ctx, repository.ListAvatarDeletionsInput{Limit: 100}).
+		Return(repository.ListAvatarDeletionsOutput{AvatarKeys: []string{"avatars/42/random-key"}}, nil)
+	s.blobStore.EXPECT().DeleteBlob(s.ctx, storage.DeleteBlobInput{Key: "avatars/42/random-key/512.jpg"}).Return(storage.DeleteBlobOutput{}, nil)
+	s.blobStore.EXPECT().DeleteBlob(s.ctx, storage.DeleteBlobInput{Key: "avatars/42/random-key/128.jpg"}).Return(storage.DeleteBlobOutput{}, nil)
+	s.repo.EXPECT().DeleteAvatarDeletion(s.ctx, repository.DeleteAvatarDeletionInput{AvatarKey: "avatars/42/random-key"}).
+		Return(repository.DeleteAvatarDeletionOutput{}, nil)
+
+	out, err := s.usecase.AnonymizeDeletedUsers(s.ctx, usecase.AnonymizeDeletedUsersInput{})
+
+	a.Nil(err)
+	a.Equal(usecase.AnonymizeDeletedUsersOutput{UserIDs: []uint64{12, 42}}, out)
+}
@@ -0,0 +1,39 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"time"
+)
+
+const (
+	readinessCheckDatabase = "database"
+	readinessCheckJwtKey   = "jwt_key"
+
+	defaultReadinessTimeout = 2 * time.Second
+)
+
+var errJwtKeyNotLoaded = errors.New("the JWT signing key is not loaded")
+
+func (u *userUsecases) CheckReadiness(ctx context.Context, input usecase.CheckReadinessInput) (output usecase.CheckReadinessOutput, err error) {
+	ctx, span := startSpan(ctx, "CheckReadiness")
+	defer func() { endSpan(span, err) }()
+
+	pingCtx, cancel := context.WithTimeout(ctx, u.readinessTimeout)
+	defer cancel()
+
+	output.Checks = map[string]error{
+		readinessCheckDatabase: u.userRepo.Ping(pingCtx),
+		readinessCheckJwtKey:   nil,
+	}
+	if u.jwtSecret == nil {
+		output.Checks[readinessCheckJwtKey] = errJwtKeyNotLoaded
+	}
+	return output, nil
+}
+
+func (u *userUsecases) Close() error {
+	// the repositories share the connection pool of the user repository
+	return u.userRepo.Close()
+}
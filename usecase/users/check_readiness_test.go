@@ -0,0 +1,45 @@
+package users
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCheckReadiness(t *testing.T) {
+	a := assert.New(t)
+	repo := repository.NewMockUserRepository(gomock.NewController(t))
+	uc := NewUserUsecases(NewUserUsecasesOptions{UserRepo: repo, JwtSecret: &rsa.PrivateKey{}})
+
+	repo.EXPECT().Ping(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		a.True(ok, "the ping must be bounded")
+		a.WithinDuration(time.Now().Add(defaultReadinessTimeout), deadline, time.Second)
+		return nil
+	})
+	output, err := uc.CheckReadiness(context.Background(), usecase.CheckReadinessInput{})
+
+	a.NoError(err)
+	a.True(output.Ready())
+	a.Equal(map[string]error{"database": nil, "jwt_key": nil}, output.Checks)
+}
+
+func TestCheckReadinessFailed(t *testing.T) {
+	a := assert.New(t)
+	repo := repository.NewMockUserRepository(gomock.NewController(t))
+	uc := NewUserUsecases(NewUserUsecasesOptions{UserRepo: repo})
+	pingErr := fmt.Errorf("dial tcp: connection refused")
+
+	repo.EXPECT().Ping(gomock.Any()).Return(pingErr)
+	output, err := uc.CheckReadiness(context.Background(), usecase.CheckReadinessInput{})
+
+	a.NoError(err)
+	a.False(output.Ready())
+	a.Equal(map[string]error{"database": pingErr, "jwt_key": errJwtKeyNotLoaded}, output.Checks)
+}
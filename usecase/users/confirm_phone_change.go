@@ -0,0 +1,104 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/sms"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"strings"
+	"time"
+)
+
+const (
+	auditActionChangePhoneNo = "user.phone_no.changed"
+
+	// maxPhoneChangeAttempts is the number of wrong codes allowed, before a new change must be requested
+	maxPhoneChangeAttempts = 5
+)
+
+func (u *userUsecases) ConfirmPhoneChange(ctx context.Context, input usecase.ConfirmPhoneChangeInput) (output usecase.ConfirmPhoneChangeOutput, err error) {
+	ctx, span := startSpan(ctx, "ConfirmPhoneChange")
+	defer func() { endSpan(span, err) }()
+
+	var usr repository.GetUserOutput
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+	if err = checkUserStatus(usr.Status); err != nil {
+		return
+	}
+
+	var change repository.GetLatestPhoneChangeOutput
+	if change, err = u.phoneChangeRepo.GetLatestPhoneChange(ctx, repository.GetLatestPhoneChangeInput{UserID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserInvalidPhoneChangeCode
+		}
+		return
+	}
+	if change.ExpiresAt.Before(time.Now()) {
+		err = usecase.UserInvalidPhoneChangeCode
+		return
+	}
+
+	// the attempt is recorded before comparing, so that concurrent guesses can't exceed the limit
+	_, err = u.phoneChangeRepo.AddPhoneChangeAttempt(ctx, repository.AddPhoneChangeAttemptInput{ID: change.ID, MaxAttempts: maxPhoneChangeAttempts})
+	if err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserInvalidPhoneChangeCode
+		}
+		return
+	}
+	if err = compareBcryptHash(change.CodeHash, input.Code); err != nil {
+		err = usecase.UserInvalidPhoneChangeCode
+		return
+	}
+
+	_, err = u.phoneChangeRepo.ConfirmPhoneChange(ctx, repository.ConfirmPhoneChangeInput{
+		ID:            change.ID,
+		UserID:        usr.ID,
+		OldPhoneNo:    usr.PhoneNo,
+		PhoneNo:       change.PhoneNo,
+		ReservedUntil: time.Now().Add(u.phoneNoCooldown),
+		UserChanges: newUserChangesInput(ctx, usr.ID, usr.ID, []repository.UserFieldChange{
+			{Field: "phone_no", OldValue: usr.PhoneNo, NewValue: change.PhoneNo},
+		}),
+		AuditEvent: repository.CreateAuditEventInput{
+			ActorUserID:  usr.ID,
+			Action:       auditActionChangePhoneNo,
+			TargetUserID: usr.ID,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserInvalidPhoneChangeCode
+		} else if errors.Is(err, repository.ErrorRecordConflict) {
+			err = usecase.UserConflictError
+		}
+		return
+	}
+
+	// the previous owner is notified, in case the change wasn't requested by them
+	_, err = u.smsSender.SendSMS(ctx, sms.SendSMSInput{
+		PhoneNo: usr.PhoneNo,
+		Message: fmt.Sprintf("The phone number of your account has been changed to %s. "+
+			"If you didn't request this change, please contact support.", maskPhoneNo(change.PhoneNo)),
+	})
+	if err != nil {
+		return
+	}
+
+	return usecase.ConfirmPhoneChangeOutput{PhoneNo: change.PhoneNo}, nil
+}
+
+// maskPhoneNo will hide the phone number, besides the country calling code prefix and the last 4 digits
+func maskPhoneNo(phoneNo string) string {
+	if len(phoneNo) <= 7 {
+		return phoneNo
+	}
+	return phoneNo[:3] + strings.Repeat("*", len(phoneNo)-7) + phoneNo[len(phoneNo)-4:]
+}
@@ -0,0 +1,241 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/sms"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+	"time"
+)
+
+type ConfirmPhoneChangeTestSuite struct {
+	suite.Suite
+
+	gomock          *gomock.Controller
+	repo            *repository.MockUserRepository
+	phoneChangeRepo *repository.MockPhoneChangeRepository
+	auditRepo       *repository.MockAuditRepository
+	userChangeRepo  *repository.MockUserChangeRepository
+	smsSender       *sms.MockSender
+
+	usecase usecase.UserUsecases
+
+	getUserOutput   repository.GetUserOutput
+	getChangeOutput repository.GetLatestPhoneChangeOutput
+	changesInput    repository.CreateUserChangesInput
+	auditInput      repository.CreateAuditEventInput
+	sendSMSInput    sms.SendSMSInput
+
+	input usecase.ConfirmPhoneChangeInput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestConfirmPhoneChangeTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfirmPhoneChangeTestSuite))
+}
+
+func (s *ConfirmPhoneChangeTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.phoneChangeRepo = repository.NewMockPhoneChangeRepository(s.gomock)
+	s.auditRepo = repository.NewMockAuditRepository(s.gomock)
+	s.userChangeRepo = repository.NewMockUserChangeRepository(s.gomock)
+	s.smsSender = sms.NewMockSender(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		UserRepo:        s.repo,
+		PhoneChangeRepo: s.phoneChangeRepo,
+		AuditRepo:       s.auditRepo,
+		UserChangeRepo:  s.userChangeRepo,
+		SMSSender:       s.smsSender,
+		Users:           config.Users{PhoneNoCooldown: 30 * 24 * time.Hour},
+	})
+
+	codeHash, _ := bcrypt.GenerateFromPassword([]byte("042917"), bcrypt.MinCost)
+	s.getUserOutput = repository.GetUserOutput{ID: 123, PhoneNo: "+62812151833", Status: "active"}
+	s.getChangeOutput = repository.GetLatestPhoneChangeOutput{
+		ID:        42,
+		UserID:    123,
+		PhoneNo:   "+6581234567",
+		CodeHash:  codeHash,
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	s.changesInput = repository.CreateUserChangesInput{
+		UserID:      123,
+		ActorUserID: 123,
+		RequestID:   "request-id",
+		Changes:     []repository.UserFieldChange{{Field: "phone_no", OldValue: "+62812151833", NewValue: "+6581234567"}},
+	}
+	s.auditInput = repository.CreateAuditEventInput{ActorUserID: 123, Action: "user.phone_no.changed", TargetUserID: 123}
+	s.sendSMSInput = sms.SendSMSInput{
+		PhoneNo: "+62812151833",
+		Message: "The phone number of your account has been changed to +65****4567. " +
+			"If you didn't request this change, please contact support.",
+	}
+
+	s.input = usecase.ConfirmPhoneChangeInput{UserID: 123, Code: "042917"}
+
+	s.ctx = usecase.ContextWithRequestID(context.Background(), "request-id")
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+// expectValidCode will expect the latest change to be found, and the attempt to be recorded
+func (s *ConfirmPhoneChangeTestSuite) expectValidCode() {
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.phoneChangeRepo.EXPECT().GetLatestPhoneChange(s.ctx, repository.GetLatestPhoneChangeInput{UserID: 123}).Return(s.getChangeOutput, nil)
+	s.phoneChangeRepo.EXPECT().AddPhoneChangeAttempt(s.ctx, repository.AddPhoneChangeAttemptInput{ID: 42, MaxAttempts: 5}).
+		Return(repository.AddPhoneChangeAttemptOutput{Attempts: 1}, nil)
+}
+
+// expectConfirmPhoneChange will expect the phone number to be swapped, with the old one reserved for the cooldown
+func (s *ConfirmPhoneChangeTestSuite) expectConfirmPhoneChange(err error) {
+	s.phoneChangeRepo.EXPECT().ConfirmPhoneChange(s.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input repository.ConfirmPhoneChangeInput) (repository.ConfirmPhoneChangeOutput, error) {
+			s.Equal(uint64(42), input.ID)
+			s.Equal(uint64(123), input.UserID)
+			s.Equal("+62812151833", input.OldPhoneNo)
+			s.Equal("+6581234567", input.PhoneNo)
+			s.WithinDuration(time.Now().Add(30*24*time.Hour), input.ReservedUntil, time.Minute)
+			s.Equal(s.changesInput, input.UserChanges)
+			s.Equal(s.auditInput, input.AuditEvent)
+			return repository.ConfirmPhoneChangeOutput{}, err
+		})
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestNoPendingChange() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.phoneChangeRepo.EXPECT().GetLatestPhoneChange(s.ctx, gomock.Any()).
+		Return(repository.GetLatestPhoneChangeOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidPhoneChangeCode)
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestExpired() {
+	a := assert.New(s.T())
+
+	s.getChangeOutput.ExpiresAt = time.Now().Add(-time.Minute)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.phoneChangeRepo.EXPECT().GetLatestPhoneChange(s.ctx, gomock.Any()).Return(s.getChangeOutput, nil)
+
+	out, err := s.usecase.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidPhoneChangeCode)
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestNoAttemptsLeft() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.phoneChangeRepo.EXPECT().GetLatestPhoneChange(s.ctx, gomock.Any()).Return(s.getChangeOutput, nil)
+	s.phoneChangeRepo.EXPECT().AddPhoneChangeAttempt(s.ctx, gomock.Any()).
+		Return(repository.AddPhoneChangeAttemptOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidPhoneChangeCode)
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestWrongCode() {
+	a := assert.New(s.T())
+
+	s.input.Code = "111111"
+	s.expectValidCode()
+
+	out, err := s.usecase.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidPhoneChangeCode)
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestPhoneNoTaken() {
+	a := assert.New(s.T())
+
+	s.expectValidCode()
+	s.expectConfirmPhoneChange(repository.ErrorRecordConflict)
+
+	out, err := s.usecase.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserConflictError)
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestAlreadyConfirmed() {
+	a := assert.New(s.T())
+
+	s.expectValidCode()
+	s.expectConfirmPhoneChange(repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidPhoneChangeCode)
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestConfirmError() {
+	a := assert.New(s.T())
+
+	s.expectValidCode()
+	s.expectConfirmPhoneChange(s.mockErr)
+
+	out, err := s.usecase.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestNotifySMSError() {
+	a := assert.New(s.T())
+
+	s.expectValidCode()
+	s.expectConfirmPhoneChange(nil)
+	s.smsSender.EXPECT().SendSMS(s.ctx, s.sendSMSInput).Return(sms.SendSMSOutput{}, s.mockErr)
+
+	out, err := s.usecase.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *ConfirmPhoneChangeTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.expectValidCode()
+	s.expectConfirmPhoneChange(nil)
+	s.smsSender.EXPECT().SendSMS(s.ctx, s.sendSMSInput).Return(sms.SendSMSOutput{MessageID: "sms-1"}, nil)
+
+	out, err := s.usecase.ConfirmPhoneChange(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(usecase.ConfirmPhoneChangeOutput{PhoneNo: "+6581234567"}, out)
+}
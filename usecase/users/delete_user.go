@@ -0,0 +1,73 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"time"
+)
+
+const (
+	auditActionRequestUserDeletion = "user.deletion.requested"
+	auditActionCancelUserDeletion  = "user.deletion.cancelled"
+)
+
+func (u *userUsecases) DeleteUser(ctx context.Context, input usecase.DeleteUserInput) (output usecase.DeleteUserOutput, err error) {
+	ctx, span := startSpan(ctx, "DeleteUser")
+	defer func() { endSpan(span, err) }()
+
+	var usr repository.GetUserOutput
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+	if err = checkUserStatus(usr.Status); err != nil {
+		return
+	}
+
+	if err = compareBcryptHash(usr.PasswordHash, input.Password); err != nil {
+		err = usecase.UserInvalidPassword
+		return
+	}
+
+	// repeated requests keep the original grace period
+	if !usr.DeletionRequestedAt.IsZero() {
+		return usecase.DeleteUserOutput{DeletionDueAt: usr.DeletionRequestedAt.Add(u.deletionGracePeriod)}, nil
+	}
+
+	requestedAt := time.Now()
+	if _, err = u.userRepo.SetUserDeletionRequest(ctx, repository.SetUserDeletionRequestInput{ID: usr.ID, RequestedAt: requestedAt}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+
+	_, err = u.auditRepo.CreateAuditEvent(ctx, repository.CreateAuditEventInput{
+		ActorUserID:  usr.ID,
+		Action:       auditActionRequestUserDeletion,
+		TargetUserID: usr.ID,
+	})
+	if err != nil {
+		return
+	}
+
+	return usecase.DeleteUserOutput{DeletionDueAt: requestedAt.Add(u.deletionGracePeriod)}, nil
+}
+
+// cancelUserDeletion will cancel the pending deletion request of the user
+func (u *userUsecases) cancelUserDeletion(ctx context.Context, userID uint64) (err error) {
+	if _, err = u.userRepo.SetUserDeletionRequest(ctx, repository.SetUserDeletionRequestInput{ID: userID}); err != nil {
+		return
+	}
+
+	_, err = u.auditRepo.CreateAuditEvent(ctx, repository.CreateAuditEventInput{
+		ActorUserID:  userID,
+		Action:       auditActionCancelUserDeletion,
+		TargetUserID: userID,
+	})
+	return err
+}
@@ -0,0 +1,143 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+	"time"
+)
+
+type DeleteUserTestSuite struct {
+	suite.Suite
+
+	gomock    *gomock.Controller
+	repo      *repository.MockUserRepository
+	auditRepo *repository.MockAuditRepository
+
+	usecase usecase.UserUsecases
+
+	getUserInput  repository.GetUserInput
+	getUserOutput repository.GetUserOutput
+	auditInput    repository.CreateAuditEventInput
+
+	input usecase.DeleteUserInput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestDeleteUserTestSuite(t *testing.T) {
+	suite.Run(t, new(DeleteUserTestSuite))
+}
+
+func (s *DeleteUserTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.auditRepo = repository.NewMockAuditRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		UserRepo:  s.repo,
+		AuditRepo: s.auditRepo,
+		Users:     config.Users{DeletionGracePeriod: 14 * 24 * time.Hour},
+	})
+
+	passwdHash, _ := bcrypt.GenerateFromPassword([]byte("SomeVal1dPassw@rd"), bcrypt.MinCost)
+	s.getUserInput = repository.GetUserInput{ID: 123}
+	s.getUserOutput = repository.GetUserOutput{ID: 123, PasswordHash: passwdHash, Status: "active"}
+	s.auditInput = repository.CreateAuditEventInput{ActorUserID: 123, Action: "user.deletion.requested", TargetUserID: 123}
+
+	s.input = usecase.DeleteUserInput{UserID: 123, Password: "SomeVal1dPassw@rd"}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *DeleteUserTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *DeleteUserTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.DeleteUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *DeleteUserTestSuite) TestUserSuspended() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "suspended"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.DeleteUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserSuspended)
+}
+
+func (s *DeleteUserTestSuite) TestInvalidPassword() {
+	a := assert.New(s.T())
+
+	s.input.Password = "invalid-password"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.DeleteUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidPassword)
+}
+
+func (s *DeleteUserTestSuite) TestAlreadyRequested() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.DeletionRequestedAt = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.DeleteUser(s.ctx, s.input)
+
+	a.Nil(err)
+	a.Equal(usecase.DeleteUserOutput{DeletionDueAt: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)}, out)
+}
+
+func (s *DeleteUserTestSuite) TestRepositoryError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().SetUserDeletionRequest(s.ctx, gomock.Any()).Return(repository.SetUserDeletionRequestOutput{}, s.mockErr)
+
+	out, err := s.usecase.DeleteUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *DeleteUserTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	var requestedAt time.Time
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().SetUserDeletionRequest(s.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input repository.SetUserDeletionRequestInput) (repository.SetUserDeletionRequestOutput, error) {
+			a.Equal(uint64(123), input.ID)
+			a.WithinDuration(time.Now(), input.RequestedAt, time.Minute)
+			requestedAt = input.RequestedAt
+			return repository.SetUserDeletionRequestOutput{}, nil
+		})
+	s.auditRepo.EXPECT().CreateAuditEvent(s.ctx, s.auditInput).Return(repository.CreateAuditEventOutput{ID: 9}, nil)
+
+	out, err := s.usecase.DeleteUser(s.ctx, s.input)
+
+	a.Nil(err)
+	a.Equal(requestedAt.Add(14*24*time.Hour), out.DeletionDueAt)
+}
@@ -0,0 +1,27 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+)
+
+func (u *userUsecases) GetAttributeSchema(ctx context.Context, input usecase.GetAttributeSchemaInput) (output usecase.GetAttributeSchemaOutput, err error) {
+	ctx, span := startSpan(ctx, "GetAttributeSchema")
+	defer func() { endSpan(span, err) }()
+
+	var resp repository.GetLatestAttributeSchemaOutput
+	if resp, err = u.attrSchemaRepo.GetLatestAttributeSchema(ctx, repository.GetLatestAttributeSchemaInput{}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.AttributeSchemaNotFound
+		}
+		return
+	}
+
+	return usecase.GetAttributeSchemaOutput{
+		Schema:    resp.Schema,
+		UpdatedBy: resp.CreatedBy,
+		UpdatedAt: resp.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type GetAttributeSchemaTestSuite struct {
+	suite.Suite
+
+	gomock         *gomock.Controller
+	attrSchemaRepo *repository.MockAttributeSchemaRepository
+
+	usecase usecase.UserUsecases
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestGetAttributeSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(GetAttributeSchemaTestSuite))
+}
+
+func (s *GetAttributeSchemaTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.attrSchemaRepo = repository.NewMockAttributeSchemaRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{AttrSchemaRepo: s.attrSchemaRepo})
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *GetAttributeSchemaTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *GetAttributeSchemaTestSuite) TestRepositoryError() {
+	a := assert.New(s.T())
+
+	s.attrSchemaRepo.EXPECT().GetLatestAttributeSchema(s.ctx, repository.GetLatestAttributeSchemaInput{}).
+		Return(repository.GetLatestAttributeSchemaOutput{}, s.mockErr)
+
+	out, err := s.usecase.GetAttributeSchema(s.ctx, usecase.GetAttributeSchemaInput{})
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *GetAttributeSchemaTestSuite) TestNotFound() {
+	a := assert.New(s.T())
+
+	s.attrSchemaRepo.EXPECT().GetLatestAttributeSchema(s.ctx, repository.GetLatestAttributeSchemaInput{}).
+		Return(repository.GetLatestAttributeSchemaOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.GetAttributeSchema(s.ctx, usecase.GetAttributeSchemaInput{})
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.AttributeSchemaNotFound)
+}
+
+func (s *GetAttributeSchemaTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	createdAt := time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)
+	s.attrSchemaRepo.EXPECT().GetLatestAttributeSchema(s.ctx, repository.GetLatestAttributeSchemaInput{}).
+		Return(repository.GetLatestAttributeSchemaOutput{ID: 3, Schema: json.RawMessage(`{"type": "object"}`), CreatedBy: 1, CreatedAt: createdAt}, nil)
+
+	out, err := s.usecase.GetAttributeSchema(s.ctx, usecase.GetAttributeSchemaInput{})
+
+	a.Empty(err)
+	a.Equal(usecase.GetAttributeSchemaOutput{Schema: json.RawMessage(`{"type": "object"}`), UpdatedBy: 1, UpdatedAt: createdAt}, out)
+}
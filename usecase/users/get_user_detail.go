@@ -0,0 +1,36 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+)
+
+func (u *userUsecases) GetUserDetail(ctx context.Context, input usecase.GetUserDetailInput) (output usecase.GetUserDetailOutput, err error) {
+	ctx, span := startSpan(ctx, "GetUserDetail")
+	defer func() { endSpan(span, err) }()
+
+	var resp repository.GetUserOutput
+	if resp, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+
+	var roles repository.GetUserRolesOutput
+	if roles, err = u.userRepo.GetUserRoles(ctx, repository.GetUserRolesInput{UserID: input.UserID}); err != nil {
+		return
+	}
+
+	return usecase.GetUserDetailOutput{UserDetail: usecase.UserDetail{
+		UserID:               resp.ID,
+		PhoneNo:              resp.PhoneNo,
+		FullName:             resp.FullName,
+		SuccessfulLoginCount: resp.SuccessfulLoginCount,
+		Roles:                roles.Roles,
+		Status:               resp.Status,
+		CreatedAt:            resp.CreatedAt,
+	}}, nil
+}
@@ -0,0 +1,109 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type GetUserDetailTestSuite struct {
+	suite.Suite
+
+	gomock *gomock.Controller
+	repo   *repository.MockUserRepository
+
+	usecase usecase.UserUsecases
+
+	getUserInput       repository.GetUserInput
+	getUserOutput      repository.GetUserOutput
+	getUserRolesInput  repository.GetUserRolesInput
+	getUserRolesOutput repository.GetUserRolesOutput
+
+	input  usecase.GetUserDetailInput
+	output usecase.GetUserDetailOutput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestGetUserDetailTestSuite(t *testing.T) {
+	suite.Run(t, new(GetUserDetailTestSuite))
+}
+
+func (s *GetUserDetailTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo})
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.getUserInput = repository.GetUserInput{ID: 123}
+	s.getUserOutput = repository.GetUserOutput{
+		ID:                   123,
+		PhoneNo:              "+628123456789",
+		FullName:             "John Doe",
+		PasswordHash:         []byte("password-hash"),
+		SuccessfulLoginCount: 2,
+		CreatedAt:            createdAt,
+	}
+	s.getUserRolesInput = repository.GetUserRolesInput{UserID: 123}
+	s.getUserRolesOutput = repository.GetUserRolesOutput{Roles: []string{"farmer"}}
+
+	s.input = usecase.GetUserDetailInput{UserID: 123}
+	s.output = usecase.GetUserDetailOutput{UserDetail: usecase.UserDetail{
+		UserID:               123,
+		PhoneNo:              "+628123456789",
+		FullName:             "John Doe",
+		SuccessfulLoginCount: 2,
+		Roles:                []string{"farmer"},
+		CreatedAt:            createdAt,
+	}}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *GetUserDetailTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *GetUserDetailTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.GetUserDetail(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *GetUserDetailTestSuite) TestGetUserRolesError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(repository.GetUserRolesOutput{}, s.mockErr)
+
+	out, err := s.usecase.GetUserDetail(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *GetUserDetailTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+
+	out, err := s.usecase.GetUserDetail(s.ctx, s.input)
+
+	a.Nil(err)
+	a.Equal(s.output, out)
+}
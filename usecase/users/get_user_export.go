@@ -0,0 +1,43 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"time"
+)
+
+func (u *userUsecases) GetUserExport(ctx context.Context, input usecase.GetUserExportInput) (output usecase.GetUserExportOutput, err error) {
+	ctx, span := startSpan(ctx, "GetUserExport")
+	defer func() { endSpan(span, err) }()
+
+	var resp repository.GetUserExportOutput
+	if resp, err = u.userExportRepo.GetUserExport(ctx, repository.GetUserExportInput{ID: input.ExportID, UserID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserExportNotFound
+		}
+		return
+	}
+
+	// expired exports might not be deleted yet by the background worker
+	if !resp.ExpiresAt.IsZero() && resp.ExpiresAt.Before(time.Now()) {
+		err = usecase.UserExportNotFound
+		return
+	}
+
+	output = usecase.GetUserExportOutput{
+		ExportID:  resp.ID,
+		Format:    resp.Format,
+		Status:    resp.Status,
+		CreatedAt: resp.CreatedAt,
+		ExpiresAt: resp.ExpiresAt,
+	}
+	if resp.Status == usecase.UserExportStatusReady {
+		output.Content = resp.Content
+		output.ContentType = resp.ContentType
+		output.FileName = fmt.Sprintf("user-export-%d.%s", resp.ID, resp.Format)
+	}
+	return output, nil
+}
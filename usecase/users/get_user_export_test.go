@@ -0,0 +1,116 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type GetUserExportTestSuite struct {
+	suite.Suite
+
+	gomock     *gomock.Controller
+	exportRepo *repository.MockUserExportRepository
+
+	usecase usecase.UserUsecases
+
+	repoInput  repository.GetUserExportInput
+	repoOutput repository.GetUserExportOutput
+	input      usecase.GetUserExportInput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestGetUserExportTestSuite(t *testing.T) {
+	suite.Run(t, new(GetUserExportTestSuite))
+}
+
+func (s *GetUserExportTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.exportRepo = repository.NewMockUserExportRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserExportRepo: s.exportRepo})
+
+	s.repoInput = repository.GetUserExportInput{ID: 7, UserID: 123}
+	s.repoOutput = repository.GetUserExportOutput{
+		ID:          7,
+		UserID:      123,
+		Format:      "json",
+		Status:      "ready",
+		Content:     []byte(`{}`),
+		ContentType: "application/json",
+		CreatedAt:   time.Now().Add(-time.Hour),
+		CompletedAt: time.Now().Add(-time.Minute),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	s.input = usecase.GetUserExportInput{UserID: 123, ExportID: 7}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *GetUserExportTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *GetUserExportTestSuite) TestNotFound() {
+	a := assert.New(s.T())
+
+	s.exportRepo.EXPECT().GetUserExport(s.ctx, s.repoInput).Return(repository.GetUserExportOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.GetUserExport(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserExportNotFound)
+}
+
+func (s *GetUserExportTestSuite) TestExpired() {
+	a := assert.New(s.T())
+
+	s.repoOutput.ExpiresAt = time.Now().Add(-time.Minute)
+	s.exportRepo.EXPECT().GetUserExport(s.ctx, s.repoInput).Return(s.repoOutput, nil)
+
+	out, err := s.usecase.GetUserExport(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserExportNotFound)
+}
+
+func (s *GetUserExportTestSuite) TestPending() {
+	a := assert.New(s.T())
+
+	s.repoOutput = repository.GetUserExportOutput{ID: 7, UserID: 123, Format: "json", Status: "pending", CreatedAt: s.repoOutput.CreatedAt}
+	s.exportRepo.EXPECT().GetUserExport(s.ctx, s.repoInput).Return(s.repoOutput, nil)
+
+	out, err := s.usecase.GetUserExport(s.ctx, s.input)
+
+	a.Nil(err)
+	a.Equal(usecase.GetUserExportOutput{ExportID: 7, Format: "json", Status: "pending", CreatedAt: s.repoOutput.CreatedAt}, out)
+}
+
+func (s *GetUserExportTestSuite) TestReady() {
+	a := assert.New(s.T())
+
+	s.exportRepo.EXPECT().GetUserExport(s.ctx, s.repoInput).Return(s.repoOutput, nil)
+
+	out, err := s.usecase.GetUserExport(s.ctx, s.input)
+
+	a.Nil(err)
+	a.Equal(usecase.GetUserExportOutput{
+		ExportID:    7,
+		Format:      "json",
+		Status:      "ready",
+		CreatedAt:   s.repoOutput.CreatedAt,
+		ExpiresAt:   s.repoOutput.ExpiresAt,
+		Content:     []byte(`{}`),
+		ContentType: "application/json",
+		FileName:    "user-export-7.json",
+	}, out)
+}
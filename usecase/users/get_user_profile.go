@@ -8,6 +8,9 @@ import (
 )
 
 func (u *userUsecases) GetUserProfile(ctx context.Context, input usecase.GetUserProfileInput) (output usecase.GetUserProfileOutput, err error) {
+	ctx, span := startSpan(ctx, "GetUserProfile")
+	defer func() { endSpan(span, err) }()
+
 	var resp repository.GetUserOutput
 	if resp, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
 		if errors.Is(err, repository.ErrorRecordNotFound) {
@@ -15,10 +18,27 @@ func (u *userUsecases) GetUserProfile(ctx context.Context, input usecase.GetUser
 		}
 		return
 	}
+	if err = checkUserStatus(resp.Status); err != nil {
+		return
+	}
+
+	var avatarURLs usecase.UploadUserAvatarOutput
+	if resp.AvatarKey != "" {
+		if avatarURLs, err = u.getAvatarURLs(ctx, resp.AvatarKey); err != nil {
+			return
+		}
+	}
+
 	return usecase.GetUserProfileOutput{
 		UserID:               resp.ID,
 		PhoneNo:              resp.PhoneNo,
 		FullName:             resp.FullName,
+		Email:                resp.Email,
+		EmailVerified:        !resp.EmailVerifiedAt.IsZero(),
 		SuccessfulLoginCount: resp.SuccessfulLoginCount,
+		AvatarURL:            avatarURLs.AvatarURL,
+		AvatarThumbnailURL:   avatarURLs.AvatarThumbnailURL,
+		Attributes:           resp.Attributes,
+		Version:              resp.Version,
 	}, nil
 }
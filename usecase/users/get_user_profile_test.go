@@ -4,18 +4,21 @@ import (
 	"context"
 	"fmt"
 	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/storage"
 	"github.com/SawitProRecruitment/UserService/usecase"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type GetUserProfileTestSuite struct {
 	suite.Suite
 
-	gomock *gomock.Controller
-	repo   *repository.MockUserRepository
+	gomock    *gomock.Controller
+	repo      *repository.MockUserRepository
+	blobStore *storage.MockBlobStore
 
 	usecase usecase.UserUsecases
 
@@ -36,16 +39,22 @@ func TestGetUserProfileTestSuite(t *testing.T) {
 func (s *GetUserProfileTestSuite) SetupTest() {
 	s.gomock = gomock.NewController(s.T())
 	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.blobStore = storage.NewMockBlobStore(s.gomock)
 
-	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo})
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo, BlobStore: s.blobStore})
 
 	s.getUserInput = repository.GetUserInput{ID: 123}
 	s.getUserOutput = repository.GetUserOutput{
 		ID:                   123,
 		PhoneNo:              "+6281215183300",
 		FullName:             "John Smith",
+		Email:                "john.smith@example.com",
+		EmailVerifiedAt:      time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC),
 		PasswordHash:         []byte("password-hash-here"),
 		SuccessfulLoginCount: 2,
+		Status:               "active",
+		Attributes:           map[string]interface{}{"estate_code": "KAL-01"},
+		Version:              3,
 	}
 
 	s.input = usecase.GetUserProfileInput{UserID: 123}
@@ -53,7 +62,11 @@ func (s *GetUserProfileTestSuite) SetupTest() {
 		UserID:               123,
 		PhoneNo:              "+6281215183300",
 		FullName:             "John Smith",
+		Email:                "john.smith@example.com",
+		EmailVerified:        true,
 		SuccessfulLoginCount: 2,
+		Attributes:           map[string]interface{}{"estate_code": "KAL-01"},
+		Version:              3,
 	}
 
 	s.ctx = context.Background()
@@ -96,3 +109,46 @@ func (s *GetUserProfileTestSuite) TestSuccess() {
 	a.Empty(err)
 	a.Equal(s.output, out)
 }
+
+func (s *GetUserProfileTestSuite) TestUserSuspended() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "suspended"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.GetUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserSuspended)
+}
+
+func (s *GetUserProfileTestSuite) TestSuccessWithAvatar() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.AvatarKey = "avatars/123/random-key"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.blobStore.EXPECT().GetBlobURL(s.ctx, storage.GetBlobURLInput{Key: "avatars/123/random-key/512.jpg"}).
+		Return(storage.GetBlobURLOutput{URL: "https://example.com/blobs/avatars/123/random-key/512.jpg"}, nil)
+	s.blobStore.EXPECT().GetBlobURL(s.ctx, storage.GetBlobURLInput{Key: "avatars/123/random-key/128.jpg"}).
+		Return(storage.GetBlobURLOutput{URL: "https://example.com/blobs/avatars/123/random-key/128.jpg"}, nil)
+
+	out, err := s.usecase.GetUserProfile(s.ctx, s.input)
+
+	s.output.AvatarURL = "https://example.com/blobs/avatars/123/random-key/512.jpg"
+	s.output.AvatarThumbnailURL = "https://example.com/blobs/avatars/123/random-key/128.jpg"
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
+
+func (s *GetUserProfileTestSuite) TestBlobStoreError() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.AvatarKey = "avatars/123/random-key"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.blobStore.EXPECT().GetBlobURL(s.ctx, gomock.Any()).Return(storage.GetBlobURLOutput{}, s.mockErr)
+
+	out, err := s.usecase.GetUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
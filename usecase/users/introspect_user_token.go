@@ -0,0 +1,51 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+)
+
+// dummyClientSecretHash is compared against when the client is unknown, so that the response time doesn't tell
+// whether the client exists. It's hashed with the same cost as the client secrets
+var dummyClientSecretHash = []byte("$2a$10$SIETgoZsI8Nr.Tyb/dRpyu3X/rRVfYsyPg19z8oPhhrSUoJYPNjC6")
+
+func (u *userUsecases) IntrospectUserToken(ctx context.Context, input usecase.IntrospectUserTokenInput) (output usecase.IntrospectUserTokenOutput, err error) {
+	ctx, span := startSpan(ctx, "IntrospectUserToken")
+	defer func() { endSpan(span, err) }()
+
+	var client repository.GetOAuthClientOutput
+	if client, err = u.oauthClientRepo.GetOAuthClient(ctx, repository.GetOAuthClientInput{ClientID: input.ClientID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			_ = compareBcryptHash(dummyClientSecretHash, input.ClientSecret)
+			err = usecase.ClientInvalidCredentials
+		}
+		return
+	}
+	if err = compareBcryptHash(client.ClientSecretHash, input.ClientSecret); err != nil {
+		err = usecase.ClientInvalidCredentials
+		return
+	}
+
+	// any validation failure simply means the token is inactive, RFC 7662 forbids telling the client why
+	var claims userTokenClaims
+	if claims, _, err = u.verifyUserToken(ctx, input.Token); err != nil {
+		var ucErr *usecase.Error
+		if errors.As(err, &ucErr) {
+			return usecase.IntrospectUserTokenOutput{Active: false}, nil
+		}
+		return
+	}
+
+	return usecase.IntrospectUserTokenOutput{
+		Active:    true,
+		Subject:   claims.Subject,
+		ExpiresAt: claims.ExpiresAt,
+		IssuedAt:  claims.IssuedAt,
+		Scope:     claims.Scope,
+		ClientID:  claims.ClientID,
+
+		ActorSubject: claims.ActorSubject,
+	}, nil
+}
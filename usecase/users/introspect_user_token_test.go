@@ -0,0 +1,243 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+	"time"
+)
+
+type IntrospectUserTokenTestSuite struct {
+	suite.Suite
+
+	gomock          *gomock.Controller
+	repo            *repository.MockUserRepository
+	tokenRepo       *repository.MockTokenRepository
+	oauthClientRepo *repository.MockOAuthClientRepository
+
+	jwtSecret *rsa.PrivateKey
+	usecase   usecase.UserUsecases
+
+	getOAuthClientInput  repository.GetOAuthClientInput
+	getOAuthClientOutput repository.GetOAuthClientOutput
+
+	getTokenRevocationInput repository.GetTokenRevocationInput
+
+	getUserInput  repository.GetUserInput
+	getUserOutput repository.GetUserOutput
+
+	input  usecase.IntrospectUserTokenInput
+	output usecase.IntrospectUserTokenOutput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestIntrospectUserTokenTestSuite(t *testing.T) {
+	suite.Run(t, new(IntrospectUserTokenTestSuite))
+}
+
+func (s *IntrospectUserTokenTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.tokenRepo = repository.NewMockTokenRepository(s.gomock)
+	s.oauthClientRepo = repository.NewMockOAuthClientRepository(s.gomock)
+
+	s.jwtSecret, _ = rsa.GenerateKey(rand.Reader, 1024)
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		UserRepo:        s.repo,
+		TokenRepo:       s.tokenRepo,
+		OAuthClientRepo: s.oauthClientRepo,
+		JwtSecret:       s.jwtSecret,
+		Auth:            config.Auth{JwtTtl: time.Minute * 5},
+	})
+
+	secretHash, _ := bcrypt.GenerateFromPassword([]byte("client-secret"), bcrypt.MinCost)
+	s.getOAuthClientInput = repository.GetOAuthClientInput{ClientID: "legacy-service"}
+	s.getOAuthClientOutput = repository.GetOAuthClientOutput{
+		ID:               7,
+		ClientID:         "legacy-service",
+		Name:             "Legacy Service",
+		ClientSecretHash: secretHash,
+	}
+
+	s.getTokenRevocationInput = repository.GetTokenRevocationInput{TokenID: "token-id"}
+
+	s.getUserInput = repository.GetUserInput{ID: 123}
+	s.getUserOutput = repository.GetUserOutput{ID: 123, Status: "active"}
+
+	iat := time.Now().Add(time.Minute * -1).Truncate(time.Second)
+	exp := time.Now().Add(time.Minute * 5).Truncate(time.Second)
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "123",
+		"jti": "token-id",
+		"iat": iat.Unix(),
+		"exp": exp.Unix(),
+	})
+	jwtToken, _ := token.SignedString(s.jwtSecret)
+	s.input = usecase.IntrospectUserTokenInput{
+		ClientID:     "legacy-service",
+		ClientSecret: "client-secret",
+		Token:        jwtToken,
+	}
+	s.output = usecase.IntrospectUserTokenOutput{
+		Active:    true,
+		Subject:   "123",
+		ExpiresAt: exp,
+		IssuedAt:  iat,
+	}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *IntrospectUserTokenTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *IntrospectUserTokenTestSuite) TestClientRepositoryError() {
+	a := assert.New(s.T())
+
+	s.oauthClientRepo.EXPECT().GetOAuthClient(s.ctx, s.getOAuthClientInput).Return(repository.GetOAuthClientOutput{}, s.mockErr)
+
+	out, err := s.usecase.IntrospectUserToken(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *IntrospectUserTokenTestSuite) TestClientNotFound() {
+	a := assert.New(s.T())
+
+	s.oauthClientRepo.EXPECT().GetOAuthClient(s.ctx, s.getOAuthClientInput).Return(repository.GetOAuthClientOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.IntrospectUserToken(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.ClientInvalidCredentials)
+}
+
+func (s *IntrospectUserTokenTestSuite) TestDummyClientSecretHash() {
+	a := assert.New(s.T())
+
+	// the unknown clients must take as long as the known ones with a wrong secret
+	cost, err := bcrypt.Cost(dummyClientSecretHash)
+
+	a.Empty(err)
+	a.Equal(bcrypt.DefaultCost, cost)
+}
+
+func (s *IntrospectUserTokenTestSuite) TestInvalidClientSecret() {
+	a := assert.New(s.T())
+
+	s.input.ClientSecret = "wrong-secret"
+	s.oauthClientRepo.EXPECT().GetOAuthClient(s.ctx, s.getOAuthClientInput).Return(s.getOAuthClientOutput, nil)
+
+	out, err := s.usecase.IntrospectUserToken(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.ClientInvalidCredentials)
+}
+
+func (s *IntrospectUserTokenTestSuite) TestExpiredToken() {
+	a := assert.New(s.T())
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "123",
+		"jti": "token-id",
+		"exp": time.Now().Add(time.Minute * -5).Unix(),
+	})
+	s.input.Token, _ = token.SignedString(s.jwtSecret)
+	s.oauthClientRepo.EXPECT().GetOAuthClient(s.ctx, s.getOAuthClientInput).Return(s.getOAuthClientOutput, nil)
+
+	out, err := s.usecase.IntrospectUserToken(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(usecase.IntrospectUserTokenOutput{Active: false}, out)
+}
+
+func (s *IntrospectUserTokenTestSuite) TestRevocationRepositoryError() {
+	a := assert.New(s.T())
+
+	s.oauthClientRepo.EXPECT().GetOAuthClient(s.ctx, s.getOAuthClientInput).Return(s.getOAuthClientOutput, nil)
+	s.tokenRepo.EXPECT().GetTokenRevocation(s.ctx, s.getTokenRevocationInput).Return(repository.GetTokenRevocationOutput{}, s.mockErr)
+
+	out, err := s.usecase.IntrospectUserToken(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *IntrospectUserTokenTestSuite) TestRevokedToken() {
+	a := assert.New(s.T())
+
+	s.oauthClientRepo.EXPECT().GetOAuthClient(s.ctx, s.getOAuthClientInput).Return(s.getOAuthClientOutput, nil)
+	s.tokenRepo.EXPECT().GetTokenRevocation(s.ctx, s.getTokenRevocationInput).Return(repository.GetTokenRevocationOutput{TokenID: "token-id"}, nil)
+
+	out, err := s.usecase.IntrospectUserToken(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(usecase.IntrospectUserTokenOutput{Active: false}, out)
+}
+
+func (s *IntrospectUserTokenTestSuite) TestUserSuspended() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "suspended"
+	s.oauthClientRepo.EXPECT().GetOAuthClient(s.ctx, s.getOAuthClientInput).Return(s.getOAuthClientOutput, nil)
+	s.tokenRepo.EXPECT().GetTokenRevocation(s.ctx, s.getTokenRevocationInput).Return(repository.GetTokenRevocationOutput{}, repository.ErrorRecordNotFound)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.IntrospectUserToken(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(usecase.IntrospectUserTokenOutput{Active: false}, out)
+}
+
+func (s *IntrospectUserTokenTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.oauthClientRepo.EXPECT().GetOAuthClient(s.ctx, s.getOAuthClientInput).Return(s.getOAuthClientOutput, nil)
+	s.tokenRepo.EXPECT().GetTokenRevocation(s.ctx, s.getTokenRevocationInput).Return(repository.GetTokenRevocationOutput{}, repository.ErrorRecordNotFound)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.IntrospectUserToken(s.ctx, s.input)
+
+	a.Empty(err)
+	a.True(out.Active)
+	a.Equal(s.output.Subject, out.Subject)
+	a.True(s.output.ExpiresAt.Equal(out.ExpiresAt))
+	a.True(s.output.IssuedAt.Equal(out.IssuedAt))
+}
+
+func (s *IntrospectUserTokenTestSuite) TestSuccessImpersonation() {
+	a := assert.New(s.T())
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "123",
+		"jti": "token-id",
+		"exp": s.output.ExpiresAt.Unix(),
+		"act": map[string]interface{}{"sub": "1"},
+	})
+	s.input.Token, _ = token.SignedString(s.jwtSecret)
+	s.oauthClientRepo.EXPECT().GetOAuthClient(s.ctx, s.getOAuthClientInput).Return(s.getOAuthClientOutput, nil)
+	s.tokenRepo.EXPECT().GetTokenRevocation(s.ctx, s.getTokenRevocationInput).Return(repository.GetTokenRevocationOutput{}, repository.ErrorRecordNotFound)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.IntrospectUserToken(s.ctx, s.input)
+
+	a.Empty(err)
+	a.True(out.Active)
+	a.Equal("123", out.Subject)
+	a.Equal("1", out.ActorSubject)
+}
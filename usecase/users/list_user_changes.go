@@ -0,0 +1,106 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"strings"
+)
+
+const (
+	defaultListUserChangesLimit = 20
+	maxListUserChangesLimit     = 100
+)
+
+var (
+	// maskedUserFields are the profile fields whose values are masked in the history
+	maskedUserFields = map[string]func(value string) string{
+		"phone_no": maskPhoneNo,
+		"email":    maskEmail,
+	}
+)
+
+func (u *userUsecases) ListUserChanges(ctx context.Context, input usecase.ListUserChangesInput) (output usecase.ListUserChangesOutput, err error) {
+	ctx, span := startSpan(ctx, "ListUserChanges")
+	defer func() { endSpan(span, err) }()
+
+	if input.Limit == 0 {
+		input.Limit = defaultListUserChangesLimit
+	}
+
+	validationErrors := map[string][]error{}
+	if input.Limit < 1 || input.Limit > maxListUserChangesLimit {
+		validationErrors["limit"] = []error{usecase.NewFieldError("limit.out_of_range", "validation.limit_range", i18n.Params{"max": maxListUserChangesLimit})}
+	}
+	var beforeID uint64
+	if input.Cursor != "" {
+		if beforeID, err = decodeIDCursor(input.Cursor); err != nil {
+			validationErrors["cursor"] = []error{usecase.NewFieldError("cursor.invalid", "validation.invalid_cursor", nil)}
+		}
+	}
+	if len(validationErrors) > 0 {
+		err = usecase.NewValidationError(validationErrors)
+		return
+	}
+
+	// the history of unknown users is reported as not found, rather than empty
+	if _, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+
+	// fetch one extra record to find out whether there is a next page
+	var resp repository.ListUserChangesOutput
+	resp, err = u.userChangeRepo.ListUserChanges(ctx, repository.ListUserChangesInput{
+		UserID:   input.UserID,
+		BeforeID: beforeID,
+		Limit:    input.Limit + 1,
+	})
+	if err != nil {
+		return
+	}
+
+	changes := resp.Changes
+	if len(changes) > input.Limit {
+		changes = changes[:input.Limit]
+		output.NextCursor = encodeIDCursor(changes[len(changes)-1].ID)
+	}
+	output.Changes = make([]usecase.UserChange, 0, len(changes))
+	for _, change := range changes {
+		output.Changes = append(output.Changes, usecase.UserChange{
+			ID:          change.ID,
+			Field:       change.Field,
+			OldValue:    maskUserFieldValue(change.Field, change.OldValue),
+			NewValue:    maskUserFieldValue(change.Field, change.NewValue),
+			ActorUserID: change.ActorUserID,
+			RequestID:   change.RequestID,
+			ChangedAt:   change.CreatedAt,
+		})
+	}
+	return output, nil
+}
+
+// maskUserFieldValue will mask the value of sensitive profile fields, other values are returned as is
+func maskUserFieldValue(field string, value interface{}) interface{} {
+	mask, ok := maskedUserFields[field]
+	if !ok {
+		return value
+	}
+	if str, ok := value.(string); ok {
+		return mask(str)
+	}
+	return value
+}
+
+// maskEmail will hide the local part of the email, besides its first character
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 1 {
+		return email
+	}
+	return email[:1] + strings.Repeat("*", at-1) + email[at:]
+}
@@ -0,0 +1,161 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type ListUserChangesTestSuite struct {
+	suite.Suite
+
+	gomock         *gomock.Controller
+	repo           *repository.MockUserRepository
+	userChangeRepo *repository.MockUserChangeRepository
+
+	usecase usecase.UserUsecases
+
+	createdAt  time.Time
+	repoInput  repository.ListUserChangesInput
+	repoOutput repository.ListUserChangesOutput
+	input      usecase.ListUserChangesInput
+	output     usecase.ListUserChangesOutput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestListUserChangesTestSuite(t *testing.T) {
+	suite.Run(t, new(ListUserChangesTestSuite))
+}
+
+func (s *ListUserChangesTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.userChangeRepo = repository.NewMockUserChangeRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo, UserChangeRepo: s.userChangeRepo})
+
+	s.createdAt = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.repoInput = repository.ListUserChangesInput{UserID: 123, BeforeID: 10, Limit: 3}
+	s.repoOutput = repository.ListUserChangesOutput{Changes: []repository.UserChange{
+		{
+			ID:              9,
+			UserFieldChange: repository.UserFieldChange{Field: "email", OldValue: "john.smith@example.com", NewValue: "john@example.com"},
+			ActorUserID:     123,
+			RequestID:       "request-id",
+			CreatedAt:       s.createdAt,
+		},
+		{
+			ID:              8,
+			UserFieldChange: repository.UserFieldChange{Field: "phone_no", OldValue: "+62812151833", NewValue: "+6581234567"},
+			ActorUserID:     123,
+			CreatedAt:       s.createdAt,
+		},
+		{
+			ID:              5,
+			UserFieldChange: repository.UserFieldChange{Field: "attributes.estate_code", NewValue: "KAL-01"},
+			CreatedAt:       s.createdAt,
+		},
+	}}
+
+	s.input = usecase.ListUserChangesInput{UserID: 123, Cursor: encodeIDCursor(10), Limit: 2}
+	s.output = usecase.ListUserChangesOutput{
+		Changes: []usecase.UserChange{
+			{ID: 9, Field: "email", OldValue: "j*********@example.com", NewValue: "j***@example.com", ActorUserID: 123, RequestID: "request-id", ChangedAt: s.createdAt},
+			{ID: 8, Field: "phone_no", OldValue: "+62*****1833", NewValue: "+65****4567", ActorUserID: 123, ChangedAt: s.createdAt},
+		},
+		NextCursor: encodeIDCursor(8),
+	}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *ListUserChangesTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *ListUserChangesTestSuite) TestInvalidPayload() {
+	a := assert.New(s.T())
+
+	s.input.Limit = 101
+	s.input.Cursor = "not-a-cursor"
+
+	out, err := s.usecase.ListUserChanges(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorContains(err, "limit must be between 1 and 100")
+	a.ErrorContains(err, "invalid cursor")
+}
+
+func (s *ListUserChangesTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.ListUserChanges(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *ListUserChangesTestSuite) TestRepoError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{ID: 123}, nil)
+	s.userChangeRepo.EXPECT().ListUserChanges(s.ctx, s.repoInput).Return(repository.ListUserChangesOutput{}, s.mockErr)
+
+	out, err := s.usecase.ListUserChanges(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *ListUserChangesTestSuite) TestDefaultLimit() {
+	a := assert.New(s.T())
+
+	s.input = usecase.ListUserChangesInput{UserID: 123}
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{ID: 123}, nil)
+	s.userChangeRepo.EXPECT().ListUserChanges(s.ctx, repository.ListUserChangesInput{UserID: 123, Limit: 21}).
+		Return(repository.ListUserChangesOutput{}, nil)
+
+	out, err := s.usecase.ListUserChanges(s.ctx, s.input)
+
+	a.Nil(err)
+	a.Equal(usecase.ListUserChangesOutput{Changes: []usecase.UserChange{}}, out)
+}
+
+func (s *ListUserChangesTestSuite) TestLastPage() {
+	a := assert.New(s.T())
+
+	s.input.Limit = 3
+	s.repoInput.Limit = 4
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{ID: 123}, nil)
+	s.userChangeRepo.EXPECT().ListUserChanges(s.ctx, s.repoInput).Return(s.repoOutput, nil)
+
+	out, err := s.usecase.ListUserChanges(s.ctx, s.input)
+
+	a.Nil(err)
+	a.Len(out.Changes, 3)
+	a.Equal(usecase.UserChange{ID: 5, Field: "attributes.estate_code", NewValue: "KAL-01", ChangedAt: s.createdAt}, out.Changes[2])
+	a.Empty(out.NextCursor)
+}
+
+func (s *ListUserChangesTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{ID: 123}, nil)
+	s.userChangeRepo.EXPECT().ListUserChanges(s.ctx, s.repoInput).Return(s.repoOutput, nil)
+
+	out, err := s.usecase.ListUserChanges(s.ctx, s.input)
+
+	a.Nil(err)
+	a.Equal(s.output, out)
+}
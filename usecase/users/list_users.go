@@ -0,0 +1,112 @@
+package users
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"strconv"
+)
+
+const (
+	defaultListUsersLimit = 20
+	maxListUsersLimit     = 100
+)
+
+func (u *userUsecases) ListUsers(ctx context.Context, input usecase.ListUsersInput) (output usecase.ListUsersOutput, err error) {
+	ctx, span := startSpan(ctx, "ListUsers")
+	defer func() { endSpan(span, err) }()
+
+	if input.Limit == 0 {
+		input.Limit = defaultListUsersLimit
+	}
+
+	beforeID, validationErrors := validateListUsersPayload(input)
+	if len(validationErrors) > 0 {
+		err = usecase.NewValidationError(validationErrors)
+		return
+	}
+
+	// fetch one extra record to find out whether there is a next page
+	var resp repository.ListUsersOutput
+	resp, err = u.userRepo.ListUsers(ctx, repository.ListUsersInput{
+		PhonePrefix:  input.PhonePrefix,
+		NameContains: input.Name,
+		CreatedFrom:  input.CreatedFrom,
+		CreatedTo:    input.CreatedTo,
+		Status:       input.Status,
+		BeforeID:     beforeID,
+		Limit:        input.Limit + 1,
+	})
+	if err != nil {
+		return
+	}
+
+	users := resp.Users
+	if len(users) > input.Limit {
+		users = users[:input.Limit]
+		output.NextCursor = encodeIDCursor(users[len(users)-1].ID)
+	}
+	output.Users = make([]usecase.UserDetail, 0, len(users))
+	for _, usr := range users {
+		output.Users = append(output.Users, usecase.UserDetail{
+			UserID:               usr.ID,
+			PhoneNo:              usr.PhoneNo,
+			FullName:             usr.FullName,
+			SuccessfulLoginCount: usr.SuccessfulLoginCount,
+			Roles:                usr.Roles,
+			Status:               usr.Status,
+			CreatedAt:            usr.CreatedAt,
+		})
+	}
+	return output, nil
+}
+
+// validateListUsersPayload will validate the filters, and decode the cursor into the last seen user ID
+func validateListUsersPayload(input usecase.ListUsersInput) (beforeID uint64, validationErrors map[string][]error) {
+	validationErrors = map[string][]error{}
+	if input.Limit < 1 || input.Limit > maxListUsersLimit {
+		validationErrors["limit"] = []error{usecase.NewFieldError("limit.out_of_range", "validation.limit_range", i18n.Params{"max": maxListUsersLimit})}
+	}
+	if !input.CreatedFrom.IsZero() && !input.CreatedTo.IsZero() && !input.CreatedFrom.Before(input.CreatedTo) {
+		validationErrors["created_to"] = []error{usecase.NewFieldError("created_to.before_created_from", "validation.created_range", nil)}
+	}
+	if input.Status != "" && !isKnownUserStatus(input.Status) {
+		validationErrors["status"] = []error{usecase.NewFieldError("status.unknown", "validation.unknown_status", i18n.Params{"status": input.Status})}
+	}
+	if input.Cursor != "" {
+		var err error
+		if beforeID, err = decodeIDCursor(input.Cursor); err != nil {
+			validationErrors["cursor"] = []error{usecase.NewFieldError("cursor.invalid", "validation.invalid_cursor", nil)}
+		}
+	}
+	return beforeID, validationErrors
+}
+
+// the cursor (of users, or profile changes) is kept opaque to the clients, so the pagination strategy can change later
+func encodeIDCursor(lastID uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(lastID, 10)))
+}
+
+func decodeIDCursor(cursor string) (uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	return id, nil
+}
+
+func isKnownUserStatus(status string) bool {
+	for _, known := range usecase.UserStatuses {
+		if status == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type ListUsersTestSuite struct {
+	suite.Suite
+
+	gomock *gomock.Controller
+	repo   *repository.MockUserRepository
+
+	usecase usecase.UserUsecases
+
+	createdAt  time.Time
+	repoInput  repository.ListUsersInput
+	repoOutput repository.ListUsersOutput
+	input      usecase.ListUsersInput
+	output     usecase.ListUsersOutput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestListUsersTestSuite(t *testing.T) {
+	suite.Run(t, new(ListUsersTestSuite))
+}
+
+func (s *ListUsersTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo})
+
+	s.createdAt = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.repoInput = repository.ListUsersInput{PhonePrefix: "+6281", NameContains: "john", Status: "active", BeforeID: 124, Limit: 3}
+	s.repoOutput = repository.ListUsersOutput{Users: []repository.ListUsersItem{
+		{ID: 123, PhoneNo: "+628123456789", FullName: "John Doe", SuccessfulLoginCount: 2, CreatedAt: s.createdAt, Roles: []string{"farmer"}},
+		{ID: 120, PhoneNo: "+628123456780", FullName: "Johnny", CreatedAt: s.createdAt},
+		{ID: 100, PhoneNo: "+628123456700", FullName: "Big John", CreatedAt: s.createdAt},
+	}}
+
+	s.input = usecase.ListUsersInput{PhonePrefix: "+6281", Name: "john", Status: "active", Cursor: encodeIDCursor(124), Limit: 2}
+	s.output = usecase.ListUsersOutput{
+		Users: []usecase.UserDetail{
+			{UserID: 123, PhoneNo: "+628123456789", FullName: "John Doe", SuccessfulLoginCount: 2, CreatedAt: s.createdAt, Roles: []string{"farmer"}},
+			{UserID: 120, PhoneNo: "+628123456780", FullName: "Johnny", CreatedAt: s.createdAt},
+		},
+		NextCursor: encodeIDCursor(120),
+	}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *ListUsersTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *ListUsersTestSuite) TestInvalidPayload() {
+	a := assert.New(s.T())
+
+	s.input.Limit = 101
+	s.input.Cursor = "not-a-cursor"
+	s.input.CreatedFrom = s.createdAt
+	s.input.CreatedTo = s.createdAt.Add(-time.Hour)
+	s.input.Status = "banned"
+
+	out, err := s.usecase.ListUsers(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorContains(err, "limit must be between 1 and 100")
+	a.ErrorContains(err, "invalid cursor")
+	a.ErrorContains(err, "created_to must be after created_from")
+	a.ErrorContains(err, `unknown status "banned"`)
+}
+
+func (s *ListUsersTestSuite) TestRepoError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().ListUsers(s.ctx, s.repoInput).Return(repository.ListUsersOutput{}, s.mockErr)
+
+	out, err := s.usecase.ListUsers(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *ListUsersTestSuite) TestDefaultLimit() {
+	a := assert.New(s.T())
+
+	s.input = usecase.ListUsersInput{}
+	s.repo.EXPECT().ListUsers(s.ctx, repository.ListUsersInput{Limit: 21}).Return(repository.ListUsersOutput{}, nil)
+
+	out, err := s.usecase.ListUsers(s.ctx, s.input)
+
+	a.Nil(err)
+	a.Equal(usecase.ListUsersOutput{Users: []usecase.UserDetail{}}, out)
+}
+
+func (s *ListUsersTestSuite) TestLastPage() {
+	a := assert.New(s.T())
+
+	s.input.Limit = 3
+	s.repoInput.Limit = 4
+	s.repo.EXPECT().ListUsers(s.ctx, s.repoInput).Return(s.repoOutput, nil)
+
+	out, err := s.usecase.ListUsers(s.ctx, s.input)
+
+	a.Nil(err)
+	a.Len(out.Users, 3)
+	a.Empty(out.NextCursor)
+}
+
+func (s *ListUsersTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().ListUsers(s.ctx, s.repoInput).Return(s.repoOutput, nil)
+
+	out, err := s.usecase.ListUsers(s.ctx, s.input)
+
+	a.Nil(err)
+	a.Equal(s.output, out)
+}
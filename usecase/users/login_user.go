@@ -2,41 +2,175 @@ package users
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"github.com/SawitProRecruitment/UserService/repository"
 	"github.com/SawitProRecruitment/UserService/usecase"
 	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
+	"strings"
 	"time"
 )
 
+var (
+	// wrapper to token ID generation to make testing easier
+	generateTokenID = func() (string, error) {
+		id := make([]byte, 16)
+		if _, err := rand.Read(id); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(id), nil
+	}
+
+	// wrapper to device token generation to make testing easier
+	generateDeviceToken = func() (string, error) {
+		token := make([]byte, 32)
+		if _, err := rand.Read(token); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(token), nil
+	}
+)
+
 func (u *userUsecases) LoginUser(ctx context.Context, input usecase.LoginUserInput) (output usecase.LoginUserOutput, err error) {
+	ctx, span := startSpan(ctx, "LoginUser")
+	defer func() { endSpan(span, err) }()
+	defer func() { recordLoginAttempt(loginMethodPassword, err) }()
+
+	var getUserPayload repository.GetUserInput
+	switch {
+	case input.PhoneNo != "":
+		getUserPayload.PhoneNo = canonicalPhoneNo(input.PhoneNo)
+	case input.Email != "":
+		getUserPayload.Email = input.Email
+	default:
+		err = usecase.UserInvalidLogin
+		return
+	}
+
 	var usr repository.GetUserOutput
-	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{PhoneNo: input.PhoneNo}); err != nil {
+	if usr, err = u.userRepo.GetUser(ctx, getUserPayload); err != nil {
 		if errors.Is(err, repository.ErrorRecordNotFound) {
 			err = usecase.UserInvalidLogin
 		}
 		return
 	}
+	// an unverified email might belong to someone else
+	if getUserPayload.Email != "" && usr.EmailVerifiedAt.IsZero() {
+		err = usecase.UserInvalidLogin
+		return
+	}
 
-	if err = bcrypt.CompareHashAndPassword(usr.PasswordHash, []byte(input.Password)); err != nil {
+	if err = compareBcryptHash(usr.PasswordHash, input.Password); err != nil {
 		err = usecase.UserInvalidLogin
 		return
 	}
 
-	updatePayload := repository.UpdateUserInput{ID: usr.ID, SuccessfulLoginCount: usr.SuccessfulLoginCount + 1}
-	if _, err = u.userRepo.UpdateUser(ctx, updatePayload); err != nil {
+	// status is only checked after the password, to avoid disclosing the status of other users accounts
+	var jwtToken string
+	if jwtToken, err = u.createUserSession(ctx, usr, input.Scope); err != nil {
+		return
+	}
+
+	// a password login lifts the PIN lockout
+	if usr.PinFailedAttempts > 0 {
+		if _, err = u.userRepo.ResetUserPINFailures(ctx, repository.ResetUserPINFailuresInput{ID: usr.ID}); err != nil {
+			return
+		}
+	}
+
+	var deviceToken string
+	if input.EnrollDevice {
+		if deviceToken, err = u.enrollUserDevice(ctx, usr.ID); err != nil {
+			return
+		}
+	}
+
+	return usecase.LoginUserOutput{JwtToken: jwtToken, DeviceToken: deviceToken}, nil
+}
+
+// enrollUserDevice will allow PIN login from the device holding the returned token
+func (u *userUsecases) enrollUserDevice(ctx context.Context, userID uint64) (deviceToken string, err error) {
+	if deviceToken, err = generateDeviceToken(); err != nil {
 		return
 	}
 
+	createDevicePayload := repository.CreateUserDeviceInput{UserID: userID, TokenHash: hashDeviceToken(deviceToken)}
+	if _, err = u.userDeviceRepo.CreateUserDevice(ctx, createDevicePayload); err != nil {
+		return
+	}
+	return deviceToken, nil
+}
+
+// createUserSession will issue a JWT Token for the user whose credentials are already verified,
+// scope is the optional space separated list of requested scopes
+func (u *userUsecases) createUserSession(ctx context.Context, usr repository.GetUserOutput, scope string) (jwtToken string, err error) {
+	switch usr.Status {
+	case usecase.UserStatusActive:
+	case usecase.UserStatusSuspended:
+		err = usecase.UserSuspended
+		return
+	default:
+		err = usecase.UserInvalidLogin
+		return
+	}
+
+	// the scopes are granted first, a login rejected for its scopes must not count nor cancel the deletion
+	var roles repository.GetUserRolesOutput
+	if roles, err = u.userRepo.GetUserRoles(ctx, repository.GetUserRolesInput{UserID: usr.ID}); err != nil {
+		return
+	}
+
+	var scopes []string
+	if scopes, err = grantUserScopes(scope, roles.Roles); err != nil {
+		return
+	}
+
+	// logging in during the grace period cancels the pending deletion request
+	if !usr.DeletionRequestedAt.IsZero() {
+		if err = u.cancelUserDeletion(ctx, usr.ID); err != nil {
+			return
+		}
+	}
+
+	if _, err = u.userRepo.IncrementUserLoginCount(ctx, repository.IncrementUserLoginCountInput{ID: usr.ID}); err != nil {
+		return
+	}
+
+	// token ID (jti) is used to identify the token on revocation
+	var tokenID string
+	if tokenID, err = generateTokenID(); err != nil {
+		return
+	}
+
+	now := time.Now()
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
-		"sub": fmt.Sprintf("%d", usr.ID),
-		"exp": time.Now().Add(u.jwtTtl).Unix(),
+		"sub":   fmt.Sprintf("%d", usr.ID),
+		"jti":   tokenID,
+		"roles": roles.Roles,
+		"scope": strings.Join(scopes, " "),
+		"iat":   now.Unix(),
+		"exp":   now.Add(u.jwtTtl).Unix(),
 	})
-	if output.JwtToken, err = token.SignedString(u.jwtSecret); err != nil {
-		return
+	return token.SignedString(u.jwtSecret)
+}
+
+// grantUserScopes will return the requested scopes (space separated), or every scope the user is allowed
+// when none is requested. Requesting a scope outside the allowed ones is an error.
+func grantUserScopes(requested string, roles []string) ([]string, error) {
+	allowed := usecase.ScopesForRoles(roles)
+	if strings.TrimSpace(requested) == "" {
+		return allowed, nil
 	}
 
-	return output, nil
+	principal := usecase.Principal{Scopes: allowed}
+	var scopes []string
+	for _, scope := range strings.Fields(requested) {
+		if !principal.HasScopes(scope) {
+			return nil, usecase.UserInvalidScope
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
 }
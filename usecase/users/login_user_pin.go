@@ -0,0 +1,77 @@
+package users
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+)
+
+const (
+	// maxPINFailedAttempts is the number of consecutive wrong PINs, before PIN login is locked until a password login
+	maxPINFailedAttempts = 3
+)
+
+func (u *userUsecases) LoginUserPIN(ctx context.Context, input usecase.LoginUserPINInput) (output usecase.LoginUserPINOutput, err error) {
+	ctx, span := startSpan(ctx, "LoginUserPIN")
+	defer func() { endSpan(span, err) }()
+	defer func() { recordLoginAttempt(loginMethodPIN, err) }()
+
+	var usr repository.GetUserOutput
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{PhoneNo: canonicalPhoneNo(input.PhoneNo)}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserInvalidPINLogin
+		}
+		return
+	}
+	if len(usr.PinHash) == 0 || input.DeviceToken == "" {
+		err = usecase.UserInvalidPINLogin
+		return
+	}
+
+	getDevicePayload := repository.GetUserDeviceInput{UserID: usr.ID, TokenHash: hashDeviceToken(input.DeviceToken)}
+	if _, err = u.userDeviceRepo.GetUserDevice(ctx, getDevicePayload); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserInvalidPINLogin
+		}
+		return
+	}
+
+	// the attempt is recorded before comparing, so that concurrent guesses can't exceed the limit
+	var attempt repository.AddUserPINAttemptOutput
+	attempt, err = u.userRepo.AddUserPINAttempt(ctx, repository.AddUserPINAttemptInput{ID: usr.ID, MaxAttempts: maxPINFailedAttempts})
+	if err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserPINLocked
+		}
+		return
+	}
+
+	if err = compareBcryptHash(usr.PinHash, input.PIN); err != nil {
+		if attempt.PinFailedAttempts >= maxPINFailedAttempts {
+			err = usecase.UserPINLocked
+			return
+		}
+		err = usecase.UserInvalidPINLogin
+		return
+	}
+
+	if _, err = u.userRepo.ResetUserPINFailures(ctx, repository.ResetUserPINFailuresInput{ID: usr.ID}); err != nil {
+		return
+	}
+
+	// status is only checked after the PIN, to avoid disclosing the status of other users accounts
+	if output.JwtToken, err = u.createUserSession(ctx, usr, input.Scope); err != nil {
+		return
+	}
+
+	return output, nil
+}
+
+// hashDeviceToken will return the hash of the device token stored on enrollment, the token itself is never stored
+func hashDeviceToken(deviceToken string) string {
+	hash := sha256.Sum256([]byte(deviceToken))
+	return hex.EncodeToString(hash[:])
+}
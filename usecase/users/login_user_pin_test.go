@@ -0,0 +1,221 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+	"time"
+)
+
+type LoginUserPINTestSuite struct {
+	suite.Suite
+
+	gomock     *gomock.Controller
+	repo       *repository.MockUserRepository
+	deviceRepo *repository.MockUserDeviceRepository
+
+	usecase usecase.UserUsecases
+
+	getUserInput        repository.GetUserInput
+	getUserOutput       repository.GetUserOutput
+	getUserDeviceInput  repository.GetUserDeviceInput
+	getUserDeviceOutput repository.GetUserDeviceOutput
+
+	input usecase.LoginUserPINInput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestLoginUserPINTestSuite(t *testing.T) {
+	suite.Run(t, new(LoginUserPINTestSuite))
+}
+
+func (s *LoginUserPINTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.deviceRepo = repository.NewMockUserDeviceRepository(s.gomock)
+
+	jwtSecret, _ := rsa.GenerateKey(rand.Reader, 1024)
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		UserRepo:       s.repo,
+		UserDeviceRepo: s.deviceRepo,
+		JwtSecret:      jwtSecret,
+		Auth:           config.Auth{JwtTtl: time.Minute * 5},
+	})
+
+	pinHash, _ := bcrypt.GenerateFromPassword([]byte("274916"), bcrypt.MinCost)
+	s.getUserInput = repository.GetUserInput{PhoneNo: "+62812151833"}
+	s.getUserOutput = repository.GetUserOutput{
+		ID:                   123,
+		PhoneNo:              "+62812151833",
+		PinHash:              pinHash,
+		SuccessfulLoginCount: 2,
+		Status:               "active",
+	}
+	s.getUserDeviceInput = repository.GetUserDeviceInput{UserID: 123, TokenHash: hashDeviceToken("device-token")}
+	s.getUserDeviceOutput = repository.GetUserDeviceOutput{ID: 7, UserID: 123, CreatedAt: time.Now().Add(-time.Hour)}
+
+	s.input = usecase.LoginUserPINInput{PhoneNo: "+62812151833", PIN: "274916", DeviceToken: "device-token"}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *LoginUserPINTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *LoginUserPINTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.LoginUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidPINLogin)
+}
+
+func (s *LoginUserPINTestSuite) TestPINNotSet() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.PinHash = nil
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.LoginUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidPINLogin)
+}
+
+func (s *LoginUserPINTestSuite) TestDeviceNotEnrolled() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.deviceRepo.EXPECT().GetUserDevice(s.ctx, s.getUserDeviceInput).Return(repository.GetUserDeviceOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.LoginUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidPINLogin)
+}
+
+func (s *LoginUserPINTestSuite) TestDeviceRepositoryError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.deviceRepo.EXPECT().GetUserDevice(s.ctx, s.getUserDeviceInput).Return(repository.GetUserDeviceOutput{}, s.mockErr)
+
+	out, err := s.usecase.LoginUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *LoginUserPINTestSuite) TestLocked() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.PinFailedAttempts = 3
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.deviceRepo.EXPECT().GetUserDevice(s.ctx, s.getUserDeviceInput).Return(s.getUserDeviceOutput, nil)
+	s.repo.EXPECT().AddUserPINAttempt(s.ctx, repository.AddUserPINAttemptInput{ID: 123, MaxAttempts: 3}).
+		Return(repository.AddUserPINAttemptOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.LoginUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserPINLocked)
+}
+
+func (s *LoginUserPINTestSuite) TestWrongPIN() {
+	a := assert.New(s.T())
+
+	s.input.PIN = "000000"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.deviceRepo.EXPECT().GetUserDevice(s.ctx, s.getUserDeviceInput).Return(s.getUserDeviceOutput, nil)
+	s.repo.EXPECT().AddUserPINAttempt(s.ctx, repository.AddUserPINAttemptInput{ID: 123, MaxAttempts: 3}).
+		Return(repository.AddUserPINAttemptOutput{PinFailedAttempts: 1}, nil)
+
+	out, err := s.usecase.LoginUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidPINLogin)
+}
+
+func (s *LoginUserPINTestSuite) TestWrongPINLocks() {
+	a := assert.New(s.T())
+
+	s.input.PIN = "000000"
+	s.getUserOutput.PinFailedAttempts = 2
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.deviceRepo.EXPECT().GetUserDevice(s.ctx, s.getUserDeviceInput).Return(s.getUserDeviceOutput, nil)
+	s.repo.EXPECT().AddUserPINAttempt(s.ctx, repository.AddUserPINAttemptInput{ID: 123, MaxAttempts: 3}).
+		Return(repository.AddUserPINAttemptOutput{PinFailedAttempts: 3}, nil)
+
+	out, err := s.usecase.LoginUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserPINLocked)
+}
+
+func (s *LoginUserPINTestSuite) TestWrongPINRepositoryError() {
+	a := assert.New(s.T())
+
+	s.input.PIN = "000000"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.deviceRepo.EXPECT().GetUserDevice(s.ctx, s.getUserDeviceInput).Return(s.getUserDeviceOutput, nil)
+	s.repo.EXPECT().AddUserPINAttempt(s.ctx, repository.AddUserPINAttemptInput{ID: 123, MaxAttempts: 3}).
+		Return(repository.AddUserPINAttemptOutput{}, s.mockErr)
+
+	out, err := s.usecase.LoginUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *LoginUserPINTestSuite) TestUserSuspended() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "suspended"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.deviceRepo.EXPECT().GetUserDevice(s.ctx, s.getUserDeviceInput).Return(s.getUserDeviceOutput, nil)
+	s.repo.EXPECT().AddUserPINAttempt(s.ctx, repository.AddUserPINAttemptInput{ID: 123, MaxAttempts: 3}).
+		Return(repository.AddUserPINAttemptOutput{PinFailedAttempts: 1}, nil)
+	s.repo.EXPECT().ResetUserPINFailures(s.ctx, repository.ResetUserPINFailuresInput{ID: 123}).
+		Return(repository.ResetUserPINFailuresOutput{}, nil)
+
+	out, err := s.usecase.LoginUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserSuspended)
+}
+
+func (s *LoginUserPINTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.PinFailedAttempts = 1
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.deviceRepo.EXPECT().GetUserDevice(s.ctx, s.getUserDeviceInput).Return(s.getUserDeviceOutput, nil)
+	s.repo.EXPECT().AddUserPINAttempt(s.ctx, repository.AddUserPINAttemptInput{ID: 123, MaxAttempts: 3}).
+		Return(repository.AddUserPINAttemptOutput{PinFailedAttempts: 2}, nil)
+	s.repo.EXPECT().ResetUserPINFailures(s.ctx, repository.ResetUserPINFailuresInput{ID: 123}).
+		Return(repository.ResetUserPINFailuresOutput{}, nil)
+	s.repo.EXPECT().IncrementUserLoginCount(s.ctx, repository.IncrementUserLoginCountInput{ID: 123}).Return(repository.IncrementUserLoginCountOutput{}, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, repository.GetUserRolesInput{UserID: 123}).
+		Return(repository.GetUserRolesOutput{Roles: []string{"farmer"}}, nil)
+
+	out, err := s.usecase.LoginUserPIN(s.ctx, s.input)
+
+	a.Empty(err)
+	a.NotEmpty(out.JwtToken)
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
 	"github.com/SawitProRecruitment/UserService/repository"
 	"github.com/SawitProRecruitment/UserService/usecase"
 	"github.com/golang-jwt/jwt/v5"
@@ -19,8 +20,10 @@ import (
 type LoginUserTestSuite struct {
 	suite.Suite
 
-	gomock *gomock.Controller
-	repo   *repository.MockUserRepository
+	gomock     *gomock.Controller
+	repo       *repository.MockUserRepository
+	auditRepo  *repository.MockAuditRepository
+	deviceRepo *repository.MockUserDeviceRepository
 
 	jwtSecret *rsa.PrivateKey
 	usecase   usecase.UserUsecases
@@ -28,8 +31,10 @@ type LoginUserTestSuite struct {
 	getUserInput  repository.GetUserInput
 	getUserOutput repository.GetUserOutput
 
-	updateUserInput  repository.UpdateUserInput
-	updateUserOutput repository.UpdateUserOutput
+	incrementLoginCountInput repository.IncrementUserLoginCountInput
+
+	getUserRolesInput  repository.GetUserRolesInput
+	getUserRolesOutput repository.GetUserRolesOutput
 
 	input usecase.LoginUserInput
 
@@ -44,12 +49,16 @@ func TestLoginUserTestSuite(t *testing.T) {
 func (s *LoginUserTestSuite) SetupTest() {
 	s.gomock = gomock.NewController(s.T())
 	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.auditRepo = repository.NewMockAuditRepository(s.gomock)
+	s.deviceRepo = repository.NewMockUserDeviceRepository(s.gomock)
 
 	s.jwtSecret, _ = rsa.GenerateKey(rand.Reader, 1024)
 	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
-		UserRepo:  s.repo,
-		JwtSecret: s.jwtSecret,
-		JwtTtl:    time.Minute * 5,
+		UserRepo:       s.repo,
+		AuditRepo:      s.auditRepo,
+		UserDeviceRepo: s.deviceRepo,
+		JwtSecret:      s.jwtSecret,
+		Auth:           config.Auth{JwtTtl: time.Minute * 5},
 	})
 
 	passwdHash, _ := bcrypt.GenerateFromPassword([]byte("SomeVal1dPassw@rd"), bcrypt.DefaultCost)
@@ -60,10 +69,13 @@ func (s *LoginUserTestSuite) SetupTest() {
 		FullName:             "John Smith",
 		PasswordHash:         passwdHash,
 		SuccessfulLoginCount: 2,
+		Status:               "active",
 	}
 
-	s.updateUserInput = repository.UpdateUserInput{ID: 123, SuccessfulLoginCount: 3}
-	s.updateUserOutput = repository.UpdateUserOutput{}
+	s.incrementLoginCountInput = repository.IncrementUserLoginCountInput{ID: 123}
+
+	s.getUserRolesInput = repository.GetUserRolesInput{UserID: 123}
+	s.getUserRolesOutput = repository.GetUserRolesOutput{Roles: []string{"estate_manager", "farmer"}}
 
 	s.input = usecase.LoginUserInput{
 		PhoneNo:  "+62812151833",
@@ -112,11 +124,48 @@ func (s *LoginUserTestSuite) TestInvalidPassword() {
 	a.ErrorIs(err, usecase.UserInvalidLogin)
 }
 
-func (s *LoginUserTestSuite) TestFailedUpdate() {
+func (s *LoginUserTestSuite) TestUserSuspended() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "suspended"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserSuspended)
+}
+
+func (s *LoginUserTestSuite) TestUserDeactivated() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "deactivated"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidLogin)
+}
+
+func (s *LoginUserTestSuite) TestFailedIncrementLoginCount() {
 	a := assert.New(s.T())
 
 	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
-	s.repo.EXPECT().UpdateUser(s.ctx, s.updateUserInput).Return(repository.UpdateUserOutput{}, s.mockErr)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+	s.repo.EXPECT().IncrementUserLoginCount(s.ctx, s.incrementLoginCountInput).Return(repository.IncrementUserLoginCountOutput{}, s.mockErr)
+
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *LoginUserTestSuite) TestFailedGetRoles() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(repository.GetUserRolesOutput{}, s.mockErr)
 
 	out, err := s.usecase.LoginUser(s.ctx, s.input)
 
@@ -128,7 +177,8 @@ func (s *LoginUserTestSuite) TestSuccess() {
 	a := assert.New(s.T())
 
 	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
-	s.repo.EXPECT().UpdateUser(s.ctx, s.updateUserInput).Return(s.updateUserOutput, nil)
+	s.repo.EXPECT().IncrementUserLoginCount(s.ctx, s.incrementLoginCountInput).Return(repository.IncrementUserLoginCountOutput{}, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
 
 	out, err := s.usecase.LoginUser(s.ctx, s.input)
 
@@ -144,4 +194,183 @@ func (s *LoginUserTestSuite) TestSuccess() {
 	exp, err := parsedToken.Claims.GetExpirationTime()
 	a.Empty(err)
 	a.True(time.Now().Before(exp.Time))
+	a.Equal([]interface{}{"estate_manager", "farmer"}, parsedToken.Claims.(jwt.MapClaims)["roles"])
+	a.Equal("profile:read profile:write", parsedToken.Claims.(jwt.MapClaims)["scope"])
+}
+
+func (s *LoginUserTestSuite) TestSuccessNormalizePhoneNo() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().IncrementUserLoginCount(s.ctx, s.incrementLoginCountInput).Return(repository.IncrementUserLoginCountOutput{}, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+
+	s.input.PhoneNo = "+62 0812-151-833"
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	a.Empty(err)
+	a.NotEmpty(out.JwtToken)
+}
+
+func (s *LoginUserTestSuite) TestCancelPendingDeletion() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.DeletionRequestedAt = time.Now().Add(-time.Hour)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().SetUserDeletionRequest(s.ctx, repository.SetUserDeletionRequestInput{ID: 123}).Return(repository.SetUserDeletionRequestOutput{}, nil)
+	s.auditRepo.EXPECT().CreateAuditEvent(s.ctx, repository.CreateAuditEventInput{
+		ActorUserID:  123,
+		Action:       "user.deletion.cancelled",
+		TargetUserID: 123,
+	}).Return(repository.CreateAuditEventOutput{ID: 9}, nil)
+	s.repo.EXPECT().IncrementUserLoginCount(s.ctx, s.incrementLoginCountInput).Return(repository.IncrementUserLoginCountOutput{}, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	a.Empty(err)
+	a.NotEmpty(out.JwtToken)
+}
+
+func (s *LoginUserTestSuite) TestCancelPendingDeletionError() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.DeletionRequestedAt = time.Now().Add(-time.Hour)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+	s.repo.EXPECT().SetUserDeletionRequest(s.ctx, repository.SetUserDeletionRequestInput{ID: 123}).Return(repository.SetUserDeletionRequestOutput{}, s.mockErr)
+
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *LoginUserTestSuite) TestInvalidScope() {
+	a := assert.New(s.T())
+
+	s.input.Scope = "profile:read admin:users"
+	s.getUserOutput.DeletionRequestedAt = time.Now().Add(-time.Hour)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	// the rejected login neither counts nor cancels the pending deletion
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidScope)
+}
+
+func (s *LoginUserTestSuite) TestSuccessRequestedScope() {
+	a := assert.New(s.T())
+
+	s.input.Scope = "profile:read admin:users"
+	s.getUserRolesOutput.Roles = []string{"admin"}
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().IncrementUserLoginCount(s.ctx, s.incrementLoginCountInput).Return(repository.IncrementUserLoginCountOutput{}, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	a.Empty(err)
+	parsedToken, err := jwt.Parse(out.JwtToken, func(token *jwt.Token) (interface{}, error) {
+		return &s.jwtSecret.PublicKey, nil
+	})
+	a.Empty(err)
+	a.Equal("profile:read admin:users", parsedToken.Claims.(jwt.MapClaims)["scope"])
+}
+
+func (s *LoginUserTestSuite) TestResetPINLockout() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.PinFailedAttempts = 3
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().IncrementUserLoginCount(s.ctx, s.incrementLoginCountInput).Return(repository.IncrementUserLoginCountOutput{}, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+	s.repo.EXPECT().ResetUserPINFailures(s.ctx, repository.ResetUserPINFailuresInput{ID: 123}).Return(repository.ResetUserPINFailuresOutput{}, nil)
+
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	a.Empty(err)
+	a.NotEmpty(out.JwtToken)
+	a.Empty(out.DeviceToken)
+}
+
+func (s *LoginUserTestSuite) TestEnrollDeviceError() {
+	a := assert.New(s.T())
+
+	s.input.EnrollDevice = true
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().IncrementUserLoginCount(s.ctx, s.incrementLoginCountInput).Return(repository.IncrementUserLoginCountOutput{}, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+	s.deviceRepo.EXPECT().CreateUserDevice(s.ctx, gomock.Any()).Return(repository.CreateUserDeviceOutput{}, s.mockErr)
+
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *LoginUserTestSuite) TestEnrollDevice() {
+	a := assert.New(s.T())
+
+	originalGenerateDeviceToken := generateDeviceToken
+	defer func() { generateDeviceToken = originalGenerateDeviceToken }()
+	generateDeviceToken = func() (string, error) { return "device-token", nil }
+
+	s.input.EnrollDevice = true
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().IncrementUserLoginCount(s.ctx, s.incrementLoginCountInput).Return(repository.IncrementUserLoginCountOutput{}, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+	s.deviceRepo.EXPECT().CreateUserDevice(s.ctx, repository.CreateUserDeviceInput{
+		UserID:    123,
+		TokenHash: hashDeviceToken("device-token"),
+	}).Return(repository.CreateUserDeviceOutput{ID: 7}, nil)
+
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	a.Empty(err)
+	a.NotEmpty(out.JwtToken)
+	a.Equal("device-token", out.DeviceToken)
+}
+
+func (s *LoginUserTestSuite) TestMissingIdentifier() {
+	a := assert.New(s.T())
+
+	s.input.PhoneNo = ""
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidLogin)
+}
+
+func (s *LoginUserTestSuite) TestEmailNotVerified() {
+	a := assert.New(s.T())
+
+	s.input.PhoneNo = ""
+	s.input.Email = "John.Smith@example.com"
+	s.getUserOutput.Email = "john.smith@example.com"
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{Email: "John.Smith@example.com"}).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidLogin)
+}
+
+func (s *LoginUserTestSuite) TestSuccessEmail() {
+	a := assert.New(s.T())
+
+	s.input.PhoneNo = ""
+	s.input.Email = "John.Smith@example.com"
+	s.getUserOutput.Email = "john.smith@example.com"
+	s.getUserOutput.EmailVerifiedAt = time.Now().Add(-time.Hour)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{Email: "John.Smith@example.com"}).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().IncrementUserLoginCount(s.ctx, s.incrementLoginCountInput).Return(repository.IncrementUserLoginCountOutput{}, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+
+	out, err := s.usecase.LoginUser(s.ctx, s.input)
+
+	a.Empty(err)
+	a.NotEmpty(out.JwtToken)
 }
@@ -0,0 +1,53 @@
+package users
+
+import (
+	"errors"
+	"github.com/SawitProRecruitment/UserService/metrics"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"golang.org/x/crypto/bcrypt"
+	"time"
+)
+
+const (
+	loginMethodPassword = "password"
+	loginMethodPIN      = "pin"
+	loginMethodOTP      = "otp"
+
+	loginOutcomeSuccess = "success"
+
+	bcryptOperationHash    = "hash"
+	bcryptOperationCompare = "compare"
+)
+
+// errorReason will return the code of the error to be used as a metric label, the errors unknown by the clients
+// (e.g. database errors) are all reported as internal
+func errorReason(err error) string {
+	var validationErrors usecase.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return usecase.ValidationErrorsCode
+	}
+	var knownErr *usecase.Error
+	if errors.As(err, &knownErr) {
+		return knownErr.Code
+	}
+	return usecase.InternalErrorCode
+}
+
+// recordLoginAttempt will count the login attempt by its outcome, err is the error returned by the login usecase
+func recordLoginAttempt(method string, err error) {
+	outcome := loginOutcomeSuccess
+	if err != nil {
+		outcome = errorReason(err)
+	}
+	metrics.LoginAttempts.WithLabelValues(method, outcome).Inc()
+}
+
+// compareBcryptHash is bcrypt.CompareHashAndPassword, observing its duration
+func compareBcryptHash(hash []byte, secret string) error {
+	defer observeBcrypt(bcryptOperationCompare, time.Now())
+	return bcrypt.CompareHashAndPassword(hash, []byte(secret))
+}
+
+func observeBcrypt(operation string, start time.Time) {
+	metrics.BcryptDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,53 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/metrics"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestErrorReason(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal("auth.invalid_credentials", errorReason(fmt.Errorf("login: %w", usecase.UserInvalidLogin)))
+	a.Equal("validation.failed", errorReason(usecase.NewValidationError(map[string][]error{"pin": {usecase.UserInvalidLogin}})))
+	a.Equal("internal", errorReason(fmt.Errorf("pq: connection refused")))
+}
+
+func TestLoginMetrics(t *testing.T) {
+	a := assert.New(t)
+	repo := repository.NewMockUserRepository(gomock.NewController(t))
+	uc := NewUserUsecases(NewUserUsecasesOptions{UserRepo: repo})
+	invalidLogin := metrics.LoginAttempts.WithLabelValues(loginMethodPassword, "auth.invalid_credentials")
+	internal := metrics.LoginAttempts.WithLabelValues(loginMethodPassword, "internal")
+	invalidBefore, internalBefore := testutil.ToFloat64(invalidLogin), testutil.ToFloat64(internal)
+
+	repo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+	_, _ = uc.LoginUser(context.Background(), usecase.LoginUserInput{PhoneNo: "+6281234567890", Password: "Secret1!"})
+	repo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(repository.GetUserOutput{}, fmt.Errorf("pq: connection refused"))
+	_, _ = uc.LoginUser(context.Background(), usecase.LoginUserInput{PhoneNo: "+6281234567890", Password: "Secret1!"})
+
+	a.Equal(invalidBefore+1, testutil.ToFloat64(invalidLogin))
+	a.Equal(internalBefore+1, testutil.ToFloat64(internal))
+}
+
+func TestTokenValidationMetrics(t *testing.T) {
+	a := assert.New(t)
+	jwtSecret, _ := rsa.GenerateKey(rand.Reader, 1024)
+	uc := NewUserUsecases(NewUserUsecasesOptions{JwtSecret: jwtSecret})
+	invalidToken := metrics.TokenValidationFailures.WithLabelValues("auth.invalid_token")
+	before := testutil.ToFloat64(invalidToken)
+
+	_, err := uc.ValidateUserToken(context.Background(), usecase.ValidateUserTokenInput{JwtToken: "not-a-jwt"})
+
+	a.ErrorIs(err, usecase.UserInvalidToken)
+	a.Equal(before+1, testutil.ToFloat64(invalidToken))
+}
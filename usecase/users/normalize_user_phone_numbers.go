@@ -0,0 +1,96 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/phone"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+)
+
+const (
+	normalizeUserPhoneNumbersBatchSize = 500
+)
+
+func (u *userUsecases) NormalizeUserPhoneNumbers(ctx context.Context, input usecase.NormalizeUserPhoneNumbersInput) (output usecase.NormalizeUserPhoneNumbersOutput, err error) {
+	ctx, span := startSpan(ctx, "NormalizeUserPhoneNumbers")
+	defer func() { endSpan(span, err) }()
+
+	// owners of the normalized phone numbers planned in this run, so that a dry run reports the same collisions
+	claimed := map[string]uint64{}
+
+	listPayload := repository.ListUserPhoneNumbersInput{Limit: normalizeUserPhoneNumbersBatchSize}
+	for {
+		var resp repository.ListUserPhoneNumbersOutput
+		if resp, err = u.userRepo.ListUserPhoneNumbers(ctx, listPayload); err != nil {
+			return usecase.NormalizeUserPhoneNumbersOutput{}, err
+		}
+
+		for _, usr := range resp.Users {
+			num, parseErr := phone.Parse(usr.PhoneNo)
+			if parseErr != nil {
+				output.Invalid = append(output.Invalid, usecase.UserPhoneNoInvalid{
+					UserID:  usr.ID,
+					PhoneNo: usr.PhoneNo,
+					Reason:  parseErr.Error(),
+				})
+				continue
+			}
+			if num.E164 == usr.PhoneNo {
+				continue
+			}
+
+			var ownerID uint64
+			if ownerID, err = u.getPhoneNoOwner(ctx, claimed, num.E164); err != nil {
+				return usecase.NormalizeUserPhoneNumbersOutput{}, err
+			}
+			if ownerID != 0 {
+				output.Collisions = append(output.Collisions, usecase.UserPhoneNoCollision{
+					UserID:            usr.ID,
+					PhoneNo:           usr.PhoneNo,
+					NormalizedPhoneNo: num.E164,
+					ConflictingUserID: ownerID,
+				})
+				continue
+			}
+
+			if !input.DryRun {
+				_, err = u.userRepo.UpdateUser(ctx, repository.UpdateUserInput{ID: usr.ID, PhoneNo: &num.E164})
+				if errors.Is(err, repository.ErrorRecordConflict) {
+					// the number has been taken in the meantime
+					output.Collisions = append(output.Collisions, usecase.UserPhoneNoCollision{
+						UserID:            usr.ID,
+						PhoneNo:           usr.PhoneNo,
+						NormalizedPhoneNo: num.E164,
+					})
+					continue
+				} else if err != nil {
+					return usecase.NormalizeUserPhoneNumbersOutput{}, err
+				}
+			}
+			claimed[num.E164] = usr.ID
+			output.Normalized = append(output.Normalized, usecase.UserPhoneNoChange{
+				UserID:            usr.ID,
+				PhoneNo:           usr.PhoneNo,
+				NormalizedPhoneNo: num.E164,
+			})
+		}
+
+		if len(resp.Users) < listPayload.Limit {
+			return output, nil
+		}
+		listPayload.AfterID = resp.Users[len(resp.Users)-1].ID
+	}
+}
+
+// getPhoneNoOwner will return the ID of the user owning the phone number, or zero when nobody owns it
+func (u *userUsecases) getPhoneNoOwner(ctx context.Context, claimed map[string]uint64, phoneNo string) (uint64, error) {
+	if id, ok := claimed[phoneNo]; ok {
+		return id, nil
+	}
+	usr, err := u.userRepo.GetUser(ctx, repository.GetUserInput{PhoneNo: phoneNo})
+	if errors.Is(err, repository.ErrorRecordNotFound) {
+		return 0, nil
+	}
+	return usr.ID, err
+}
@@ -0,0 +1,169 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type NormalizeUserPhoneNumbersTestSuite struct {
+	suite.Suite
+
+	gomock *gomock.Controller
+	repo   *repository.MockUserRepository
+
+	usecase usecase.UserUsecases
+
+	listOutput repository.ListUserPhoneNumbersOutput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestNormalizeUserPhoneNumbersTestSuite(t *testing.T) {
+	suite.Run(t, new(NormalizeUserPhoneNumbersTestSuite))
+}
+
+func (s *NormalizeUserPhoneNumbersTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo})
+
+	s.listOutput = repository.ListUserPhoneNumbersOutput{Users: []repository.UserPhoneNo{
+		{ID: 1, PhoneNo: "+62812151833"},
+		{ID: 2, PhoneNo: "+62 812-151-834"},
+		{ID: 3, PhoneNo: "+620812151833"},
+		{ID: 4, PhoneNo: "+62 0812 151 834"},
+		{ID: 5, PhoneNo: "0812151835"},
+	}}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *NormalizeUserPhoneNumbersTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *NormalizeUserPhoneNumbersTestSuite) TestListError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().ListUserPhoneNumbers(s.ctx, gomock.Any()).Return(repository.ListUserPhoneNumbersOutput{}, s.mockErr)
+
+	out, err := s.usecase.NormalizeUserPhoneNumbers(s.ctx, usecase.NormalizeUserPhoneNumbersInput{})
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *NormalizeUserPhoneNumbersTestSuite) TestGetUserError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().ListUserPhoneNumbers(s.ctx, gomock.Any()).Return(s.listOutput, nil)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{PhoneNo: "+62812151834"}).Return(repository.GetUserOutput{}, s.mockErr)
+
+	out, err := s.usecase.NormalizeUserPhoneNumbers(s.ctx, usecase.NormalizeUserPhoneNumbersInput{})
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *NormalizeUserPhoneNumbersTestSuite) TestUpdateError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().ListUserPhoneNumbers(s.ctx, gomock.Any()).Return(s.listOutput, nil)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{PhoneNo: "+62812151834"}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+	phoneNo := "+62812151834"
+	s.repo.EXPECT().UpdateUser(s.ctx, repository.UpdateUserInput{ID: 2, PhoneNo: &phoneNo}).Return(repository.UpdateUserOutput{}, s.mockErr)
+
+	out, err := s.usecase.NormalizeUserPhoneNumbers(s.ctx, usecase.NormalizeUserPhoneNumbersInput{})
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *NormalizeUserPhoneNumbersTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().ListUserPhoneNumbers(s.ctx, repository.ListUserPhoneNumbersInput{Limit: 500}).Return(s.listOutput, nil)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{PhoneNo: "+62812151834"}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+	phoneNo := "+62812151834"
+	s.repo.EXPECT().UpdateUser(s.ctx, repository.UpdateUserInput{ID: 2, PhoneNo: &phoneNo}).Return(repository.UpdateUserOutput{}, nil)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{PhoneNo: "+62812151833"}).Return(repository.GetUserOutput{ID: 1}, nil)
+
+	out, err := s.usecase.NormalizeUserPhoneNumbers(s.ctx, usecase.NormalizeUserPhoneNumbersInput{})
+
+	a.Empty(err)
+	a.Equal(usecase.NormalizeUserPhoneNumbersOutput{
+		Normalized: []usecase.UserPhoneNoChange{
+			{UserID: 2, PhoneNo: "+62 812-151-834", NormalizedPhoneNo: "+62812151834"},
+		},
+		Collisions: []usecase.UserPhoneNoCollision{
+			{UserID: 3, PhoneNo: "+620812151833", NormalizedPhoneNo: "+62812151833", ConflictingUserID: 1},
+			{UserID: 4, PhoneNo: "+62 0812 151 834", NormalizedPhoneNo: "+62812151834", ConflictingUserID: 2},
+		},
+		Invalid: []usecase.UserPhoneNoInvalid{
+			{UserID: 5, PhoneNo: "0812151835", Reason: "must start with the country calling code, e.g. +62"},
+		},
+	}, out)
+}
+
+func (s *NormalizeUserPhoneNumbersTestSuite) TestSuccessDryRun() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().ListUserPhoneNumbers(s.ctx, gomock.Any()).Return(s.listOutput, nil)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{PhoneNo: "+62812151834"}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{PhoneNo: "+62812151833"}).Return(repository.GetUserOutput{ID: 1}, nil)
+
+	out, err := s.usecase.NormalizeUserPhoneNumbers(s.ctx, usecase.NormalizeUserPhoneNumbersInput{DryRun: true})
+
+	a.Empty(err)
+	a.Len(out.Normalized, 1)
+	a.Len(out.Collisions, 2)
+	a.Equal(uint64(2), out.Collisions[1].ConflictingUserID)
+	a.Len(out.Invalid, 1)
+}
+
+func (s *NormalizeUserPhoneNumbersTestSuite) TestUpdateConflict() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().ListUserPhoneNumbers(s.ctx, gomock.Any()).Return(repository.ListUserPhoneNumbersOutput{Users: []repository.UserPhoneNo{
+		{ID: 2, PhoneNo: "+62 812-151-834"},
+	}}, nil)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{PhoneNo: "+62812151834"}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+	s.repo.EXPECT().UpdateUser(s.ctx, gomock.Any()).Return(repository.UpdateUserOutput{}, repository.ErrorRecordConflict)
+
+	out, err := s.usecase.NormalizeUserPhoneNumbers(s.ctx, usecase.NormalizeUserPhoneNumbersInput{})
+
+	a.Empty(err)
+	a.Equal([]usecase.UserPhoneNoCollision{
+		{UserID: 2, PhoneNo: "+62 812-151-834", NormalizedPhoneNo: "+62812151834"},
+	}, out.Collisions)
+	a.Empty(out.Normalized)
+}
+
+func (s *NormalizeUserPhoneNumbersTestSuite) TestPagination() {
+	a := assert.New(s.T())
+
+	page := make([]repository.UserPhoneNo, 500)
+	for i := range page {
+		page[i] = repository.UserPhoneNo{ID: uint64(i + 1), PhoneNo: fmt.Sprintf("+62812%06d", i)}
+	}
+	gomock.InOrder(
+		s.repo.EXPECT().ListUserPhoneNumbers(s.ctx, repository.ListUserPhoneNumbersInput{Limit: 500}).
+			Return(repository.ListUserPhoneNumbersOutput{Users: page}, nil),
+		s.repo.EXPECT().ListUserPhoneNumbers(s.ctx, repository.ListUserPhoneNumbersInput{AfterID: 500, Limit: 500}).
+			Return(repository.ListUserPhoneNumbersOutput{}, nil),
+	)
+
+	out, err := s.usecase.NormalizeUserPhoneNumbers(s.ctx, usecase.NormalizeUserPhoneNumbersInput{})
+
+	a.Empty(err)
+	a.Empty(out)
+}
@@ -0,0 +1,159 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"github.com/SawitProRecruitment/UserService/jsonpatch"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"reflect"
+)
+
+func (u *userUsecases) PatchUserProfile(ctx context.Context, input usecase.PatchUserProfileInput) (output usecase.PatchUserProfileOutput, err error) {
+	ctx, span := startSpan(ctx, "PatchUserProfile")
+	defer func() { endSpan(span, err) }()
+
+	var usr repository.GetUserOutput
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+	if input.Version != 0 && input.Version != usr.Version {
+		err = usecase.UserVersionMismatch
+		return
+	}
+
+	current := userProfileDocument(usr)
+	var patched interface{}
+	switch input.Format {
+	case usecase.PatchFormatMergePatch:
+		var patch interface{}
+		if err = json.Unmarshal(input.Patch, &patch); err != nil {
+			err = usecase.NewValidationError(map[string][]error{"patch": {usecase.NewFieldError("patch.invalid_json", "validation.patch_invalid_json", nil)}})
+			return
+		}
+		patched = jsonpatch.MergePatch(current, patch)
+	case usecase.PatchFormatJSONPatch:
+		var ops []jsonpatch.Operation
+		if ops, err = jsonpatch.Decode(input.Patch); err != nil {
+			err = usecase.NewValidationError(map[string][]error{"patch": {usecase.NewFieldError("patch.invalid_operation", "validation.patch_operation", nil)}})
+			return
+		}
+		if patched, err = jsonpatch.Apply(current, ops); err != nil {
+			if errors.Is(err, jsonpatch.ErrInvalidPatch) {
+				err = usecase.NewValidationError(map[string][]error{"patch": {usecase.NewFieldError("patch.invalid_operation", "validation.patch_operation", nil)}})
+			} else {
+				err = usecase.UserPatchConflict
+			}
+			return
+		}
+	default:
+		err = fmt.Errorf("unknown patch format %q", input.Format)
+		return
+	}
+
+	updateInput, validationErrors := diffUserProfileDocument(current, patched)
+	if len(validationErrors) > 0 {
+		err = usecase.NewValidationError(validationErrors)
+		return
+	}
+	// the unchanged profile isn't written, so that its version (and ETag) stays the same
+	if updateInput.FullName == nil && updateInput.PhoneNo == nil && updateInput.Email == nil && updateInput.Attributes == nil {
+		return usecase.PatchUserProfileOutput{Version: usr.Version}, nil
+	}
+
+	// the update is based on the profile the patch was applied to, it's rejected if the profile changed in-between
+	updateInput.UserID = input.UserID
+	updateInput.Version = usr.Version
+	var resp usecase.UpdateUserProfileOutput
+	if resp, err = u.UpdateUserProfile(ctx, updateInput); err != nil {
+		return
+	}
+	return usecase.PatchUserProfileOutput{Version: resp.Version}, nil
+}
+
+// userProfileDocument is the JSON document patches apply to, it only contains the fields of UpdateUserRequest
+func userProfileDocument(usr repository.GetUserOutput) map[string]interface{} {
+	doc := map[string]interface{}{
+		"full_name":  usr.FullName,
+		"phone_no":   usr.PhoneNo,
+		"attributes": map[string]interface{}{},
+	}
+	if usr.Email != "" {
+		doc["email"] = usr.Email
+	}
+	for name, value := range usr.Attributes {
+		doc["attributes"].(map[string]interface{})[name] = value
+	}
+	return doc
+}
+
+// diffUserProfileDocument will turn the changes between the current and the patched profile documents into an update
+// of the profile, removed attributes are set to nil so that they're removed from the current ones
+func diffUserProfileDocument(current map[string]interface{}, patched interface{}) (input usecase.UpdateUserProfileInput, validationErrors map[string][]error) {
+	validationErrors = map[string][]error{}
+	doc, ok := patched.(map[string]interface{})
+	if !ok {
+		validationErrors["patch"] = []error{usecase.NewFieldError("patch.not_object", "validation.patch_not_object", nil)}
+		return
+	}
+	for field := range doc {
+		if _, ok := current[field]; !ok && field != "email" {
+			validationErrors[field] = []error{usecase.NewFieldError("profile.unknown_field", "validation.unknown_profile_field", i18n.Params{"field": field})}
+		}
+	}
+
+	if value, ok := doc["full_name"]; !ok || value == nil {
+		validationErrors["full_name"] = []error{usecase.NewFieldError("full_name.required", "validation.not_removable", i18n.Params{"field": "full_name"})}
+	} else if fullName, ok := value.(string); !ok {
+		validationErrors["full_name"] = []error{usecase.NewFieldError("full_name.invalid_type", "validation.must_be_string", i18n.Params{"field": "full_name"})}
+	} else if fullName != current["full_name"] {
+		input.FullName = &fullName
+	}
+
+	// any change of the phone number is rejected by UpdateUserProfile
+	if value := doc["phone_no"]; value != current["phone_no"] {
+		phoneNo, _ := value.(string)
+		input.PhoneNo = &phoneNo
+	}
+
+	if value := doc["email"]; value == nil {
+		if current["email"] != nil {
+			email := ""
+			input.Email = &email
+		}
+	} else if email, ok := value.(string); !ok {
+		validationErrors["email"] = []error{usecase.NewFieldError("email.invalid_type", "validation.must_be_string", i18n.Params{"field": "email"})}
+	} else if email != current["email"] {
+		input.Email = &email
+	}
+
+	currentAttrs := current["attributes"].(map[string]interface{})
+	attrs, ok := doc["attributes"].(map[string]interface{})
+	if doc["attributes"] != nil && !ok {
+		validationErrors["/attributes"] = []error{usecase.NewFieldError("attributes.invalid_type", "validation.must_be_object", i18n.Params{"field": "/attributes"})}
+		return
+	}
+	for name, value := range attrs {
+		if oldValue, ok := currentAttrs[name]; !ok || !reflect.DeepEqual(oldValue, value) {
+			if input.Attributes == nil {
+				input.Attributes = map[string]interface{}{}
+			}
+			input.Attributes[name] = value
+		}
+	}
+	for name := range currentAttrs {
+		if _, ok := attrs[name]; !ok {
+			if input.Attributes == nil {
+				input.Attributes = map[string]interface{}{}
+			}
+			input.Attributes[name] = nil
+		}
+	}
+	return input, validationErrors
+}
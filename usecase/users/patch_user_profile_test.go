@@ -0,0 +1,258 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type PatchUserProfileTestSuite struct {
+	suite.Suite
+
+	gomock         *gomock.Controller
+	repo           *repository.MockUserRepository
+	attrSchemaRepo *repository.MockAttributeSchemaRepository
+	userChangeRepo *repository.MockUserChangeRepository
+
+	usecase usecase.UserUsecases
+
+	getUserOutput repository.GetUserOutput
+
+	input  usecase.PatchUserProfileInput
+	output usecase.PatchUserProfileOutput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestPatchUserProfileTestSuite(t *testing.T) {
+	suite.Run(t, new(PatchUserProfileTestSuite))
+}
+
+func (s *PatchUserProfileTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.attrSchemaRepo = repository.NewMockAttributeSchemaRepository(s.gomock)
+	s.userChangeRepo = repository.NewMockUserChangeRepository(s.gomock)
+
+	jwtSecret, _ := rsa.GenerateKey(rand.Reader, 1024)
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		UserRepo:       s.repo,
+		AttrSchemaRepo: s.attrSchemaRepo,
+		UserChangeRepo: s.userChangeRepo,
+		JwtSecret:      jwtSecret,
+	})
+
+	s.getUserOutput = repository.GetUserOutput{
+		ID:         123,
+		PhoneNo:    "+6281215183300",
+		FullName:   "Jane Smith",
+		Email:      "jane.smith@example.com",
+		Attributes: map[string]interface{}{"estate_code": "KAL-01", "preferred_language": "id"},
+		Version:    3,
+	}
+
+	s.input = usecase.PatchUserProfileInput{
+		UserID: 123,
+		Format: usecase.PatchFormatMergePatch,
+		Patch:  []byte(`{"full_name":"John Smith","email":null}`),
+	}
+	s.output = usecase.PatchUserProfileOutput{Version: 4}
+
+	s.ctx = usecase.ContextWithRequestID(context.Background(), "request-id")
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *PatchUserProfileTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+// expectGetUser will expect the current profile of the user to be loaded, by the patch and by the update
+func (s *PatchUserProfileTestSuite) expectGetUser(times int) {
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil).Times(times)
+}
+
+func (s *PatchUserProfileTestSuite) assertValidationError(err error, field string) {
+	var validationError usecase.ValidationErrors
+	if s.True(errors.As(err, &validationError)) {
+		s.NotEmpty(validationError.GetErrors()[field], field)
+	}
+}
+
+func (s *PatchUserProfileTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.PatchUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *PatchUserProfileTestSuite) TestVersionMismatch() {
+	a := assert.New(s.T())
+
+	s.input.Version = 2
+	s.expectGetUser(1)
+
+	out, err := s.usecase.PatchUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserVersionMismatch)
+}
+
+func (s *PatchUserProfileTestSuite) TestInvalidMergePatch() {
+	a := assert.New(s.T())
+
+	s.input.Patch = []byte(`{"full_name":`)
+	s.expectGetUser(1)
+
+	out, err := s.usecase.PatchUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	s.assertValidationError(err, "patch")
+}
+
+func (s *PatchUserProfileTestSuite) TestInvalidJSONPatch() {
+	a := assert.New(s.T())
+
+	s.input.Format = usecase.PatchFormatJSONPatch
+	s.input.Patch = []byte(`[{"op":"add","path":"/full_name"}]`)
+	s.expectGetUser(1)
+
+	out, err := s.usecase.PatchUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	s.assertValidationError(err, "patch")
+	var fieldErr *usecase.Error
+	a.ErrorAs(err.(usecase.ValidationErrors).GetErrors()["patch"][0], &fieldErr)
+	a.Equal("patch.invalid_operation", fieldErr.Code)
+}
+
+func (s *PatchUserProfileTestSuite) TestJSONPatchTestFailed() {
+	a := assert.New(s.T())
+
+	s.input.Format = usecase.PatchFormatJSONPatch
+	s.input.Patch = []byte(`[{"op":"test","path":"/full_name","value":"John Smith"},{"op":"replace","path":"/full_name","value":"Alex Smith"}]`)
+	s.expectGetUser(1)
+
+	out, err := s.usecase.PatchUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserPatchConflict)
+}
+
+func (s *PatchUserProfileTestSuite) TestInvalidFields() {
+	a := assert.New(s.T())
+
+	s.input.Patch = []byte(`{"full_name":null,"email":42,"user_id":7,"attributes":"KAL-01"}`)
+	s.expectGetUser(1)
+
+	out, err := s.usecase.PatchUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	for _, field := range []string{"full_name", "email", "user_id", "/attributes"} {
+		s.assertValidationError(err, field)
+	}
+}
+
+func (s *PatchUserProfileTestSuite) TestPhoneNoRejected() {
+	a := assert.New(s.T())
+
+	s.input.Patch = []byte(`{"phone_no":null}`)
+	s.expectGetUser(1)
+
+	out, err := s.usecase.PatchUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	s.assertValidationError(err, "phone_no")
+}
+
+func (s *PatchUserProfileTestSuite) TestUnchanged() {
+	a := assert.New(s.T())
+
+	s.input.Patch = []byte(`{"full_name":"Jane Smith","attributes":{"estate_code":"KAL-01"}}`)
+	s.expectGetUser(1)
+
+	out, err := s.usecase.PatchUserProfile(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(usecase.PatchUserProfileOutput{Version: 3}, out)
+}
+
+func (s *PatchUserProfileTestSuite) TestUpdateError() {
+	a := assert.New(s.T())
+
+	s.expectGetUser(2)
+	s.repo.EXPECT().UpdateUser(s.ctx, gomock.Any()).Return(repository.UpdateUserOutput{}, repository.ErrorRecordOutdated)
+
+	out, err := s.usecase.PatchUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserVersionMismatch)
+}
+
+func (s *PatchUserProfileTestSuite) TestSuccessMergePatch() {
+	a := assert.New(s.T())
+
+	s.input.Version = 3
+	fullName, email := "John Smith", ""
+	s.expectGetUser(2)
+	s.repo.EXPECT().UpdateUser(s.ctx, repository.UpdateUserInput{ID: 123, FullName: &fullName, Email: &email, Version: 3}).
+		Return(repository.UpdateUserOutput{Version: 4}, nil)
+	s.userChangeRepo.EXPECT().CreateUserChanges(s.ctx, repository.CreateUserChangesInput{
+		UserID:      123,
+		ActorUserID: 123,
+		RequestID:   "request-id",
+		Changes: []repository.UserFieldChange{
+			{Field: "full_name", OldValue: "Jane Smith", NewValue: "John Smith"},
+			{Field: "email", OldValue: "jane.smith@example.com"},
+		},
+	}).Return(repository.CreateUserChangesOutput{}, nil)
+
+	out, err := s.usecase.PatchUserProfile(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
+
+func (s *PatchUserProfileTestSuite) TestSuccessJSONPatch() {
+	a := assert.New(s.T())
+
+	s.input.Format = usecase.PatchFormatJSONPatch
+	s.input.Patch = []byte(`[
+		{"op":"test","path":"/full_name","value":"Jane Smith"},
+		{"op":"remove","path":"/attributes/preferred_language"},
+		{"op":"add","path":"/attributes/cooperative","value":{"membership_no":"KSU-12"}}
+	]`)
+	s.expectGetUser(2)
+	s.attrSchemaRepo.EXPECT().GetLatestAttributeSchema(s.ctx, repository.GetLatestAttributeSchemaInput{}).
+		Return(repository.GetLatestAttributeSchemaOutput{ID: 3, Schema: json.RawMessage(`{"type": "object"}`)}, nil)
+	attrs := map[string]interface{}{"estate_code": "KAL-01", "cooperative": map[string]interface{}{"membership_no": "KSU-12"}}
+	s.repo.EXPECT().UpdateUser(s.ctx, repository.UpdateUserInput{ID: 123, Attributes: attrs, Version: 3}).
+		Return(repository.UpdateUserOutput{Version: 4}, nil)
+	s.userChangeRepo.EXPECT().CreateUserChanges(s.ctx, repository.CreateUserChangesInput{
+		UserID:      123,
+		ActorUserID: 123,
+		RequestID:   "request-id",
+		Changes: []repository.UserFieldChange{
+			{Field: "attributes.cooperative", NewValue: map[string]interface{}{"membership_no": "KSU-12"}},
+			{Field: "attributes.preferred_language", OldValue: "id"},
+		},
+	}).Return(repository.CreateUserChangesOutput{}, nil)
+
+	out, err := s.usecase.PatchUserProfile(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
@@ -0,0 +1,343 @@
+package users
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"log"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultProcessUserExportsLimit = 10
+	// userExportClaimTimeout is well above the generation time, the exports still processing after it were abandoned
+	// (e.g. the worker was stopped mid-job) and are generated again
+	userExportClaimTimeout = 15 * time.Minute
+	// userExportChangesPageSize is how many profile changes are read at once while generating an export
+	userExportChangesPageSize = 100
+)
+
+var (
+	// wrapper to the logging of failed exports to make testing easier, the clients only see the failed status
+	logUserExportFailure = func(exportID, userID uint64, err error) {
+		log.Printf("user export %d of user %d failed: %v", exportID, userID, err)
+	}
+)
+
+// userExportDocument contains every personal data held about a user. This service doesn't keep sessions (the tokens
+// are stateless JWTs) or consents, nor a login history: the login codes are kept per phone number to throttle them,
+// not per user, so the login history is summarized by the successful login count of the profile. The audit events
+// are only the ones affecting the user, the actions performed by the user on other users are about them.
+type userExportDocument struct {
+	GeneratedAt    time.Time                 `json:"generated_at"`
+	Profile        userExportProfile         `json:"profile"`
+	Roles          []string                  `json:"roles"`
+	ProfileChanges []userExportProfileChange `json:"profile_changes"`
+	PhoneChanges   []userExportPhoneChange   `json:"phone_changes"`
+	Devices        []userExportDevice        `json:"devices"`
+	AuditEvents    []userExportAuditEvent    `json:"audit_events"`
+}
+
+type userExportProfile struct {
+	UserID               uint64                 `json:"user_id"`
+	PhoneNo              string                 `json:"phone_no"`
+	FullName             string                 `json:"full_name"`
+	Email                string                 `json:"email,omitempty"`
+	EmailVerified        bool                   `json:"email_verified"`
+	SuccessfulLoginCount uint64                 `json:"successful_login_count"`
+	Attributes           map[string]interface{} `json:"attributes,omitempty"`
+}
+
+type userExportProfileChange struct {
+	ID          uint64      `json:"id"`
+	Field       string      `json:"field"`
+	OldValue    interface{} `json:"old_value"`
+	NewValue    interface{} `json:"new_value"`
+	ActorUserID uint64      `json:"actor_user_id,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+type userExportPhoneChange struct {
+	ID          uint64     `json:"id"`
+	PhoneNo     string     `json:"phone_no"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+}
+
+type userExportDevice struct {
+	ID        uint64    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type userExportAuditEvent struct {
+	ID           uint64                 `json:"id"`
+	Action       string                 `json:"action"`
+	ActorUserID  uint64                 `json:"actor_user_id,omitempty"`
+	TargetUserID uint64                 `json:"target_user_id,omitempty"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
+}
+
+func (u *userUsecases) ProcessUserExports(ctx context.Context, input usecase.ProcessUserExportsInput) (output usecase.ProcessUserExportsOutput, err error) {
+	ctx, span := startSpan(ctx, "ProcessUserExports")
+	defer func() { endSpan(span, err) }()
+
+	if input.Limit <= 0 {
+		input.Limit = defaultProcessUserExportsLimit
+	}
+
+	var claimed repository.ClaimUserExportsOutput
+	if claimed, err = u.userExportRepo.ClaimUserExports(ctx, repository.ClaimUserExportsInput{Limit: input.Limit, StaleAfter: userExportClaimTimeout}); err != nil {
+		return
+	}
+
+	for _, export := range claimed.Exports {
+		// failed exports expire as well, so they are eventually cleaned up
+		result := repository.CompleteUserExportInput{
+			ID:        export.ID,
+			Status:    usecase.UserExportStatusReady,
+			ExpiresAt: time.Now().Add(u.userExportRetention),
+		}
+		if result.Content, result.ContentType, err = u.generateUserExport(ctx, export.UserID, export.Format); err != nil {
+			logUserExportFailure(export.ID, export.UserID, err)
+			result = repository.CompleteUserExportInput{ID: export.ID, Status: usecase.UserExportStatusFailed, ExpiresAt: result.ExpiresAt}
+		}
+
+		if _, err = u.userExportRepo.CompleteUserExport(ctx, result); err != nil {
+			return usecase.ProcessUserExportsOutput{}, err
+		}
+		if result.Status == usecase.UserExportStatusReady {
+			output.ReadyExportIDs = append(output.ReadyExportIDs, export.ID)
+		} else {
+			output.FailedExportIDs = append(output.FailedExportIDs, export.ID)
+		}
+	}
+
+	var expired repository.DeleteExpiredUserExportsOutput
+	if expired, err = u.userExportRepo.DeleteExpiredUserExports(ctx, repository.DeleteExpiredUserExportsInput{ExpiredBefore: time.Now()}); err != nil {
+		return usecase.ProcessUserExportsOutput{}, err
+	}
+	output.ExpiredCount = expired.Count
+
+	return output, nil
+}
+
+// generateUserExport will assemble the personal data of the user, encoded in the requested format
+func (u *userUsecases) generateUserExport(ctx context.Context, userID uint64, format string) (content []byte, contentType string, err error) {
+	doc := userExportDocument{
+		GeneratedAt:    time.Now().UTC(),
+		Roles:          []string{},
+		ProfileChanges: []userExportProfileChange{},
+		PhoneChanges:   []userExportPhoneChange{},
+		Devices:        []userExportDevice{},
+		AuditEvents:    []userExportAuditEvent{},
+	}
+
+	var profile usecase.GetUserProfileOutput
+	if profile, err = u.GetUserProfile(ctx, usecase.GetUserProfileInput{UserID: userID}); err != nil {
+		return
+	}
+	doc.Profile = userExportProfile{
+		UserID:               profile.UserID,
+		PhoneNo:              profile.PhoneNo,
+		FullName:             profile.FullName,
+		Email:                profile.Email,
+		EmailVerified:        profile.EmailVerified,
+		SuccessfulLoginCount: profile.SuccessfulLoginCount,
+		Attributes:           profile.Attributes,
+	}
+
+	var roles repository.GetUserRolesOutput
+	if roles, err = u.userRepo.GetUserRoles(ctx, repository.GetUserRolesInput{UserID: userID}); err != nil {
+		return
+	}
+	doc.Roles = append(doc.Roles, roles.Roles...)
+
+	if doc.ProfileChanges, err = u.listUserExportProfileChanges(ctx, userID); err != nil {
+		return
+	}
+
+	var phoneChanges repository.ListPhoneChangesOutput
+	if phoneChanges, err = u.phoneChangeRepo.ListPhoneChanges(ctx, repository.ListPhoneChangesInput{UserID: userID}); err != nil {
+		return
+	}
+	for _, change := range phoneChanges.Changes {
+		exported := userExportPhoneChange{ID: change.ID, PhoneNo: change.PhoneNo, CreatedAt: change.CreatedAt}
+		if !change.ConfirmedAt.IsZero() {
+			confirmedAt := change.ConfirmedAt
+			exported.ConfirmedAt = &confirmedAt
+		}
+		doc.PhoneChanges = append(doc.PhoneChanges, exported)
+	}
+
+	var devices repository.ListUserDevicesOutput
+	if devices, err = u.userDeviceRepo.ListUserDevices(ctx, repository.ListUserDevicesInput{UserID: userID}); err != nil {
+		return
+	}
+	for _, device := range devices.Devices {
+		doc.Devices = append(doc.Devices, userExportDevice{ID: device.ID, CreatedAt: device.CreatedAt})
+	}
+
+	var events repository.ListAuditEventsOutput
+	if events, err = u.auditRepo.ListAuditEvents(ctx, repository.ListAuditEventsInput{UserID: userID}); err != nil {
+		return
+	}
+	for _, event := range events.Events {
+		doc.AuditEvents = append(doc.AuditEvents, userExportAuditEvent{
+			ID:           event.ID,
+			Action:       event.Action,
+			ActorUserID:  event.ActorUserID,
+			TargetUserID: event.TargetUserID,
+			Details:      event.Details,
+			CreatedAt:    event.CreatedAt,
+		})
+	}
+
+	switch format {
+	case usecase.UserExportFormatJSON:
+		content, err = json.MarshalIndent(doc, "", "  ")
+		return content, "application/json", err
+	case usecase.UserExportFormatZip:
+		content, err = encodeUserExportZip(doc)
+		return content, "application/zip", err
+	default:
+		return nil, "", fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// listUserExportProfileChanges will read every change made to the profile of the user, oldest first
+func (u *userUsecases) listUserExportProfileChanges(ctx context.Context, userID uint64) ([]userExportProfileChange, error) {
+	changes := []userExportProfileChange{}
+	input := repository.ListUserChangesInput{UserID: userID, Limit: userExportChangesPageSize}
+	for {
+		page, err := u.userChangeRepo.ListUserChanges(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range page.Changes {
+			changes = append(changes, userExportProfileChange{
+				ID:          change.ID,
+				Field:       change.Field,
+				OldValue:    change.OldValue,
+				NewValue:    change.NewValue,
+				ActorUserID: change.ActorUserID,
+				CreatedAt:   change.CreatedAt,
+			})
+		}
+		if len(page.Changes) < input.Limit {
+			break
+		}
+		input.BeforeID = page.Changes[len(page.Changes)-1].ID
+	}
+
+	// the changes are listed newest first
+	for i, j := 0, len(changes)-1; i < j; i, j = i+1, j-1 {
+		changes[i], changes[j] = changes[j], changes[i]
+	}
+	return changes, nil
+}
+
+// encodeUserExportZip will encode each section of the export document as a CSV file inside a zip archive
+func encodeUserExportZip(doc userExportDocument) ([]byte, error) {
+	attributes := []byte("{}")
+	if len(doc.Profile.Attributes) > 0 {
+		var err error
+		if attributes, err = json.Marshal(doc.Profile.Attributes); err != nil {
+			return nil, err
+		}
+	}
+	files := map[string][][]string{
+		"profile.csv": {
+			{"user_id", "phone_no", "full_name", "email", "email_verified", "successful_login_count", "attributes"},
+			{strconv.FormatUint(doc.Profile.UserID, 10), doc.Profile.PhoneNo, doc.Profile.FullName, doc.Profile.Email, strconv.FormatBool(doc.Profile.EmailVerified), strconv.FormatUint(doc.Profile.SuccessfulLoginCount, 10), string(attributes)},
+		},
+		"roles.csv":           {{"role"}},
+		"profile_changes.csv": {{"id", "field", "old_value", "new_value", "actor_user_id", "created_at"}},
+		"phone_changes.csv":   {{"id", "phone_no", "created_at", "confirmed_at"}},
+		"devices.csv":         {{"id", "created_at"}},
+		"audit_events.csv":    {{"id", "action", "actor_user_id", "target_user_id", "details", "created_at"}},
+	}
+	for _, role := range doc.Roles {
+		files["roles.csv"] = append(files["roles.csv"], []string{role})
+	}
+	for _, change := range doc.ProfileChanges {
+		oldValue, err := json.Marshal(change.OldValue)
+		if err != nil {
+			return nil, err
+		}
+		newValue, err := json.Marshal(change.NewValue)
+		if err != nil {
+			return nil, err
+		}
+		files["profile_changes.csv"] = append(files["profile_changes.csv"], []string{
+			strconv.FormatUint(change.ID, 10),
+			change.Field,
+			string(oldValue),
+			string(newValue),
+			formatOptionalID(change.ActorUserID),
+			change.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	for _, change := range doc.PhoneChanges {
+		confirmedAt := ""
+		if change.ConfirmedAt != nil {
+			confirmedAt = change.ConfirmedAt.Format(time.RFC3339)
+		}
+		files["phone_changes.csv"] = append(files["phone_changes.csv"], []string{
+			strconv.FormatUint(change.ID, 10),
+			change.PhoneNo,
+			change.CreatedAt.Format(time.RFC3339),
+			confirmedAt,
+		})
+	}
+	for _, device := range doc.Devices {
+		files["devices.csv"] = append(files["devices.csv"], []string{
+			strconv.FormatUint(device.ID, 10),
+			device.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	for _, event := range doc.AuditEvents {
+		details, err := json.Marshal(event.Details)
+		if err != nil {
+			return nil, err
+		}
+		files["audit_events.csv"] = append(files["audit_events.csv"], []string{
+			strconv.FormatUint(event.ID, 10),
+			event.Action,
+			formatOptionalID(event.ActorUserID),
+			formatOptionalID(event.TargetUserID),
+			string(details),
+			event.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, name := range []string{"profile.csv", "roles.csv", "profile_changes.csv", "phone_changes.csv", "devices.csv", "audit_events.csv"} {
+		f, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if err = csv.NewWriter(f).WriteAll(files[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatOptionalID will format the zero ID as an empty string, e.g. for actions performed by the system itself
+func formatOptionalID(id uint64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatUint(id, 10)
+}
@@ -0,0 +1,246 @@
+package users
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"testing"
+	"time"
+)
+
+type ProcessUserExportsTestSuite struct {
+	suite.Suite
+
+	gomock          *gomock.Controller
+	repo            *repository.MockUserRepository
+	auditRepo       *repository.MockAuditRepository
+	exportRepo      *repository.MockUserExportRepository
+	userChangeRepo  *repository.MockUserChangeRepository
+	phoneChangeRepo *repository.MockPhoneChangeRepository
+	deviceRepo      *repository.MockUserDeviceRepository
+
+	usecase usecase.UserUsecases
+
+	createdAt time.Time
+
+	ctx                          context.Context
+	mockErr                      error
+	originalLogUserExportFailure func(exportID, userID uint64, err error)
+}
+
+func TestProcessUserExportsTestSuite(t *testing.T) {
+	suite.Run(t, new(ProcessUserExportsTestSuite))
+}
+
+func (s *ProcessUserExportsTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.auditRepo = repository.NewMockAuditRepository(s.gomock)
+	s.exportRepo = repository.NewMockUserExportRepository(s.gomock)
+	s.userChangeRepo = repository.NewMockUserChangeRepository(s.gomock)
+	s.phoneChangeRepo = repository.NewMockPhoneChangeRepository(s.gomock)
+	s.deviceRepo = repository.NewMockUserDeviceRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		UserRepo:        s.repo,
+		AuditRepo:       s.auditRepo,
+		UserExportRepo:  s.exportRepo,
+		UserChangeRepo:  s.userChangeRepo,
+		PhoneChangeRepo: s.phoneChangeRepo,
+		UserDeviceRepo:  s.deviceRepo,
+		Users:           config.Users{UserExportRetention: 7 * 24 * time.Hour},
+	})
+
+	s.createdAt = time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+	s.originalLogUserExportFailure = logUserExportFailure
+	logUserExportFailure = func(exportID, userID uint64, err error) {}
+}
+
+func (s *ProcessUserExportsTestSuite) TearDownTest() {
+	logUserExportFailure = s.originalLogUserExportFailure
+	s.gomock.Finish()
+}
+
+func (s *ProcessUserExportsTestSuite) expectUserData() {
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{
+		ID:                   123,
+		PhoneNo:              "+6281215183300",
+		FullName:             "John Smith",
+		Email:                "john.smith@example.com",
+		EmailVerifiedAt:      s.createdAt,
+		SuccessfulLoginCount: 2,
+		Status:               "active",
+		Attributes:           map[string]interface{}{"estate_code": "KAL-01"},
+	}, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, repository.GetUserRolesInput{UserID: 123}).
+		Return(repository.GetUserRolesOutput{Roles: []string{"farmer"}}, nil)
+	s.userChangeRepo.EXPECT().ListUserChanges(s.ctx, repository.ListUserChangesInput{UserID: 123, Limit: 100}).
+		Return(repository.ListUserChangesOutput{Changes: []repository.UserChange{
+			{ID: 5, UserFieldChange: repository.UserFieldChange{Field: "full_name", OldValue: "John", NewValue: "John Smith"}, ActorUserID: 123, CreatedAt: s.createdAt},
+		}}, nil)
+	s.phoneChangeRepo.EXPECT().ListPhoneChanges(s.ctx, repository.ListPhoneChangesInput{UserID: 123}).
+		Return(repository.ListPhoneChangesOutput{Changes: []repository.PhoneChange{
+			{ID: 3, PhoneNo: "+6281215183301", CreatedAt: s.createdAt},
+		}}, nil)
+	s.deviceRepo.EXPECT().ListUserDevices(s.ctx, repository.ListUserDevicesInput{UserID: 123}).
+		Return(repository.ListUserDevicesOutput{Devices: []repository.UserDevice{{ID: 9, CreatedAt: s.createdAt}}}, nil)
+	s.auditRepo.EXPECT().ListAuditEvents(s.ctx, repository.ListAuditEventsInput{UserID: 123}).
+		Return(repository.ListAuditEventsOutput{Events: []repository.AuditEvent{
+			{ID: 1, ActorUserID: 1, Action: "user.roles.set", TargetUserID: 123, CreatedAt: s.createdAt},
+		}}, nil)
+}
+
+func (s *ProcessUserExportsTestSuite) TestClaimError() {
+	a := assert.New(s.T())
+
+	s.exportRepo.EXPECT().ClaimUserExports(s.ctx, repository.ClaimUserExportsInput{Limit: 10, StaleAfter: 15 * time.Minute}).
+		Return(repository.ClaimUserExportsOutput{}, s.mockErr)
+
+	out, err := s.usecase.ProcessUserExports(s.ctx, usecase.ProcessUserExportsInput{})
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *ProcessUserExportsTestSuite) TestGenerateFailed() {
+	a := assert.New(s.T())
+
+	var logged bool
+	logUserExportFailure = func(exportID, userID uint64, err error) {
+		logged = true
+		a.Equal(uint64(7), exportID)
+		a.Equal(uint64(123), userID)
+		a.ErrorIs(err, s.mockErr)
+	}
+
+	s.exportRepo.EXPECT().ClaimUserExports(s.ctx, repository.ClaimUserExportsInput{Limit: 10, StaleAfter: 15 * time.Minute}).
+		Return(repository.ClaimUserExportsOutput{Exports: []repository.ClaimedUserExport{{ID: 7, UserID: 123, Format: "json"}}}, nil)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{}, s.mockErr)
+	s.exportRepo.EXPECT().CompleteUserExport(s.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input repository.CompleteUserExportInput) (repository.CompleteUserExportOutput, error) {
+			a.Equal(uint64(7), input.ID)
+			a.Equal("failed", input.Status)
+			a.Empty(input.Content)
+			return repository.CompleteUserExportOutput{}, nil
+		})
+	s.exportRepo.EXPECT().DeleteExpiredUserExports(s.ctx, gomock.Any()).Return(repository.DeleteExpiredUserExportsOutput{}, nil)
+
+	out, err := s.usecase.ProcessUserExports(s.ctx, usecase.ProcessUserExportsInput{})
+
+	a.Nil(err)
+	a.Equal(usecase.ProcessUserExportsOutput{FailedExportIDs: []uint64{7}}, out)
+	a.True(logged)
+}
+
+func (s *ProcessUserExportsTestSuite) TestSuccessJson() {
+	a := assert.New(s.T())
+
+	s.exportRepo.EXPECT().ClaimUserExports(s.ctx, repository.ClaimUserExportsInput{Limit: 10, StaleAfter: 15 * time.Minute}).
+		Return(repository.ClaimUserExportsOutput{Exports: []repository.ClaimedUserExport{{ID: 7, UserID: 123, Format: "json"}}}, nil)
+	s.expectUserData()
+	s.exportRepo.EXPECT().CompleteUserExport(s.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input repository.CompleteUserExportInput) (repository.CompleteUserExportOutput, error) {
+			a.Equal("ready", input.Status)
+			a.Equal("application/json", input.ContentType)
+			a.WithinDuration(time.Now().Add(7*24*time.Hour), input.ExpiresAt, time.Minute)
+
+			var doc map[string]interface{}
+			a.Nil(json.Unmarshal(input.Content, &doc))
+			a.Equal(map[string]interface{}{
+				"user_id":                float64(123),
+				"phone_no":               "+6281215183300",
+				"full_name":              "John Smith",
+				"email":                  "john.smith@example.com",
+				"email_verified":         true,
+				"successful_login_count": float64(2),
+				"attributes":             map[string]interface{}{"estate_code": "KAL-01"},
+			}, doc["profile"])
+			a.Equal([]interface{}{"farmer"}, doc["roles"])
+			a.Equal([]interface{}{map[string]interface{}{
+				"id": float64(5), "field": "full_name", "old_value": "John", "new_value": "John Smith",
+				"actor_user_id": float64(123), "created_at": "2024-03-16T09:55:00Z",
+			}}, doc["profile_changes"])
+			a.Equal([]interface{}{map[string]interface{}{
+				"id": float64(3), "phone_no": "+6281215183301", "created_at": "2024-03-16T09:55:00Z",
+			}}, doc["phone_changes"])
+			a.Equal([]interface{}{map[string]interface{}{"id": float64(9), "created_at": "2024-03-16T09:55:00Z"}}, doc["devices"])
+			a.Len(doc["audit_events"], 1)
+			return repository.CompleteUserExportOutput{}, nil
+		})
+	s.exportRepo.EXPECT().DeleteExpiredUserExports(s.ctx, gomock.Any()).Return(repository.DeleteExpiredUserExportsOutput{Count: 2}, nil)
+
+	out, err := s.usecase.ProcessUserExports(s.ctx, usecase.ProcessUserExportsInput{})
+
+	a.Nil(err)
+	a.Equal(usecase.ProcessUserExportsOutput{ReadyExportIDs: []uint64{7}, ExpiredCount: 2}, out)
+}
+
+func (s *ProcessUserExportsTestSuite) TestSuccessZip() {
+	a := assert.New(s.T())
+
+	s.exportRepo.EXPECT().ClaimUserExports(s.ctx, repository.ClaimUserExportsInput{Limit: 10, StaleAfter: 15 * time.Minute}).
+		Return(repository.ClaimUserExportsOutput{Exports: []repository.ClaimedUserExport{{ID: 8, UserID: 123, Format: "zip"}}}, nil)
+	s.expectUserData()
+	s.exportRepo.EXPECT().CompleteUserExport(s.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input repository.CompleteUserExportInput) (repository.CompleteUserExportOutput, error) {
+			a.Equal("ready", input.Status)
+			a.Equal("application/zip", input.ContentType)
+
+			archive, err := zip.NewReader(bytes.NewReader(input.Content), int64(len(input.Content)))
+			a.Nil(err)
+			files := map[string]string{}
+			for _, f := range archive.File {
+				r, _ := f.Open()
+				content, _ := io.ReadAll(r)
+				files[f.Name] = string(content)
+			}
+			a.Equal("user_id,phone_no,full_name,email,email_verified,successful_login_count,attributes\n"+
+				`123,+6281215183300,John Smith,john.smith@example.com,true,2,"{""estate_code"":""KAL-01""}"`+"\n", files["profile.csv"])
+			a.Equal("role\nfarmer\n", files["roles.csv"])
+			a.Equal("id,field,old_value,new_value,actor_user_id,created_at\n"+
+				`5,full_name,"""John""","""John Smith""",123,2024-03-16T09:55:00Z`+"\n", files["profile_changes.csv"])
+			a.Equal("id,phone_no,created_at,confirmed_at\n3,+6281215183301,2024-03-16T09:55:00Z,\n", files["phone_changes.csv"])
+			a.Equal("id,created_at\n9,2024-03-16T09:55:00Z\n", files["devices.csv"])
+			a.Equal("id,action,actor_user_id,target_user_id,details,created_at\n1,user.roles.set,1,123,null,2024-03-16T09:55:00Z\n", files["audit_events.csv"])
+			return repository.CompleteUserExportOutput{}, nil
+		})
+	s.exportRepo.EXPECT().DeleteExpiredUserExports(s.ctx, gomock.Any()).Return(repository.DeleteExpiredUserExportsOutput{}, nil)
+
+	out, err := s.usecase.ProcessUserExports(s.ctx, usecase.ProcessUserExportsInput{})
+
+	a.Nil(err)
+	a.Equal(usecase.ProcessUserExportsOutput{ReadyExportIDs: []uint64{8}}, out)
+}
+
+func (s *ProcessUserExportsTestSuite) TestProfileChangesPaged() {
+	a := assert.New(s.T())
+
+	firstPage := make([]repository.UserChange, 100)
+	for i := range firstPage {
+		firstPage[i] = repository.UserChange{ID: uint64(200 - i), UserFieldChange: repository.UserFieldChange{Field: "full_name"}}
+	}
+
+	s.userChangeRepo.EXPECT().ListUserChanges(s.ctx, repository.ListUserChangesInput{UserID: 123, Limit: 100}).
+		Return(repository.ListUserChangesOutput{Changes: firstPage}, nil)
+	s.userChangeRepo.EXPECT().ListUserChanges(s.ctx, repository.ListUserChangesInput{UserID: 123, BeforeID: 101, Limit: 100}).
+		Return(repository.ListUserChangesOutput{Changes: []repository.UserChange{{ID: 42}}}, nil)
+
+	changes, err := s.usecase.(*userUsecases).listUserExportProfileChanges(s.ctx, 123)
+
+	a.Nil(err)
+	a.Len(changes, 101)
+	a.Equal(uint64(42), changes[0].ID, "the oldest change comes first")
+	a.Equal(uint64(200), changes[100].ID)
+}
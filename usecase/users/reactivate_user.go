@@ -0,0 +1,27 @@
+package users
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+)
+
+const (
+	auditActionReactivateUser = "user.reactivated"
+)
+
+func (u *userUsecases) ReactivateUser(ctx context.Context, input usecase.ReactivateUserInput) (output usecase.ReactivateUserOutput, err error) {
+	ctx, span := startSpan(ctx, "ReactivateUser")
+	defer func() { endSpan(span, err) }()
+
+	err = u.changeUserStatus(ctx, input.UserID, usecase.UserStatusSuspended, usecase.UserStatusActive, repository.CreateAuditEventInput{
+		ActorUserID:  input.ActorUserID,
+		Action:       auditActionReactivateUser,
+		TargetUserID: input.UserID,
+	})
+	if err != nil {
+		return
+	}
+
+	return usecase.ReactivateUserOutput{UserID: input.UserID, Status: usecase.UserStatusActive}, nil
+}
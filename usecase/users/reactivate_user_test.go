@@ -0,0 +1,96 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ReactivateUserTestSuite struct {
+	suite.Suite
+
+	gomock *gomock.Controller
+	repo   *repository.MockUserRepository
+
+	usecase usecase.UserUsecases
+
+	getUserInput       repository.GetUserInput
+	getUserOutput      repository.GetUserOutput
+	setUserStatusInput repository.SetUserStatusInput
+
+	input  usecase.ReactivateUserInput
+	output usecase.ReactivateUserOutput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestReactivateUserTestSuite(t *testing.T) {
+	suite.Run(t, new(ReactivateUserTestSuite))
+}
+
+func (s *ReactivateUserTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo})
+
+	s.getUserInput = repository.GetUserInput{ID: 123}
+	s.getUserOutput = repository.GetUserOutput{ID: 123, Status: "suspended"}
+	s.setUserStatusInput = repository.SetUserStatusInput{
+		ID:         123,
+		From:       "suspended",
+		To:         "active",
+		AuditEvent: repository.CreateAuditEventInput{ActorUserID: 1, Action: "user.reactivated", TargetUserID: 123},
+	}
+
+	s.input = usecase.ReactivateUserInput{ActorUserID: 1, UserID: 123}
+	s.output = usecase.ReactivateUserOutput{UserID: 123, Status: "active"}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *ReactivateUserTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *ReactivateUserTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.ReactivateUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *ReactivateUserTestSuite) TestNotSuspended() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "deactivated"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.ReactivateUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserStatusConflict)
+}
+
+func (s *ReactivateUserTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().SetUserStatus(s.ctx, s.setUserStatusInput).Return(repository.SetUserStatusOutput{Version: 4}, nil)
+
+	out, err := s.usecase.ReactivateUser(s.ctx, s.input)
+
+	a.Nil(err)
+	a.Equal(s.output, out)
+}
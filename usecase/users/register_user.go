@@ -6,17 +6,22 @@ import (
 	"github.com/SawitProRecruitment/UserService/repository"
 	"github.com/SawitProRecruitment/UserService/usecase"
 	"golang.org/x/crypto/bcrypt"
+	"time"
 )
 
 var (
 	// wrapper to bcrypt function call to make testing easier
 	generateBcryptHash = func(password string) ([]byte, error) {
+		defer observeBcrypt(bcryptOperationHash, time.Now())
 		return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	}
 )
 
 func (u *userUsecases) RegisterUser(ctx context.Context, input usecase.RegisterUserInput) (output usecase.RegisterUserOutput, err error) {
-	if validationErrors := validateRegisterUserPayload(input); len(validationErrors) > 0 {
+	ctx, span := startSpan(ctx, "RegisterUser")
+	defer func() { endSpan(span, err) }()
+
+	if validationErrors := u.validateRegisterUserPayload(&input); len(validationErrors) > 0 {
 		err = usecase.NewValidationError(validationErrors)
 		return
 	}
@@ -43,12 +48,14 @@ func (u *userUsecases) RegisterUser(ctx context.Context, input usecase.RegisterU
 	return usecase.RegisterUserOutput{UserID: resp.ID}, nil
 }
 
-func validateRegisterUserPayload(input usecase.RegisterUserInput) map[string][]error {
+// validateRegisterUserPayload will also normalize the phone number of the input
+func (u *userUsecases) validateRegisterUserPayload(input *usecase.RegisterUserInput) map[string][]error {
 	var validationErrors = map[string][]error{}
 	if errs := validateUserFullName(input.FullName); len(errs) > 0 {
 		validationErrors["full_name"] = errs
 	}
-	if errs := validateUserPhoneNo(input.PhoneNo); len(errs) > 0 {
+	var errs []error
+	if input.PhoneNo, errs = u.normalizeUserPhoneNo(input.PhoneNo); len(errs) > 0 {
 		validationErrors["phone_no"] = errs
 	}
 	if errs := validateUserPassword(input.Password); len(errs) > 0 {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
 	"github.com/SawitProRecruitment/UserService/repository"
 	"github.com/SawitProRecruitment/UserService/usecase"
 	"github.com/golang/mock/gomock"
@@ -77,9 +78,7 @@ func (s *RegisterUserTestSuite) TestValidationFailed() {
 	var validationError usecase.ValidationErrors
 	a.True(errors.As(err, &validationError))
 	validationErrors := validationError.GetErrors()
-	a.Equal("phone_no must be between 10 and 13 characters long", validationErrors["phone_no"][0].Error())
-	a.Equal("phone_no must start with indonesia country code (\"+62\")", validationErrors["phone_no"][1].Error())
-	a.Equal("besides the country code, phone_no must only contain numbers", validationErrors["phone_no"][2].Error())
+	a.Equal("phone_no must start with the country calling code, e.g. +62", validationErrors["phone_no"][0].Error())
 	a.Equal("full_name must be between 3 and 60 characters long", validationErrors["full_name"][0].Error())
 	a.Equal("password must be between 6 and 64 characters long", validationErrors["password"][0].Error())
 	a.Equal("password must contains at least one number [0-9]", validationErrors["password"][1].Error())
@@ -99,13 +98,29 @@ func (s *RegisterUserTestSuite) TestValidationPartialFailed() {
 	var validationError usecase.ValidationErrors
 	a.True(errors.As(err, &validationError))
 	validationErrors := validationError.GetErrors()
-	a.Equal("phone_no must be between 10 and 13 characters long", validationErrors["phone_no"][0].Error())
+	a.Equal("phone_no has an invalid number of digits, ID numbers must have 8 to 12 digits after +62", validationErrors["phone_no"][0].Error())
+	var phoneErr *usecase.Error
+	a.ErrorAs(validationErrors["phone_no"][0], &phoneErr)
+	a.Equal("phone_no.invalid_length", phoneErr.Code)
 	a.Equal("full_name must be between 3 and 60 characters long", validationErrors["full_name"][0].Error())
 	a.Equal("password must be between 6 and 64 characters long", validationErrors["password"][0].Error())
 	a.Equal("password must contains at least one number [0-9]", validationErrors["password"][1].Error())
 	a.Equal("password must contains at least one non alphanumeric character", validationErrors["password"][2].Error())
 }
 
+func (s *RegisterUserTestSuite) TestCountryNotAllowed() {
+	a := assert.New(s.T())
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo, Users: config.Users{AllowedPhoneCountries: []string{"ID", "SG"}}})
+	s.input.PhoneNo = "+60 12-345 6789"
+	out, err := s.usecase.RegisterUser(s.ctx, s.input)
+
+	a.Empty(out)
+	var validationError usecase.ValidationErrors
+	a.True(errors.As(err, &validationError))
+	a.Equal("phone_no from MY is not allowed", validationError.GetErrors()["phone_no"][0].Error())
+}
+
 func (s *RegisterUserTestSuite) TestBcryptError() {
 	a := assert.New(s.T())
 
@@ -150,3 +165,15 @@ func (s *RegisterUserTestSuite) TestSuccess() {
 	a.Empty(err)
 	a.Equal(s.output, out)
 }
+
+func (s *RegisterUserTestSuite) TestSuccessNormalizePhoneNo() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().CreateUser(s.ctx, s.createUserInput).Return(s.createUserOutput, nil)
+
+	s.input.PhoneNo = "+62 (0812) 151-833"
+	out, err := s.usecase.RegisterUser(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
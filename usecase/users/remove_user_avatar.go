@@ -0,0 +1,38 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+)
+
+func (u *userUsecases) RemoveUserAvatar(ctx context.Context, input usecase.RemoveUserAvatarInput) (output usecase.RemoveUserAvatarOutput, err error) {
+	ctx, span := startSpan(ctx, "RemoveUserAvatar")
+	defer func() { endSpan(span, err) }()
+
+	var usr repository.GetUserOutput
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+	if err = checkUserStatus(usr.Status); err != nil {
+		return
+	}
+	if usr.AvatarKey == "" {
+		return output, nil
+	}
+
+	var resp repository.SetUserAvatarOutput
+	if resp, err = u.userRepo.SetUserAvatar(ctx, repository.SetUserAvatarInput{ID: usr.ID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+	u.deleteReplacedAvatar(ctx, resp.PreviousAvatarKey)
+
+	return output, nil
+}
@@ -0,0 +1,124 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/storage"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type RemoveUserAvatarTestSuite struct {
+	suite.Suite
+
+	gomock    *gomock.Controller
+	repo      *repository.MockUserRepository
+	blobStore *storage.MockBlobStore
+
+	usecase usecase.UserUsecases
+
+	getUserOutput repository.GetUserOutput
+	input         usecase.RemoveUserAvatarInput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestRemoveUserAvatarTestSuite(t *testing.T) {
+	suite.Run(t, new(RemoveUserAvatarTestSuite))
+}
+
+func (s *RemoveUserAvatarTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.blobStore = storage.NewMockBlobStore(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo, BlobStore: s.blobStore})
+
+	s.getUserOutput = repository.GetUserOutput{ID: 123, Status: "active", AvatarKey: "avatars/123/random-key"}
+	s.input = usecase.RemoveUserAvatarInput{UserID: 123}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *RemoveUserAvatarTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *RemoveUserAvatarTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	_, err := s.usecase.RemoveUserAvatar(s.ctx, s.input)
+
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *RemoveUserAvatarTestSuite) TestUserSuspended() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "suspended"
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+
+	_, err := s.usecase.RemoveUserAvatar(s.ctx, s.input)
+
+	a.ErrorIs(err, usecase.UserSuspended)
+}
+
+func (s *RemoveUserAvatarTestSuite) TestNoAvatar() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.AvatarKey = ""
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+
+	_, err := s.usecase.RemoveUserAvatar(s.ctx, s.input)
+
+	a.Empty(err)
+}
+
+func (s *RemoveUserAvatarTestSuite) TestRepositoryError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().SetUserAvatar(s.ctx, repository.SetUserAvatarInput{ID: 123}).Return(repository.SetUserAvatarOutput{}, s.mockErr)
+
+	_, err := s.usecase.RemoveUserAvatar(s.ctx, s.input)
+
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *RemoveUserAvatarTestSuite) TestBlobStoreError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().SetUserAvatar(s.ctx, repository.SetUserAvatarInput{ID: 123}).
+		Return(repository.SetUserAvatarOutput{PreviousAvatarKey: "avatars/123/random-key"}, nil)
+	s.blobStore.EXPECT().DeleteBlob(s.ctx, gomock.Any()).Return(storage.DeleteBlobOutput{}, s.mockErr)
+
+	// the avatar stays queued for deletion, hence the removal still succeeds
+	_, err := s.usecase.RemoveUserAvatar(s.ctx, s.input)
+
+	a.Empty(err)
+}
+
+func (s *RemoveUserAvatarTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().SetUserAvatar(s.ctx, repository.SetUserAvatarInput{ID: 123}).
+		Return(repository.SetUserAvatarOutput{PreviousAvatarKey: "avatars/123/random-key"}, nil)
+	s.blobStore.EXPECT().DeleteBlob(s.ctx, storage.DeleteBlobInput{Key: "avatars/123/random-key/512.jpg"}).Return(storage.DeleteBlobOutput{}, nil)
+	s.blobStore.EXPECT().DeleteBlob(s.ctx, storage.DeleteBlobInput{Key: "avatars/123/random-key/128.jpg"}).Return(storage.DeleteBlobOutput{}, nil)
+	s.repo.EXPECT().DeleteAvatarDeletion(s.ctx, repository.DeleteAvatarDeletionInput{AvatarKey: "avatars/123/random-key"}).
+		Return(repository.DeleteAvatarDeletionOutput{}, nil)
+
+	_, err := s.usecase.RemoveUserAvatar(s.ctx, s.input)
+
+	a.Empty(err)
+}
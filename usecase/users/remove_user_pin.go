@@ -0,0 +1,22 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+)
+
+func (u *userUsecases) RemoveUserPIN(ctx context.Context, input usecase.RemoveUserPINInput) (output usecase.RemoveUserPINOutput, err error) {
+	ctx, span := startSpan(ctx, "RemoveUserPIN")
+	defer func() { endSpan(span, err) }()
+
+	if _, err = u.userRepo.SetUserPIN(ctx, repository.SetUserPINInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+
+	return output, nil
+}
@@ -0,0 +1,81 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type RemoveUserPINTestSuite struct {
+	suite.Suite
+
+	gomock *gomock.Controller
+	repo   *repository.MockUserRepository
+
+	usecase usecase.UserUsecases
+
+	setUserPINInput repository.SetUserPINInput
+	input           usecase.RemoveUserPINInput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestRemoveUserPINTestSuite(t *testing.T) {
+	suite.Run(t, new(RemoveUserPINTestSuite))
+}
+
+func (s *RemoveUserPINTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo})
+
+	s.setUserPINInput = repository.SetUserPINInput{ID: 123}
+	s.input = usecase.RemoveUserPINInput{UserID: 123}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *RemoveUserPINTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *RemoveUserPINTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().SetUserPIN(s.ctx, s.setUserPINInput).Return(repository.SetUserPINOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.RemoveUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *RemoveUserPINTestSuite) TestRepositoryError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().SetUserPIN(s.ctx, s.setUserPINInput).Return(repository.SetUserPINOutput{}, s.mockErr)
+
+	out, err := s.usecase.RemoveUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *RemoveUserPINTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().SetUserPIN(s.ctx, s.setUserPINInput).Return(repository.SetUserPINOutput{}, nil)
+
+	out, err := s.usecase.RemoveUserPIN(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Empty(out)
+}
@@ -0,0 +1,84 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/mail"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang-jwt/jwt/v5"
+	"net/url"
+	"time"
+)
+
+const (
+	// emailVerificationTokenType is the `typ` header of email verification tokens, so they can't be used as
+	// access tokens, and access tokens can't be used to verify an email (RFC 8725 explicit typing)
+	emailVerificationTokenType = "email-verification+jwt"
+	// emailVerificationPath is the route verifying the emails (GET /user/email/verify), relative to the public URL
+	emailVerificationPath = "/user/email/verify"
+)
+
+func (u *userUsecases) RequestEmailVerification(ctx context.Context, input usecase.RequestEmailVerificationInput) (output usecase.RequestEmailVerificationOutput, err error) {
+	ctx, span := startSpan(ctx, "RequestEmailVerification")
+	defer func() { endSpan(span, err) }()
+
+	var usr repository.GetUserOutput
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+	if err = checkUserStatus(usr.Status); err != nil {
+		return
+	}
+
+	if usr.Email == "" {
+		err = usecase.UserEmailNotSet
+		return
+	}
+	// nothing left to verify
+	if !usr.EmailVerifiedAt.IsZero() {
+		return output, nil
+	}
+
+	if err = u.sendEmailVerification(ctx, usr.ID, usr.Email); err != nil {
+		return
+	}
+	return output, nil
+}
+
+// sendEmailVerification will send a link containing a signed token of the user email, to the email itself
+func (u *userUsecases) sendEmailVerification(ctx context.Context, userID uint64, email string) (err error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":   fmt.Sprintf("%d", userID),
+		"email": email,
+		"iat":   now.Unix(),
+		"exp":   now.Add(u.emailVerificationTtl).Unix(),
+	})
+	token.Header["typ"] = emailVerificationTokenType
+
+	var signedToken string
+	if signedToken, err = token.SignedString(u.jwtSecret); err != nil {
+		return
+	}
+
+	var link *url.URL
+	if link, err = url.Parse(u.emailVerificationURL); err != nil {
+		return
+	}
+	query := link.Query()
+	query.Set("token", signedToken)
+	link.RawQuery = query.Encode()
+
+	_, err = u.mailSender.SendMail(ctx, mail.SendMailInput{
+		To:      email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Please verify your email address by opening the link below, it expires in %s.\n\n%s\n\n"+
+			"If you didn't add this email address to your account, please ignore this email.\n", u.emailVerificationTtl, link),
+	})
+	return err
+}
@@ -0,0 +1,142 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/mail"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type RequestEmailVerificationTestSuite struct {
+	suite.Suite
+
+	gomock     *gomock.Controller
+	repo       *repository.MockUserRepository
+	mailSender *mail.MockSender
+
+	usecase usecase.UserUsecases
+
+	getUserInput  repository.GetUserInput
+	getUserOutput repository.GetUserOutput
+
+	input usecase.RequestEmailVerificationInput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestRequestEmailVerificationTestSuite(t *testing.T) {
+	suite.Run(t, new(RequestEmailVerificationTestSuite))
+}
+
+func (s *RequestEmailVerificationTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.mailSender = mail.NewMockSender(s.gomock)
+
+	jwtSecret, _ := rsa.GenerateKey(rand.Reader, 1024)
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		UserRepo:   s.repo,
+		MailSender: s.mailSender,
+		JwtSecret:  jwtSecret,
+		HTTP:       config.HTTP{PublicURL: "https://users.example.com"},
+		Auth:       config.Auth{EmailVerificationTtl: 24 * time.Hour},
+	})
+
+	s.getUserInput = repository.GetUserInput{ID: 123}
+	s.getUserOutput = repository.GetUserOutput{ID: 123, Email: "john.smith@example.com", Status: "active"}
+
+	s.input = usecase.RequestEmailVerificationInput{UserID: 123}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *RequestEmailVerificationTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *RequestEmailVerificationTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.RequestEmailVerification(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *RequestEmailVerificationTestSuite) TestUserSuspended() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "suspended"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.RequestEmailVerification(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserSuspended)
+}
+
+func (s *RequestEmailVerificationTestSuite) TestEmailNotSet() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Email = ""
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.RequestEmailVerification(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserEmailNotSet)
+}
+
+func (s *RequestEmailVerificationTestSuite) TestAlreadyVerified() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.EmailVerifiedAt = time.Now().Add(-time.Hour)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.RequestEmailVerification(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Empty(out)
+}
+
+func (s *RequestEmailVerificationTestSuite) TestSendError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.mailSender.EXPECT().SendMail(s.ctx, gomock.Any()).Return(mail.SendMailOutput{}, s.mockErr)
+
+	out, err := s.usecase.RequestEmailVerification(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *RequestEmailVerificationTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.mailSender.EXPECT().SendMail(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, input mail.SendMailInput) (mail.SendMailOutput, error) {
+		a.Equal("john.smith@example.com", input.To)
+		a.Equal("Verify your email address", input.Subject)
+		a.Contains(input.Body, "https://users.example.com/user/email/verify?token=")
+		return mail.SendMailOutput{MessageID: "message-id"}, nil
+	})
+
+	out, err := s.usecase.RequestEmailVerification(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Empty(out)
+}
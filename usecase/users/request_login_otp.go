@@ -0,0 +1,98 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/sms"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"math/big"
+	"time"
+)
+
+const (
+	loginOTPLength = 6
+
+	// at most maxLoginOTPsPerPhoneNo & maxLoginOTPsPerIPAddress codes can be requested within loginOTPThrottleWindow
+	loginOTPThrottleWindow   = time.Hour
+	maxLoginOTPsPerPhoneNo   = 5
+	maxLoginOTPsPerIPAddress = 20
+)
+
+var (
+	// wrapper to login code generation to make testing easier
+	generateLoginOTPCode = func() (string, error) {
+		n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%0*d", loginOTPLength, n.Int64()), nil
+	}
+)
+
+func (u *userUsecases) RequestLoginOTP(ctx context.Context, input usecase.RequestLoginOTPInput) (output usecase.RequestLoginOTPOutput, err error) {
+	ctx, span := startSpan(ctx, "RequestLoginOTP")
+	defer func() { endSpan(span, err) }()
+
+	var errs []error
+	if input.PhoneNo, errs = u.normalizeUserPhoneNo(input.PhoneNo); len(errs) > 0 {
+		err = usecase.NewValidationError(map[string][]error{"phone_no": errs})
+		return
+	}
+
+	now := time.Now()
+	var counts repository.CountLoginOTPsOutput
+	counts, err = u.loginOTPRepo.CountLoginOTPs(ctx, repository.CountLoginOTPsInput{
+		PhoneNo:      input.PhoneNo,
+		IPAddress:    input.IPAddress,
+		CreatedAfter: now.Add(-loginOTPThrottleWindow),
+	})
+	if err != nil {
+		return
+	}
+	if counts.PhoneNoCount >= maxLoginOTPsPerPhoneNo || counts.IPAddressCount >= maxLoginOTPsPerIPAddress {
+		err = usecase.UserLoginOTPThrottled
+		return
+	}
+
+	var code string
+	if code, err = generateLoginOTPCode(); err != nil {
+		return
+	}
+	var codeHash []byte
+	if codeHash, err = generateBcryptHash(code); err != nil {
+		return
+	}
+
+	// the code is stored even for unknown phone numbers, so that they count toward the IP address throttling
+	output.ExpiresAt = now.Add(u.loginOTPTtl)
+	_, err = u.loginOTPRepo.CreateLoginOTP(ctx, repository.CreateLoginOTPInput{
+		PhoneNo:   input.PhoneNo,
+		CodeHash:  codeHash,
+		IPAddress: input.IPAddress,
+		ExpiresAt: output.ExpiresAt,
+	})
+	if err != nil {
+		return usecase.RequestLoginOTPOutput{}, err
+	}
+
+	if _, err = u.userRepo.GetUser(ctx, repository.GetUserInput{PhoneNo: input.PhoneNo}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			return output, nil
+		}
+		return usecase.RequestLoginOTPOutput{}, err
+	}
+
+	_, err = u.smsSender.SendSMS(ctx, sms.SendSMSInput{
+		PhoneNo: input.PhoneNo,
+		Message: fmt.Sprintf("Your login code is %s, valid for %d minutes. Never share this code with anyone.",
+			code, int(u.loginOTPTtl.Minutes())),
+	})
+	if err != nil {
+		return usecase.RequestLoginOTPOutput{}, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,220 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/sms"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type RequestLoginOTPTestSuite struct {
+	suite.Suite
+
+	gomock    *gomock.Controller
+	repo      *repository.MockUserRepository
+	otpRepo   *repository.MockLoginOTPRepository
+	smsSender *sms.MockSender
+
+	usecase usecase.UserUsecases
+
+	countOutput  repository.CountLoginOTPsOutput
+	getUserInput repository.GetUserInput
+	sendSMSInput sms.SendSMSInput
+
+	input usecase.RequestLoginOTPInput
+
+	ctx                          context.Context
+	mockErr                      error
+	originalGenerateLoginOTPCode func() (string, error)
+	originalGenerateBcryptHash   func(password string) ([]byte, error)
+}
+
+func TestRequestLoginOTPTestSuite(t *testing.T) {
+	suite.Run(t, new(RequestLoginOTPTestSuite))
+}
+
+func (s *RequestLoginOTPTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.otpRepo = repository.NewMockLoginOTPRepository(s.gomock)
+	s.smsSender = sms.NewMockSender(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		UserRepo:     s.repo,
+		LoginOTPRepo: s.otpRepo,
+		SMSSender:    s.smsSender,
+		Auth:         config.Auth{LoginOTPTtl: time.Minute * 5},
+	})
+
+	s.countOutput = repository.CountLoginOTPsOutput{PhoneNoCount: 1, IPAddressCount: 3}
+	s.getUserInput = repository.GetUserInput{PhoneNo: "+62812151833"}
+	s.sendSMSInput = sms.SendSMSInput{
+		PhoneNo: "+62812151833",
+		Message: "Your login code is 042917, valid for 5 minutes. Never share this code with anyone.",
+	}
+
+	s.input = usecase.RequestLoginOTPInput{PhoneNo: "+62812151833", IPAddress: "10.0.0.1"}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+
+	s.originalGenerateLoginOTPCode = generateLoginOTPCode
+	s.originalGenerateBcryptHash = generateBcryptHash
+	generateLoginOTPCode = func() (string, error) { return "042917", nil }
+	generateBcryptHash = func(password string) ([]byte, error) { return []byte("hash:" + password), nil }
+}
+
+func (s *RequestLoginOTPTestSuite) TearDownTest() {
+	generateLoginOTPCode = s.originalGenerateLoginOTPCode
+	generateBcryptHash = s.originalGenerateBcryptHash
+	s.gomock.Finish()
+}
+
+// expectCreateLoginOTP will expect the code to be stored, with the expiry according to the configured TTL
+func (s *RequestLoginOTPTestSuite) expectCreateLoginOTP(err error) {
+	s.otpRepo.EXPECT().CreateLoginOTP(s.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input repository.CreateLoginOTPInput) (repository.CreateLoginOTPOutput, error) {
+			s.Equal("+62812151833", input.PhoneNo)
+			s.Equal([]byte("hash:042917"), input.CodeHash)
+			s.Equal("10.0.0.1", input.IPAddress)
+			s.WithinDuration(time.Now().Add(time.Minute*5), input.ExpiresAt, time.Minute)
+			if err != nil {
+				return repository.CreateLoginOTPOutput{}, err
+			}
+			return repository.CreateLoginOTPOutput{ID: 42}, nil
+		})
+}
+
+func (s *RequestLoginOTPTestSuite) TestInvalidPhoneNo() {
+	a := assert.New(s.T())
+
+	s.input.PhoneNo = "0812151833"
+
+	out, err := s.usecase.RequestLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorContains(err, `phone_no must start with the country calling code, e.g. +62`)
+}
+
+func (s *RequestLoginOTPTestSuite) TestCountError() {
+	a := assert.New(s.T())
+
+	s.otpRepo.EXPECT().CountLoginOTPs(s.ctx, gomock.Any()).Return(repository.CountLoginOTPsOutput{}, s.mockErr)
+
+	out, err := s.usecase.RequestLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *RequestLoginOTPTestSuite) TestPhoneNoThrottled() {
+	a := assert.New(s.T())
+
+	s.countOutput.PhoneNoCount = 5
+	s.otpRepo.EXPECT().CountLoginOTPs(s.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input repository.CountLoginOTPsInput) (repository.CountLoginOTPsOutput, error) {
+			a.Equal("+62812151833", input.PhoneNo)
+			a.Equal("10.0.0.1", input.IPAddress)
+			a.WithinDuration(time.Now().Add(-time.Hour), input.CreatedAfter, time.Minute)
+			return s.countOutput, nil
+		})
+
+	out, err := s.usecase.RequestLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserLoginOTPThrottled)
+}
+
+func (s *RequestLoginOTPTestSuite) TestIPAddressThrottled() {
+	a := assert.New(s.T())
+
+	s.countOutput.IPAddressCount = 20
+	s.otpRepo.EXPECT().CountLoginOTPs(s.ctx, gomock.Any()).Return(s.countOutput, nil)
+
+	out, err := s.usecase.RequestLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserLoginOTPThrottled)
+}
+
+func (s *RequestLoginOTPTestSuite) TestCreateLoginOTPError() {
+	a := assert.New(s.T())
+
+	s.otpRepo.EXPECT().CountLoginOTPs(s.ctx, gomock.Any()).Return(s.countOutput, nil)
+	s.expectCreateLoginOTP(s.mockErr)
+
+	out, err := s.usecase.RequestLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *RequestLoginOTPTestSuite) TestUnknownPhoneNo() {
+	a := assert.New(s.T())
+
+	s.otpRepo.EXPECT().CountLoginOTPs(s.ctx, gomock.Any()).Return(s.countOutput, nil)
+	s.expectCreateLoginOTP(nil)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.RequestLoginOTP(s.ctx, s.input)
+
+	a.Empty(err)
+	a.WithinDuration(time.Now().Add(time.Minute*5), out.ExpiresAt, time.Minute)
+}
+
+func (s *RequestLoginOTPTestSuite) TestGetUserError() {
+	a := assert.New(s.T())
+
+	s.otpRepo.EXPECT().CountLoginOTPs(s.ctx, gomock.Any()).Return(s.countOutput, nil)
+	s.expectCreateLoginOTP(nil)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{}, s.mockErr)
+
+	out, err := s.usecase.RequestLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *RequestLoginOTPTestSuite) TestSendSMSError() {
+	a := assert.New(s.T())
+
+	s.otpRepo.EXPECT().CountLoginOTPs(s.ctx, gomock.Any()).Return(s.countOutput, nil)
+	s.expectCreateLoginOTP(nil)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{ID: 123, Status: "active"}, nil)
+	s.smsSender.EXPECT().SendSMS(s.ctx, s.sendSMSInput).Return(sms.SendSMSOutput{}, s.mockErr)
+
+	out, err := s.usecase.RequestLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *RequestLoginOTPTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.otpRepo.EXPECT().CountLoginOTPs(s.ctx, gomock.Any()).Return(s.countOutput, nil)
+	s.expectCreateLoginOTP(nil)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{ID: 123, Status: "active"}, nil)
+	s.smsSender.EXPECT().SendSMS(s.ctx, s.sendSMSInput).Return(sms.SendSMSOutput{MessageID: "msg-1"}, nil)
+
+	out, err := s.usecase.RequestLoginOTP(s.ctx, s.input)
+
+	a.Empty(err)
+	a.WithinDuration(time.Now().Add(time.Minute*5), out.ExpiresAt, time.Minute)
+}
+
+func (s *RequestLoginOTPTestSuite) TestGenerateLoginOTPCode() {
+	a := assert.New(s.T())
+
+	code, err := s.originalGenerateLoginOTPCode()
+
+	a.Empty(err)
+	a.Regexp(`^\d{6}$`, code)
+}
@@ -0,0 +1,117 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/sms"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"time"
+)
+
+const (
+	// at most maxPhoneChangesPerUser changes can be requested within phoneChangeThrottleWindow
+	phoneChangeThrottleWindow = time.Hour
+	maxPhoneChangesPerUser    = 3
+)
+
+func (u *userUsecases) RequestPhoneChange(ctx context.Context, input usecase.RequestPhoneChangeInput) (output usecase.RequestPhoneChangeOutput, err error) {
+	ctx, span := startSpan(ctx, "RequestPhoneChange")
+	defer func() { endSpan(span, err) }()
+
+	var errs []error
+	if input.PhoneNo, errs = u.normalizeUserPhoneNo(input.PhoneNo); len(errs) > 0 {
+		err = usecase.NewValidationError(map[string][]error{"phone_no": errs})
+		return
+	}
+
+	var usr repository.GetUserOutput
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+	if err = checkUserStatus(usr.Status); err != nil {
+		return
+	}
+	if usr.PhoneNo == input.PhoneNo {
+		err = usecase.NewValidationError(map[string][]error{
+			"phone_no": {usecase.NewFieldError("phone_no.unchanged", "validation.phone_unchanged", nil)},
+		})
+		return
+	}
+
+	// checked upfront so that no code is sent for a phone number that can't be confirmed anyway
+	if err = u.checkPhoneNoAvailable(ctx, input.UserID, input.PhoneNo); err != nil {
+		return
+	}
+
+	now := time.Now()
+	var count repository.CountPhoneChangesOutput
+	count, err = u.phoneChangeRepo.CountPhoneChanges(ctx, repository.CountPhoneChangesInput{
+		UserID:       input.UserID,
+		CreatedAfter: now.Add(-phoneChangeThrottleWindow),
+	})
+	if err != nil {
+		return
+	}
+	if count.Count >= maxPhoneChangesPerUser {
+		err = usecase.UserPhoneChangeThrottled
+		return
+	}
+
+	var code string
+	if code, err = generateLoginOTPCode(); err != nil {
+		return
+	}
+	var codeHash []byte
+	if codeHash, err = generateBcryptHash(code); err != nil {
+		return
+	}
+
+	output.ExpiresAt = now.Add(u.loginOTPTtl)
+	_, err = u.phoneChangeRepo.CreatePhoneChange(ctx, repository.CreatePhoneChangeInput{
+		UserID:    input.UserID,
+		PhoneNo:   input.PhoneNo,
+		CodeHash:  codeHash,
+		ExpiresAt: output.ExpiresAt,
+	})
+	if err != nil {
+		return usecase.RequestPhoneChangeOutput{}, err
+	}
+
+	_, err = u.smsSender.SendSMS(ctx, sms.SendSMSInput{
+		PhoneNo: input.PhoneNo,
+		Message: fmt.Sprintf("Your code to use this phone number for your account is %s, valid for %d minutes. "+
+			"Never share this code with anyone.", code, int(u.loginOTPTtl.Minutes())),
+	})
+	if err != nil {
+		return usecase.RequestPhoneChangeOutput{}, err
+	}
+
+	return output, nil
+}
+
+// checkPhoneNoAvailable will return UserConflictError when the phone number belongs to another user, or is
+// reserved to another user after a phone number change
+func (u *userUsecases) checkPhoneNoAvailable(ctx context.Context, userID uint64, phoneNo string) error {
+	if _, err := u.userRepo.GetUser(ctx, repository.GetUserInput{PhoneNo: phoneNo}); err == nil {
+		return usecase.UserConflictError
+	} else if !errors.Is(err, repository.ErrorRecordNotFound) {
+		return err
+	}
+
+	reservation, err := u.phoneChangeRepo.GetPhoneNoReservation(ctx, repository.GetPhoneNoReservationInput{PhoneNo: phoneNo})
+	if err == nil {
+		// users can take back their own previous phone number
+		if reservation.UserID != userID {
+			return usecase.UserConflictError
+		}
+		return nil
+	} else if !errors.Is(err, repository.ErrorRecordNotFound) {
+		return err
+	}
+	return nil
+}
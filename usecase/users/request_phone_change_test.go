@@ -0,0 +1,252 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/sms"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type RequestPhoneChangeTestSuite struct {
+	suite.Suite
+
+	gomock          *gomock.Controller
+	repo            *repository.MockUserRepository
+	phoneChangeRepo *repository.MockPhoneChangeRepository
+	smsSender       *sms.MockSender
+
+	usecase usecase.UserUsecases
+
+	getUserOutput repository.GetUserOutput
+	sendSMSInput  sms.SendSMSInput
+
+	input usecase.RequestPhoneChangeInput
+
+	ctx                          context.Context
+	mockErr                      error
+	originalGenerateLoginOTPCode func() (string, error)
+	originalGenerateBcryptHash   func(password string) ([]byte, error)
+}
+
+func TestRequestPhoneChangeTestSuite(t *testing.T) {
+	suite.Run(t, new(RequestPhoneChangeTestSuite))
+}
+
+func (s *RequestPhoneChangeTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.phoneChangeRepo = repository.NewMockPhoneChangeRepository(s.gomock)
+	s.smsSender = sms.NewMockSender(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		UserRepo:        s.repo,
+		PhoneChangeRepo: s.phoneChangeRepo,
+		SMSSender:       s.smsSender,
+		Auth:            config.Auth{LoginOTPTtl: time.Minute * 5},
+	})
+
+	s.getUserOutput = repository.GetUserOutput{ID: 123, PhoneNo: "+62812151833", Status: "active"}
+	s.sendSMSInput = sms.SendSMSInput{
+		PhoneNo: "+6581234567",
+		Message: "Your code to use this phone number for your account is 042917, valid for 5 minutes. " +
+			"Never share this code with anyone.",
+	}
+
+	s.input = usecase.RequestPhoneChangeInput{UserID: 123, PhoneNo: "+65 8123 4567"}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+
+	s.originalGenerateLoginOTPCode = generateLoginOTPCode
+	s.originalGenerateBcryptHash = generateBcryptHash
+	generateLoginOTPCode = func() (string, error) { return "042917", nil }
+	generateBcryptHash = func(password string) ([]byte, error) { return []byte("hash:" + password), nil }
+}
+
+func (s *RequestPhoneChangeTestSuite) TearDownTest() {
+	generateLoginOTPCode = s.originalGenerateLoginOTPCode
+	generateBcryptHash = s.originalGenerateBcryptHash
+	s.gomock.Finish()
+}
+
+// expectAvailable will expect the new phone number to be neither taken nor reserved
+func (s *RequestPhoneChangeTestSuite) expectAvailable() {
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{PhoneNo: "+6581234567"}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+	s.phoneChangeRepo.EXPECT().GetPhoneNoReservation(s.ctx, repository.GetPhoneNoReservationInput{PhoneNo: "+6581234567"}).
+		Return(repository.GetPhoneNoReservationOutput{}, repository.ErrorRecordNotFound)
+}
+
+// expectCreatePhoneChange will expect the change to be stored, with the expiry according to the configured TTL
+func (s *RequestPhoneChangeTestSuite) expectCreatePhoneChange(err error) {
+	s.phoneChangeRepo.EXPECT().CreatePhoneChange(s.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input repository.CreatePhoneChangeInput) (repository.CreatePhoneChangeOutput, error) {
+			s.Equal(uint64(123), input.UserID)
+			s.Equal("+6581234567", input.PhoneNo)
+			s.Equal([]byte("hash:042917"), input.CodeHash)
+			s.WithinDuration(time.Now().Add(time.Minute*5), input.ExpiresAt, time.Minute)
+			if err != nil {
+				return repository.CreatePhoneChangeOutput{}, err
+			}
+			return repository.CreatePhoneChangeOutput{ID: 42}, nil
+		})
+}
+
+func (s *RequestPhoneChangeTestSuite) TestInvalidPhoneNo() {
+	a := assert.New(s.T())
+
+	s.input.PhoneNo = "0812151833"
+
+	out, err := s.usecase.RequestPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	var validationError usecase.ValidationErrors
+	a.True(errors.As(err, &validationError))
+	a.Equal("phone_no must start with the country calling code, e.g. +62", validationError.GetErrors()["phone_no"][0].Error())
+}
+
+func (s *RequestPhoneChangeTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.RequestPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *RequestPhoneChangeTestSuite) TestUserSuspended() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "suspended"
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.RequestPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserSuspended)
+}
+
+func (s *RequestPhoneChangeTestSuite) TestSamePhoneNo() {
+	a := assert.New(s.T())
+
+	s.input.PhoneNo = "+62 812-151-833"
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.RequestPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	var validationError usecase.ValidationErrors
+	a.True(errors.As(err, &validationError))
+	a.Equal("phone_no must be different from the current phone number", validationError.GetErrors()["phone_no"][0].Error())
+}
+
+func (s *RequestPhoneChangeTestSuite) TestPhoneNoTaken() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{PhoneNo: "+6581234567"}).Return(repository.GetUserOutput{ID: 456}, nil)
+
+	out, err := s.usecase.RequestPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserConflictError)
+}
+
+func (s *RequestPhoneChangeTestSuite) TestPhoneNoReserved() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{PhoneNo: "+6581234567"}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+	s.phoneChangeRepo.EXPECT().GetPhoneNoReservation(s.ctx, gomock.Any()).
+		Return(repository.GetPhoneNoReservationOutput{UserID: 456, ReservedUntil: time.Now().Add(time.Hour)}, nil)
+
+	out, err := s.usecase.RequestPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserConflictError)
+}
+
+func (s *RequestPhoneChangeTestSuite) TestThrottled() {
+	a := assert.New(s.T())
+
+	s.expectAvailable()
+	s.phoneChangeRepo.EXPECT().CountPhoneChanges(s.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input repository.CountPhoneChangesInput) (repository.CountPhoneChangesOutput, error) {
+			a.Equal(uint64(123), input.UserID)
+			a.WithinDuration(time.Now().Add(-time.Hour), input.CreatedAfter, time.Minute)
+			return repository.CountPhoneChangesOutput{Count: 3}, nil
+		})
+
+	out, err := s.usecase.RequestPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserPhoneChangeThrottled)
+}
+
+func (s *RequestPhoneChangeTestSuite) TestCreatePhoneChangeError() {
+	a := assert.New(s.T())
+
+	s.expectAvailable()
+	s.phoneChangeRepo.EXPECT().CountPhoneChanges(s.ctx, gomock.Any()).Return(repository.CountPhoneChangesOutput{}, nil)
+	s.expectCreatePhoneChange(s.mockErr)
+
+	out, err := s.usecase.RequestPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *RequestPhoneChangeTestSuite) TestSendSMSError() {
+	a := assert.New(s.T())
+
+	s.expectAvailable()
+	s.phoneChangeRepo.EXPECT().CountPhoneChanges(s.ctx, gomock.Any()).Return(repository.CountPhoneChangesOutput{}, nil)
+	s.expectCreatePhoneChange(nil)
+	s.smsSender.EXPECT().SendSMS(s.ctx, s.sendSMSInput).Return(sms.SendSMSOutput{}, s.mockErr)
+
+	out, err := s.usecase.RequestPhoneChange(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *RequestPhoneChangeTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.expectAvailable()
+	s.phoneChangeRepo.EXPECT().CountPhoneChanges(s.ctx, gomock.Any()).Return(repository.CountPhoneChangesOutput{Count: 2}, nil)
+	s.expectCreatePhoneChange(nil)
+	s.smsSender.EXPECT().SendSMS(s.ctx, s.sendSMSInput).Return(sms.SendSMSOutput{MessageID: "sms-1"}, nil)
+
+	out, err := s.usecase.RequestPhoneChange(s.ctx, s.input)
+
+	a.Empty(err)
+	a.WithinDuration(time.Now().Add(time.Minute*5), out.ExpiresAt, time.Minute)
+}
+
+func (s *RequestPhoneChangeTestSuite) TestSuccessOwnReservedPhoneNo() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{PhoneNo: "+6581234567"}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+	s.phoneChangeRepo.EXPECT().GetPhoneNoReservation(s.ctx, gomock.Any()).
+		Return(repository.GetPhoneNoReservationOutput{UserID: 123, ReservedUntil: time.Now().Add(time.Hour)}, nil)
+	s.phoneChangeRepo.EXPECT().CountPhoneChanges(s.ctx, gomock.Any()).Return(repository.CountPhoneChangesOutput{}, nil)
+	s.expectCreatePhoneChange(nil)
+	s.smsSender.EXPECT().SendSMS(s.ctx, s.sendSMSInput).Return(sms.SendSMSOutput{MessageID: "sms-1"}, nil)
+
+	out, err := s.usecase.RequestPhoneChange(s.ctx, s.input)
+
+	a.Empty(err)
+	a.NotEmpty(out.ExpiresAt)
+}
@@ -0,0 +1,30 @@
+package users
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+)
+
+func (u *userUsecases) RequestUserExport(ctx context.Context, input usecase.RequestUserExportInput) (output usecase.RequestUserExportOutput, err error) {
+	ctx, span := startSpan(ctx, "RequestUserExport")
+	defer func() { endSpan(span, err) }()
+
+	if input.Format == "" {
+		input.Format = usecase.UserExportFormatJSON
+	}
+	if input.Format != usecase.UserExportFormatJSON && input.Format != usecase.UserExportFormatZip {
+		err = usecase.NewValidationError(map[string][]error{
+			"format": {usecase.NewFieldError("format.unsupported", "validation.export_format", i18n.Params{"json": usecase.UserExportFormatJSON, "zip": usecase.UserExportFormatZip})},
+		})
+		return
+	}
+
+	var resp repository.CreateUserExportOutput
+	if resp, err = u.userExportRepo.CreateUserExport(ctx, repository.CreateUserExportInput{UserID: input.UserID, Format: input.Format}); err != nil {
+		return
+	}
+
+	return usecase.RequestUserExportOutput{ExportID: resp.ID, Status: usecase.UserExportStatusPending, CreatedAt: resp.CreatedAt}, nil
+}
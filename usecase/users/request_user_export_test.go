@@ -0,0 +1,76 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type RequestUserExportTestSuite struct {
+	suite.Suite
+
+	gomock     *gomock.Controller
+	exportRepo *repository.MockUserExportRepository
+
+	usecase usecase.UserUsecases
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestRequestUserExportTestSuite(t *testing.T) {
+	suite.Run(t, new(RequestUserExportTestSuite))
+}
+
+func (s *RequestUserExportTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.exportRepo = repository.NewMockUserExportRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserExportRepo: s.exportRepo})
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *RequestUserExportTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *RequestUserExportTestSuite) TestInvalidFormat() {
+	a := assert.New(s.T())
+
+	out, err := s.usecase.RequestUserExport(s.ctx, usecase.RequestUserExportInput{UserID: 123, Format: "xml"})
+
+	a.Empty(out)
+	a.ErrorContains(err, `format must be either "json" or "zip"`)
+}
+
+func (s *RequestUserExportTestSuite) TestRepositoryError() {
+	a := assert.New(s.T())
+
+	s.exportRepo.EXPECT().CreateUserExport(s.ctx, repository.CreateUserExportInput{UserID: 123, Format: "zip"}).
+		Return(repository.CreateUserExportOutput{}, s.mockErr)
+
+	out, err := s.usecase.RequestUserExport(s.ctx, usecase.RequestUserExportInput{UserID: 123, Format: "zip"})
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *RequestUserExportTestSuite) TestSuccessDefaultFormat() {
+	a := assert.New(s.T())
+
+	s.exportRepo.EXPECT().CreateUserExport(s.ctx, repository.CreateUserExportInput{UserID: 123, Format: "json"}).
+		Return(repository.CreateUserExportOutput{ID: 7, CreatedAt: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)}, nil)
+
+	out, err := s.usecase.RequestUserExport(s.ctx, usecase.RequestUserExportInput{UserID: 123})
+
+	a.Nil(err)
+	a.Equal(usecase.RequestUserExportOutput{ExportID: 7, Status: "pending", CreatedAt: time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)}, out)
+}
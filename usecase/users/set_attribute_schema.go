@@ -0,0 +1,48 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/attributes"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+)
+
+const (
+	auditActionSetAttributeSchema = "attributes.schema.set"
+)
+
+func (u *userUsecases) SetAttributeSchema(ctx context.Context, input usecase.SetAttributeSchemaInput) (output usecase.SetAttributeSchemaOutput, err error) {
+	ctx, span := startSpan(ctx, "SetAttributeSchema")
+	defer func() { endSpan(span, err) }()
+
+	// the schema is compiled before it's stored, so that an invalid schema never blocks every profile update
+	if _, err = attributes.Compile(input.Schema); err != nil {
+		err = usecase.NewValidationError(map[string][]error{"schema": {fmt.Errorf(`schema %w`, err)}})
+		return
+	}
+
+	var resp repository.CreateAttributeSchemaOutput
+	resp, err = u.attrSchemaRepo.CreateAttributeSchema(ctx, repository.CreateAttributeSchemaInput{
+		Schema:    input.Schema,
+		CreatedBy: input.ActorUserID,
+	})
+	if err != nil {
+		return
+	}
+
+	_, err = u.auditRepo.CreateAuditEvent(ctx, repository.CreateAuditEventInput{
+		ActorUserID: input.ActorUserID,
+		Action:      auditActionSetAttributeSchema,
+		Details:     map[string]interface{}{"schema_id": resp.ID},
+	})
+	if err != nil {
+		return
+	}
+
+	return usecase.SetAttributeSchemaOutput{
+		Schema:    input.Schema,
+		UpdatedBy: input.ActorUserID,
+		UpdatedAt: resp.CreatedAt,
+	}, nil
+}
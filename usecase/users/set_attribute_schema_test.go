@@ -0,0 +1,108 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type SetAttributeSchemaTestSuite struct {
+	suite.Suite
+
+	gomock         *gomock.Controller
+	attrSchemaRepo *repository.MockAttributeSchemaRepository
+	auditRepo      *repository.MockAuditRepository
+
+	usecase usecase.UserUsecases
+
+	input     usecase.SetAttributeSchemaInput
+	createdAt time.Time
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestSetAttributeSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(SetAttributeSchemaTestSuite))
+}
+
+func (s *SetAttributeSchemaTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.attrSchemaRepo = repository.NewMockAttributeSchemaRepository(s.gomock)
+	s.auditRepo = repository.NewMockAuditRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{AttrSchemaRepo: s.attrSchemaRepo, AuditRepo: s.auditRepo})
+
+	s.input = usecase.SetAttributeSchemaInput{
+		ActorUserID: 1,
+		Schema:      json.RawMessage(`{"type": "object", "properties": {"estate_code": {"type": "string"}}}`),
+	}
+	s.createdAt = time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *SetAttributeSchemaTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *SetAttributeSchemaTestSuite) TestInvalidSchema() {
+	a := assert.New(s.T())
+
+	for _, schema := range []string{`{"type": "object", "properties": {"x": {"type": "text"}}}`, `{"type": "array"}`} {
+		s.input.Schema = json.RawMessage(schema)
+		out, err := s.usecase.SetAttributeSchema(s.ctx, s.input)
+
+		a.Empty(out)
+		a.IsType(usecase.ValidationErrors{}, err)
+		a.Len(err.(usecase.ValidationErrors).GetErrors()["schema"], 1)
+	}
+}
+
+func (s *SetAttributeSchemaTestSuite) TestRepositoryError() {
+	a := assert.New(s.T())
+
+	s.attrSchemaRepo.EXPECT().CreateAttributeSchema(s.ctx, gomock.Any()).Return(repository.CreateAttributeSchemaOutput{}, s.mockErr)
+
+	out, err := s.usecase.SetAttributeSchema(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *SetAttributeSchemaTestSuite) TestAuditError() {
+	a := assert.New(s.T())
+
+	s.attrSchemaRepo.EXPECT().CreateAttributeSchema(s.ctx, gomock.Any()).Return(repository.CreateAttributeSchemaOutput{ID: 3}, nil)
+	s.auditRepo.EXPECT().CreateAuditEvent(s.ctx, gomock.Any()).Return(repository.CreateAuditEventOutput{}, s.mockErr)
+
+	out, err := s.usecase.SetAttributeSchema(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *SetAttributeSchemaTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.attrSchemaRepo.EXPECT().CreateAttributeSchema(s.ctx, repository.CreateAttributeSchemaInput{Schema: s.input.Schema, CreatedBy: 1}).
+		Return(repository.CreateAttributeSchemaOutput{ID: 3, CreatedAt: s.createdAt}, nil)
+	s.auditRepo.EXPECT().CreateAuditEvent(s.ctx, repository.CreateAuditEventInput{
+		ActorUserID: 1,
+		Action:      "attributes.schema.set",
+		Details:     map[string]interface{}{"schema_id": uint64(3)},
+	}).Return(repository.CreateAuditEventOutput{ID: 1}, nil)
+
+	out, err := s.usecase.SetAttributeSchema(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(usecase.SetAttributeSchemaOutput{Schema: s.input.Schema, UpdatedBy: 1, UpdatedAt: s.createdAt}, out)
+}
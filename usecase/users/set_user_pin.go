@@ -0,0 +1,48 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+)
+
+func (u *userUsecases) SetUserPIN(ctx context.Context, input usecase.SetUserPINInput) (output usecase.SetUserPINOutput, err error) {
+	ctx, span := startSpan(ctx, "SetUserPIN")
+	defer func() { endSpan(span, err) }()
+
+	var usr repository.GetUserOutput
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+	if err = checkUserStatus(usr.Status); err != nil {
+		return
+	}
+
+	if err = compareBcryptHash(usr.PasswordHash, input.Password); err != nil {
+		err = usecase.UserInvalidPassword
+		return
+	}
+
+	if errs := validateUserPIN(input.PIN, usr.PhoneNo); len(errs) > 0 {
+		err = usecase.NewValidationError(map[string][]error{"pin": errs})
+		return
+	}
+
+	var pinHash []byte
+	if pinHash, err = generateBcryptHash(input.PIN); err != nil {
+		return
+	}
+
+	if _, err = u.userRepo.SetUserPIN(ctx, repository.SetUserPINInput{ID: usr.ID, PinHash: pinHash}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+
+	return output, nil
+}
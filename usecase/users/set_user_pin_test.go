@@ -0,0 +1,136 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+)
+
+type SetUserPINTestSuite struct {
+	suite.Suite
+
+	gomock *gomock.Controller
+	repo   *repository.MockUserRepository
+
+	usecase usecase.UserUsecases
+
+	getUserInput  repository.GetUserInput
+	getUserOutput repository.GetUserOutput
+
+	input usecase.SetUserPINInput
+
+	ctx     context.Context
+	mockErr error
+
+	originalGenerateBcryptHash func(string) ([]byte, error)
+}
+
+func TestSetUserPINTestSuite(t *testing.T) {
+	suite.Run(t, new(SetUserPINTestSuite))
+}
+
+func (s *SetUserPINTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo})
+
+	passwdHash, _ := bcrypt.GenerateFromPassword([]byte("SomeVal1dPassw@rd"), bcrypt.MinCost)
+	s.getUserInput = repository.GetUserInput{ID: 123}
+	s.getUserOutput = repository.GetUserOutput{ID: 123, PhoneNo: "+62812151833", PasswordHash: passwdHash, Status: "active"}
+
+	s.input = usecase.SetUserPINInput{UserID: 123, Password: "SomeVal1dPassw@rd", PIN: "274916"}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+
+	s.originalGenerateBcryptHash = generateBcryptHash
+	generateBcryptHash = func(password string) ([]byte, error) { return []byte("hashed-" + password), nil }
+}
+
+func (s *SetUserPINTestSuite) TearDownTest() {
+	generateBcryptHash = s.originalGenerateBcryptHash
+	s.gomock.Finish()
+}
+
+func (s *SetUserPINTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.SetUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *SetUserPINTestSuite) TestUserSuspended() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "suspended"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.SetUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserSuspended)
+}
+
+func (s *SetUserPINTestSuite) TestInvalidPassword() {
+	a := assert.New(s.T())
+
+	s.input.Password = "invalid-password"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.SetUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidPassword)
+}
+
+func (s *SetUserPINTestSuite) TestInvalidPIN() {
+	a := assert.New(s.T())
+
+	for _, pin := range []string{"", "12345", "1234567", "12a456", "+12345", "000000", "123456", "890123", "654321", "151833"} {
+		s.input.PIN = pin
+		s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+		out, err := s.usecase.SetUserPIN(s.ctx, s.input)
+
+		a.Empty(out)
+		a.IsType(usecase.ValidationErrors{}, err, pin)
+		a.Contains(err.(usecase.ValidationErrors).GetErrors(), "pin", pin)
+	}
+}
+
+func (s *SetUserPINTestSuite) TestRepositoryError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().SetUserPIN(s.ctx, repository.SetUserPINInput{ID: 123, PinHash: []byte("hashed-274916")}).
+		Return(repository.SetUserPINOutput{}, s.mockErr)
+
+	out, err := s.usecase.SetUserPIN(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *SetUserPINTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().SetUserPIN(s.ctx, repository.SetUserPINInput{ID: 123, PinHash: []byte("hashed-274916")}).
+		Return(repository.SetUserPINOutput{}, nil)
+
+	out, err := s.usecase.SetUserPIN(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Empty(out)
+}
@@ -0,0 +1,81 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"sort"
+)
+
+const (
+	auditActionSetUserRoles = "user.roles.set"
+)
+
+func (u *userUsecases) SetUserRoles(ctx context.Context, input usecase.SetUserRolesInput) (output usecase.SetUserRolesOutput, err error) {
+	ctx, span := startSpan(ctx, "SetUserRoles")
+	defer func() { endSpan(span, err) }()
+
+	roles, validationErrors := normalizeUserRoles(input.Roles)
+	if len(validationErrors) > 0 {
+		err = usecase.NewValidationError(map[string][]error{"roles": validationErrors})
+		return
+	}
+
+	if _, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+
+	var oldRoles repository.GetUserRolesOutput
+	if oldRoles, err = u.userRepo.GetUserRoles(ctx, repository.GetUserRolesInput{UserID: input.UserID}); err != nil {
+		return
+	}
+
+	_, err = u.userRepo.SetUserRoles(ctx, repository.SetUserRolesInput{
+		UserID: input.UserID,
+		Roles:  roles,
+		AuditEvent: repository.CreateAuditEventInput{
+			ActorUserID:  input.ActorUserID,
+			Action:       auditActionSetUserRoles,
+			TargetUserID: input.UserID,
+			Details: map[string]interface{}{
+				"old_roles": oldRoles.Roles,
+				"new_roles": roles,
+			},
+		},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+
+	return usecase.SetUserRolesOutput{UserID: input.UserID, Roles: roles}, nil
+}
+
+// normalizeUserRoles will validate that every role is known, and return the roles sorted without duplicates
+func normalizeUserRoles(roles []string) (res []string, errs []error) {
+	known := map[string]bool{}
+	for _, role := range usecase.Roles {
+		known[role] = true
+	}
+
+	seen := map[string]bool{}
+	for _, role := range roles {
+		if !known[role] {
+			errs = append(errs, usecase.NewFieldError("roles.unknown", "validation.unknown_role", i18n.Params{"role": role}))
+			continue
+		}
+		if !seen[role] {
+			seen[role] = true
+			res = append(res, role)
+		}
+	}
+	sort.Strings(res)
+	return res, errs
+}
@@ -0,0 +1,119 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type SetUserRolesTestSuite struct {
+	suite.Suite
+
+	gomock *gomock.Controller
+	repo   *repository.MockUserRepository
+
+	usecase usecase.UserUsecases
+
+	getUserInput       repository.GetUserInput
+	getUserRolesInput  repository.GetUserRolesInput
+	getUserRolesOutput repository.GetUserRolesOutput
+	setUserRolesInput  repository.SetUserRolesInput
+
+	input  usecase.SetUserRolesInput
+	output usecase.SetUserRolesOutput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestSetUserRolesTestSuite(t *testing.T) {
+	suite.Run(t, new(SetUserRolesTestSuite))
+}
+
+func (s *SetUserRolesTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo})
+
+	s.getUserInput = repository.GetUserInput{ID: 123}
+	s.getUserRolesInput = repository.GetUserRolesInput{UserID: 123}
+	s.getUserRolesOutput = repository.GetUserRolesOutput{Roles: []string{"farmer"}}
+	s.setUserRolesInput = repository.SetUserRolesInput{
+		UserID: 123,
+		Roles:  []string{"estate_manager", "farmer"},
+		AuditEvent: repository.CreateAuditEventInput{
+			ActorUserID:  1,
+			Action:       "user.roles.set",
+			TargetUserID: 123,
+			Details: map[string]interface{}{
+				"old_roles": []string{"farmer"},
+				"new_roles": []string{"estate_manager", "farmer"},
+			},
+		},
+	}
+
+	s.input = usecase.SetUserRolesInput{ActorUserID: 1, UserID: 123, Roles: []string{"farmer", "estate_manager", "farmer"}}
+	s.output = usecase.SetUserRolesOutput{UserID: 123, Roles: []string{"estate_manager", "farmer"}}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *SetUserRolesTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *SetUserRolesTestSuite) TestUnknownRole() {
+	a := assert.New(s.T())
+
+	s.input.Roles = []string{"farmer", "superuser"}
+
+	out, err := s.usecase.SetUserRoles(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorContains(err, `unknown role "superuser"`)
+	a.IsType(usecase.ValidationErrors{}, err)
+}
+
+func (s *SetUserRolesTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.SetUserRoles(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *SetUserRolesTestSuite) TestSetRolesError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{ID: 123}, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+	s.repo.EXPECT().SetUserRoles(s.ctx, s.setUserRolesInput).Return(repository.SetUserRolesOutput{}, s.mockErr)
+
+	out, err := s.usecase.SetUserRoles(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *SetUserRolesTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{ID: 123}, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+	s.repo.EXPECT().SetUserRoles(s.ctx, s.setUserRolesInput).Return(repository.SetUserRolesOutput{}, nil)
+
+	out, err := s.usecase.SetUserRoles(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
@@ -0,0 +1,104 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang-jwt/jwt/v5"
+	"strings"
+	"time"
+)
+
+const (
+	auditActionStartImpersonation = "user.impersonation.started"
+
+	maxImpersonationReasonLength = 512
+)
+
+// impersonationScopes are granted to impersonation tokens, impersonating admins can only look at what the user sees
+var impersonationScopes = []string{usecase.ScopeProfileRead}
+
+func (u *userUsecases) StartImpersonation(ctx context.Context, input usecase.StartImpersonationInput) (output usecase.StartImpersonationOutput, err error) {
+	ctx, span := startSpan(ctx, "StartImpersonation")
+	defer func() { endSpan(span, err) }()
+
+	input.Reason = strings.TrimSpace(input.Reason)
+	if validationErrors := validateStartImpersonationPayload(input); len(validationErrors) > 0 {
+		err = usecase.NewValidationError(validationErrors)
+		return
+	}
+
+	var usr repository.GetUserOutput
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+	if usr.Status != usecase.UserStatusActive {
+		err = usecase.UserStatusConflict
+		return
+	}
+
+	var roles repository.GetUserRolesOutput
+	if roles, err = u.userRepo.GetUserRoles(ctx, repository.GetUserRolesInput{UserID: usr.ID}); err != nil {
+		return
+	}
+	// admins can see everything through the admin APIs already, impersonating them would only hide who did what
+	if (usecase.Principal{Roles: roles.Roles}).HasAnyRole(usecase.RoleAdmin) {
+		err = usecase.UserForbidden
+		return
+	}
+
+	var tokenID string
+	if tokenID, err = generateTokenID(); err != nil {
+		return
+	}
+
+	now := time.Now()
+	output.ExpiresAt = now.Add(u.impersonationTtl)
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":   fmt.Sprintf("%d", usr.ID),
+		"jti":   tokenID,
+		"roles": roles.Roles,
+		"scope": strings.Join(impersonationScopes, " "),
+		"iat":   now.Unix(),
+		"exp":   output.ExpiresAt.Unix(),
+		"act":   map[string]interface{}{"sub": fmt.Sprintf("%d", input.ActorUserID)},
+	})
+	if output.JwtToken, err = token.SignedString(u.jwtSecret); err != nil {
+		return
+	}
+
+	_, err = u.auditRepo.CreateAuditEvent(ctx, repository.CreateAuditEventInput{
+		ActorUserID:  input.ActorUserID,
+		Action:       auditActionStartImpersonation,
+		TargetUserID: usr.ID,
+		Details: map[string]interface{}{
+			"token_id":   tokenID,
+			"reason":     input.Reason,
+			"expires_at": output.ExpiresAt.UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return usecase.StartImpersonationOutput{}, err
+	}
+
+	return output, nil
+}
+
+func validateStartImpersonationPayload(input usecase.StartImpersonationInput) map[string][]error {
+	validationErrors := map[string][]error{}
+	if input.Reason == "" {
+		validationErrors["reason"] = []error{usecase.NewFieldError("reason.required", "validation.required", i18n.Params{"field": "reason"})}
+	} else if len(input.Reason) > maxImpersonationReasonLength {
+		validationErrors["reason"] = []error{usecase.NewFieldError("reason.too_long", "validation.max_length", i18n.Params{"field": "reason", "max": maxImpersonationReasonLength})}
+	}
+	if input.ActorUserID == input.UserID {
+		validationErrors["user_id"] = []error{usecase.NewFieldError("user_id.self", "validation.impersonate_self", nil)}
+	}
+	return validationErrors
+}
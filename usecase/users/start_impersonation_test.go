@@ -0,0 +1,201 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+	"time"
+)
+
+type StartImpersonationTestSuite struct {
+	suite.Suite
+
+	gomock    *gomock.Controller
+	repo      *repository.MockUserRepository
+	auditRepo *repository.MockAuditRepository
+
+	jwtSecret *rsa.PrivateKey
+	usecase   usecase.UserUsecases
+
+	getUserInput       repository.GetUserInput
+	getUserOutput      repository.GetUserOutput
+	getUserRolesInput  repository.GetUserRolesInput
+	getUserRolesOutput repository.GetUserRolesOutput
+
+	input usecase.StartImpersonationInput
+
+	ctx                     context.Context
+	mockErr                 error
+	originalGenerateTokenID func() (string, error)
+}
+
+func TestStartImpersonationTestSuite(t *testing.T) {
+	suite.Run(t, new(StartImpersonationTestSuite))
+}
+
+func (s *StartImpersonationTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.auditRepo = repository.NewMockAuditRepository(s.gomock)
+
+	s.jwtSecret, _ = rsa.GenerateKey(rand.Reader, 1024)
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		UserRepo:  s.repo,
+		AuditRepo: s.auditRepo,
+		JwtSecret: s.jwtSecret,
+		Auth:      config.Auth{ImpersonationTtl: time.Minute * 10},
+	})
+
+	s.getUserInput = repository.GetUserInput{ID: 123}
+	s.getUserOutput = repository.GetUserOutput{ID: 123, Status: "active"}
+	s.getUserRolesInput = repository.GetUserRolesInput{UserID: 123}
+	s.getUserRolesOutput = repository.GetUserRolesOutput{Roles: []string{"farmer"}}
+
+	s.input = usecase.StartImpersonationInput{ActorUserID: 1, UserID: 123, Reason: " support ticket #42 "}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+	s.originalGenerateTokenID = generateTokenID
+	generateTokenID = func() (string, error) { return "token-id", nil }
+}
+
+func (s *StartImpersonationTestSuite) TearDownTest() {
+	generateTokenID = s.originalGenerateTokenID
+	s.gomock.Finish()
+}
+
+func (s *StartImpersonationTestSuite) TestInvalidPayload() {
+	a := assert.New(s.T())
+
+	s.input.UserID = 1
+	s.input.Reason = strings.Repeat("x", 513)
+
+	out, err := s.usecase.StartImpersonation(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorContains(err, "reason must not exceed 512 characters")
+	a.ErrorContains(err, "you can't impersonate your own account")
+}
+
+func (s *StartImpersonationTestSuite) TestEmptyReason() {
+	a := assert.New(s.T())
+
+	s.input.Reason = "  "
+
+	out, err := s.usecase.StartImpersonation(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorContains(err, "reason must not be empty")
+}
+
+func (s *StartImpersonationTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.StartImpersonation(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *StartImpersonationTestSuite) TestUserNotActive() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "suspended"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.StartImpersonation(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserStatusConflict)
+}
+
+func (s *StartImpersonationTestSuite) TestGetUserRolesError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(repository.GetUserRolesOutput{}, s.mockErr)
+
+	out, err := s.usecase.StartImpersonation(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *StartImpersonationTestSuite) TestImpersonateAdmin() {
+	a := assert.New(s.T())
+
+	s.getUserRolesOutput.Roles = []string{"admin"}
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+
+	out, err := s.usecase.StartImpersonation(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserForbidden)
+}
+
+func (s *StartImpersonationTestSuite) TestCreateAuditEventError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+	s.auditRepo.EXPECT().CreateAuditEvent(s.ctx, gomock.Any()).Return(repository.CreateAuditEventOutput{}, s.mockErr)
+
+	out, err := s.usecase.StartImpersonation(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *StartImpersonationTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+	var auditInput repository.CreateAuditEventInput
+	s.auditRepo.EXPECT().CreateAuditEvent(s.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input repository.CreateAuditEventInput) (repository.CreateAuditEventOutput, error) {
+			auditInput = input
+			return repository.CreateAuditEventOutput{ID: 42}, nil
+		})
+
+	out, err := s.usecase.StartImpersonation(s.ctx, s.input)
+
+	a.Empty(err)
+	a.WithinDuration(time.Now().Add(time.Minute*10), out.ExpiresAt, time.Minute)
+
+	parsedToken, err := jwt.Parse(out.JwtToken, func(token *jwt.Token) (interface{}, error) {
+		return &s.jwtSecret.PublicKey, nil
+	})
+	a.Empty(err)
+	claims := parsedToken.Claims.(jwt.MapClaims)
+	a.Equal("123", claims["sub"])
+	a.Equal("token-id", claims["jti"])
+	a.Equal("profile:read", claims["scope"])
+	a.Equal([]interface{}{"farmer"}, claims["roles"])
+	a.Equal(map[string]interface{}{"sub": "1"}, claims["act"])
+	a.Equal(float64(out.ExpiresAt.Unix()), claims["exp"])
+
+	a.Equal(repository.CreateAuditEventInput{
+		ActorUserID:  1,
+		Action:       "user.impersonation.started",
+		TargetUserID: 123,
+		Details: map[string]interface{}{
+			"token_id":   "token-id",
+			"reason":     "support ticket #42",
+			"expires_at": out.ExpiresAt.UTC().Format(time.RFC3339),
+		},
+	}, auditInput)
+}
@@ -0,0 +1,46 @@
+package users
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+)
+
+const (
+	auditActionStopImpersonation = "user.impersonation.stopped"
+)
+
+func (u *userUsecases) StopImpersonation(ctx context.Context, input usecase.StopImpersonationInput) (output usecase.StopImpersonationOutput, err error) {
+	ctx, span := startSpan(ctx, "StopImpersonation")
+	defer func() { endSpan(span, err) }()
+
+	var claims userTokenClaims
+	if claims, err = u.parseUserToken(input.JwtToken); err != nil {
+		return
+	}
+	if claims.ActorUserID == 0 {
+		err = usecase.UserNotImpersonating
+		return
+	}
+
+	_, err = u.tokenRepo.CreateTokenRevocation(ctx, repository.CreateTokenRevocationInput{
+		TokenID:   claims.TokenID,
+		UserID:    claims.UserID,
+		ExpiresAt: claims.ExpiresAt,
+	})
+	if err != nil {
+		return
+	}
+
+	_, err = u.auditRepo.CreateAuditEvent(ctx, repository.CreateAuditEventInput{
+		ActorUserID:  claims.ActorUserID,
+		Action:       auditActionStopImpersonation,
+		TargetUserID: claims.UserID,
+		Details:      map[string]interface{}{"token_id": claims.TokenID},
+	})
+	if err != nil {
+		return
+	}
+
+	return output, nil
+}
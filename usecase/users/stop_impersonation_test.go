@@ -0,0 +1,140 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type StopImpersonationTestSuite struct {
+	suite.Suite
+
+	gomock    *gomock.Controller
+	tokenRepo *repository.MockTokenRepository
+	auditRepo *repository.MockAuditRepository
+
+	jwtSecret *rsa.PrivateKey
+	usecase   usecase.UserUsecases
+
+	revocationInput repository.CreateTokenRevocationInput
+	auditInput      repository.CreateAuditEventInput
+
+	input usecase.StopImpersonationInput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestStopImpersonationTestSuite(t *testing.T) {
+	suite.Run(t, new(StopImpersonationTestSuite))
+}
+
+func (s *StopImpersonationTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.tokenRepo = repository.NewMockTokenRepository(s.gomock)
+	s.auditRepo = repository.NewMockAuditRepository(s.gomock)
+
+	s.jwtSecret, _ = rsa.GenerateKey(rand.Reader, 1024)
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		TokenRepo: s.tokenRepo,
+		AuditRepo: s.auditRepo,
+		JwtSecret: s.jwtSecret,
+	})
+
+	exp := time.Now().Add(time.Minute * 5).Truncate(time.Second)
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":   "123",
+		"jti":   "token-id",
+		"exp":   exp.Unix(),
+		"scope": "profile:read",
+		"act":   map[string]interface{}{"sub": "1"},
+	})
+	jwtToken, _ := token.SignedString(s.jwtSecret)
+	s.input = usecase.StopImpersonationInput{JwtToken: jwtToken}
+
+	s.revocationInput = repository.CreateTokenRevocationInput{TokenID: "token-id", UserID: 123, ExpiresAt: exp}
+	s.auditInput = repository.CreateAuditEventInput{
+		ActorUserID:  1,
+		Action:       "user.impersonation.stopped",
+		TargetUserID: 123,
+		Details:      map[string]interface{}{"token_id": "token-id"},
+	}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *StopImpersonationTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *StopImpersonationTestSuite) TestInvalidToken() {
+	a := assert.New(s.T())
+
+	s.input.JwtToken = "invalid"
+
+	out, err := s.usecase.StopImpersonation(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidToken)
+}
+
+func (s *StopImpersonationTestSuite) TestNotImpersonating() {
+	a := assert.New(s.T())
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "123",
+		"jti": "token-id",
+		"exp": time.Now().Add(time.Minute * 5).Unix(),
+	})
+	s.input.JwtToken, _ = token.SignedString(s.jwtSecret)
+
+	out, err := s.usecase.StopImpersonation(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotImpersonating)
+}
+
+func (s *StopImpersonationTestSuite) TestCreateTokenRevocationError() {
+	a := assert.New(s.T())
+
+	s.tokenRepo.EXPECT().CreateTokenRevocation(s.ctx, s.revocationInput).Return(repository.CreateTokenRevocationOutput{}, s.mockErr)
+
+	out, err := s.usecase.StopImpersonation(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *StopImpersonationTestSuite) TestCreateAuditEventError() {
+	a := assert.New(s.T())
+
+	s.tokenRepo.EXPECT().CreateTokenRevocation(s.ctx, s.revocationInput).Return(repository.CreateTokenRevocationOutput{}, nil)
+	s.auditRepo.EXPECT().CreateAuditEvent(s.ctx, s.auditInput).Return(repository.CreateAuditEventOutput{}, s.mockErr)
+
+	out, err := s.usecase.StopImpersonation(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *StopImpersonationTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.tokenRepo.EXPECT().CreateTokenRevocation(s.ctx, s.revocationInput).Return(repository.CreateTokenRevocationOutput{}, nil)
+	s.auditRepo.EXPECT().CreateAuditEvent(s.ctx, s.auditInput).Return(repository.CreateAuditEventOutput{ID: 42}, nil)
+
+	out, err := s.usecase.StopImpersonation(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Empty(out)
+}
@@ -0,0 +1,77 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"strings"
+)
+
+const (
+	auditActionSuspendUser = "user.suspended"
+
+	maxSuspendReasonLength = 512
+)
+
+func (u *userUsecases) SuspendUser(ctx context.Context, input usecase.SuspendUserInput) (output usecase.SuspendUserOutput, err error) {
+	ctx, span := startSpan(ctx, "SuspendUser")
+	defer func() { endSpan(span, err) }()
+
+	input.Reason = strings.TrimSpace(input.Reason)
+	if validationErrors := validateSuspendUserPayload(input); len(validationErrors) > 0 {
+		err = usecase.NewValidationError(validationErrors)
+		return
+	}
+
+	err = u.changeUserStatus(ctx, input.UserID, usecase.UserStatusActive, usecase.UserStatusSuspended, repository.CreateAuditEventInput{
+		ActorUserID:  input.ActorUserID,
+		Action:       auditActionSuspendUser,
+		TargetUserID: input.UserID,
+		Details:      map[string]interface{}{"reason": input.Reason},
+	})
+	if err != nil {
+		return
+	}
+
+	return usecase.SuspendUserOutput{UserID: input.UserID, Status: usecase.UserStatusSuspended}, nil
+}
+
+func validateSuspendUserPayload(input usecase.SuspendUserInput) map[string][]error {
+	validationErrors := map[string][]error{}
+	if input.Reason == "" {
+		validationErrors["reason"] = []error{usecase.NewFieldError("reason.required", "validation.required", i18n.Params{"field": "reason"})}
+	} else if len(input.Reason) > maxSuspendReasonLength {
+		validationErrors["reason"] = []error{usecase.NewFieldError("reason.too_long", "validation.max_length", i18n.Params{"field": "reason", "max": maxSuspendReasonLength})}
+	}
+	// locking yourself out would leave the admin unable to reactivate their own account
+	if input.ActorUserID == input.UserID {
+		validationErrors["user_id"] = []error{usecase.NewFieldError("user_id.self", "validation.suspend_self", nil)}
+	}
+	return validationErrors
+}
+
+// changeUserStatus will move the user account status, only when the account is currently in the expected status,
+// and record the audit event along with it
+func (u *userUsecases) changeUserStatus(ctx context.Context, userID uint64, from, to string, event repository.CreateAuditEventInput) (err error) {
+	var usr repository.GetUserOutput
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: userID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+	if usr.Status != from {
+		return usecase.UserStatusConflict
+	}
+
+	// the status is compared again on update, in case it changed concurrently
+	if _, err = u.userRepo.SetUserStatus(ctx, repository.SetUserStatusInput{ID: userID, From: from, To: to, AuditEvent: event}); err != nil {
+		if errors.Is(err, repository.ErrorRecordOutdated) {
+			err = usecase.UserStatusConflict
+		}
+		return
+	}
+	return nil
+}
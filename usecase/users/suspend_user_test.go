@@ -0,0 +1,150 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type SuspendUserTestSuite struct {
+	suite.Suite
+
+	gomock *gomock.Controller
+	repo   *repository.MockUserRepository
+
+	usecase usecase.UserUsecases
+
+	getUserInput       repository.GetUserInput
+	getUserOutput      repository.GetUserOutput
+	setUserStatusInput repository.SetUserStatusInput
+
+	input  usecase.SuspendUserInput
+	output usecase.SuspendUserOutput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestSuspendUserTestSuite(t *testing.T) {
+	suite.Run(t, new(SuspendUserTestSuite))
+}
+
+func (s *SuspendUserTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo})
+
+	s.getUserInput = repository.GetUserInput{ID: 123}
+	s.getUserOutput = repository.GetUserOutput{ID: 123, Status: "active"}
+	s.setUserStatusInput = repository.SetUserStatusInput{
+		ID:   123,
+		From: "active",
+		To:   "suspended",
+		AuditEvent: repository.CreateAuditEventInput{
+			ActorUserID:  1,
+			Action:       "user.suspended",
+			TargetUserID: 123,
+			Details:      map[string]interface{}{"reason": "fraudulent activity"},
+		},
+	}
+
+	s.input = usecase.SuspendUserInput{ActorUserID: 1, UserID: 123, Reason: " fraudulent activity "}
+	s.output = usecase.SuspendUserOutput{UserID: 123, Status: "suspended"}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *SuspendUserTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *SuspendUserTestSuite) TestInvalidPayload() {
+	a := assert.New(s.T())
+
+	s.input.UserID = 1
+	s.input.Reason = strings.Repeat("x", 513)
+
+	out, err := s.usecase.SuspendUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorContains(err, "reason must not exceed 512 characters")
+	a.ErrorContains(err, "you can't suspend your own account")
+}
+
+func (s *SuspendUserTestSuite) TestEmptyReason() {
+	a := assert.New(s.T())
+
+	s.input.Reason = "  "
+
+	out, err := s.usecase.SuspendUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorContains(err, "reason must not be empty")
+}
+
+func (s *SuspendUserTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.SuspendUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *SuspendUserTestSuite) TestAlreadySuspended() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "suspended"
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.SuspendUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserStatusConflict)
+}
+
+func (s *SuspendUserTestSuite) TestUpdateError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().SetUserStatus(s.ctx, s.setUserStatusInput).Return(repository.SetUserStatusOutput{}, s.mockErr)
+
+	out, err := s.usecase.SuspendUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *SuspendUserTestSuite) TestConcurrentlyChanged() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().SetUserStatus(s.ctx, s.setUserStatusInput).Return(repository.SetUserStatusOutput{}, repository.ErrorRecordOutdated)
+
+	out, err := s.usecase.SuspendUser(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserStatusConflict)
+}
+
+func (s *SuspendUserTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().SetUserStatus(s.ctx, s.setUserStatusInput).Return(repository.SetUserStatusOutput{Version: 4}, nil)
+
+	out, err := s.usecase.SuspendUser(s.ctx, s.input)
+
+	a.Nil(err)
+	a.Equal(s.output, out)
+}
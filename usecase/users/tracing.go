@@ -0,0 +1,27 @@
+package users
+
+import (
+	"context"
+	"github.com/SawitProRecruitment/UserService/tracing"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan will start the span of the usecase method, e.g. UserUsecases.LoginUser
+func startSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "UserUsecases."+method)
+}
+
+// endSpan will end the span of a usecase method, the errors known by the clients (e.g. a wrong password) are only
+// recorded by their code as they're not failures of the service
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		reason := errorReason(err)
+		span.SetAttributes(attribute.String("error.code", reason))
+		if reason != usecase.InternalErrorCode {
+			err = nil
+		}
+	}
+	tracing.End(span, err)
+}
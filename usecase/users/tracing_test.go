@@ -0,0 +1,47 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func TestTracing(t *testing.T) {
+	a := assert.New(t)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	repo := repository.NewMockUserRepository(gomock.NewController(t))
+	uc := NewUserUsecases(NewUserUsecasesOptions{UserRepo: repo})
+
+	var repoSpan trace.SpanContext
+	repo.EXPECT().GetUser(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input repository.GetUserInput) (repository.GetUserOutput, error) {
+		repoSpan = trace.SpanContextFromContext(ctx)
+		return repository.GetUserOutput{}, repository.ErrorRecordNotFound
+	})
+	_, err := uc.GetUserProfile(context.Background(), usecase.GetUserProfileInput{UserID: 123})
+	a.ErrorIs(err, usecase.UserNotFoundError)
+	repo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(repository.GetUserOutput{}, fmt.Errorf("pq: connection refused"))
+	_, err = uc.GetUserProfile(context.Background(), usecase.GetUserProfileInput{UserID: 123})
+	a.Error(err)
+
+	spans := recorder.Ended()
+	a.Len(spans, 2)
+	a.Equal("UserUsecases.GetUserProfile", spans[0].Name())
+	a.Equal(spans[0].SpanContext(), repoSpan, "the span is propagated to the repository")
+	a.Contains(spans[0].Attributes(), attribute.String("error.code", "user.not_found"))
+	a.Equal(codes.Unset, spans[0].Status().Code, "the errors known by the clients are not failures")
+	a.Contains(spans[1].Attributes(), attribute.String("error.code", "internal"))
+	a.Equal(codes.Error, spans[1].Status().Code)
+}
@@ -3,32 +3,95 @@ package users
 import (
 	"context"
 	"errors"
+	"github.com/SawitProRecruitment/UserService/attributes"
+	"github.com/SawitProRecruitment/UserService/i18n"
 	"github.com/SawitProRecruitment/UserService/repository"
 	"github.com/SawitProRecruitment/UserService/usecase"
+	"reflect"
+	"sort"
+	"strings"
 )
 
 func (u *userUsecases) UpdateUserProfile(ctx context.Context, input usecase.UpdateUserProfileInput) (output usecase.UpdateUserProfileOutput, err error) {
-	if validationErrors := validateUpdateUserPayload(input); len(validationErrors) > 0 {
+	ctx, span := startSpan(ctx, "UpdateUserProfile")
+	defer func() { endSpan(span, err) }()
+
+	// emails are case-insensitive, they are stored lowercase
+	if input.Email != nil {
+		email := strings.ToLower(strings.TrimSpace(*input.Email))
+		input.Email = &email
+	}
+	validationErrors := validateUpdateUserPayload(input)
+	// the attributes are only validated once merged into the current ones, their errors are reported along with the others
+	if len(validationErrors) > 0 && input.Attributes == nil {
 		err = usecase.NewValidationError(validationErrors)
 		return
 	}
 
-	var updatePayload = repository.UpdateUserInput{ID: input.UserID}
-	if input.FullName != nil {
-		updatePayload.FullName = *input.FullName
+	// the current profile is needed to merge the attributes, and to record the changed fields
+	var usr repository.GetUserOutput
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
 	}
-	if input.PhoneNo != nil {
-		updatePayload.PhoneNo = *input.PhoneNo
+	if input.Version != 0 && input.Version != usr.Version {
+		err = usecase.UserVersionMismatch
+		return
+	}
+	// resending the current email must neither clear its verification nor send another link
+	if input.Email != nil && *input.Email == usr.Email {
+		input.Email = nil
 	}
-	if _, err = u.userRepo.UpdateUser(ctx, updatePayload); err != nil {
+
+	var attrs map[string]interface{}
+	if input.Attributes != nil {
+		var attrErrors map[string][]error
+		if attrs, attrErrors, err = u.mergeUserAttributes(ctx, usr.Attributes, input.Attributes); err != nil {
+			return
+		}
+		for field, errs := range attrErrors {
+			validationErrors[field] = errs
+		}
+	}
+	if len(validationErrors) > 0 {
+		err = usecase.NewValidationError(validationErrors)
+		return
+	}
+
+	// the verification link is sent before the update, so that a failed send doesn't leave an email that was changed
+	// but can't be verified. A link sent for an update that fails afterwards is rejected, as it's not the current email
+	if input.Email != nil && *input.Email != "" {
+		if err = u.sendEmailVerification(ctx, input.UserID, *input.Email); err != nil {
+			return
+		}
+	}
+
+	// the update is only applied to the profile that was read, so that concurrent updates neither overwrite each other
+	// nor record a wrong history
+	var updatePayload = repository.UpdateUserInput{
+		ID:         input.UserID,
+		FullName:   input.FullName,
+		Email:      input.Email,
+		Attributes: attrs,
+		Version:    usr.Version,
+	}
+	var updateResp repository.UpdateUserOutput
+	if updateResp, err = u.userRepo.UpdateUser(ctx, updatePayload); err != nil {
 		if errors.Is(err, repository.ErrorRecordNotFound) {
 			err = usecase.UserNotFoundError
+		} else if errors.Is(err, repository.ErrorRecordOutdated) {
+			err = usecase.UserVersionMismatch
 		} else if errors.Is(err, repository.ErrorRecordConflict) {
 			err = usecase.UserConflictError
 		}
 		return
 	}
-	return usecase.UpdateUserProfileOutput{}, nil
+	if err = u.recordUserChanges(ctx, input.UserID, input.UserID, diffUserProfile(usr, updatePayload)); err != nil {
+		return
+	}
+	return usecase.UpdateUserProfileOutput{Version: updateResp.Version}, nil
 }
 
 func validateUpdateUserPayload(input usecase.UpdateUserProfileInput) map[string][]error {
@@ -38,10 +101,94 @@ func validateUpdateUserPayload(input usecase.UpdateUserProfileInput) map[string]
 			validationErrors["full_name"] = errs
 		}
 	}
+	// the phone number is the login identifier, changing it requires confirming a code sent to the new one
 	if input.PhoneNo != nil {
-		if errs := validateUserPhoneNo(*input.PhoneNo); len(errs) > 0 {
-			validationErrors["phone_no"] = errs
+		validationErrors["phone_no"] = []error{usecase.NewFieldError("phone_no.read_only", "validation.phone_change_endpoint", nil)}
+	}
+	// an empty email removes it
+	if input.Email != nil && *input.Email != "" {
+		if errs := validateUserEmail(*input.Email); len(errs) > 0 {
+			validationErrors["email"] = errs
 		}
 	}
 	return validationErrors
 }
+
+// mergeUserAttributes will merge the updated attributes into the current attributes of the user, and validate the
+// result against the attribute schema. The validation errors are keyed by the JSON pointer of the invalid value
+// within the request, e.g. `/attributes/estate_code`.
+func (u *userUsecases) mergeUserAttributes(ctx context.Context, current, updates map[string]interface{}) (attrs map[string]interface{}, validationErrors map[string][]error, err error) {
+	var schema *attributes.Schema
+	var schemaResp repository.GetLatestAttributeSchemaOutput
+	if schemaResp, err = u.attrSchemaRepo.GetLatestAttributeSchema(ctx, repository.GetLatestAttributeSchemaInput{}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			return nil, map[string][]error{"/attributes": {usecase.NewFieldError("attributes.no_schema", "validation.attributes_no_schema", nil)}}, nil
+		}
+		return
+	}
+	if schema, err = attributes.Compile(schemaResp.Schema); err != nil {
+		return
+	}
+
+	attrs = make(map[string]interface{}, len(current)+len(updates))
+	for name, value := range current {
+		attrs[name] = value
+	}
+	for name, value := range updates {
+		if value == nil {
+			delete(attrs, name)
+		} else {
+			attrs[name] = value
+		}
+	}
+
+	validationErrors = map[string][]error{}
+	for pointer, errs := range schema.Validate(attrs) {
+		field := "/attributes" + pointer
+		for _, e := range errs {
+			var violation *attributes.Violation
+			if !errors.As(e, &violation) {
+				return nil, nil, e
+			}
+			validationErrors[field] = append(validationErrors[field], usecase.NewFieldError("attributes.schema_violation", "validation.attribute_schema",
+				i18n.Params{"field": field, "keyword": violation.Keyword, "message": violation.Message}))
+		}
+	}
+	return attrs, validationErrors, nil
+}
+
+// diffUserProfile will list the fields of the profile changed by the update, the attributes are compared one by one
+// (e.g. `attributes.estate_code`) so that the history doesn't repeat the unchanged ones
+func diffUserProfile(usr repository.GetUserOutput, update repository.UpdateUserInput) (changes []repository.UserFieldChange) {
+	if update.FullName != nil && *update.FullName != usr.FullName {
+		changes = append(changes, repository.UserFieldChange{Field: "full_name", OldValue: usr.FullName, NewValue: *update.FullName})
+	}
+	if update.Email != nil && *update.Email != usr.Email {
+		change := repository.UserFieldChange{Field: "email"}
+		if usr.Email != "" {
+			change.OldValue = usr.Email
+		}
+		if *update.Email != "" {
+			change.NewValue = *update.Email
+		}
+		changes = append(changes, change)
+	}
+	if update.Attributes != nil {
+		var names []string
+		for name := range usr.Attributes {
+			names = append(names, name)
+		}
+		for name := range update.Attributes {
+			if _, ok := usr.Attributes[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if oldValue, newValue := usr.Attributes[name], update.Attributes[name]; !reflect.DeepEqual(oldValue, newValue) {
+				changes = append(changes, repository.UserFieldChange{Field: "attributes." + name, OldValue: oldValue, NewValue: newValue})
+			}
+		}
+	}
+	return changes
+}
@@ -2,26 +2,38 @@ package users
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/mail"
 	"github.com/SawitProRecruitment/UserService/repository"
 	"github.com/SawitProRecruitment/UserService/usecase"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"strings"
 	"testing"
+	"time"
 )
 
 type UpdateUserProfileTestSuite struct {
 	suite.Suite
 
-	gomock *gomock.Controller
-	repo   *repository.MockUserRepository
+	gomock         *gomock.Controller
+	repo           *repository.MockUserRepository
+	attrSchemaRepo *repository.MockAttributeSchemaRepository
+	userChangeRepo *repository.MockUserChangeRepository
+	mailSender     *mail.MockSender
 
 	usecase usecase.UserUsecases
 
+	getUserOutput    repository.GetUserOutput
 	updateUserInput  repository.UpdateUserInput
 	updateUserOutput repository.UpdateUserOutput
+	changesInput     repository.CreateUserChangesInput
 
 	input  usecase.UpdateUserProfileInput
 	output usecase.UpdateUserProfileOutput
@@ -37,24 +49,48 @@ func TestUpdateUserProfileTestSuite(t *testing.T) {
 func (s *UpdateUserProfileTestSuite) SetupTest() {
 	s.gomock = gomock.NewController(s.T())
 	s.repo = repository.NewMockUserRepository(s.gomock)
-
-	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo})
-
+	s.attrSchemaRepo = repository.NewMockAttributeSchemaRepository(s.gomock)
+	s.userChangeRepo = repository.NewMockUserChangeRepository(s.gomock)
+	s.mailSender = mail.NewMockSender(s.gomock)
+
+	jwtSecret, _ := rsa.GenerateKey(rand.Reader, 1024)
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		UserRepo:       s.repo,
+		AttrSchemaRepo: s.attrSchemaRepo,
+		UserChangeRepo: s.userChangeRepo,
+		MailSender:     s.mailSender,
+		JwtSecret:      jwtSecret,
+		HTTP:           config.HTTP{PublicURL: "https://users.example.com"},
+		Auth:           config.Auth{EmailVerificationTtl: 24 * time.Hour},
+	})
+
+	s.getUserOutput = repository.GetUserOutput{
+		ID:         123,
+		FullName:   "Jane Smith",
+		Attributes: map[string]interface{}{"estate_code": "KAL-01", "preferred_language": "id"},
+		Version:    3,
+	}
+	fullName := "John Smith"
 	s.updateUserInput = repository.UpdateUserInput{
 		ID:       123,
-		PhoneNo:  "+62812151833",
-		FullName: "John Smith",
+		FullName: &fullName,
+		Version:  3,
+	}
+	s.updateUserOutput = repository.UpdateUserOutput{Version: 4}
+	s.changesInput = repository.CreateUserChangesInput{
+		UserID:      123,
+		ActorUserID: 123,
+		RequestID:   "request-id",
+		Changes:     []repository.UserFieldChange{{Field: "full_name", OldValue: "Jane Smith", NewValue: "John Smith"}},
 	}
-	s.updateUserOutput = repository.UpdateUserOutput{}
 
 	s.input = usecase.UpdateUserProfileInput{
 		UserID:   123,
-		PhoneNo:  &s.updateUserInput.PhoneNo,
-		FullName: &s.updateUserInput.FullName,
+		FullName: s.updateUserInput.FullName,
 	}
-	s.output = usecase.UpdateUserProfileOutput{}
+	s.output = usecase.UpdateUserProfileOutput{Version: 4}
 
-	s.ctx = context.Background()
+	s.ctx = usecase.ContextWithRequestID(context.Background(), "request-id")
 	s.mockErr = fmt.Errorf("simulated error")
 }
 
@@ -74,15 +110,30 @@ func (s *UpdateUserProfileTestSuite) TestValidationFailed() {
 	var validationError usecase.ValidationErrors
 	a.True(errors.As(err, &validationError))
 	validationErrors := validationError.GetErrors()
-	a.Equal("phone_no must be between 10 and 13 characters long", validationErrors["phone_no"][0].Error())
-	a.Equal("phone_no must start with indonesia country code (\"+62\")", validationErrors["phone_no"][1].Error())
-	a.Equal("besides the country code, phone_no must only contain numbers", validationErrors["phone_no"][2].Error())
+	a.Equal("phone_no must be changed with POST /user/phone", validationErrors["phone_no"][0].Error())
 	a.Equal("full_name must be between 3 and 60 characters long", validationErrors["full_name"][0].Error())
 }
 
+// expectGetUser will expect the current profile of the user to be loaded
+func (s *UpdateUserProfileTestSuite) expectGetUser() {
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+}
+
+func (s *UpdateUserProfileTestSuite) TestGetUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
 func (s *UpdateUserProfileTestSuite) TestRepositoryError() {
 	a := assert.New(s.T())
 
+	s.expectGetUser()
 	s.repo.EXPECT().UpdateUser(s.ctx, s.updateUserInput).Return(repository.UpdateUserOutput{}, s.mockErr)
 
 	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
@@ -94,6 +145,7 @@ func (s *UpdateUserProfileTestSuite) TestRepositoryError() {
 func (s *UpdateUserProfileTestSuite) TestUserNotFound() {
 	a := assert.New(s.T())
 
+	s.expectGetUser()
 	s.repo.EXPECT().UpdateUser(s.ctx, s.updateUserInput).Return(repository.UpdateUserOutput{}, repository.ErrorRecordNotFound)
 
 	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
@@ -105,6 +157,7 @@ func (s *UpdateUserProfileTestSuite) TestUserNotFound() {
 func (s *UpdateUserProfileTestSuite) TestUserConflict() {
 	a := assert.New(s.T())
 
+	s.expectGetUser()
 	s.repo.EXPECT().UpdateUser(s.ctx, s.updateUserInput).Return(repository.UpdateUserOutput{}, repository.ErrorRecordConflict)
 
 	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
@@ -113,10 +166,244 @@ func (s *UpdateUserProfileTestSuite) TestUserConflict() {
 	a.ErrorIs(err, usecase.UserConflictError)
 }
 
+func (s *UpdateUserProfileTestSuite) TestVersionMismatch() {
+	a := assert.New(s.T())
+
+	s.input.Version = 2
+	s.expectGetUser()
+
+	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserVersionMismatch)
+}
+
+func (s *UpdateUserProfileTestSuite) TestConcurrentUpdate() {
+	a := assert.New(s.T())
+
+	s.input.Version = 3
+	s.expectGetUser()
+	s.repo.EXPECT().UpdateUser(s.ctx, s.updateUserInput).Return(repository.UpdateUserOutput{}, repository.ErrorRecordOutdated)
+
+	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserVersionMismatch)
+}
+
+func (s *UpdateUserProfileTestSuite) TestRecordChangesError() {
+	a := assert.New(s.T())
+
+	s.expectGetUser()
+	s.repo.EXPECT().UpdateUser(s.ctx, s.updateUserInput).Return(s.updateUserOutput, nil)
+	s.userChangeRepo.EXPECT().CreateUserChanges(s.ctx, s.changesInput).Return(repository.CreateUserChangesOutput{}, s.mockErr)
+
+	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
 func (s *UpdateUserProfileTestSuite) TestSuccess() {
 	a := assert.New(s.T())
 
+	s.expectGetUser()
+	s.repo.EXPECT().UpdateUser(s.ctx, s.updateUserInput).Return(s.updateUserOutput, nil)
+	s.userChangeRepo.EXPECT().CreateUserChanges(s.ctx, s.changesInput).Return(repository.CreateUserChangesOutput{}, nil)
+
+	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
+
+func (s *UpdateUserProfileTestSuite) TestSuccessUnchanged() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.FullName = "John Smith"
+	s.expectGetUser()
+	s.repo.EXPECT().UpdateUser(s.ctx, s.updateUserInput).Return(s.updateUserOutput, nil)
+
+	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
+
+func (s *UpdateUserProfileTestSuite) TestInvalidEmail() {
+	a := assert.New(s.T())
+
+	for _, email := range []string{"john.smith", "John <john.smith@example.com>", strings.Repeat("a", 250) + "@example.com"} {
+		s.input.Email = &email
+		out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+		a.Empty(out)
+		var validationError usecase.ValidationErrors
+		a.True(errors.As(err, &validationError), email)
+		a.NotEmpty(validationError.GetErrors()["email"], email)
+	}
+}
+
+func (s *UpdateUserProfileTestSuite) TestSendEmailVerificationError() {
+	a := assert.New(s.T())
+
+	email := " John.Smith@Example.com"
+	s.input.Email = &email
+	normalizedEmail := "john.smith@example.com"
+	s.updateUserInput.Email = &normalizedEmail
+	s.changesInput.Changes = append(s.changesInput.Changes, repository.UserFieldChange{Field: "email", NewValue: "john.smith@example.com"})
+	s.expectGetUser()
+	// the profile isn't updated when the verification link can't be sent
+	s.mailSender.EXPECT().SendMail(s.ctx, gomock.Any()).Return(mail.SendMailOutput{}, s.mockErr)
+
+	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *UpdateUserProfileTestSuite) TestSuccessEmail() {
+	a := assert.New(s.T())
+
+	email := " John.Smith@Example.com"
+	s.input.Email = &email
+	normalizedEmail := "john.smith@example.com"
+	s.updateUserInput.Email = &normalizedEmail
+	s.changesInput.Changes = append(s.changesInput.Changes, repository.UserFieldChange{Field: "email", NewValue: "john.smith@example.com"})
+	s.expectGetUser()
+	s.repo.EXPECT().UpdateUser(s.ctx, s.updateUserInput).Return(s.updateUserOutput, nil)
+	s.userChangeRepo.EXPECT().CreateUserChanges(s.ctx, s.changesInput).Return(repository.CreateUserChangesOutput{}, nil)
+	s.mailSender.EXPECT().SendMail(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, input mail.SendMailInput) (mail.SendMailOutput, error) {
+		a.Equal("john.smith@example.com", input.To)
+		a.Contains(input.Body, "https://users.example.com/user/email/verify?token=")
+		return mail.SendMailOutput{MessageID: "message-id"}, nil
+	})
+
+	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
+
+func (s *UpdateUserProfileTestSuite) TestSuccessSameEmailKeepsVerification() {
+	a := assert.New(s.T())
+
+	email := " John.Smith@Example.com"
+	s.input.Email = &email
+	s.getUserOutput.Email = "john.smith@example.com"
+	s.getUserOutput.EmailVerifiedAt = time.Date(2024, 3, 16, 9, 55, 0, 0, time.UTC)
+	s.expectGetUser()
+	// the email isn't updated, so that it stays verified, and no verification link is sent
+	s.repo.EXPECT().UpdateUser(s.ctx, s.updateUserInput).Return(s.updateUserOutput, nil)
+	s.userChangeRepo.EXPECT().CreateUserChanges(s.ctx, s.changesInput).Return(repository.CreateUserChangesOutput{}, nil)
+
+	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
+
+func (s *UpdateUserProfileTestSuite) TestSuccessRemoveEmail() {
+	a := assert.New(s.T())
+
+	email := ""
+	s.input.Email = &email
+	s.updateUserInput.Email = &email
+	s.getUserOutput.Email = "john.smith@example.com"
+	s.changesInput.Changes = append(s.changesInput.Changes, repository.UserFieldChange{Field: "email", OldValue: "john.smith@example.com"})
+	s.expectGetUser()
+	s.repo.EXPECT().UpdateUser(s.ctx, s.updateUserInput).Return(s.updateUserOutput, nil)
+	s.userChangeRepo.EXPECT().CreateUserChanges(s.ctx, s.changesInput).Return(repository.CreateUserChangesOutput{}, nil)
+
+	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
+
+// expectAttributeSchema will expect the current profile of the user to be loaded, along with the attribute schema
+func (s *UpdateUserProfileTestSuite) expectAttributeSchema() {
+	s.expectGetUser()
+	s.attrSchemaRepo.EXPECT().GetLatestAttributeSchema(s.ctx, repository.GetLatestAttributeSchemaInput{}).
+		Return(repository.GetLatestAttributeSchemaOutput{ID: 3, Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"estate_code": {"type": "string", "pattern": "^[A-Z]{3}-[0-9]{2}$"},
+				"preferred_language": {"enum": ["id", "en"]}
+			},
+			"required": ["estate_code"],
+			"additionalProperties": false
+		}`)}, nil)
+}
+
+func (s *UpdateUserProfileTestSuite) TestAttributesWithoutSchema() {
+	a := assert.New(s.T())
+
+	s.input.Attributes = map[string]interface{}{"estate_code": "KAL-02"}
+	s.expectGetUser()
+	s.attrSchemaRepo.EXPECT().GetLatestAttributeSchema(s.ctx, gomock.Any()).
+		Return(repository.GetLatestAttributeSchemaOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.IsType(usecase.ValidationErrors{}, err)
+	a.Equal(map[string][]error{
+		"/attributes": {usecase.NewFieldError("attributes.no_schema", "validation.attributes_no_schema", nil)},
+	}, err.(usecase.ValidationErrors).GetErrors())
+}
+
+func (s *UpdateUserProfileTestSuite) TestAttributesSchemaError() {
+	a := assert.New(s.T())
+
+	s.input.Attributes = map[string]interface{}{"estate_code": "KAL-02"}
+	s.expectGetUser()
+	s.attrSchemaRepo.EXPECT().GetLatestAttributeSchema(s.ctx, gomock.Any()).
+		Return(repository.GetLatestAttributeSchemaOutput{}, s.mockErr)
+
+	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *UpdateUserProfileTestSuite) TestAttributesValidationFailed() {
+	a := assert.New(s.T())
+
+	badString := "no"
+	s.input.FullName = &badString
+	s.input.Attributes = map[string]interface{}{"estate_code": nil, "preferred_language": "fr", "shoe_size": float64(42)}
+	s.expectAttributeSchema()
+
+	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
+
+	a.Empty(out)
+	a.IsType(usecase.ValidationErrors{}, err)
+	errs := err.(usecase.ValidationErrors).GetErrors()
+	a.Len(errs, 3)
+	a.Len(errs["full_name"], 1)
+	a.Len(errs["/attributes"], 2)
+	a.Contains(errs["/attributes"][0].Error()+errs["/attributes"][1].Error(), "estate_code")
+	a.Contains(errs["/attributes"][0].Error()+errs["/attributes"][1].Error(), "shoe_size")
+	a.Len(errs["/attributes/preferred_language"], 1)
+	a.True(strings.HasPrefix(errs["/attributes/preferred_language"][0].Error(), "/attributes/preferred_language "))
+	var fieldErr *usecase.Error
+	a.ErrorAs(errs["/attributes/preferred_language"][0], &fieldErr)
+	a.Equal("attributes.schema_violation", fieldErr.Code)
+}
+
+func (s *UpdateUserProfileTestSuite) TestSuccessAttributes() {
+	a := assert.New(s.T())
+
+	s.input.Attributes = map[string]interface{}{"estate_code": "KAL-02", "preferred_language": nil}
+	s.expectAttributeSchema()
+	s.updateUserInput.Attributes = map[string]interface{}{"estate_code": "KAL-02"}
+	s.changesInput.Changes = append(s.changesInput.Changes,
+		repository.UserFieldChange{Field: "attributes.estate_code", OldValue: "KAL-01", NewValue: "KAL-02"},
+		repository.UserFieldChange{Field: "attributes.preferred_language", OldValue: "id"},
+	)
 	s.repo.EXPECT().UpdateUser(s.ctx, s.updateUserInput).Return(s.updateUserOutput, nil)
+	s.userChangeRepo.EXPECT().CreateUserChanges(s.ctx, s.changesInput).Return(repository.CreateUserChangesOutput{}, nil)
 
 	out, err := s.usecase.UpdateUserProfile(s.ctx, s.input)
 
@@ -0,0 +1,140 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/avatar"
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/storage"
+	"github.com/SawitProRecruitment/UserService/usecase"
+)
+
+const (
+	// maxAvatarBytes is the maximum size of an uploaded avatar, before resizing
+	maxAvatarBytes = 5 << 20
+
+	avatarSize          = 512
+	avatarThumbnailSize = 128
+)
+
+// avatarErrors maps the errors of avatar.Decode into their validation error
+var avatarErrors = map[error]*usecase.Error{
+	avatar.ErrUnsupportedFormat: usecase.NewFieldError("avatar.unsupported_format", "validation.avatar_format", nil),
+	avatar.ErrTooLarge:          usecase.NewFieldError("avatar.too_many_pixels", "validation.avatar_pixels", i18n.Params{"max": avatar.MaxPixels / 1_000_000}),
+	avatar.ErrInvalidImage:      usecase.NewFieldError("avatar.invalid_image", "validation.avatar_invalid", nil),
+}
+
+func (u *userUsecases) UploadUserAvatar(ctx context.Context, input usecase.UploadUserAvatarInput) (output usecase.UploadUserAvatarOutput, err error) {
+	ctx, span := startSpan(ctx, "UploadUserAvatar")
+	defer func() { endSpan(span, err) }()
+
+	if len(input.Content) > maxAvatarBytes {
+		err = usecase.NewValidationError(map[string][]error{
+			"avatar": {usecase.NewFieldError("avatar.too_large", "validation.avatar_size", i18n.Params{"max": maxAvatarBytes >> 20})},
+		})
+		return
+	}
+
+	var usr repository.GetUserOutput
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: input.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+	if err = checkUserStatus(usr.Status); err != nil {
+		return
+	}
+
+	// the content type is sniffed from the content, the one declared by the client is never trusted
+	var img *avatar.Image
+	if img, err = avatar.Decode(input.Content); err != nil {
+		if avatarErr, ok := avatarErrors[err]; ok {
+			err = usecase.NewValidationError(map[string][]error{"avatar": {avatarErr}})
+		} else {
+			err = usecase.NewValidationError(map[string][]error{"avatar": {fmt.Errorf(`avatar %w`, err)}})
+		}
+		return
+	}
+
+	// every upload is stored under a new key, so that cached URLs of the previous avatar are never served stale
+	var id string
+	if id, err = generateTokenID(); err != nil {
+		return
+	}
+	avatarKey := fmt.Sprintf("avatars/%d/%s", usr.ID, id)
+
+	for _, size := range []int{avatarSize, avatarThumbnailSize} {
+		var content []byte
+		if content, err = img.Thumbnail(size); err != nil {
+			return
+		}
+		_, err = u.blobStore.PutBlob(ctx, storage.PutBlobInput{
+			Key:         avatarBlobKey(avatarKey, size),
+			ContentType: "image/jpeg",
+			Content:     content,
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	var resp repository.SetUserAvatarOutput
+	if resp, err = u.userRepo.SetUserAvatar(ctx, repository.SetUserAvatarInput{ID: usr.ID, AvatarKey: avatarKey}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserNotFoundError
+		}
+		return
+	}
+	u.deleteReplacedAvatar(ctx, resp.PreviousAvatarKey)
+
+	return u.getAvatarURLs(ctx, avatarKey)
+}
+
+// avatarBlobKey will return the key of the avatar thumbnail with the specified size
+func avatarBlobKey(avatarKey string, size int) string {
+	return fmt.Sprintf("%s/%d.jpg", avatarKey, size)
+}
+
+// getAvatarURLs will return the public URLs of the avatar thumbnails
+func (u *userUsecases) getAvatarURLs(ctx context.Context, avatarKey string) (output usecase.UploadUserAvatarOutput, err error) {
+	var resp storage.GetBlobURLOutput
+	if resp, err = u.blobStore.GetBlobURL(ctx, storage.GetBlobURLInput{Key: avatarBlobKey(avatarKey, avatarSize)}); err != nil {
+		return
+	}
+	output.AvatarURL = resp.URL
+
+	if resp, err = u.blobStore.GetBlobURL(ctx, storage.GetBlobURLInput{Key: avatarBlobKey(avatarKey, avatarThumbnailSize)}); err != nil {
+		return usecase.UploadUserAvatarOutput{}, err
+	}
+	output.AvatarThumbnailURL = resp.URL
+
+	return output, nil
+}
+
+// deleteAvatar will remove the avatar thumbnails from the blob store, an empty avatarKey is ignored
+func (u *userUsecases) deleteAvatar(ctx context.Context, avatarKey string) (err error) {
+	if avatarKey == "" {
+		return nil
+	}
+	for _, size := range []int{avatarSize, avatarThumbnailSize} {
+		if _, err = u.blobStore.DeleteBlob(ctx, storage.DeleteBlobInput{Key: avatarBlobKey(avatarKey, size)}); err != nil {
+			return
+		}
+	}
+	return nil
+}
+
+// deleteReplacedAvatar will remove the avatar replaced by SetUserAvatar from the blob store and from the deletion
+// queue. The removal is best-effort, as the avatar stays queued on failure and is retried by deleteQueuedAvatars
+func (u *userUsecases) deleteReplacedAvatar(ctx context.Context, avatarKey string) {
+	if avatarKey == "" {
+		return
+	}
+	if err := u.deleteAvatar(ctx, avatarKey); err != nil {
+		return
+	}
+	_, _ = u.userRepo.DeleteAvatarDeletion(ctx, repository.DeleteAvatarDeletionInput{AvatarKey: avatarKey})
+}
@@ -0,0 +1,213 @@
+package users
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/storage"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+type UploadUserAvatarTestSuite struct {
+	suite.Suite
+
+	gomock    *gomock.Controller
+	repo      *repository.MockUserRepository
+	blobStore *storage.MockBlobStore
+
+	usecase usecase.UserUsecases
+
+	getUserOutput repository.GetUserOutput
+
+	input  usecase.UploadUserAvatarInput
+	output usecase.UploadUserAvatarOutput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestUploadUserAvatarTestSuite(t *testing.T) {
+	suite.Run(t, new(UploadUserAvatarTestSuite))
+}
+
+func (s *UploadUserAvatarTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.blobStore = storage.NewMockBlobStore(s.gomock)
+
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo, BlobStore: s.blobStore})
+
+	var content bytes.Buffer
+	_ = png.Encode(&content, image.NewRGBA(image.Rect(0, 0, 800, 600)))
+
+	s.getUserOutput = repository.GetUserOutput{ID: 123, Status: "active"}
+	s.input = usecase.UploadUserAvatarInput{UserID: 123, Content: content.Bytes()}
+	s.output = usecase.UploadUserAvatarOutput{
+		AvatarURL:          "https://example.com/blobs/avatars/123/token-id/512.jpg",
+		AvatarThumbnailURL: "https://example.com/blobs/avatars/123/token-id/128.jpg",
+	}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+	generateTokenID = func() (string, error) { return "token-id", nil }
+}
+
+func (s *UploadUserAvatarTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+// expectPutBlobs will expect both thumbnails to be stored as JPEG, with the expected dimensions
+func (s *UploadUserAvatarTestSuite) expectPutBlobs() {
+	for _, size := range []int{512, 128} {
+		size := size
+		s.blobStore.EXPECT().PutBlob(s.ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, input storage.PutBlobInput) (storage.PutBlobOutput, error) {
+				s.Equal(fmt.Sprintf("avatars/123/token-id/%d.jpg", size), input.Key)
+				s.Equal("image/jpeg", input.ContentType)
+				cfg, err := jpeg.DecodeConfig(bytes.NewReader(input.Content))
+				s.Empty(err)
+				s.Equal(size, cfg.Width)
+				s.Equal(size, cfg.Height)
+				return storage.PutBlobOutput{}, nil
+			})
+	}
+}
+
+func (s *UploadUserAvatarTestSuite) expectGetBlobURLs() {
+	s.blobStore.EXPECT().GetBlobURL(s.ctx, storage.GetBlobURLInput{Key: "avatars/123/token-id/512.jpg"}).
+		Return(storage.GetBlobURLOutput{URL: s.output.AvatarURL}, nil)
+	s.blobStore.EXPECT().GetBlobURL(s.ctx, storage.GetBlobURLInput{Key: "avatars/123/token-id/128.jpg"}).
+		Return(storage.GetBlobURLOutput{URL: s.output.AvatarThumbnailURL}, nil)
+}
+
+func (s *UploadUserAvatarTestSuite) TestTooLarge() {
+	a := assert.New(s.T())
+
+	s.input.Content = make([]byte, 5<<20+1)
+	out, err := s.usecase.UploadUserAvatar(s.ctx, s.input)
+
+	a.Empty(out)
+	a.IsType(usecase.ValidationErrors{}, err)
+	a.ErrorContains(err, "avatar must be at most 5 MB")
+}
+
+func (s *UploadUserAvatarTestSuite) TestUnsupportedFormat() {
+	a := assert.New(s.T())
+
+	s.input.Content = []byte("GIF89a....")
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.UploadUserAvatar(s.ctx, s.input)
+
+	a.Empty(out)
+	a.IsType(usecase.ValidationErrors{}, err)
+	a.ErrorContains(err, "avatar must be a JPEG, PNG or WebP image")
+}
+
+func (s *UploadUserAvatarTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.UploadUserAvatar(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserNotFoundError)
+}
+
+func (s *UploadUserAvatarTestSuite) TestUserSuspended() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "suspended"
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.UploadUserAvatar(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserSuspended)
+}
+
+func (s *UploadUserAvatarTestSuite) TestBlobStoreError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.blobStore.EXPECT().PutBlob(s.ctx, gomock.Any()).Return(storage.PutBlobOutput{}, s.mockErr)
+
+	out, err := s.usecase.UploadUserAvatar(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *UploadUserAvatarTestSuite) TestRepositoryError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.expectPutBlobs()
+	s.repo.EXPECT().SetUserAvatar(s.ctx, repository.SetUserAvatarInput{ID: 123, AvatarKey: "avatars/123/token-id"}).
+		Return(repository.SetUserAvatarOutput{}, s.mockErr)
+
+	out, err := s.usecase.UploadUserAvatar(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *UploadUserAvatarTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.expectPutBlobs()
+	s.repo.EXPECT().SetUserAvatar(s.ctx, repository.SetUserAvatarInput{ID: 123, AvatarKey: "avatars/123/token-id"}).
+		Return(repository.SetUserAvatarOutput{}, nil)
+	s.expectGetBlobURLs()
+
+	out, err := s.usecase.UploadUserAvatar(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
+
+func (s *UploadUserAvatarTestSuite) TestSuccessReplace() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.expectPutBlobs()
+	s.repo.EXPECT().SetUserAvatar(s.ctx, repository.SetUserAvatarInput{ID: 123, AvatarKey: "avatars/123/token-id"}).
+		Return(repository.SetUserAvatarOutput{PreviousAvatarKey: "avatars/123/old-key"}, nil)
+	s.blobStore.EXPECT().DeleteBlob(s.ctx, storage.DeleteBlobInput{Key: "avatars/123/old-key/512.jpg"}).Return(storage.DeleteBlobOutput{}, nil)
+	s.blobStore.EXPECT().DeleteBlob(s.ctx, storage.DeleteBlobInput{Key: "avatars/123/old-key/128.jpg"}).Return(storage.DeleteBlobOutput{}, nil)
+	s.repo.EXPECT().DeleteAvatarDeletion(s.ctx, repository.DeleteAvatarDeletionInput{AvatarKey: "avatars/123/old-key"}).
+		Return(repository.DeleteAvatarDeletionOutput{}, nil)
+	s.expectGetBlobURLs()
+
+	out, err := s.usecase.UploadUserAvatar(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
+
+func (s *UploadUserAvatarTestSuite) TestSuccessReplaceDeleteError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(s.getUserOutput, nil)
+	s.expectPutBlobs()
+	s.repo.EXPECT().SetUserAvatar(s.ctx, repository.SetUserAvatarInput{ID: 123, AvatarKey: "avatars/123/token-id"}).
+		Return(repository.SetUserAvatarOutput{PreviousAvatarKey: "avatars/123/old-key"}, nil)
+	s.blobStore.EXPECT().DeleteBlob(s.ctx, storage.DeleteBlobInput{Key: "avatars/123/old-key/512.jpg"}).Return(storage.DeleteBlobOutput{}, s.mockErr)
+	s.expectGetBlobURLs()
+
+	// the previous avatar stays queued for deletion, hence the upload still succeeds
+	out, err := s.usecase.UploadUserAvatar(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
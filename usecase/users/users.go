@@ -2,10 +2,19 @@
 package users
 
 import (
+	"context"
 	"crypto/rsa"
+	"errors"
 	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"github.com/SawitProRecruitment/UserService/mail"
+	"github.com/SawitProRecruitment/UserService/phone"
 	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/sms"
+	"github.com/SawitProRecruitment/UserService/storage"
 	"github.com/SawitProRecruitment/UserService/usecase"
+	netmail "net/mail"
 	"regexp"
 	"strings"
 	"time"
@@ -18,39 +27,182 @@ var (
 	digitRegex    = regexp.MustCompile(`^.*\d.*$`)     // match string containing at least one number
 	capitalRegex  = regexp.MustCompile(`^.*[A-Z].*$`)  // match string containing at least one Capital letter
 	specialRegex  = regexp.MustCompile(`^.*[\W_].*$`)  // match string containing at least one special character (non alphanumeric)
+
+	// phoneErrors maps the errors of phone.Parse into their validation error, except the invalid length errors which
+	// detail the lengths expected by the country
+	phoneErrors = map[error]*usecase.Error{
+		phone.ErrMissingCountryCode: usecase.NewFieldError("phone_no.invalid_country_code", "validation.phone_country_code", nil),
+		phone.ErrInvalidCharacters:  usecase.NewFieldError("phone_no.invalid_characters", "validation.phone_characters", nil),
+		phone.ErrUnsupportedCountry: usecase.NewFieldError("phone_no.unsupported_country", "validation.phone_unsupported", nil),
+	}
 )
 
 // userUsecases is an implementation of usecase.UserUsecases
 type userUsecases struct {
-	userRepo  repository.UserRepository
-	jwtSecret *rsa.PrivateKey
-	jwtTtl    time.Duration
+	userRepo        repository.UserRepository
+	tokenRepo       repository.TokenRepository
+	oauthClientRepo repository.OAuthClientRepository
+	auditRepo       repository.AuditRepository
+	userExportRepo  repository.UserExportRepository
+	loginOTPRepo    repository.LoginOTPRepository
+	userDeviceRepo  repository.UserDeviceRepository
+	phoneChangeRepo repository.PhoneChangeRepository
+	attrSchemaRepo  repository.AttributeSchemaRepository
+	userChangeRepo  repository.UserChangeRepository
+	smsSender       sms.Sender
+	mailSender      mail.Sender
+	blobStore       storage.BlobStore
+	jwtSecret       *rsa.PrivateKey
+	jwtTtl          time.Duration
+
+	loginOTPTtl     time.Duration
+	phoneNoCooldown time.Duration
+	// emailVerificationURL is the link sent to verify an email, the verification token is added as `token` query param
+	emailVerificationURL string
+	emailVerificationTtl time.Duration
+	impersonationTtl     time.Duration
+	deletionGracePeriod  time.Duration
+	userExportRetention  time.Duration
+	readinessTimeout     time.Duration
+
+	// allowedPhoneCountries is nil when every country supported by the phone package is allowed
+	allowedPhoneCountries map[string]bool
 }
 
 type NewUserUsecasesOptions struct {
-	UserRepo  repository.UserRepository
+	UserRepo        repository.UserRepository
+	TokenRepo       repository.TokenRepository
+	OAuthClientRepo repository.OAuthClientRepository
+	AuditRepo       repository.AuditRepository
+	UserExportRepo  repository.UserExportRepository
+	LoginOTPRepo    repository.LoginOTPRepository
+	UserDeviceRepo  repository.UserDeviceRepository
+	PhoneChangeRepo repository.PhoneChangeRepository
+	AttrSchemaRepo  repository.AttributeSchemaRepository
+	UserChangeRepo  repository.UserChangeRepository
+	SMSSender       sms.Sender
+	MailSender      mail.Sender
+	BlobStore       storage.BlobStore
+	// JwtSecret signs the tokens, it's Auth.JwtSecret() unless the caller had to generate one
 	JwtSecret *rsa.PrivateKey
-	JwtTtl    time.Duration
+	// Auth contains the lifetimes of the tokens and codes
+	Auth config.Auth
+	// Users contains the settings of the user accounts, e.g. the deletion grace period
+	Users config.Users
+	// HTTP.PublicURL is the base of the links sent by email, e.g. to verify an email
+	HTTP config.HTTP
+	// Database.PingTimeout bounds the database check of CheckReadiness, so that a probe doesn't hang on an
+	// unreachable database. Defaults to 2 seconds
+	Database config.Database
 }
 
 func NewUserUsecases(opts NewUserUsecasesOptions) usecase.UserUsecases {
+	var allowedPhoneCountries map[string]bool
+	if len(opts.Users.AllowedPhoneCountries) > 0 {
+		allowedPhoneCountries = make(map[string]bool, len(opts.Users.AllowedPhoneCountries))
+		for _, country := range opts.Users.AllowedPhoneCountries {
+			allowedPhoneCountries[strings.ToUpper(country)] = true
+		}
+	}
+
+	readinessTimeout := opts.Database.PingTimeout
+	if readinessTimeout <= 0 {
+		readinessTimeout = defaultReadinessTimeout
+	}
+
 	return &userUsecases{
-		userRepo:  opts.UserRepo,
-		jwtSecret: opts.JwtSecret,
-		jwtTtl:    opts.JwtTtl,
+		userRepo:        opts.UserRepo,
+		tokenRepo:       opts.TokenRepo,
+		oauthClientRepo: opts.OAuthClientRepo,
+		auditRepo:       opts.AuditRepo,
+		userExportRepo:  opts.UserExportRepo,
+		loginOTPRepo:    opts.LoginOTPRepo,
+		userDeviceRepo:  opts.UserDeviceRepo,
+		phoneChangeRepo: opts.PhoneChangeRepo,
+		attrSchemaRepo:  opts.AttrSchemaRepo,
+		userChangeRepo:  opts.UserChangeRepo,
+		smsSender:       opts.SMSSender,
+		mailSender:      opts.MailSender,
+		blobStore:       opts.BlobStore,
+		jwtSecret:       opts.JwtSecret,
+		jwtTtl:          opts.Auth.JwtTtl,
+
+		loginOTPTtl:          opts.Auth.LoginOTPTtl,
+		phoneNoCooldown:      opts.Users.PhoneNoCooldown,
+		emailVerificationURL: opts.HTTP.PublicURL + emailVerificationPath,
+		emailVerificationTtl: opts.Auth.EmailVerificationTtl,
+		impersonationTtl:     opts.Auth.ImpersonationTtl,
+		deletionGracePeriod:  opts.Users.DeletionGracePeriod,
+		userExportRetention:  opts.Users.UserExportRetention,
+		readinessTimeout:     readinessTimeout,
+
+		allowedPhoneCountries: allowedPhoneCountries,
 	}
 }
 
-func validateUserPhoneNo(phoneNo string) []error {
-	var res []error
-	if len(phoneNo) < 10 || len(phoneNo) > 13 {
-		res = append(res, fmt.Errorf(`phone_no must be between 10 and 13 characters long`))
+// normalizeUserPhoneNo will parse the phone number into its canonical E.164 format,
+// only numbers from the allowed countries are accepted
+func (u *userUsecases) normalizeUserPhoneNo(phoneNo string) (string, []error) {
+	num, err := phone.Parse(phoneNo)
+	if err != nil {
+		var lengthErr *phone.LengthError
+		if errors.As(err, &lengthErr) {
+			country := lengthErr.Country
+			params := i18n.Params{"country": country.Code, "calling_code": country.CallingCode, "min": country.MinLength, "max": country.MaxLength}
+			if country.MinLength == country.MaxLength {
+				return phoneNo, []error{usecase.NewFieldError("phone_no.invalid_length", "validation.phone_length_exact", params)}
+			}
+			return phoneNo, []error{usecase.NewFieldError("phone_no.invalid_length", "validation.phone_length", params)}
+		}
+		if phoneErr, ok := phoneErrors[err]; ok {
+			return phoneNo, []error{phoneErr}
+		}
+		return phoneNo, []error{fmt.Errorf(`phone_no %w`, err)}
+	}
+	if u.allowedPhoneCountries != nil && !u.allowedPhoneCountries[num.Country] {
+		return phoneNo, []error{usecase.NewFieldError("phone_no.country_not_allowed", "validation.phone_country_denied", i18n.Params{"country": num.Country})}
+	}
+	return num.E164, nil
+}
+
+// recordUserChanges will record the changes made to the profile of the user by the actor, along with the ID of the
+// current request
+func (u *userUsecases) recordUserChanges(ctx context.Context, actorUserID, userID uint64, changes []repository.UserFieldChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	_, err := u.userChangeRepo.CreateUserChanges(ctx, newUserChangesInput(ctx, actorUserID, userID, changes))
+	return err
+}
+
+// newUserChangesInput will return the changes made to the profile of the user by the actor, along with the ID of the
+// current request, e.g. for the repositories recording them in the transaction applying them
+func newUserChangesInput(ctx context.Context, actorUserID, userID uint64, changes []repository.UserFieldChange) repository.CreateUserChangesInput {
+	return repository.CreateUserChangesInput{
+		UserID:      userID,
+		ActorUserID: actorUserID,
+		RequestID:   usecase.RequestIDFromContext(ctx),
+		Changes:     changes,
 	}
-	if !strings.HasPrefix(phoneNo, "+62") {
-		res = append(res, fmt.Errorf(`phone_no must start with indonesia country code ("+62")`))
+}
+
+// canonicalPhoneNo will return the E.164 format of the phone number to look it up,
+// invalid numbers are returned as is since they can't match any user anyway
+func canonicalPhoneNo(phoneNo string) string {
+	if num, err := phone.Parse(phoneNo); err == nil {
+		return num.E164
 	}
-	if !allDigitRegex.MatchString(phoneNo) {
-		res = append(res, fmt.Errorf(`besides the country code, phone_no must only contain numbers`))
+	return phoneNo
+}
+
+func validateUserEmail(email string) []error {
+	var res []error
+	if len(email) > 254 {
+		res = append(res, usecase.NewFieldError("email.too_long", "validation.email_length", i18n.Params{"max": 254}))
+	}
+	// display names (e.g. `John <john@example.com>`) are rejected, only the bare address is accepted
+	if addr, err := netmail.ParseAddress(email); err != nil || addr.Address != email {
+		res = append(res, usecase.NewFieldError("email.invalid", "validation.email_invalid", nil))
 	}
 	return res
 }
@@ -58,7 +210,7 @@ func validateUserPhoneNo(phoneNo string) []error {
 func validateUserFullName(fullName string) []error {
 	var res []error
 	if len(fullName) < 3 || len(fullName) > 60 {
-		res = append(res, fmt.Errorf(`full_name must be between 3 and 60 characters long`))
+		res = append(res, usecase.NewFieldError("full_name.invalid_length", "validation.full_name_length", i18n.Params{"min": 3, "max": 60}))
 	}
 	return res
 }
@@ -66,16 +218,47 @@ func validateUserFullName(fullName string) []error {
 func validateUserPassword(password string) []error {
 	var res []error
 	if len(password) < 6 || len(password) > 64 {
-		res = append(res, fmt.Errorf(`password must be between 6 and 64 characters long`))
+		res = append(res, usecase.NewFieldError("password.invalid_length", "validation.password_length", i18n.Params{"min": 6, "max": 64}))
 	}
 	if !digitRegex.MatchString(password) {
-		res = append(res, fmt.Errorf(`password must contains at least one number [0-9]`))
+		res = append(res, usecase.NewFieldError("password.missing_number", "validation.password_number", nil))
 	}
 	if !capitalRegex.MatchString(password) {
-		res = append(res, fmt.Errorf(`password must contains at least one capital letter [A-Z]`))
+		res = append(res, usecase.NewFieldError("password.missing_capital_letter", "validation.password_capital", nil))
 	}
 	if !specialRegex.MatchString(password) {
-		res = append(res, fmt.Errorf(`password must contains at least one non alphanumeric character`))
+		res = append(res, usecase.NewFieldError("password.missing_symbol", "validation.password_symbol", nil))
 	}
 	return res
 }
+
+func validateUserPIN(pin string, phoneNo string) []error {
+	var res []error
+	if len(pin) != 6 || !allDigitRegex.MatchString(pin) || strings.HasPrefix(pin, "+") {
+		return append(res, usecase.NewFieldError("pin.invalid_format", "validation.pin_format", nil))
+	}
+	if isTrivialPIN(pin) {
+		res = append(res, usecase.NewFieldError("pin.too_simple", "validation.pin_sequence", nil))
+	}
+	if strings.HasSuffix(phoneNo, pin) {
+		res = append(res, usecase.NewFieldError("pin.matches_phone_no", "validation.pin_phone_no", nil))
+	}
+	return res
+}
+
+// isTrivialPIN will check whether every digit is the same, or is incremented / decremented by one (e.g. 890123)
+func isTrivialPIN(pin string) bool {
+	for _, step := range []byte{0, 1, 9} {
+		trivial := true
+		for i := 1; i < len(pin); i++ {
+			if (pin[i-1]-'0'+step)%10 != pin[i]-'0' {
+				trivial = false
+				break
+			}
+		}
+		if trivial {
+			return true
+		}
+	}
+	return false
+}
@@ -2,17 +2,105 @@ package users
 
 import (
 	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/i18n"
+	"github.com/SawitProRecruitment/UserService/metrics"
+	"github.com/SawitProRecruitment/UserService/repository"
 	"github.com/SawitProRecruitment/UserService/usecase"
 	"github.com/golang-jwt/jwt/v5"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// preferredLanguageAttribute is the custom profile attribute holding the language the user wants messages in
+const preferredLanguageAttribute = "preferred_language"
+
+// userTokenClaims contains the claims of a successfully parsed & verified user JWT Token
+type userTokenClaims struct {
+	UserID    uint64
+	Roles     []string
+	TokenID   string
+	Subject   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	Scope     string
+	ClientID  string
+	// ActorSubject & ActorUserID are parsed from the `act` claim (RFC 8693), only present on impersonation tokens
+	ActorSubject string
+	ActorUserID  uint64
+}
+
 func (u *userUsecases) ValidateUserToken(ctx context.Context, input usecase.ValidateUserTokenInput) (output usecase.ValidateUserTokenOutput, err error) {
-	parsedToken, err := jwt.Parse(input.JwtToken, func(token *jwt.Token) (interface{}, error) {
+	ctx, span := startSpan(ctx, "ValidateUserToken")
+	defer func() { endSpan(span, err) }()
+	defer func() {
+		if err != nil {
+			metrics.TokenValidationFailures.WithLabelValues(errorReason(err)).Inc()
+		}
+	}()
+
+	var claims userTokenClaims
+	var usr repository.GetUserOutput
+	if claims, usr, err = u.verifyUserToken(ctx, input.JwtToken); err != nil {
+		return
+	}
+
+	output.UserID = claims.UserID
+	output.Roles = claims.Roles
+	output.Scopes = strings.Fields(claims.Scope)
+	output.ActorUserID = claims.ActorUserID
+	if lang, ok := usr.Attributes[preferredLanguageAttribute].(string); ok && i18n.IsSupported(lang) {
+		output.Language = lang
+	}
+	return output, nil
+}
+
+// verifyUserToken will parse the JWT Token, and check that it hasn't been revoked and that its user is still allowed
+// to use the service. Will return UserInvalidToken, UserTokenExpired or UserSuspended for the tokens to reject
+func (u *userUsecases) verifyUserToken(ctx context.Context, jwtToken string) (claims userTokenClaims, usr repository.GetUserOutput, err error) {
+	if claims, err = u.parseUserToken(jwtToken); err != nil {
+		return
+	}
+
+	// tokens without ID are issued before revocation is supported, and can't be revoked
+	if claims.TokenID != "" {
+		_, err = u.tokenRepo.GetTokenRevocation(ctx, repository.GetTokenRevocationInput{TokenID: claims.TokenID})
+		if err == nil {
+			err = usecase.UserInvalidToken
+			return
+		} else if !errors.Is(err, repository.ErrorRecordNotFound) {
+			return
+		}
+	}
+
+	// the account might have been suspended after the token is issued
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{ID: claims.UserID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserInvalidToken
+		}
+		return
+	}
+	if err = checkUserStatus(usr.Status); err != nil {
+		return
+	}
+
+	return claims, usr, nil
+}
+
+// parseUserToken will verify the JWT Token signature & expiry, and extract the claims used by this service
+func (u *userUsecases) parseUserToken(jwtToken string) (claims userTokenClaims, err error) {
+	parsedToken, err := jwt.Parse(jwtToken, func(token *jwt.Token) (interface{}, error) {
+		// tokens issued for other purposes (e.g. email verification) are signed by the same key, but typed differently
+		if typ, ok := token.Header["typ"].(string); ok && typ != "JWT" {
+			return nil, usecase.UserInvalidToken
+		}
 		return &u.jwtSecret.PublicKey, nil
 	})
-	if err != nil {
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		err = usecase.UserTokenExpired
+		return
+	} else if err != nil {
 		err = usecase.UserInvalidToken
 		return
 	}
@@ -24,21 +112,63 @@ func (u *userUsecases) ValidateUserToken(ctx context.Context, input usecase.Vali
 		return
 	}
 	if exp.Before(time.Now()) {
-		err = usecase.UserInvalidToken
+		err = usecase.UserTokenExpired
 		return
 	}
+	claims.ExpiresAt = exp.Time
 
 	// get userID from token subject
-	subject, err := parsedToken.Claims.GetSubject()
+	claims.Subject, err = parsedToken.Claims.GetSubject()
 	if err != nil {
 		err = usecase.UserInvalidToken
 		return
 	}
-	output.UserID, err = strconv.ParseUint(subject, 10, 64)
+	claims.UserID, err = strconv.ParseUint(claims.Subject, 10, 64)
 	if err != nil {
 		err = usecase.UserInvalidToken
 		return
 	}
 
-	return output, nil
+	// optional claims, tokens issued by older versions might not have these
+	if iat, _ := parsedToken.Claims.GetIssuedAt(); iat != nil {
+		claims.IssuedAt = iat.Time
+	}
+	if mapClaims, ok := parsedToken.Claims.(jwt.MapClaims); ok {
+		claims.TokenID, _ = mapClaims["jti"].(string)
+		claims.Scope, _ = mapClaims["scope"].(string)
+		claims.ClientID, _ = mapClaims["client_id"].(string)
+		if roles, ok := mapClaims["roles"].([]interface{}); ok {
+			for _, role := range roles {
+				if role, ok := role.(string); ok {
+					claims.Roles = append(claims.Roles, role)
+				}
+			}
+		}
+		if act, ok := mapClaims["act"].(map[string]interface{}); ok {
+			claims.ActorSubject, _ = act["sub"].(string)
+			if claims.ActorUserID, err = strconv.ParseUint(claims.ActorSubject, 10, 64); err != nil || claims.ActorUserID == 0 {
+				err = usecase.UserInvalidToken
+				return
+			}
+			// impersonation tokens must be revocable
+			if claims.TokenID == "" {
+				err = usecase.UserInvalidToken
+				return
+			}
+		}
+	}
+
+	return claims, nil
+}
+
+// checkUserStatus will return an error when the user account status doesn't allow using the service
+func checkUserStatus(status string) error {
+	switch status {
+	case usecase.UserStatusActive:
+		return nil
+	case usecase.UserStatusSuspended:
+		return usecase.UserSuspended
+	default:
+		return usecase.UserInvalidToken
+	}
 }
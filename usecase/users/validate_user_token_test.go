@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
 	"github.com/SawitProRecruitment/UserService/repository"
 	"github.com/SawitProRecruitment/UserService/usecase"
 	"github.com/golang-jwt/jwt/v5"
@@ -18,8 +19,9 @@ import (
 type ValidateUserTokenTestSuite struct {
 	suite.Suite
 
-	gomock *gomock.Controller
-	repo   *repository.MockUserRepository
+	gomock    *gomock.Controller
+	repo      *repository.MockUserRepository
+	tokenRepo *repository.MockTokenRepository
 
 	jwtSecret *rsa.PrivateKey
 	usecase   usecase.UserUsecases
@@ -38,21 +40,25 @@ func TestValidateUserTokenTestSuite(t *testing.T) {
 func (s *ValidateUserTokenTestSuite) SetupTest() {
 	s.gomock = gomock.NewController(s.T())
 	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.tokenRepo = repository.NewMockTokenRepository(s.gomock)
 
 	s.jwtSecret, _ = rsa.GenerateKey(rand.Reader, 1024)
 	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
 		UserRepo:  s.repo,
+		TokenRepo: s.tokenRepo,
 		JwtSecret: s.jwtSecret,
-		JwtTtl:    time.Minute * 5,
+		Auth:      config.Auth{JwtTtl: time.Minute * 5},
 	})
 
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
-		"sub": "123",
-		"exp": time.Now().Add(time.Minute * 5).Unix(),
+		"sub":   "123",
+		"exp":   time.Now().Add(time.Minute * 5).Unix(),
+		"roles": []string{"admin"},
+		"scope": "profile:read admin:users",
 	})
 	jwtToken, _ := token.SignedString(s.jwtSecret)
 	s.input = usecase.ValidateUserTokenInput{JwtToken: jwtToken}
-	s.output = usecase.ValidateUserTokenOutput{UserID: 123}
+	s.output = usecase.ValidateUserTokenOutput{UserID: 123, Roles: []string{"admin"}, Scopes: []string{"profile:read", "admin:users"}}
 
 	s.ctx = context.Background()
 	s.mockErr = fmt.Errorf("simulated error")
@@ -101,14 +107,14 @@ func (s *ValidateUserTokenTestSuite) TestTokenExpired() {
 	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
 
 	a.Empty(out)
-	a.ErrorIs(err, usecase.UserInvalidToken)
+	a.ErrorIs(err, usecase.UserTokenExpired)
 }
 
 func (s *ValidateUserTokenTestSuite) TestTokenMissingSub() {
 	a := assert.New(s.T())
 
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
-		"exp": time.Now().Add(time.Minute * -5).Unix(),
+		"exp": time.Now().Add(time.Minute * 5).Unix(),
 	})
 	s.input.JwtToken, _ = token.SignedString(s.jwtSecret)
 	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
@@ -122,7 +128,7 @@ func (s *ValidateUserTokenTestSuite) TestTokenSubjectInvalid() {
 
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
 		"sub": "asdf",
-		"exp": time.Now().Add(time.Minute * -5).Unix(),
+		"exp": time.Now().Add(time.Minute * 5).Unix(),
 	})
 	s.input.JwtToken, _ = token.SignedString(s.jwtSecret)
 	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
@@ -131,11 +137,185 @@ func (s *ValidateUserTokenTestSuite) TestTokenSubjectInvalid() {
 	a.ErrorIs(err, usecase.UserInvalidToken)
 }
 
+func (s *ValidateUserTokenTestSuite) TestUserNotFound() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidToken)
+}
+
+func (s *ValidateUserTokenTestSuite) TestRepositoryError() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{}, s.mockErr)
+
+	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *ValidateUserTokenTestSuite) TestUserSuspended() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{ID: 123, Status: "suspended"}, nil)
+
+	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserSuspended)
+}
+
 func (s *ValidateUserTokenTestSuite) TestSuccess() {
 	a := assert.New(s.T())
 
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{ID: 123, Status: "active"}, nil)
+
+	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(s.output, out)
+}
+
+func (s *ValidateUserTokenTestSuite) TestPreferredLanguage() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{
+		ID: 123, Status: "active", Attributes: map[string]interface{}{"preferred_language": "id"},
+	}, nil)
+
+	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
+
+	a.Empty(err)
+	s.output.Language = "id"
+	a.Equal(s.output, out)
+}
+
+func (s *ValidateUserTokenTestSuite) TestPreferredLanguageUnsupported() {
+	a := assert.New(s.T())
+
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{
+		ID: 123, Status: "active", Attributes: map[string]interface{}{"preferred_language": "fr"},
+	}, nil)
+
 	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
 
 	a.Empty(err)
 	a.Equal(s.output, out)
 }
+
+func (s *ValidateUserTokenTestSuite) signToken(claims jwt.MapClaims) string {
+	claims["sub"] = "123"
+	claims["exp"] = time.Now().Add(time.Minute * 5).Unix()
+	claims["roles"] = []string{"farmer"}
+	claims["scope"] = "profile:read"
+	jwtToken, _ := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(s.jwtSecret)
+	return jwtToken
+}
+
+func (s *ValidateUserTokenTestSuite) TestImpersonationActorInvalid() {
+	a := assert.New(s.T())
+
+	s.input.JwtToken = s.signToken(jwt.MapClaims{"jti": "token-id", "act": map[string]interface{}{"sub": "asdf"}})
+	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidToken)
+}
+
+func (s *ValidateUserTokenTestSuite) TestImpersonationMissingTokenID() {
+	a := assert.New(s.T())
+
+	s.input.JwtToken = s.signToken(jwt.MapClaims{"act": map[string]interface{}{"sub": "1"}})
+	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidToken)
+}
+
+func (s *ValidateUserTokenTestSuite) TestImpersonationRevoked() {
+	a := assert.New(s.T())
+
+	s.input.JwtToken = s.signToken(jwt.MapClaims{"jti": "token-id", "act": map[string]interface{}{"sub": "1"}})
+	s.tokenRepo.EXPECT().GetTokenRevocation(s.ctx, repository.GetTokenRevocationInput{TokenID: "token-id"}).
+		Return(repository.GetTokenRevocationOutput{TokenID: "token-id"}, nil)
+
+	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidToken)
+}
+
+func (s *ValidateUserTokenTestSuite) TestImpersonationRevocationError() {
+	a := assert.New(s.T())
+
+	s.input.JwtToken = s.signToken(jwt.MapClaims{"jti": "token-id", "act": map[string]interface{}{"sub": "1"}})
+	s.tokenRepo.EXPECT().GetTokenRevocation(s.ctx, repository.GetTokenRevocationInput{TokenID: "token-id"}).
+		Return(repository.GetTokenRevocationOutput{}, s.mockErr)
+
+	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *ValidateUserTokenTestSuite) TestRevoked() {
+	a := assert.New(s.T())
+
+	s.input.JwtToken = s.signToken(jwt.MapClaims{"jti": "token-id"})
+	s.tokenRepo.EXPECT().GetTokenRevocation(s.ctx, repository.GetTokenRevocationInput{TokenID: "token-id"}).
+		Return(repository.GetTokenRevocationOutput{TokenID: "token-id"}, nil)
+
+	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidToken)
+}
+
+func (s *ValidateUserTokenTestSuite) TestNotRevokedSuccess() {
+	a := assert.New(s.T())
+
+	s.input.JwtToken = s.signToken(jwt.MapClaims{"jti": "token-id"})
+	s.tokenRepo.EXPECT().GetTokenRevocation(s.ctx, repository.GetTokenRevocationInput{TokenID: "token-id"}).
+		Return(repository.GetTokenRevocationOutput{}, repository.ErrorRecordNotFound)
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{ID: 123, Status: "active"}, nil)
+
+	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(usecase.ValidateUserTokenOutput{UserID: 123, Roles: []string{"farmer"}, Scopes: []string{"profile:read"}}, out)
+}
+
+func (s *ValidateUserTokenTestSuite) TestImpersonationSuccess() {
+	a := assert.New(s.T())
+
+	s.input.JwtToken = s.signToken(jwt.MapClaims{"jti": "token-id", "act": map[string]interface{}{"sub": "1"}})
+	s.repo.EXPECT().GetUser(s.ctx, repository.GetUserInput{ID: 123}).Return(repository.GetUserOutput{ID: 123, Status: "active"}, nil)
+	s.tokenRepo.EXPECT().GetTokenRevocation(s.ctx, repository.GetTokenRevocationInput{TokenID: "token-id"}).
+		Return(repository.GetTokenRevocationOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
+
+	a.Empty(err)
+	a.Equal(usecase.ValidateUserTokenOutput{UserID: 123, Roles: []string{"farmer"}, Scopes: []string{"profile:read"}, ActorUserID: 1}, out)
+}
+
+func (s *ValidateUserTokenTestSuite) TestEmailVerificationToken() {
+	a := assert.New(s.T())
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":   "123",
+		"email": "john.smith@example.com",
+		"exp":   time.Now().Add(time.Minute * 5).Unix(),
+	})
+	token.Header["typ"] = "email-verification+jwt"
+	s.input.JwtToken, _ = token.SignedString(s.jwtSecret)
+	out, err := s.usecase.ValidateUserToken(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidToken)
+}
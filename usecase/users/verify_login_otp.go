@@ -0,0 +1,69 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"time"
+)
+
+const (
+	// maxLoginOTPAttempts is the number of wrong codes allowed, before a new code must be requested
+	maxLoginOTPAttempts = 5
+)
+
+func (u *userUsecases) VerifyLoginOTP(ctx context.Context, input usecase.VerifyLoginOTPInput) (output usecase.VerifyLoginOTPOutput, err error) {
+	ctx, span := startSpan(ctx, "VerifyLoginOTP")
+	defer func() { endSpan(span, err) }()
+	defer func() { recordLoginAttempt(loginMethodOTP, err) }()
+
+	// codes are stored for the canonical phone number
+	input.PhoneNo = canonicalPhoneNo(input.PhoneNo)
+
+	var otp repository.GetLatestLoginOTPOutput
+	if otp, err = u.loginOTPRepo.GetLatestLoginOTP(ctx, repository.GetLatestLoginOTPInput{PhoneNo: input.PhoneNo}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserInvalidLoginOTP
+		}
+		return
+	}
+	if otp.ExpiresAt.Before(time.Now()) {
+		err = usecase.UserInvalidLoginOTP
+		return
+	}
+
+	// the attempt is recorded before comparing, so that concurrent guesses can't exceed the limit
+	_, err = u.loginOTPRepo.AddLoginOTPAttempt(ctx, repository.AddLoginOTPAttemptInput{ID: otp.ID, MaxAttempts: maxLoginOTPAttempts})
+	if err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserInvalidLoginOTP
+		}
+		return
+	}
+	if err = compareBcryptHash(otp.CodeHash, input.Code); err != nil {
+		err = usecase.UserInvalidLoginOTP
+		return
+	}
+
+	if _, err = u.loginOTPRepo.ConsumeLoginOTP(ctx, repository.ConsumeLoginOTPInput{ID: otp.ID}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserInvalidLoginOTP
+		}
+		return
+	}
+
+	var usr repository.GetUserOutput
+	if usr, err = u.userRepo.GetUser(ctx, repository.GetUserInput{PhoneNo: input.PhoneNo}); err != nil {
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserInvalidLoginOTP
+		}
+		return
+	}
+
+	if output.JwtToken, err = u.createUserSession(ctx, usr, input.Scope); err != nil {
+		return
+	}
+
+	return output, nil
+}
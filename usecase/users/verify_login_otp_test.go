@@ -0,0 +1,215 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+	"time"
+)
+
+type VerifyLoginOTPTestSuite struct {
+	suite.Suite
+
+	gomock  *gomock.Controller
+	repo    *repository.MockUserRepository
+	otpRepo *repository.MockLoginOTPRepository
+
+	jwtSecret *rsa.PrivateKey
+	usecase   usecase.UserUsecases
+
+	getLatestInput  repository.GetLatestLoginOTPInput
+	getLatestOutput repository.GetLatestLoginOTPOutput
+	attemptInput    repository.AddLoginOTPAttemptInput
+	consumeInput    repository.ConsumeLoginOTPInput
+
+	getUserInput             repository.GetUserInput
+	getUserOutput            repository.GetUserOutput
+	incrementLoginCountInput repository.IncrementUserLoginCountInput
+	getUserRolesInput        repository.GetUserRolesInput
+	getUserRolesOutput       repository.GetUserRolesOutput
+
+	input usecase.VerifyLoginOTPInput
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestVerifyLoginOTPTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifyLoginOTPTestSuite))
+}
+
+func (s *VerifyLoginOTPTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+	s.otpRepo = repository.NewMockLoginOTPRepository(s.gomock)
+
+	s.jwtSecret, _ = rsa.GenerateKey(rand.Reader, 1024)
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{
+		UserRepo:     s.repo,
+		LoginOTPRepo: s.otpRepo,
+		JwtSecret:    s.jwtSecret,
+		Auth:         config.Auth{JwtTtl: time.Minute * 5},
+	})
+
+	codeHash, _ := bcrypt.GenerateFromPassword([]byte("042917"), bcrypt.MinCost)
+	s.getLatestInput = repository.GetLatestLoginOTPInput{PhoneNo: "+62812151833"}
+	s.getLatestOutput = repository.GetLatestLoginOTPOutput{
+		ID:        42,
+		PhoneNo:   "+62812151833",
+		CodeHash:  codeHash,
+		CreatedAt: time.Now().Add(-time.Minute),
+		ExpiresAt: time.Now().Add(time.Minute * 4),
+	}
+	s.attemptInput = repository.AddLoginOTPAttemptInput{ID: 42, MaxAttempts: 5}
+	s.consumeInput = repository.ConsumeLoginOTPInput{ID: 42}
+
+	s.getUserInput = repository.GetUserInput{PhoneNo: "+62812151833"}
+	s.getUserOutput = repository.GetUserOutput{ID: 123, PhoneNo: "+62812151833", SuccessfulLoginCount: 2, Status: "active"}
+	s.incrementLoginCountInput = repository.IncrementUserLoginCountInput{ID: 123}
+	s.getUserRolesInput = repository.GetUserRolesInput{UserID: 123}
+	s.getUserRolesOutput = repository.GetUserRolesOutput{Roles: []string{"farmer"}}
+
+	s.input = usecase.VerifyLoginOTPInput{PhoneNo: "+62812151833", Code: "042917"}
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *VerifyLoginOTPTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *VerifyLoginOTPTestSuite) TestNoLoginOTP() {
+	a := assert.New(s.T())
+
+	s.otpRepo.EXPECT().GetLatestLoginOTP(s.ctx, s.getLatestInput).Return(repository.GetLatestLoginOTPOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.VerifyLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidLoginOTP)
+}
+
+func (s *VerifyLoginOTPTestSuite) TestGetLatestLoginOTPError() {
+	a := assert.New(s.T())
+
+	s.otpRepo.EXPECT().GetLatestLoginOTP(s.ctx, s.getLatestInput).Return(repository.GetLatestLoginOTPOutput{}, s.mockErr)
+
+	out, err := s.usecase.VerifyLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *VerifyLoginOTPTestSuite) TestExpired() {
+	a := assert.New(s.T())
+
+	s.getLatestOutput.ExpiresAt = time.Now().Add(-time.Second)
+	s.otpRepo.EXPECT().GetLatestLoginOTP(s.ctx, s.getLatestInput).Return(s.getLatestOutput, nil)
+
+	out, err := s.usecase.VerifyLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidLoginOTP)
+}
+
+func (s *VerifyLoginOTPTestSuite) TestNoAttemptsLeft() {
+	a := assert.New(s.T())
+
+	s.otpRepo.EXPECT().GetLatestLoginOTP(s.ctx, s.getLatestInput).Return(s.getLatestOutput, nil)
+	s.otpRepo.EXPECT().AddLoginOTPAttempt(s.ctx, s.attemptInput).Return(repository.AddLoginOTPAttemptOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.VerifyLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidLoginOTP)
+}
+
+func (s *VerifyLoginOTPTestSuite) TestWrongCode() {
+	a := assert.New(s.T())
+
+	s.input.Code = "000000"
+	s.otpRepo.EXPECT().GetLatestLoginOTP(s.ctx, s.getLatestInput).Return(s.getLatestOutput, nil)
+	s.otpRepo.EXPECT().AddLoginOTPAttempt(s.ctx, s.attemptInput).Return(repository.AddLoginOTPAttemptOutput{Attempts: 1}, nil)
+
+	out, err := s.usecase.VerifyLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidLoginOTP)
+}
+
+func (s *VerifyLoginOTPTestSuite) TestAlreadyConsumed() {
+	a := assert.New(s.T())
+
+	s.otpRepo.EXPECT().GetLatestLoginOTP(s.ctx, s.getLatestInput).Return(s.getLatestOutput, nil)
+	s.otpRepo.EXPECT().AddLoginOTPAttempt(s.ctx, s.attemptInput).Return(repository.AddLoginOTPAttemptOutput{Attempts: 1}, nil)
+	s.otpRepo.EXPECT().ConsumeLoginOTP(s.ctx, s.consumeInput).Return(repository.ConsumeLoginOTPOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.VerifyLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidLoginOTP)
+}
+
+func (s *VerifyLoginOTPTestSuite) TestUnknownPhoneNo() {
+	a := assert.New(s.T())
+
+	s.otpRepo.EXPECT().GetLatestLoginOTP(s.ctx, s.getLatestInput).Return(s.getLatestOutput, nil)
+	s.otpRepo.EXPECT().AddLoginOTPAttempt(s.ctx, s.attemptInput).Return(repository.AddLoginOTPAttemptOutput{Attempts: 1}, nil)
+	s.otpRepo.EXPECT().ConsumeLoginOTP(s.ctx, s.consumeInput).Return(repository.ConsumeLoginOTPOutput{}, nil)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(repository.GetUserOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.VerifyLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidLoginOTP)
+}
+
+func (s *VerifyLoginOTPTestSuite) TestUserSuspended() {
+	a := assert.New(s.T())
+
+	s.getUserOutput.Status = "suspended"
+	s.otpRepo.EXPECT().GetLatestLoginOTP(s.ctx, s.getLatestInput).Return(s.getLatestOutput, nil)
+	s.otpRepo.EXPECT().AddLoginOTPAttempt(s.ctx, s.attemptInput).Return(repository.AddLoginOTPAttemptOutput{Attempts: 1}, nil)
+	s.otpRepo.EXPECT().ConsumeLoginOTP(s.ctx, s.consumeInput).Return(repository.ConsumeLoginOTPOutput{}, nil)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+
+	out, err := s.usecase.VerifyLoginOTP(s.ctx, s.input)
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserSuspended)
+}
+
+func (s *VerifyLoginOTPTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	s.otpRepo.EXPECT().GetLatestLoginOTP(s.ctx, s.getLatestInput).Return(s.getLatestOutput, nil)
+	s.otpRepo.EXPECT().AddLoginOTPAttempt(s.ctx, s.attemptInput).Return(repository.AddLoginOTPAttemptOutput{Attempts: 1}, nil)
+	s.otpRepo.EXPECT().ConsumeLoginOTP(s.ctx, s.consumeInput).Return(repository.ConsumeLoginOTPOutput{}, nil)
+	s.repo.EXPECT().GetUser(s.ctx, s.getUserInput).Return(s.getUserOutput, nil)
+	s.repo.EXPECT().IncrementUserLoginCount(s.ctx, s.incrementLoginCountInput).Return(repository.IncrementUserLoginCountOutput{}, nil)
+	s.repo.EXPECT().GetUserRoles(s.ctx, s.getUserRolesInput).Return(s.getUserRolesOutput, nil)
+
+	out, err := s.usecase.VerifyLoginOTP(s.ctx, s.input)
+
+	a.Empty(err)
+	parsedToken, err := jwt.Parse(out.JwtToken, func(token *jwt.Token) (interface{}, error) {
+		return &s.jwtSecret.PublicKey, nil
+	})
+	a.Empty(err)
+	a.True(parsedToken.Valid)
+	subj, err := parsedToken.Claims.GetSubject()
+	a.Empty(err)
+	a.Equal("123", subj)
+	a.Equal("profile:read profile:write", parsedToken.Claims.(jwt.MapClaims)["scope"])
+}
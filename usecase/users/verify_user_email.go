@@ -0,0 +1,58 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang-jwt/jwt/v5"
+	"strconv"
+	"time"
+)
+
+func (u *userUsecases) VerifyUserEmail(ctx context.Context, input usecase.VerifyUserEmailInput) (output usecase.VerifyUserEmailOutput, err error) {
+	ctx, span := startSpan(ctx, "VerifyUserEmail")
+	defer func() { endSpan(span, err) }()
+
+	if output.UserID, output.Email, err = u.parseEmailVerificationToken(input.Token); err != nil {
+		return
+	}
+
+	payload := repository.VerifyUserEmailInput{ID: output.UserID, Email: output.Email, VerifiedAt: time.Now()}
+	if _, err = u.userRepo.VerifyUserEmail(ctx, payload); err != nil {
+		// the email has been changed since the link is sent
+		if errors.Is(err, repository.ErrorRecordNotFound) {
+			err = usecase.UserInvalidEmailVerification
+		} else if errors.Is(err, repository.ErrorRecordConflict) {
+			err = usecase.UserEmailTaken
+		}
+		return usecase.VerifyUserEmailOutput{}, err
+	}
+
+	return output, nil
+}
+
+// parseEmailVerificationToken will verify the token signature, type & expiry, and return the user & email it's issued for
+func (u *userUsecases) parseEmailVerificationToken(token string) (userID uint64, email string, err error) {
+	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != emailVerificationTokenType {
+			return nil, usecase.UserInvalidEmailVerification
+		}
+		return &u.jwtSecret.PublicKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return 0, "", usecase.UserInvalidEmailVerification
+	}
+
+	subject, _ := parsedToken.Claims.GetSubject()
+	if userID, err = strconv.ParseUint(subject, 10, 64); err != nil {
+		return 0, "", usecase.UserInvalidEmailVerification
+	}
+	if mapClaims, ok := parsedToken.Claims.(jwt.MapClaims); ok {
+		email, _ = mapClaims["email"].(string)
+	}
+	if email == "" {
+		return 0, "", usecase.UserInvalidEmailVerification
+	}
+	return userID, email, nil
+}
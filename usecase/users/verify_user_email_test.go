@@ -0,0 +1,132 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/usecase"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type VerifyUserEmailTestSuite struct {
+	suite.Suite
+
+	gomock *gomock.Controller
+	repo   *repository.MockUserRepository
+
+	jwtSecret *rsa.PrivateKey
+	usecase   usecase.UserUsecases
+
+	ctx     context.Context
+	mockErr error
+}
+
+func TestVerifyUserEmailTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifyUserEmailTestSuite))
+}
+
+func (s *VerifyUserEmailTestSuite) SetupTest() {
+	s.gomock = gomock.NewController(s.T())
+	s.repo = repository.NewMockUserRepository(s.gomock)
+
+	s.jwtSecret, _ = rsa.GenerateKey(rand.Reader, 1024)
+	s.usecase = NewUserUsecases(NewUserUsecasesOptions{UserRepo: s.repo, JwtSecret: s.jwtSecret})
+
+	s.ctx = context.Background()
+	s.mockErr = fmt.Errorf("simulated error")
+}
+
+func (s *VerifyUserEmailTestSuite) TearDownTest() {
+	s.gomock.Finish()
+}
+
+func (s *VerifyUserEmailTestSuite) signToken(typ string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["typ"] = typ
+	signed, _ := token.SignedString(s.jwtSecret)
+	return signed
+}
+
+func (s *VerifyUserEmailTestSuite) TestInvalidTokens() {
+	a := assert.New(s.T())
+
+	otherSecret, _ := rsa.GenerateKey(rand.Reader, 1024)
+	otherSigned, _ := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "123", "email": "john.smith@example.com", "exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString(otherSecret)
+
+	for name, token := range map[string]string{
+		"malformed":     "not-a-token",
+		"other key":     otherSigned,
+		"access token":  s.signToken("JWT", jwt.MapClaims{"sub": "123", "email": "john.smith@example.com", "exp": time.Now().Add(time.Hour).Unix()}),
+		"expired":       s.signToken("email-verification+jwt", jwt.MapClaims{"sub": "123", "email": "john.smith@example.com", "exp": time.Now().Add(-time.Hour).Unix()}),
+		"missing exp":   s.signToken("email-verification+jwt", jwt.MapClaims{"sub": "123", "email": "john.smith@example.com"}),
+		"missing email": s.signToken("email-verification+jwt", jwt.MapClaims{"sub": "123", "exp": time.Now().Add(time.Hour).Unix()}),
+		"invalid sub":   s.signToken("email-verification+jwt", jwt.MapClaims{"sub": "abc", "email": "john.smith@example.com", "exp": time.Now().Add(time.Hour).Unix()}),
+	} {
+		out, err := s.usecase.VerifyUserEmail(s.ctx, usecase.VerifyUserEmailInput{Token: token})
+
+		a.Empty(out, name)
+		a.ErrorIs(err, usecase.UserInvalidEmailVerification, name)
+	}
+}
+
+func (s *VerifyUserEmailTestSuite) TestEmailChanged() {
+	a := assert.New(s.T())
+
+	token := s.signToken("email-verification+jwt", jwt.MapClaims{"sub": "123", "email": "john.smith@example.com", "exp": time.Now().Add(time.Hour).Unix()})
+	s.repo.EXPECT().VerifyUserEmail(s.ctx, gomock.Any()).Return(repository.VerifyUserEmailOutput{}, repository.ErrorRecordNotFound)
+
+	out, err := s.usecase.VerifyUserEmail(s.ctx, usecase.VerifyUserEmailInput{Token: token})
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserInvalidEmailVerification)
+}
+
+func (s *VerifyUserEmailTestSuite) TestEmailTaken() {
+	a := assert.New(s.T())
+
+	token := s.signToken("email-verification+jwt", jwt.MapClaims{"sub": "123", "email": "john.smith@example.com", "exp": time.Now().Add(time.Hour).Unix()})
+	s.repo.EXPECT().VerifyUserEmail(s.ctx, gomock.Any()).Return(repository.VerifyUserEmailOutput{}, repository.ErrorRecordConflict)
+
+	out, err := s.usecase.VerifyUserEmail(s.ctx, usecase.VerifyUserEmailInput{Token: token})
+
+	a.Empty(out)
+	a.ErrorIs(err, usecase.UserEmailTaken)
+}
+
+func (s *VerifyUserEmailTestSuite) TestRepositoryError() {
+	a := assert.New(s.T())
+
+	token := s.signToken("email-verification+jwt", jwt.MapClaims{"sub": "123", "email": "john.smith@example.com", "exp": time.Now().Add(time.Hour).Unix()})
+	s.repo.EXPECT().VerifyUserEmail(s.ctx, gomock.Any()).Return(repository.VerifyUserEmailOutput{}, s.mockErr)
+
+	out, err := s.usecase.VerifyUserEmail(s.ctx, usecase.VerifyUserEmailInput{Token: token})
+
+	a.Empty(out)
+	a.ErrorIs(err, s.mockErr)
+}
+
+func (s *VerifyUserEmailTestSuite) TestSuccess() {
+	a := assert.New(s.T())
+
+	token := s.signToken("email-verification+jwt", jwt.MapClaims{"sub": "123", "email": "john.smith@example.com", "exp": time.Now().Add(time.Hour).Unix()})
+	s.repo.EXPECT().VerifyUserEmail(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, input repository.VerifyUserEmailInput) (repository.VerifyUserEmailOutput, error) {
+		a.Equal(uint64(123), input.ID)
+		a.Equal("john.smith@example.com", input.Email)
+		a.WithinDuration(time.Now(), input.VerifiedAt, time.Minute)
+		return repository.VerifyUserEmailOutput{}, nil
+	})
+
+	out, err := s.usecase.VerifyUserEmail(s.ctx, usecase.VerifyUserEmailInput{Token: token})
+
+	a.Empty(err)
+	a.Equal(usecase.VerifyUserEmailOutput{UserID: 123, Email: "john.smith@example.com"}, out)
+}